@@ -107,6 +107,26 @@ var (
 	// task config template is used to generate task config before user create a real task by openapi. user can modify eg:
 	// import from running tasks/create/update/delete the template and those changes will not affect the running tasks.
 	OpenAPITaskTemplateKeyAdapter KeyAdapter = keyHexEncoderDecoder("/dm-master/openapi-task-template/")
+	// OpenAPITaskTemplateVersionKeyAdapter is used to store the history versions of openapi task-config-template,
+	// the version is a zero-padded number so the keys of one task are ordered by version.
+	// k/v: Encode(task-name, version) -> openapi.Task.
+	OpenAPITaskTemplateVersionKeyAdapter KeyAdapter = keyHexEncoderDecoder("/dm-master/openapi-task-template-version/")
+	// OpenAPITaskTemplateDeletedKeyAdapter is used to store the soft-deleted openapi task-config-template, the deleted
+	// time is a zero-padded unix nano timestamp so the keys of one task are ordered by the deleted time.
+	// k/v: Encode(task-name, deleted-time) -> openapi.Task.
+	OpenAPITaskTemplateDeletedKeyAdapter KeyAdapter = keyHexEncoderDecoder("/dm-master/openapi-task-template-deleted/")
+	// OpenAPITaskTemplateNamespaceKeyAdapter is used to store the openapi task-config-template in a namespace other
+	// than the default one, templates in the default namespace are still stored by OpenAPITaskTemplateKeyAdapter.
+	// k/v: Encode(namespace, task-name) -> openapi.Task.
+	OpenAPITaskTemplateNamespaceKeyAdapter KeyAdapter = keyHexEncoderDecoder("/dm-master/openapi-task-template-namespace/")
+	// OpenAPITaskTemplateNamespaceVersionKeyAdapter is used to store the history versions of openapi
+	// task-config-template in a namespace other than the default one.
+	// k/v: Encode(namespace, task-name, version) -> openapi.Task.
+	OpenAPITaskTemplateNamespaceVersionKeyAdapter KeyAdapter = keyHexEncoderDecoder("/dm-master/openapi-task-template-namespace-version/")
+	// OpenAPITaskTemplateLabelsKeyAdapter is used to store the labels of openapi task-config-template in the default
+	// namespace, they are stored apart from openapi.Task and removed together with the task-config-template.
+	// k/v: Encode(task-name) -> labels.
+	OpenAPITaskTemplateLabelsKeyAdapter KeyAdapter = keyHexEncoderDecoder("/dm-master/openapi-task-template-labels/")
 	// TaskCliArgsKeyAdapter is used to store the command line arguments of task. They are different from the task
 	// config because the command line arguments may be expected to take effect only once when failover.
 	// kv: Encode(task-name, source-id) -> TaskCliArgs.
@@ -117,13 +137,17 @@ func keyAdapterKeysLen(s KeyAdapter) int {
 	switch s {
 	case WorkerRegisterKeyAdapter, UpstreamConfigKeyAdapter, UpstreamBoundWorkerKeyAdapter,
 		WorkerKeepAliveKeyAdapter, StageRelayKeyAdapter,
-		UpstreamLastBoundWorkerKeyAdapter, UpstreamRelayWorkerKeyAdapter, OpenAPITaskTemplateKeyAdapter:
+		UpstreamLastBoundWorkerKeyAdapter, UpstreamRelayWorkerKeyAdapter, OpenAPITaskTemplateKeyAdapter,
+		OpenAPITaskTemplateLabelsKeyAdapter:
 		return 1
 	case UpstreamSubTaskKeyAdapter, StageSubTaskKeyAdapter, StageValidatorKeyAdapter,
 		ShardDDLPessimismInfoKeyAdapter, ShardDDLPessimismOperationKeyAdapter,
 		ShardDDLOptimismSourceTablesKeyAdapter, LoadTaskKeyAdapter, TaskCliArgsKeyAdapter,
-		LightningCoordinationKeyAdapter:
+		LightningCoordinationKeyAdapter, OpenAPITaskTemplateVersionKeyAdapter, OpenAPITaskTemplateDeletedKeyAdapter,
+		OpenAPITaskTemplateNamespaceKeyAdapter:
 		return 2
+	case OpenAPITaskTemplateNamespaceVersionKeyAdapter:
+		return 3
 	case ShardDDLOptimismInfoKeyAdapter, ShardDDLOptimismOperationKeyAdapter:
 		return 4
 	case ShardDDLOptimismDroppedColumnsKeyAdapter:
@@ -80,6 +80,31 @@ func (t *testCommon) TestKeyAdapter(c *check.C) {
 			adapter: OpenAPITaskTemplateKeyAdapter,
 			want:    "/dm-master/openapi-task-template/7461736b2d31",
 		},
+		{
+			keys:    []string{"task-1", "00000000000000000001"},
+			adapter: OpenAPITaskTemplateVersionKeyAdapter,
+			want:    "/dm-master/openapi-task-template-version/7461736b2d31/3030303030303030303030303030303030303031",
+		},
+		{
+			keys:    []string{"task-1", "01700000000000000000"},
+			adapter: OpenAPITaskTemplateDeletedKeyAdapter,
+			want:    "/dm-master/openapi-task-template-deleted/7461736b2d31/3031373030303030303030303030303030303030",
+		},
+		{
+			keys:    []string{"team-1", "task-1"},
+			adapter: OpenAPITaskTemplateNamespaceKeyAdapter,
+			want:    "/dm-master/openapi-task-template-namespace/7465616d2d31/7461736b2d31",
+		},
+		{
+			keys:    []string{"team-1", "task-1", "00000000000000000001"},
+			adapter: OpenAPITaskTemplateNamespaceVersionKeyAdapter,
+			want:    "/dm-master/openapi-task-template-namespace-version/7465616d2d31/7461736b2d31/3030303030303030303030303030303030303031",
+		},
+		{
+			keys:    []string{"task-1"},
+			adapter: OpenAPITaskTemplateLabelsKeyAdapter,
+			want:    "/dm-master/openapi-task-template-labels/7461736b2d31",
+		},
 	}
 
 	for _, ca := range testCases {
@@ -33,6 +33,7 @@ import (
 	"github.com/pingcap/tidb/pkg/util/dbutil"
 	"github.com/pingcap/tidb/pkg/util/filter"
 	regexprrouter "github.com/pingcap/tidb/pkg/util/regexpr-router"
+	tfilter "github.com/pingcap/tidb/pkg/util/table-filter"
 	router "github.com/pingcap/tidb/pkg/util/table-router"
 	"github.com/pingcap/tiflow/dm/config/dbconfig"
 	"github.com/pingcap/tiflow/dm/pkg/log"
@@ -432,9 +433,17 @@ func (c *SubTaskConfig) Adjust(verifyDecryptPassword bool) error {
 	if _, err := bf.NewBinlogEvent(c.CaseSensitive, c.FilterRules); err != nil {
 		return terror.ErrConfigBinlogEventFilter.Delegate(err)
 	}
+	if len(c.AppendOnlyTables) > 0 {
+		if _, err := tfilter.Parse(c.AppendOnlyTables); err != nil {
+			return terror.ErrConfigInvalidAppendOnlyTables.Delegate(err, c.AppendOnlyTables)
+		}
+	}
 	if err := c.LoaderConfig.adjust(); err != nil {
 		return err
 	}
+	if err := c.SyncerConfig.adjust(); err != nil {
+		return err
+	}
 	if err := c.ValidatorCfg.Adjust(); err != nil {
 		return err
 	}
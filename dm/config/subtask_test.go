@@ -166,6 +166,22 @@ func TestSubTaskAdjustFail(t *testing.T) {
 			},
 			"Message: online scheme rtc not supported",
 		},
+		{
+			func() *SubTaskConfig {
+				cfg := newSubTaskConfig()
+				cfg.ConflictWindowInterval = -1
+				return cfg
+			},
+			"Message: invalid -1 for `conflict-window-interval` of syncer config, it should not be negative",
+		},
+		{
+			func() *SubTaskConfig {
+				cfg := newSubTaskConfig()
+				cfg.CausalityQueueSize = -1
+				return cfg
+			},
+			"Message: invalid -1 for `causality-queue-size` of syncer config, it should not be negative",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -392,6 +392,17 @@ func (m *LoaderConfig) adjust() error {
 	return nil
 }
 
+// CausalityIndexRule restricts the causality keys of a downstream table to be generated only from the named
+// unique indexes, other unique indexes of the table don't participate in conflict detection. "primary" stands
+// for the primary key.
+// NOTE: omitting a unique index whose value may be changed by UPDATE can cause data inconsistency, because DMLs
+// conflicting on that index may be executed concurrently in the wrong order.
+type CausalityIndexRule struct {
+	TargetSchema string   `yaml:"target-schema" toml:"target-schema" json:"target-schema"`
+	TargetTable  string   `yaml:"target-table" toml:"target-table" json:"target-table"`
+	Indexes      []string `yaml:"indexes" toml:"indexes" json:"indexes"`
+}
+
 // SyncerConfig represents syncer process unit's specific config.
 type SyncerConfig struct {
 	MetaFile    string `yaml:"meta-file" toml:"meta-file" json:"meta-file"` // meta filename, used only when load SubConfig directly
@@ -403,6 +414,80 @@ type SyncerConfig struct {
 	// TODO: add this two new config items for openapi.
 	Compact      bool `yaml:"compact" toml:"compact" json:"compact"`
 	MultipleRows bool `yaml:"multiple-rows" toml:"multiple-rows" json:"multiple-rows"`
+	// max number of keys kept in causality before forcing a flush, 0 means unlimited.
+	MaxCausalityKeys int `yaml:"max-causality-keys" toml:"max-causality-keys" json:"max-causality-keys"`
+	// table patterns of append-only tables, DMLs of these tables skip causality conflict detection.
+	// NOTE: enabling it on a table which receives UPDATE or DELETE may cause data inconsistency.
+	AppendOnlyTables []string `yaml:"append-only-tables" toml:"append-only-tables" json:"append-only-tables"`
+	// hash the causality relation before dispatching DMLs to DML workers, to spread load more evenly.
+	HashCausalityKey bool `yaml:"hash-causality-key" toml:"hash-causality-key" json:"hash-causality-key"`
+	// max number of conflicting DMLs held by causality before sending one conflict job for all of them, 0 means
+	// sending a conflict job on every conflict.
+	ConflictWindowSize int `yaml:"conflict-window-size" toml:"conflict-window-size" json:"conflict-window-size"`
+	// max time in milliseconds a conflicting DML is held by causality, only used when conflict-window-size > 0.
+	ConflictWindowInterval int `yaml:"conflict-window-interval" toml:"conflict-window-interval" json:"conflict-window-interval"`
+	// UNSAFE, for analysis only. causality only records conflicts to metrics and logs without resolving them, which
+	// breaks the correctness of concurrent DMLs and may cause data inconsistency.
+	UnsafeCausalityDryRun bool `yaml:"unsafe-causality-dry-run" toml:"unsafe-causality-dry-run" json:"unsafe-causality-dry-run"`
+	// restrict the indexes used to generate causality keys of some downstream tables, see CausalityIndexRule.
+	CausalityIndexes []*CausalityIndexRule `yaml:"causality-indexes" toml:"causality-indexes" json:"causality-indexes"`
+	// keep a fixed-size hash instead of the full causality key in causality relation to reduce memory, a hash
+	// collision may cause a false conflict.
+	HashedCausalityKeys bool `yaml:"hashed-causality-keys" toml:"hashed-causality-keys" json:"hashed-causality-keys"`
+	// detect conflicts of all DMLs of an upstream transaction together and dispatch them to the same DML worker,
+	// it's ignored when compact is enabled.
+	AtomicTxnCausality bool `yaml:"atomic-txn-causality" toml:"atomic-txn-causality" json:"atomic-txn-causality"`
+	// time in milliseconds without any incoming job after which causality clears its relation if DML workers are
+	// drained, 0 means the relation is only released by flush and conflict jobs.
+	CausalityIdleInterval int `yaml:"causality-idle-interval" toml:"causality-idle-interval" json:"causality-idle-interval"`
+	// max number of groups kept in causality relation, the oldest groups are merged when exceeded, 0 means unlimited.
+	MaxCausalityGroups int `yaml:"max-causality-groups" toml:"max-causality-groups" json:"max-causality-groups"`
+	// max age in milliseconds of the oldest group of causality relation, all DML workers are flushed to clear the
+	// relation when exceeded, which bounds the relation if flush jobs are stalled. 0 means unlimited.
+	MaxCausalityGroupAge int `yaml:"max-causality-group-age" toml:"max-causality-group-age" json:"max-causality-group-age"`
+	// for debugging, the number of latest causality decisions kept in memory to replay offline, 0 means disabled.
+	CausalityDecisionLog int `yaml:"causality-decision-log" toml:"causality-decision-log" json:"causality-decision-log"`
+	// estimated number of keys in one group of causality relation to pre-size its map, which avoids rehashing as
+	// keys are added for large and predictable workloads. 0 means the map grows on demand.
+	CausalityRelationCapacity int `yaml:"causality-relation-capacity" toml:"causality-relation-capacity" json:"causality-relation-capacity"`
+	// time in seconds to wait for DML workers to be drained by a conflict job before reporting the stuck workers,
+	// the waiting continues after reporting. 0 means the default 10 minutes.
+	ConflictFlushTimeout int `yaml:"conflict-flush-timeout" toml:"conflict-flush-timeout" json:"conflict-flush-timeout"`
+	// fail the task when DML workers are not drained by a conflict job in conflict-flush-timeout.
+	FailOnConflictFlushTimeout bool `yaml:"fail-on-conflict-flush-timeout" toml:"fail-on-conflict-flush-timeout" json:"fail-on-conflict-flush-timeout"`
+	// buffer size of the channel from causality to DML workers, 0 means queue-size. every buffered job holds the row
+	// data of its DML, so a large buffer may take much memory for wide rows.
+	CausalityQueueSize int `yaml:"causality-queue-size" toml:"causality-queue-size" json:"causality-queue-size"`
+	// for staging, interval in milliseconds to check the invariants of causality relation, violations are logged
+	// and counted in metrics without failing the task. 0 means disabled.
+	CausalitySelfCheckInterval int `yaml:"causality-self-check-interval" toml:"causality-self-check-interval" json:"causality-self-check-interval"`
+	// send flush jobs to causality by a separate channel when compact is disabled, so they are not queued behind the
+	// buffered DMLs. they are still handled after all jobs sent before them.
+	PrioritizeCausalityFlush bool `yaml:"prioritize-causality-flush" toml:"prioritize-causality-flush" json:"prioritize-causality-flush"`
+	// only write 1 in N debug logs of causality for every DML and conflict, to capture a slice of its behavior under
+	// load. 0 or 1 means all debug logs are written.
+	CausalityLogSampleRate int `yaml:"causality-log-sample-rate" toml:"causality-log-sample-rate" json:"causality-log-sample-rate"`
+	// time in milliseconds causality is blocked on sending a job to DML workers before it logs a warning, which is
+	// repeated every interval until the job is sent, so operators can tell DML workers are the bottleneck. 0 means
+	// causality blocks silently, the blocked time is always observed in metrics.
+	CausalityBlockWarnInterval int `yaml:"causality-block-warn-interval" toml:"causality-block-warn-interval" json:"causality-block-warn-interval"`
+	// for staging, verify that rows of 1 in N causality keys are executed by DML workers in the order decided by
+	// causality, discrepancies are logged and counted. 0 means the verification is disabled.
+	CausalityVerifySampleRate int `yaml:"causality-verify-sample-rate" toml:"causality-verify-sample-rate" json:"causality-verify-sample-rate"`
+	// persist the causality relation of DMLs after the flushed checkpoint into the meta schema on every checkpoint
+	// flush, and restore it when the syncer restarts from the same checkpoint, so the replayed DMLs are dispatched to
+	// DML workers like before the restart. the persisted relation is discarded if it's not at the checkpoint.
+	PersistCausalityRelation bool `yaml:"persist-causality-relation" toml:"persist-causality-relation" json:"persist-causality-relation"`
+	// EXPERIMENTAL. on a conflict, only drain the DML workers of the conflicting relations and merge the relations,
+	// instead of draining all DML workers and clearing the relation. it keeps the correctness since all DMLs of a
+	// relation are executed in order by one DML worker, and DML workers don't dispatch DMLs until the drained workers
+	// are done. conflict-window-size is ignored when it's enabled.
+	ExperimentalPartialConflictFlush bool `yaml:"experimental-partial-conflict-flush" toml:"experimental-partial-conflict-flush" json:"experimental-partial-conflict-flush"`
+	// EXPERIMENTAL. the number of causality shards which detect conflicts in parallel, DMLs are partitioned across
+	// shards by their causality keys, and a DML whose keys are owned by different shards flushes all DML workers.
+	// 0 or 1 means a single causality. atomic-txn-causality, prioritize-causality-flush, causality-decision-log and
+	// persist-causality-relation are ignored when it's enabled, and the causality relation can't be dumped.
+	ExperimentalCausalityShards int `yaml:"experimental-causality-shards" toml:"experimental-causality-shards" json:"experimental-causality-shards"`
 
 	// deprecated
 	MaxRetry int `yaml:"max-retry" toml:"max-retry" json:"max-retry"`
@@ -442,6 +527,34 @@ func (m *SyncerConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	return nil
 }
 
+func (m *SyncerConfig) adjust() error {
+	for _, item := range []struct {
+		name  string
+		value int
+	}{
+		{"max-causality-keys", m.MaxCausalityKeys},
+		{"conflict-window-size", m.ConflictWindowSize},
+		{"conflict-window-interval", m.ConflictWindowInterval},
+		{"causality-idle-interval", m.CausalityIdleInterval},
+		{"max-causality-groups", m.MaxCausalityGroups},
+		{"max-causality-group-age", m.MaxCausalityGroupAge},
+		{"causality-decision-log", m.CausalityDecisionLog},
+		{"causality-relation-capacity", m.CausalityRelationCapacity},
+		{"conflict-flush-timeout", m.ConflictFlushTimeout},
+		{"causality-queue-size", m.CausalityQueueSize},
+		{"causality-self-check-interval", m.CausalitySelfCheckInterval},
+		{"causality-log-sample-rate", m.CausalityLogSampleRate},
+		{"causality-block-warn-interval", m.CausalityBlockWarnInterval},
+		{"causality-verify-sample-rate", m.CausalityVerifySampleRate},
+		{"experimental-causality-shards", m.ExperimentalCausalityShards},
+	} {
+		if item.value < 0 {
+			return terror.ErrConfigInvalidSyncerConfig.Generate(item.value, item.name)
+		}
+	}
+	return nil
+}
+
 type ValidatorConfig struct {
 	Mode               string   `yaml:"mode" toml:"mode" json:"mode"`
 	WorkerCount        int      `yaml:"worker-count" toml:"worker-count" json:"worker-count"`
@@ -898,6 +1011,9 @@ func (c *TaskConfig) adjust() error {
 		} else if inst.Syncer.SafeMode && duration == 0 {
 			return terror.ErrConfigConfictSafeModeDurationAndSafeMode.Generate()
 		}
+		if err := inst.Syncer.adjust(); err != nil {
+			return err
+		}
 		if inst.SyncerThread != 0 {
 			inst.Syncer.WorkerCount = inst.SyncerThread
 		}
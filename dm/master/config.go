@@ -28,6 +28,7 @@ import (
 
 	"github.com/BurntSushi/toml"
 	"github.com/pingcap/tiflow/dm/config/security"
+	"github.com/pingcap/tiflow/dm/pkg/ha"
 	"github.com/pingcap/tiflow/dm/pkg/log"
 	"github.com/pingcap/tiflow/dm/pkg/terror"
 	"github.com/pingcap/tiflow/dm/pkg/utils"
@@ -134,6 +135,15 @@ type Config struct {
 	QuotaBackendBytes       int64  `toml:"quota-backend-bytes" json:"quota-backend-bytes"`
 	OpenAPI                 bool   `toml:"openapi" json:"openapi"`
 
+	// OpenAPITaskTemplateEnvelope writes openapi task templates in the envelope with checksum, metadata and
+	// compression, which can't be read by dm-master of older versions, see ha.WithOpenAPITaskTemplateEnvelope.
+	OpenAPITaskTemplateEnvelope bool `toml:"openapi-task-template-envelope" json:"openapi-task-template-envelope"`
+	// OpenAPITaskTemplateVersionsToKeep is the max number of history versions kept for each openapi task template.
+	OpenAPITaskTemplateVersionsToKeep int `toml:"openapi-task-template-versions-to-keep" json:"openapi-task-template-versions-to-keep"`
+	// OpenAPITaskTemplateValidation validates openapi task templates before writing them, see
+	// ha.WithOpenAPITaskTemplateValidation.
+	OpenAPITaskTemplateValidation bool `toml:"openapi-task-template-validation" json:"openapi-task-template-validation"`
+
 	// directory path used to store source config files when upgrading from v1.0.x.
 	// if this path set, DM-master leader will try to upgrade from v1.0.x to the current version.
 	V1SourcesPath string `toml:"v1-sources-path" json:"v1-sources-path"`
@@ -337,6 +347,10 @@ func (c *Config) adjust() error {
 		c.QuotaBackendBytes = quotaBackendBytesLowerBound
 	}
 
+	if c.OpenAPITaskTemplateVersionsToKeep <= 0 {
+		c.OpenAPITaskTemplateVersionsToKeep = ha.DefaultOpenAPITaskTemplateVersionsToKeep
+	}
+
 	if c.ExperimentalFeatures.OpenAPI {
 		c.OpenAPI = true
 		c.ExperimentalFeatures.OpenAPI = false
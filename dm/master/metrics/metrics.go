@@ -18,6 +18,7 @@ import (
 	"time"
 
 	cpu "github.com/pingcap/tidb/pkg/util"
+	"github.com/pingcap/tiflow/dm/pkg/ha"
 	"github.com/pingcap/tiflow/engine/pkg/promutil"
 	"github.com/prometheus/client_golang/prometheus"
 )
@@ -127,6 +128,8 @@ func RegistryMetrics() {
 	registry.MustRegister(ddlErrCounter)
 	registry.MustRegister(workerEventErrCounter)
 	registry.MustRegister(startLeaderCounter)
+
+	ha.RegisterMetrics(registry)
 }
 
 // ReportWorkerStage is a setter for workerState.
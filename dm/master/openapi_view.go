@@ -809,7 +809,7 @@ func (s *Server) DMAPIImportTaskTemplate(c *gin.Context) {
 		SuccessTaskList: []string{},
 	}
 	for _, task := range config.SubTaskConfigsToOpenAPITaskList(s.scheduler.GetALlSubTaskCfgs()) {
-		if err := ha.PutOpenAPITaskTemplate(s.etcdClient, *task, req.Overwrite); err != nil {
+		if err := ha.PutOpenAPITaskTemplate(s.etcdClient, *task, req.Overwrite, s.openAPITaskTemplateOptions()...); err != nil {
 			resp.FailedTaskList = append(resp.FailedTaskList, struct {
 				ErrorMsg string `json:"error_msg"`
 				TaskName string `json:"task_name"`
@@ -842,7 +842,7 @@ func (s *Server) DMAPICreateTaskTemplate(c *gin.Context) {
 		_ = c.Error(terror.WithClass(adjustDBErr, terror.ClassDMMaster))
 		return
 	}
-	if err := ha.PutOpenAPITaskTemplate(s.etcdClient, *task, false); err != nil {
+	if err := ha.PutOpenAPITaskTemplate(s.etcdClient, *task, false, s.openAPITaskTemplateOptions()...); err != nil {
 		_ = c.Error(err)
 		return
 	}
@@ -904,13 +904,22 @@ func (s *Server) DMAPUpdateTaskTemplate(c *gin.Context, taskName string) {
 		_ = c.Error(terror.WithClass(adjustDBErr, terror.ClassDMMaster))
 		return
 	}
-	if err := ha.UpdateOpenAPITaskTemplate(s.etcdClient, *task); err != nil {
+	if err := ha.UpdateOpenAPITaskTemplate(s.etcdClient, *task, s.openAPITaskTemplateOptions()...); err != nil {
 		_ = c.Error(err)
 		return
 	}
 	c.IndentedJSON(http.StatusOK, task)
 }
 
+// openAPITaskTemplateOptions returns the options to write openapi task templates according to the config.
+func (s *Server) openAPITaskTemplateOptions() []ha.OpenAPITaskTemplateOption {
+	return []ha.OpenAPITaskTemplateOption{
+		ha.WithOpenAPITaskTemplateEnvelope(s.cfg.OpenAPITaskTemplateEnvelope),
+		ha.WithOpenAPITaskTemplateVersionsToKeep(s.cfg.OpenAPITaskTemplateVersionsToKeep),
+		ha.WithOpenAPITaskTemplateValidation(s.cfg.OpenAPITaskTemplateValidation),
+	}
+}
+
 func terrorHTTPErrorHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Next()
@@ -171,6 +171,77 @@ var (
 		"task_mode": "all"
 	}
 	`
+
+	multiSourceShardAndFilterTaskJSONStr = `
+	{
+		"binlog_filter_rule": {
+		  "filterA": { "ignore_event": ["drop database"], "ignore_sql": ["^Drop"] },
+		  "filterB": { "ignore_event": ["truncate table"] }
+		},
+		"enhance_online_schema_change": true,
+		"meta_schema": "dm_meta",
+		"name": "test",
+		"on_duplicate": "replace",
+		"shard_mode": "pessimistic",
+		"source_config": {
+		  "full_migrate_conf": {
+			"data_dir": "./exported_data",
+			"export_threads": 4,
+			"import_mode": "logical",
+			"import_threads": 16
+		  },
+		  "incr_migrate_conf": { "repl_batch": 200, "repl_threads": 32 },
+		  "source_conf": [
+			{
+			  "binlog_name": "mysql-bin.001",
+			  "binlog_pos": 4,
+			  "source_name": "mysql-replica-01"
+			},
+			{
+			  "binlog_gtid": "12e57f06-f360-11eb-8235-585cc2bc66c9:1-24",
+			  "source_name": "mysql-replica-02"
+			},
+			{ "source_name": "mysql-replica-03" }
+		  ]
+		},
+		"table_migrate_rule": [
+		  {
+			"binlog_filter_rule": ["filterA"],
+			"source": {
+			  "schema": "db_*",
+			  "source_name": "mysql-replica-01",
+			  "table": "tbl_*"
+			},
+			"target": { "schema": "db1", "table": "tbl" }
+		  },
+		  {
+			"binlog_filter_rule": ["filterA", "filterB"],
+			"source": {
+			  "schema": "db_*",
+			  "source_name": "mysql-replica-02",
+			  "table": "tbl_*"
+			},
+			"target": { "schema": "db1", "table": "tbl" }
+		  },
+		  {
+			"source": {
+			  "schema": "db_*",
+			  "source_name": "mysql-replica-03",
+			  "table": "tbl_*"
+			},
+			"target": { "schema": "db1", "table": "tbl" }
+		  }
+		],
+		"target_config": {
+		  "host": "root",
+		  "password": "123456",
+		  "port": 4000,
+		  "security": null,
+		  "user": "root"
+		},
+		"task_mode": "all"
+	}
+	`
 )
 
 // GenNoShardOpenAPITaskForTest generates a no-shard openapi.Task for test.
@@ -202,3 +273,31 @@ func GenShardAndFilterOpenAPITaskForTest() (openapi.Task, error) {
 	err := json.Unmarshal([]byte(shardAndFilterTaskJSONStr), &t)
 	return t, err
 }
+
+// GenPessimisticShardAndFilterOpenAPITaskForTest generates a shard-and-filter openapi.Task in pessimistic shard mode
+// for test.
+func GenPessimisticShardAndFilterOpenAPITaskForTest() (openapi.Task, error) {
+	t, err := GenShardAndFilterOpenAPITaskForTest()
+	shardMode := openapi.TaskShardModePessimistic
+	t.ShardMode = &shardMode
+	// strict_optimistic_shard_mode only works in optimistic shard mode.
+	t.StrictOptimisticShardMode = nil
+	return t, err
+}
+
+// GenOptimisticShardAndFilterOpenAPITaskForTest generates a shard-and-filter openapi.Task in non-strict optimistic
+// shard mode for test, GenShardAndFilterOpenAPITaskForTest generates the strict one.
+func GenOptimisticShardAndFilterOpenAPITaskForTest() (openapi.Task, error) {
+	t, err := GenShardAndFilterOpenAPITaskForTest()
+	strict := false
+	t.StrictOptimisticShardMode = &strict
+	return t, err
+}
+
+// GenMultiSourceShardAndFilterOpenAPITaskForTest generates a shard-and-filter openapi.Task in pessimistic shard mode
+// with three upstream sources for test.
+func GenMultiSourceShardAndFilterOpenAPITaskForTest() (openapi.Task, error) {
+	t := openapi.Task{}
+	err := json.Unmarshal([]byte(multiSourceShardAndFilterTaskJSONStr), &t)
+	return t, err
+}
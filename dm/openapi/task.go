@@ -15,10 +15,19 @@ package openapi
 
 import (
 	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
 )
 
 var defaultMetaSchema = "dm_meta"
 
+const (
+	openAPISchemaRefPrefix = "#/components/schemas/"
+	jsonSchemaRefPrefix    = "#/definitions/"
+)
+
 // Adjust adjusts task and set default value.
 func (t *Task) Adjust() error {
 	if t.MetaSchema == nil {
@@ -36,3 +45,222 @@ func (t *Task) FromJSON(data []byte) error {
 func (t *Task) ToJSON() ([]byte, error) {
 	return json.Marshal(t)
 }
+
+// FieldChange is a changed field between two tasks.
+type FieldChange struct {
+	// Path is the JSON path of the field, like `source_config.source_conf[0].binlog_name`.
+	Path string
+	// Old and New are the values of the field, nil means the field is not set.
+	Old interface{}
+	New interface{}
+}
+
+// DiffTask returns the changed fields from oldTask to newTask, the runtime status of task is ignored.
+// the order of elements in slices is not taken into account, reordered slices are treated as equal.
+func DiffTask(oldTask, newTask Task) []FieldChange {
+	var changes []FieldChange
+	diffValue("", reflect.ValueOf(oldTask), reflect.ValueOf(newTask), &changes)
+	return changes
+}
+
+func diffValue(path string, oldV, newV reflect.Value, changes *[]FieldChange) {
+	switch oldV.Kind() {
+	case reflect.Ptr:
+		if oldV.IsNil() && newV.IsNil() {
+			return
+		}
+		if oldV.IsNil() || newV.IsNil() {
+			*changes = append(*changes, FieldChange{Path: path, Old: interfaceOf(oldV), New: interfaceOf(newV)})
+			return
+		}
+		diffValue(path, oldV.Elem(), newV.Elem(), changes)
+	case reflect.Struct:
+		for i := 0; i < oldV.NumField(); i++ {
+			name := strings.Split(oldV.Type().Field(i).Tag.Get("json"), ",")[0]
+			switch name {
+			case "status_list":
+				// runtime status is not a part of task config.
+				continue
+			case "-":
+				// fields like additional properties are marshaled into the parent.
+				diffValue(path, oldV.Field(i), newV.Field(i), changes)
+			default:
+				diffValue(joinFieldPath(path, name), oldV.Field(i), newV.Field(i), changes)
+			}
+		}
+	case reflect.Map:
+		keys := make(map[string]reflect.Value, oldV.Len()+newV.Len())
+		for _, key := range append(oldV.MapKeys(), newV.MapKeys()...) {
+			keys[fmt.Sprint(key.Interface())] = key
+		}
+		names := make([]string, 0, len(keys))
+		for name := range keys {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			oldElem, newElem := oldV.MapIndex(keys[name]), newV.MapIndex(keys[name])
+			if !oldElem.IsValid() || !newElem.IsValid() {
+				*changes = append(*changes, FieldChange{
+					Path: joinFieldPath(path, name), Old: interfaceOf(oldElem), New: interfaceOf(newElem),
+				})
+				continue
+			}
+			diffValue(joinFieldPath(path, name), oldElem, newElem, changes)
+		}
+	case reflect.Slice:
+		if sameElements(oldV, newV) {
+			return
+		}
+		if oldV.Type().Elem().Kind() != reflect.Struct {
+			*changes = append(*changes, FieldChange{Path: path, Old: interfaceOf(oldV), New: interfaceOf(newV)})
+			return
+		}
+		// compare struct elements one by one, so the changed fields of them can be reported.
+		for i := 0; i < oldV.Len() || i < newV.Len(); i++ {
+			elemPath := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case i >= oldV.Len():
+				*changes = append(*changes, FieldChange{Path: elemPath, New: newV.Index(i).Interface()})
+			case i >= newV.Len():
+				*changes = append(*changes, FieldChange{Path: elemPath, Old: oldV.Index(i).Interface()})
+			default:
+				diffValue(elemPath, oldV.Index(i), newV.Index(i), changes)
+			}
+		}
+	default:
+		if !reflect.DeepEqual(oldV.Interface(), newV.Interface()) {
+			*changes = append(*changes, FieldChange{Path: path, Old: oldV.Interface(), New: newV.Interface()})
+		}
+	}
+}
+
+// sameElements checks whether two slices have the same elements regardless of order.
+func sameElements(oldV, newV reflect.Value) bool {
+	if oldV.Len() != newV.Len() {
+		return false
+	}
+	matched := make([]bool, newV.Len())
+	for i := 0; i < oldV.Len(); i++ {
+		found := false
+		for j := 0; j < newV.Len(); j++ {
+			if !matched[j] && reflect.DeepEqual(oldV.Index(i).Interface(), newV.Index(j).Interface()) {
+				matched[j] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// interfaceOf returns the underlying value of v, or nil if v is invalid or a nil pointer.
+func interfaceOf(v reflect.Value) interface{} {
+	if !v.IsValid() {
+		return nil
+	}
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	return v.Interface()
+}
+
+func joinFieldPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// TaskJSONSchema returns the JSON Schema (draft-07) of Task, which is converted from the schemas in the OpenAPI
+// specification, so clients can validate task configs the same as the server. the referenced schemas are put in
+// `definitions`, and the OpenAPI only keywords are converted or removed.
+func TaskJSONSchema() ([]byte, error) {
+	swagger, err := GetSwagger()
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(swagger.Components.Schemas)
+	if err != nil {
+		return nil, err
+	}
+	var schemas map[string]interface{}
+	if err = json.Unmarshal(data, &schemas); err != nil {
+		return nil, err
+	}
+
+	definitions := make(map[string]interface{})
+	pending := []string{"Task"}
+	for len(pending) > 0 {
+		name := pending[0]
+		pending = pending[1:]
+		if _, ok := definitions[name]; ok {
+			continue
+		}
+		schema, ok := schemas[name]
+		if !ok {
+			return nil, fmt.Errorf("schema %s is not found in the OpenAPI specification", name)
+		}
+		definitions[name] = toJSONSchema(schema, &pending)
+	}
+	return json.MarshalIndent(map[string]interface{}{
+		"$schema":     "http://json-schema.org/draft-07/schema#",
+		"title":       "Task",
+		"$ref":        jsonSchemaRefPrefix + "Task",
+		"definitions": definitions,
+	}, "", "  ")
+}
+
+// toJSONSchema converts the decoded OpenAPI schema v to JSON Schema, and appends the names of referenced schemas to
+// refs. `nullable` is converted to a "null" type, and `example` is removed.
+func toJSONSchema(v interface{}, refs *[]string) interface{} {
+	schema, ok := v.(map[string]interface{})
+	if !ok {
+		// like `additionalProperties: true`.
+		return v
+	}
+	ret := make(map[string]interface{}, len(schema))
+	for key, value := range schema {
+		switch key {
+		case "$ref":
+			if ref, ok := value.(string); ok && strings.HasPrefix(ref, openAPISchemaRefPrefix) {
+				name := strings.TrimPrefix(ref, openAPISchemaRefPrefix)
+				*refs = append(*refs, name)
+				value = jsonSchemaRefPrefix + name
+			}
+		case "example", "nullable":
+			continue
+		case "properties":
+			if properties, ok := value.(map[string]interface{}); ok {
+				converted := make(map[string]interface{}, len(properties))
+				for name, property := range properties {
+					converted[name] = toJSONSchema(property, refs)
+				}
+				value = converted
+			}
+		case "items", "additionalProperties", "not":
+			value = toJSONSchema(value, refs)
+		case "allOf", "anyOf", "oneOf":
+			if subSchemas, ok := value.([]interface{}); ok {
+				converted := make([]interface{}, 0, len(subSchemas))
+				for _, subSchema := range subSchemas {
+					converted = append(converted, toJSONSchema(subSchema, refs))
+				}
+				value = converted
+			}
+		}
+		ret[key] = value
+	}
+	if nullable, _ := schema["nullable"].(bool); nullable {
+		if tp, ok := ret["type"].(string); ok {
+			ret["type"] = []interface{}{tp, "null"}
+		}
+	}
+	return ret
+}
@@ -14,6 +14,9 @@
 package openapi
 
 import (
+	"encoding/json"
+	"sort"
+	"strings"
 	"testing"
 
 	"github.com/pingcap/check"
@@ -39,3 +42,111 @@ func (t *taskSuite) TestTaskAdjust(c *check.C) {
 	c.Assert(task3.Adjust(), check.IsNil)
 	c.Assert(*task3.MetaSchema, check.Equals, defaultMetaSchema)
 }
+
+func (t *taskSuite) TestDiffTask(c *check.C) {
+	meta := "dm_meta"
+	binlogName := "mysql-bin.000001"
+	ignoreEvent := []string{"truncate table", "drop table"}
+	task1 := Task{
+		Name:         "test",
+		MetaSchema:   &meta,
+		TaskMode:     TaskTaskModeAll,
+		OnDuplicate:  TaskOnDuplicateError,
+		TargetConfig: TaskTargetDataBase{Host: "127.0.0.1", Port: 4000, User: "root"},
+		SourceConfig: TaskSourceConfig{SourceConf: []TaskSourceConf{
+			{SourceName: "mysql-01"},
+			{SourceName: "mysql-02"},
+		}},
+		TableMigrateRule: []TaskTableMigrateRule{
+			{Source: TaskTableMigrateRuleSource{SourceName: "mysql-01", Schema: "db", Table: "t1"}},
+			{Source: TaskTableMigrateRuleSource{SourceName: "mysql-02", Schema: "db", Table: "t2"}},
+		},
+		BinlogFilterRule: &Task_BinlogFilterRule{AdditionalProperties: map[string]TaskBinLogFilterRule{
+			"filter-1": {IgnoreEvent: &ignoreEvent},
+		}},
+	}
+	c.Assert(DiffTask(task1, task1), check.HasLen, 0)
+
+	// reordered slices are equal.
+	ignoreEvent2 := []string{"drop table", "truncate table"}
+	task2 := task1
+	task2.SourceConfig.SourceConf = []TaskSourceConf{task1.SourceConfig.SourceConf[1], task1.SourceConfig.SourceConf[0]}
+	task2.TableMigrateRule = []TaskTableMigrateRule{task1.TableMigrateRule[1], task1.TableMigrateRule[0]}
+	task2.BinlogFilterRule = &Task_BinlogFilterRule{AdditionalProperties: map[string]TaskBinLogFilterRule{
+		"filter-1": {IgnoreEvent: &ignoreEvent2},
+	}}
+	task2.StatusList = &[]SubTaskStatus{{Name: "test"}}
+	c.Assert(DiffTask(task1, task2), check.HasLen, 0)
+
+	// changed fields.
+	ignoreEvent3 := []string{"drop table"}
+	task3 := task1
+	task3.TaskMode = TaskTaskModeIncremental
+	task3.MetaSchema = nil
+	task3.TargetConfig.Port = 4001
+	task3.SourceConfig.SourceConf = []TaskSourceConf{{SourceName: "mysql-01", BinlogName: &binlogName}, {SourceName: "mysql-02"}}
+	task3.TableMigrateRule = append([]TaskTableMigrateRule{}, task1.TableMigrateRule...)
+	task3.TableMigrateRule = append(task3.TableMigrateRule, TaskTableMigrateRule{
+		Source: TaskTableMigrateRuleSource{SourceName: "mysql-02", Schema: "db", Table: "t3"},
+	})
+	task3.BinlogFilterRule = &Task_BinlogFilterRule{AdditionalProperties: map[string]TaskBinLogFilterRule{
+		"filter-1": {IgnoreEvent: &ignoreEvent3},
+		"filter-2": {IgnoreEvent: &ignoreEvent},
+	}}
+	c.Assert(DiffTask(task1, task3), check.DeepEquals, []FieldChange{
+		{Path: "binlog_filter_rule.filter-1.ignore_event", Old: ignoreEvent, New: ignoreEvent3},
+		{Path: "binlog_filter_rule.filter-2", New: TaskBinLogFilterRule{IgnoreEvent: &ignoreEvent}},
+		{Path: "meta_schema", Old: meta},
+		{Path: "source_config.source_conf[0].binlog_name", New: binlogName},
+		{Path: "table_migrate_rule[2]", New: task3.TableMigrateRule[2]},
+		{Path: "target_config.port", Old: 4000, New: 4001},
+		{Path: "task_mode", Old: TaskTaskModeAll, New: TaskTaskModeIncremental},
+	})
+}
+
+func (t *taskSuite) TestTaskJSONSchema(c *check.C) {
+	data, err := TaskJSONSchema()
+	c.Assert(err, check.IsNil)
+	var schema struct {
+		Schema      string                            `json:"$schema"`
+		Ref         string                            `json:"$ref"`
+		Definitions map[string]map[string]interface{} `json:"definitions"`
+	}
+	c.Assert(json.Unmarshal(data, &schema), check.IsNil)
+	c.Assert(schema.Schema, check.Equals, "http://json-schema.org/draft-07/schema#")
+	c.Assert(schema.Ref, check.Equals, "#/definitions/Task")
+	// only the schemas referenced by Task are included.
+	names := make([]string, 0, len(schema.Definitions))
+	for name := range schema.Definitions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	c.Assert(names, check.DeepEquals, []string{
+		"DumpStatus", "LoadStatus", "Security", "ShardingGroup", "SubTaskStatus", "SyncStatus", "Task",
+		"TaskBinLogFilterRule", "TaskFullMigrateConf", "TaskIncrMigrateConf", "TaskSourceConf", "TaskSourceConfig",
+		"TaskStage", "TaskTableMigrateRule", "TaskTableMigrateRuleSource", "TaskTableMigrateRuleTarget",
+		"TaskTargetDataBase",
+	})
+	c.Assert(strings.Contains(string(data), "#/components/"), check.IsFalse)
+
+	task := schema.Definitions["Task"]
+	c.Assert(task["type"], check.Equals, "object")
+	c.Assert(task["required"], check.DeepEquals, []interface{}{
+		"name", "task_mode", "enhance_online_schema_change", "on_duplicate", "target_config", "table_migrate_rule", "source_config",
+	})
+	properties := task["properties"].(map[string]interface{})
+	taskMode := properties["task_mode"].(map[string]interface{})
+	c.Assert(taskMode["enum"], check.DeepEquals, []interface{}{"full", "incremental", "all", "dump", "load"})
+	c.Assert(taskMode["example"], check.IsNil)
+	c.Assert(properties["source_config"], check.DeepEquals, map[string]interface{}{"$ref": "#/definitions/TaskSourceConfig"})
+	tableMigrateRule := properties["table_migrate_rule"].(map[string]interface{})
+	c.Assert(tableMigrateRule["items"], check.DeepEquals, map[string]interface{}{"$ref": "#/definitions/TaskTableMigrateRule"})
+	// nullable is converted to a "null" type.
+	c.Assert(schema.Definitions["Security"]["type"], check.DeepEquals, []interface{}{"object", "null"})
+	c.Assert(schema.Definitions["Security"]["nullable"], check.IsNil)
+
+	// the output is stable.
+	data2, err := TaskJSONSchema()
+	c.Assert(err, check.IsNil)
+	c.Assert(string(data2), check.Equals, string(data))
+}
@@ -965,6 +965,8 @@ type SyncStatus struct {
 	IoTotalBytes uint64 `protobuf:"varint,18,opt,name=ioTotalBytes,proto3" json:"ioTotalBytes,omitempty"`
 	// meter TCP io from upstream of the subtask
 	DumpIOTotalBytes uint64 `protobuf:"varint,19,opt,name=dumpIOTotalBytes,proto3" json:"dumpIOTotalBytes,omitempty"`
+	// conflict statistics of causality, it's nil before the first statistics are published
+	Causality *CausalityStatus `protobuf:"bytes,20,opt,name=causality,proto3" json:"causality,omitempty"`
 }
 
 func (m *SyncStatus) Reset()         { *m = SyncStatus{} }
@@ -1133,6 +1135,87 @@ func (m *SyncStatus) GetDumpIOTotalBytes() uint64 {
 	return 0
 }
 
+func (m *SyncStatus) GetCausality() *CausalityStatus {
+	if m != nil {
+		return m.Causality
+	}
+	return nil
+}
+
+// CausalityStatus represents the conflict statistics of causality in sync unit
+type CausalityStatus struct {
+	// conflicts per second in the last statistics interval
+	ConflictsPerSecond float64 `protobuf:"fixed64,1,opt,name=conflictsPerSecond,proto3" json:"conflictsPerSecond,omitempty"`
+	// number of keys in causality relation
+	RelationSize int64 `protobuf:"varint,2,opt,name=relationSize,proto3" json:"relationSize,omitempty"`
+	// max number of DMLs dispatched to a DML worker divided by the average in the last statistics interval,
+	// 1 means DMLs are dispatched evenly
+	WorkerSkew float64 `protobuf:"fixed64,3,opt,name=workerSkew,proto3" json:"workerSkew,omitempty"`
+	// number of conflicts since causality starts, it's not reset when causality relation is cleared
+	TotalConflicts int64 `protobuf:"varint,4,opt,name=totalConflicts,proto3" json:"totalConflicts,omitempty"`
+}
+
+func (m *CausalityStatus) Reset()         { *m = CausalityStatus{} }
+func (m *CausalityStatus) String() string { return proto.CompactTextString(m) }
+func (*CausalityStatus) ProtoMessage()    {}
+func (*CausalityStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51a1b9e17fd67b10, []int{8}
+}
+func (m *CausalityStatus) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CausalityStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CausalityStatus.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CausalityStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CausalityStatus.Merge(m, src)
+}
+func (m *CausalityStatus) XXX_Size() int {
+	return m.Size()
+}
+func (m *CausalityStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_CausalityStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CausalityStatus proto.InternalMessageInfo
+
+func (m *CausalityStatus) GetConflictsPerSecond() float64 {
+	if m != nil {
+		return m.ConflictsPerSecond
+	}
+	return 0
+}
+
+func (m *CausalityStatus) GetRelationSize() int64 {
+	if m != nil {
+		return m.RelationSize
+	}
+	return 0
+}
+
+func (m *CausalityStatus) GetWorkerSkew() float64 {
+	if m != nil {
+		return m.WorkerSkew
+	}
+	return 0
+}
+
+func (m *CausalityStatus) GetTotalConflicts() int64 {
+	if m != nil {
+		return m.TotalConflicts
+	}
+	return 0
+}
+
 // SourceStatus represents status for source runing on dm-worker
 type SourceStatus struct {
 	Source      string         `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
@@ -1145,7 +1228,7 @@ func (m *SourceStatus) Reset()         { *m = SourceStatus{} }
 func (m *SourceStatus) String() string { return proto.CompactTextString(m) }
 func (*SourceStatus) ProtoMessage()    {}
 func (*SourceStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{8}
+	return fileDescriptor_51a1b9e17fd67b10, []int{9}
 }
 func (m *SourceStatus) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1218,7 +1301,7 @@ func (m *RelayStatus) Reset()         { *m = RelayStatus{} }
 func (m *RelayStatus) String() string { return proto.CompactTextString(m) }
 func (*RelayStatus) ProtoMessage()    {}
 func (*RelayStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{9}
+	return fileDescriptor_51a1b9e17fd67b10, []int{10}
 }
 func (m *RelayStatus) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1337,7 +1420,7 @@ func (m *SubTaskStatus) Reset()         { *m = SubTaskStatus{} }
 func (m *SubTaskStatus) String() string { return proto.CompactTextString(m) }
 func (*SubTaskStatus) ProtoMessage()    {}
 func (*SubTaskStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{10}
+	return fileDescriptor_51a1b9e17fd67b10, []int{11}
 }
 func (m *SubTaskStatus) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1498,7 +1581,7 @@ func (m *SubTaskStatusList) Reset()         { *m = SubTaskStatusList{} }
 func (m *SubTaskStatusList) String() string { return proto.CompactTextString(m) }
 func (*SubTaskStatusList) ProtoMessage()    {}
 func (*SubTaskStatusList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{11}
+	return fileDescriptor_51a1b9e17fd67b10, []int{12}
 }
 func (m *SubTaskStatusList) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1544,7 +1627,7 @@ func (m *CheckError) Reset()         { *m = CheckError{} }
 func (m *CheckError) String() string { return proto.CompactTextString(m) }
 func (*CheckError) ProtoMessage()    {}
 func (*CheckError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{12}
+	return fileDescriptor_51a1b9e17fd67b10, []int{13}
 }
 func (m *CheckError) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1590,7 +1673,7 @@ func (m *DumpError) Reset()         { *m = DumpError{} }
 func (m *DumpError) String() string { return proto.CompactTextString(m) }
 func (*DumpError) ProtoMessage()    {}
 func (*DumpError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{13}
+	return fileDescriptor_51a1b9e17fd67b10, []int{14}
 }
 func (m *DumpError) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1635,7 +1718,7 @@ func (m *LoadError) Reset()         { *m = LoadError{} }
 func (m *LoadError) String() string { return proto.CompactTextString(m) }
 func (*LoadError) ProtoMessage()    {}
 func (*LoadError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{14}
+	return fileDescriptor_51a1b9e17fd67b10, []int{15}
 }
 func (m *LoadError) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1682,7 +1765,7 @@ func (m *SyncSQLError) Reset()         { *m = SyncSQLError{} }
 func (m *SyncSQLError) String() string { return proto.CompactTextString(m) }
 func (*SyncSQLError) ProtoMessage()    {}
 func (*SyncSQLError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{15}
+	return fileDescriptor_51a1b9e17fd67b10, []int{16}
 }
 func (m *SyncSQLError) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1741,7 +1824,7 @@ func (m *SyncError) Reset()         { *m = SyncError{} }
 func (m *SyncError) String() string { return proto.CompactTextString(m) }
 func (*SyncError) ProtoMessage()    {}
 func (*SyncError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{16}
+	return fileDescriptor_51a1b9e17fd67b10, []int{17}
 }
 func (m *SyncError) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1789,7 +1872,7 @@ func (m *SourceError) Reset()         { *m = SourceError{} }
 func (m *SourceError) String() string { return proto.CompactTextString(m) }
 func (*SourceError) ProtoMessage()    {}
 func (*SourceError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{17}
+	return fileDescriptor_51a1b9e17fd67b10, []int{18}
 }
 func (m *SourceError) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1855,7 +1938,7 @@ func (m *RelayError) Reset()         { *m = RelayError{} }
 func (m *RelayError) String() string { return proto.CompactTextString(m) }
 func (*RelayError) ProtoMessage()    {}
 func (*RelayError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{18}
+	return fileDescriptor_51a1b9e17fd67b10, []int{19}
 }
 func (m *RelayError) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1916,7 +1999,7 @@ func (m *SubTaskError) Reset()         { *m = SubTaskError{} }
 func (m *SubTaskError) String() string { return proto.CompactTextString(m) }
 func (*SubTaskError) ProtoMessage()    {}
 func (*SubTaskError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{19}
+	return fileDescriptor_51a1b9e17fd67b10, []int{20}
 }
 func (m *SubTaskError) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2056,7 +2139,7 @@ func (m *SubTaskErrorList) Reset()         { *m = SubTaskErrorList{} }
 func (m *SubTaskErrorList) String() string { return proto.CompactTextString(m) }
 func (*SubTaskErrorList) ProtoMessage()    {}
 func (*SubTaskErrorList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{20}
+	return fileDescriptor_51a1b9e17fd67b10, []int{21}
 }
 func (m *SubTaskErrorList) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2108,7 +2191,7 @@ func (m *ProcessResult) Reset()         { *m = ProcessResult{} }
 func (m *ProcessResult) String() string { return proto.CompactTextString(m) }
 func (*ProcessResult) ProtoMessage()    {}
 func (*ProcessResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{21}
+	return fileDescriptor_51a1b9e17fd67b10, []int{22}
 }
 func (m *ProcessResult) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2175,7 +2258,7 @@ func (m *ProcessError) Reset()         { *m = ProcessError{} }
 func (m *ProcessError) String() string { return proto.CompactTextString(m) }
 func (*ProcessError) ProtoMessage()    {}
 func (*ProcessError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{22}
+	return fileDescriptor_51a1b9e17fd67b10, []int{23}
 }
 func (m *ProcessError) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2269,7 +2352,7 @@ func (m *PurgeRelayRequest) Reset()         { *m = PurgeRelayRequest{} }
 func (m *PurgeRelayRequest) String() string { return proto.CompactTextString(m) }
 func (*PurgeRelayRequest) ProtoMessage()    {}
 func (*PurgeRelayRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{23}
+	return fileDescriptor_51a1b9e17fd67b10, []int{24}
 }
 func (m *PurgeRelayRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2343,7 +2426,7 @@ func (m *OperateWorkerSchemaRequest) Reset()         { *m = OperateWorkerSchemaR
 func (m *OperateWorkerSchemaRequest) String() string { return proto.CompactTextString(m) }
 func (*OperateWorkerSchemaRequest) ProtoMessage()    {}
 func (*OperateWorkerSchemaRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{24}
+	return fileDescriptor_51a1b9e17fd67b10, []int{25}
 }
 func (m *OperateWorkerSchemaRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2454,7 +2537,7 @@ func (m *V1SubTaskMeta) Reset()         { *m = V1SubTaskMeta{} }
 func (m *V1SubTaskMeta) String() string { return proto.CompactTextString(m) }
 func (*V1SubTaskMeta) ProtoMessage()    {}
 func (*V1SubTaskMeta) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{25}
+	return fileDescriptor_51a1b9e17fd67b10, []int{26}
 }
 func (m *V1SubTaskMeta) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2519,7 +2602,7 @@ func (m *OperateV1MetaRequest) Reset()         { *m = OperateV1MetaRequest{} }
 func (m *OperateV1MetaRequest) String() string { return proto.CompactTextString(m) }
 func (*OperateV1MetaRequest) ProtoMessage()    {}
 func (*OperateV1MetaRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{26}
+	return fileDescriptor_51a1b9e17fd67b10, []int{27}
 }
 func (m *OperateV1MetaRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2565,7 +2648,7 @@ func (m *OperateV1MetaResponse) Reset()         { *m = OperateV1MetaResponse{} }
 func (m *OperateV1MetaResponse) String() string { return proto.CompactTextString(m) }
 func (*OperateV1MetaResponse) ProtoMessage()    {}
 func (*OperateV1MetaResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{27}
+	return fileDescriptor_51a1b9e17fd67b10, []int{28}
 }
 func (m *OperateV1MetaResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2626,7 +2709,7 @@ func (m *HandleWorkerErrorRequest) Reset()         { *m = HandleWorkerErrorReque
 func (m *HandleWorkerErrorRequest) String() string { return proto.CompactTextString(m) }
 func (*HandleWorkerErrorRequest) ProtoMessage()    {}
 func (*HandleWorkerErrorRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{28}
+	return fileDescriptor_51a1b9e17fd67b10, []int{29}
 }
 func (m *HandleWorkerErrorRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2690,7 +2773,7 @@ func (m *GetWorkerCfgRequest) Reset()         { *m = GetWorkerCfgRequest{} }
 func (m *GetWorkerCfgRequest) String() string { return proto.CompactTextString(m) }
 func (*GetWorkerCfgRequest) ProtoMessage()    {}
 func (*GetWorkerCfgRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{29}
+	return fileDescriptor_51a1b9e17fd67b10, []int{30}
 }
 func (m *GetWorkerCfgRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2727,7 +2810,7 @@ func (m *GetWorkerCfgResponse) Reset()         { *m = GetWorkerCfgResponse{} }
 func (m *GetWorkerCfgResponse) String() string { return proto.CompactTextString(m) }
 func (*GetWorkerCfgResponse) ProtoMessage()    {}
 func (*GetWorkerCfgResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{30}
+	return fileDescriptor_51a1b9e17fd67b10, []int{31}
 }
 func (m *GetWorkerCfgResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2771,7 +2854,7 @@ func (m *CheckSubtasksCanUpdateRequest) Reset()         { *m = CheckSubtasksCanU
 func (m *CheckSubtasksCanUpdateRequest) String() string { return proto.CompactTextString(m) }
 func (*CheckSubtasksCanUpdateRequest) ProtoMessage()    {}
 func (*CheckSubtasksCanUpdateRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{31}
+	return fileDescriptor_51a1b9e17fd67b10, []int{32}
 }
 func (m *CheckSubtasksCanUpdateRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2816,7 +2899,7 @@ func (m *CheckSubtasksCanUpdateResponse) Reset()         { *m = CheckSubtasksCan
 func (m *CheckSubtasksCanUpdateResponse) String() string { return proto.CompactTextString(m) }
 func (*CheckSubtasksCanUpdateResponse) ProtoMessage()    {}
 func (*CheckSubtasksCanUpdateResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{32}
+	return fileDescriptor_51a1b9e17fd67b10, []int{33}
 }
 func (m *CheckSubtasksCanUpdateResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2868,7 +2951,7 @@ func (m *GetValidationStatusRequest) Reset()         { *m = GetValidationStatusR
 func (m *GetValidationStatusRequest) String() string { return proto.CompactTextString(m) }
 func (*GetValidationStatusRequest) ProtoMessage()    {}
 func (*GetValidationStatusRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{33}
+	return fileDescriptor_51a1b9e17fd67b10, []int{34}
 }
 func (m *GetValidationStatusRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2930,7 +3013,7 @@ func (m *ValidationStatus) Reset()         { *m = ValidationStatus{} }
 func (m *ValidationStatus) String() string { return proto.CompactTextString(m) }
 func (*ValidationStatus) ProtoMessage()    {}
 func (*ValidationStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{34}
+	return fileDescriptor_51a1b9e17fd67b10, []int{35}
 }
 func (m *ValidationStatus) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3055,7 +3138,7 @@ func (m *ValidationTableStatus) Reset()         { *m = ValidationTableStatus{} }
 func (m *ValidationTableStatus) String() string { return proto.CompactTextString(m) }
 func (*ValidationTableStatus) ProtoMessage()    {}
 func (*ValidationTableStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{35}
+	return fileDescriptor_51a1b9e17fd67b10, []int{36}
 }
 func (m *ValidationTableStatus) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3130,7 +3213,7 @@ func (m *GetValidationStatusResponse) Reset()         { *m = GetValidationStatus
 func (m *GetValidationStatusResponse) String() string { return proto.CompactTextString(m) }
 func (*GetValidationStatusResponse) ProtoMessage()    {}
 func (*GetValidationStatusResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{36}
+	return fileDescriptor_51a1b9e17fd67b10, []int{37}
 }
 func (m *GetValidationStatusResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3196,7 +3279,7 @@ func (m *GetValidationErrorRequest) Reset()         { *m = GetValidationErrorReq
 func (m *GetValidationErrorRequest) String() string { return proto.CompactTextString(m) }
 func (*GetValidationErrorRequest) ProtoMessage()    {}
 func (*GetValidationErrorRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{37}
+	return fileDescriptor_51a1b9e17fd67b10, []int{38}
 }
 func (m *GetValidationErrorRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3256,7 +3339,7 @@ func (m *ValidationError) Reset()         { *m = ValidationError{} }
 func (m *ValidationError) String() string { return proto.CompactTextString(m) }
 func (*ValidationError) ProtoMessage()    {}
 func (*ValidationError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{38}
+	return fileDescriptor_51a1b9e17fd67b10, []int{39}
 }
 func (m *ValidationError) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3365,7 +3448,7 @@ func (m *GetValidationErrorResponse) Reset()         { *m = GetValidationErrorRe
 func (m *GetValidationErrorResponse) String() string { return proto.CompactTextString(m) }
 func (*GetValidationErrorResponse) ProtoMessage()    {}
 func (*GetValidationErrorResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{39}
+	return fileDescriptor_51a1b9e17fd67b10, []int{40}
 }
 func (m *GetValidationErrorResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3426,7 +3509,7 @@ func (m *OperateValidationErrorRequest) Reset()         { *m = OperateValidation
 func (m *OperateValidationErrorRequest) String() string { return proto.CompactTextString(m) }
 func (*OperateValidationErrorRequest) ProtoMessage()    {}
 func (*OperateValidationErrorRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{40}
+	return fileDescriptor_51a1b9e17fd67b10, []int{41}
 }
 func (m *OperateValidationErrorRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3492,7 +3575,7 @@ func (m *OperateValidationErrorResponse) Reset()         { *m = OperateValidatio
 func (m *OperateValidationErrorResponse) String() string { return proto.CompactTextString(m) }
 func (*OperateValidationErrorResponse) ProtoMessage()    {}
 func (*OperateValidationErrorResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{41}
+	return fileDescriptor_51a1b9e17fd67b10, []int{42}
 }
 func (m *OperateValidationErrorResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3545,7 +3628,7 @@ func (m *UpdateValidationWorkerRequest) Reset()         { *m = UpdateValidationW
 func (m *UpdateValidationWorkerRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateValidationWorkerRequest) ProtoMessage()    {}
 func (*UpdateValidationWorkerRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{42}
+	return fileDescriptor_51a1b9e17fd67b10, []int{43}
 }
 func (m *UpdateValidationWorkerRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3614,6 +3697,7 @@ func init() {
 	proto.RegisterType((*LoadStatus)(nil), "pb.LoadStatus")
 	proto.RegisterType((*ShardingGroup)(nil), "pb.ShardingGroup")
 	proto.RegisterType((*SyncStatus)(nil), "pb.SyncStatus")
+	proto.RegisterType((*CausalityStatus)(nil), "pb.CausalityStatus")
 	proto.RegisterType((*SourceStatus)(nil), "pb.SourceStatus")
 	proto.RegisterType((*RelayStatus)(nil), "pb.RelayStatus")
 	proto.RegisterType((*SubTaskStatus)(nil), "pb.SubTaskStatus")
@@ -3655,193 +3739,197 @@ func init() {
 func init() { proto.RegisterFile("dmworker.proto", fileDescriptor_51a1b9e17fd67b10) }
 
 var fileDescriptor_51a1b9e17fd67b10 = []byte{
-	// 2972 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x3a, 0x4d, 0x6f, 0xdc, 0xd6,
-	0xb5, 0x43, 0x72, 0x3e, 0xcf, 0x8c, 0x24, 0xea, 0x4a, 0xf6, 0x9b, 0x28, 0xf6, 0x44, 0xa1, 0x83,
-	0x3c, 0x45, 0xc8, 0x13, 0x12, 0xbd, 0x3c, 0xe4, 0x21, 0x40, 0x9b, 0xc4, 0x92, 0x23, 0x3b, 0x95,
-	0x23, 0x9b, 0x52, 0xdc, 0x55, 0x81, 0x52, 0x9c, 0xab, 0x11, 0x2b, 0x0e, 0x49, 0x93, 0x1c, 0x09,
-	0x5a, 0x14, 0xdd, 0x75, 0xdb, 0x6e, 0x5a, 0xa0, 0x45, 0x37, 0x2d, 0xd0, 0x6d, 0x17, 0xfd, 0x01,
-	0xdd, 0xb5, 0xcd, 0x32, 0xe8, 0xaa, 0xab, 0xa2, 0x48, 0xfe, 0x45, 0x17, 0x45, 0x71, 0xce, 0xbd,
-	0x97, 0xbc, 0x9c, 0x0f, 0x39, 0x2e, 0xd0, 0x1d, 0xcf, 0xc7, 0x3d, 0xf7, 0xf0, 0x7c, 0x1f, 0xce,
-	0xc0, 0xf2, 0x70, 0x7c, 0x15, 0xa7, 0x17, 0x3c, 0xdd, 0x49, 0xd2, 0x38, 0x8f, 0x99, 0x99, 0x9c,
-	0x3a, 0x5b, 0xc0, 0x9e, 0x4e, 0x78, 0x7a, 0x7d, 0x9c, 0x7b, 0xf9, 0x24, 0x73, 0xf9, 0xf3, 0x09,
-	0xcf, 0x72, 0xc6, 0xa0, 0x1e, 0x79, 0x63, 0xde, 0x37, 0x36, 0x8d, 0xad, 0x8e, 0x4b, 0xcf, 0x4e,
-	0x02, 0xeb, 0x7b, 0xf1, 0x78, 0x1c, 0x47, 0xdf, 0x25, 0x19, 0x2e, 0xcf, 0x92, 0x38, 0xca, 0x38,
-	0xbb, 0x0d, 0xcd, 0x94, 0x67, 0x93, 0x30, 0x27, 0xee, 0xb6, 0x2b, 0x21, 0x66, 0x83, 0x35, 0xce,
-	0x46, 0x7d, 0x93, 0x44, 0xe0, 0x23, 0x72, 0x66, 0xf1, 0x24, 0xf5, 0x79, 0xdf, 0x22, 0xa4, 0x84,
-	0x10, 0x2f, 0xf4, 0xea, 0xd7, 0x05, 0x5e, 0x40, 0xce, 0xef, 0x0c, 0x58, 0xab, 0x28, 0xf7, 0xd2,
-	0x37, 0xbe, 0x07, 0x3d, 0x71, 0x87, 0x90, 0x40, 0xf7, 0x76, 0x77, 0xed, 0x9d, 0xe4, 0x74, 0xe7,
-	0x58, 0xc3, 0xbb, 0x15, 0x2e, 0xf6, 0x3e, 0x2c, 0x65, 0x93, 0xd3, 0x13, 0x2f, 0xbb, 0x90, 0xc7,
-	0xea, 0x9b, 0xd6, 0x56, 0x77, 0x77, 0x95, 0x8e, 0xe9, 0x04, 0xb7, 0xca, 0xe7, 0xfc, 0xd6, 0x80,
-	0xee, 0xde, 0x39, 0xf7, 0x25, 0x8c, 0x8a, 0x26, 0x5e, 0x96, 0xf1, 0xa1, 0x52, 0x54, 0x40, 0x6c,
-	0x1d, 0x1a, 0x79, 0x9c, 0x7b, 0x21, 0xa9, 0xda, 0x70, 0x05, 0xc0, 0x06, 0x00, 0xd9, 0xc4, 0xf7,
-	0x79, 0x96, 0x9d, 0x4d, 0x42, 0x52, 0xb5, 0xe1, 0x6a, 0x18, 0x94, 0x76, 0xe6, 0x05, 0x21, 0x1f,
-	0x92, 0x99, 0x1a, 0xae, 0x84, 0x58, 0x1f, 0x5a, 0x57, 0x5e, 0x1a, 0x05, 0xd1, 0xa8, 0xdf, 0x20,
-	0x82, 0x02, 0xf1, 0xc4, 0x90, 0xe7, 0x5e, 0x10, 0xf6, 0x9b, 0x9b, 0xc6, 0x56, 0xcf, 0x95, 0x90,
-	0xf3, 0x4f, 0x03, 0x60, 0x7f, 0x32, 0x4e, 0xa4, 0x9a, 0x9b, 0xd0, 0x25, 0x0d, 0x4e, 0xbc, 0xd3,
-	0x90, 0x67, 0xa4, 0xab, 0xe5, 0xea, 0x28, 0xb6, 0x05, 0x2b, 0x7e, 0x3c, 0x4e, 0x42, 0x9e, 0xf3,
-	0xa1, 0xe4, 0x42, 0xd5, 0x0d, 0x77, 0x1a, 0xcd, 0xde, 0x80, 0xa5, 0xb3, 0x20, 0x0a, 0xb2, 0x73,
-	0x3e, 0xbc, 0x7f, 0x9d, 0x73, 0x61, 0x72, 0xc3, 0xad, 0x22, 0x99, 0x03, 0x3d, 0x85, 0x70, 0xe3,
-	0xab, 0x8c, 0x5e, 0xc8, 0x70, 0x2b, 0x38, 0xf6, 0x36, 0xac, 0xf2, 0x2c, 0x0f, 0xc6, 0x5e, 0xce,
-	0x4f, 0x50, 0x15, 0x62, 0x6c, 0x10, 0xe3, 0x2c, 0x01, 0x7d, 0x7f, 0x9a, 0x64, 0xf4, 0x9e, 0x96,
-	0x8b, 0x8f, 0x6c, 0x03, 0xda, 0x49, 0x1a, 0x8f, 0x52, 0x9e, 0x65, 0xfd, 0x16, 0x85, 0x44, 0x01,
-	0x3b, 0x5f, 0x18, 0x00, 0x87, 0xb1, 0x37, 0x94, 0x06, 0x98, 0x51, 0x5a, 0x98, 0x60, 0x4a, 0xe9,
-	0x01, 0x00, 0xd9, 0x44, 0xb0, 0x98, 0xc4, 0xa2, 0x61, 0x2a, 0x17, 0x5a, 0xd5, 0x0b, 0xf1, 0xec,
-	0x98, 0xe7, 0xde, 0xfd, 0x20, 0x0a, 0xe3, 0x91, 0x0c, 0x73, 0x0d, 0xc3, 0xde, 0x84, 0xe5, 0x12,
-	0x3a, 0x38, 0x79, 0xb4, 0x4f, 0x6f, 0xda, 0x71, 0xa7, 0xb0, 0xb3, 0xaf, 0xe9, 0xfc, 0xcc, 0x80,
-	0xa5, 0xe3, 0x73, 0x2f, 0x1d, 0x06, 0xd1, 0xe8, 0x20, 0x8d, 0x27, 0x09, 0x7a, 0x3d, 0xf7, 0xd2,
-	0x11, 0xcf, 0x65, 0xfa, 0x4a, 0x08, 0x93, 0x7a, 0x7f, 0xff, 0x10, 0x35, 0xb7, 0x30, 0xa9, 0xf1,
-	0x59, 0xbc, 0x79, 0x9a, 0xe5, 0x87, 0xb1, 0xef, 0xe5, 0x41, 0x1c, 0x49, 0xc5, 0xab, 0x48, 0x4a,
-	0xdc, 0xeb, 0xc8, 0xa7, 0xc8, 0xb3, 0x28, 0x71, 0x09, 0xc2, 0x37, 0x9e, 0x44, 0x92, 0xd2, 0x20,
-	0x4a, 0x01, 0x3b, 0x7f, 0x6c, 0x00, 0x1c, 0x5f, 0x47, 0xfe, 0x54, 0x8c, 0x3d, 0xb8, 0xe4, 0x51,
-	0x5e, 0x8d, 0x31, 0x81, 0x42, 0x61, 0x22, 0xe4, 0x12, 0x65, 0xdc, 0x02, 0x66, 0x77, 0xa0, 0x93,
-	0x72, 0x9f, 0x47, 0x39, 0x12, 0x2d, 0x22, 0x96, 0x08, 0x8c, 0xa6, 0xb1, 0x97, 0xe5, 0x3c, 0xad,
-	0x98, 0xb7, 0x82, 0x63, 0xdb, 0x60, 0xeb, 0xf0, 0x41, 0x1e, 0x0c, 0xa5, 0x89, 0x67, 0xf0, 0x28,
-	0x8f, 0x5e, 0x42, 0xc9, 0x6b, 0x0a, 0x79, 0x3a, 0x0e, 0xe5, 0xe9, 0x30, 0xc9, 0x13, 0x51, 0x36,
-	0x83, 0x47, 0x79, 0xa7, 0x61, 0xec, 0x5f, 0x04, 0xd1, 0x88, 0x1c, 0xd0, 0x26, 0x53, 0x55, 0x70,
-	0xec, 0x5b, 0x60, 0x4f, 0xa2, 0x94, 0x67, 0x71, 0x78, 0xc9, 0x87, 0xe4, 0xc7, 0xac, 0xdf, 0xd1,
-	0xca, 0x8e, 0xee, 0x61, 0x77, 0x86, 0x55, 0xf3, 0x10, 0x88, 0x4a, 0x23, 0x3d, 0x34, 0x00, 0x38,
-	0x25, 0x45, 0x4e, 0xae, 0x13, 0xde, 0xef, 0x8a, 0xb8, 0x2b, 0x31, 0xec, 0x1d, 0x58, 0xcb, 0xb8,
-	0x1f, 0x47, 0xc3, 0xec, 0x3e, 0x3f, 0x0f, 0xa2, 0xe1, 0x63, 0xb2, 0x45, 0xbf, 0x47, 0x26, 0x9e,
-	0x47, 0xc2, 0x88, 0x21, 0xc5, 0xf7, 0xf7, 0x0f, 0x8f, 0xae, 0x22, 0x9e, 0xf6, 0x97, 0x44, 0xc4,
-	0x54, 0x90, 0xe8, 0x6e, 0x3f, 0x8e, 0xce, 0xc2, 0xc0, 0xcf, 0x1f, 0x67, 0xa3, 0xfe, 0x32, 0xf1,
-	0xe8, 0x28, 0x74, 0x69, 0x5e, 0xa4, 0xf5, 0x8a, 0x70, 0x69, 0x81, 0x28, 0x82, 0xc1, 0x4d, 0xb2,
-	0xbe, 0xad, 0x05, 0x83, 0xab, 0x07, 0x03, 0x12, 0x57, 0xf5, 0x60, 0x70, 0x45, 0x30, 0x04, 0xf1,
-	0x49, 0x99, 0xa7, 0x6c, 0xd3, 0xd8, 0xaa, 0xbb, 0x15, 0x1c, 0x3a, 0x6f, 0x38, 0x19, 0x27, 0x8f,
-	0x8e, 0x34, 0xbe, 0x35, 0xe2, 0x9b, 0xc1, 0x3b, 0xbf, 0x32, 0xa0, 0xa7, 0xf7, 0x0a, 0xad, 0x8b,
-	0x19, 0x0b, 0xba, 0x98, 0xa9, 0x77, 0x31, 0xf6, 0x56, 0xd1, 0xad, 0x44, 0xf7, 0x21, 0x7f, 0x3e,
-	0x49, 0x63, 0x2c, 0xeb, 0x2e, 0x11, 0x8a, 0x06, 0xf6, 0x2e, 0x74, 0x53, 0x1e, 0x7a, 0xd7, 0x45,
-	0xdb, 0x41, 0xfe, 0x15, 0xe4, 0x77, 0x4b, 0xb4, 0xab, 0xf3, 0x38, 0x7f, 0x36, 0xa1, 0xab, 0x11,
-	0x67, 0x72, 0xc1, 0xf8, 0x86, 0xb9, 0x60, 0x2e, 0xc8, 0x85, 0x4d, 0xa5, 0xd2, 0xe4, 0x74, 0x3f,
-	0x48, 0x65, 0x79, 0xd0, 0x51, 0x05, 0x47, 0x25, 0xf9, 0x74, 0x14, 0x76, 0x0f, 0x0d, 0xd4, 0x52,
-	0x6f, 0x1a, 0xcd, 0x76, 0x80, 0x11, 0x6a, 0xcf, 0xcb, 0xfd, 0xf3, 0xcf, 0x13, 0x19, 0x8d, 0x4d,
-	0x0a, 0xe9, 0x39, 0x14, 0xf6, 0x1a, 0x34, 0xb2, 0xdc, 0x1b, 0x71, 0x4a, 0xbd, 0xe5, 0xdd, 0x0e,
-	0xa5, 0x0a, 0x22, 0x5c, 0x81, 0xd7, 0x8c, 0xdf, 0x7e, 0x81, 0xf1, 0x9d, 0xdf, 0x5b, 0xb0, 0x54,
-	0xe9, 0xee, 0xf3, 0xa6, 0xa0, 0xf2, 0x46, 0x73, 0xc1, 0x8d, 0x9b, 0x50, 0x9f, 0x44, 0x81, 0x70,
-	0xf6, 0xf2, 0x6e, 0x0f, 0xe9, 0x9f, 0x47, 0x41, 0x8e, 0xd9, 0xe6, 0x12, 0x45, 0xd3, 0xa9, 0xfe,
-	0xa2, 0x80, 0x78, 0x07, 0xd6, 0xca, 0x54, 0xdf, 0xdf, 0x3f, 0x3c, 0x8c, 0xfd, 0x8b, 0xa2, 0x37,
-	0xcc, 0x23, 0x31, 0x26, 0x66, 0x20, 0x2a, 0x59, 0x0f, 0x6b, 0x62, 0x0a, 0xfa, 0x6f, 0x68, 0xf8,
-	0x38, 0x95, 0x90, 0x95, 0x64, 0x40, 0x69, 0x63, 0xca, 0xc3, 0x9a, 0x2b, 0xe8, 0xec, 0x0d, 0xa8,
-	0x63, 0xfc, 0x4b, 0x5b, 0x2d, 0x23, 0x5f, 0x39, 0x26, 0x3c, 0xac, 0xb9, 0x44, 0x45, 0xae, 0x30,
-	0xf6, 0x86, 0xfd, 0x4e, 0xc9, 0x55, 0xf6, 0x52, 0xe4, 0x42, 0x2a, 0x72, 0x61, 0x0d, 0xa2, 0x7a,
-	0x24, 0xb9, 0xca, 0x76, 0x80, 0x5c, 0x48, 0x65, 0xef, 0x01, 0x5c, 0x7a, 0x61, 0x30, 0x14, 0xcd,
-	0xa7, 0x4b, 0xbc, 0xeb, 0xc8, 0xfb, 0xac, 0xc0, 0xca, 0xa8, 0xd7, 0xf8, 0xee, 0xb7, 0xa1, 0x99,
-	0x89, 0xf0, 0xff, 0x36, 0xac, 0x56, 0x7c, 0x76, 0x18, 0x64, 0x64, 0x60, 0x41, 0xee, 0x1b, 0x8b,
-	0x06, 0x37, 0x75, 0x7e, 0x00, 0x40, 0x96, 0x78, 0x90, 0xa6, 0x71, 0xaa, 0x06, 0x48, 0xa3, 0x18,
-	0x20, 0x9d, 0xbb, 0xd0, 0x41, 0x0b, 0xdc, 0x40, 0xc6, 0x57, 0x5f, 0x44, 0x4e, 0xa0, 0x47, 0xef,
-	0xfc, 0xf4, 0x70, 0x01, 0x07, 0xdb, 0x85, 0x75, 0x31, 0xc5, 0x89, 0x24, 0x78, 0x12, 0x67, 0x01,
-	0x59, 0x42, 0xa4, 0xe3, 0x5c, 0x1a, 0xd6, 0x46, 0x8e, 0xe2, 0x8e, 0x9f, 0x1e, 0xaa, 0x39, 0x43,
-	0xc1, 0xce, 0xff, 0x41, 0x07, 0x6f, 0x14, 0xd7, 0x6d, 0x41, 0x93, 0x08, 0xca, 0x0e, 0x76, 0xe1,
-	0x04, 0xa9, 0x90, 0x2b, 0xe9, 0xce, 0x4f, 0x0c, 0xe8, 0x8a, 0x22, 0x27, 0x4e, 0xbe, 0x6c, 0x8d,
-	0xdb, 0xac, 0x1c, 0x57, 0x55, 0x42, 0x97, 0xb8, 0x03, 0x40, 0x65, 0x4a, 0x30, 0xd4, 0xcb, 0xa0,
-	0x28, 0xb1, 0xae, 0xc6, 0x81, 0x8e, 0x29, 0xa1, 0x39, 0xa6, 0xfd, 0x85, 0x09, 0x3d, 0xe9, 0x52,
-	0xc1, 0xf2, 0x1f, 0x4a, 0x56, 0x99, 0x4f, 0x75, 0x3d, 0x9f, 0xde, 0x54, 0xf9, 0xd4, 0x28, 0x5f,
-	0xa3, 0x8c, 0xa2, 0x32, 0x9d, 0xee, 0xc9, 0x74, 0x6a, 0x12, 0xdb, 0x92, 0x4a, 0x27, 0xc5, 0x25,
-	0xb2, 0xe9, 0x9e, 0xcc, 0xa6, 0x56, 0xc9, 0x54, 0x84, 0x54, 0x91, 0x4c, 0xf7, 0x64, 0x32, 0xb5,
-	0x4b, 0xa6, 0xc2, 0xcd, 0x2a, 0x97, 0xee, 0xb7, 0xa0, 0x41, 0xee, 0x74, 0x3e, 0x00, 0x5b, 0x37,
-	0x0d, 0xe5, 0xc4, 0x9b, 0x92, 0x58, 0x09, 0x05, 0x8d, 0xc9, 0x95, 0x67, 0x9f, 0xc3, 0x52, 0xa5,
-	0x14, 0xe1, 0x04, 0x11, 0x64, 0x7b, 0x5e, 0xe4, 0xf3, 0xb0, 0xd8, 0x63, 0x34, 0x8c, 0x16, 0x64,
-	0x66, 0x29, 0x59, 0x8a, 0xa8, 0x04, 0x99, 0xb6, 0x8d, 0x58, 0x95, 0x6d, 0xe4, 0x2f, 0x06, 0xf4,
-	0xf4, 0x03, 0xb8, 0xd0, 0x3c, 0x48, 0xd3, 0xbd, 0x78, 0x28, 0xbc, 0xd9, 0x70, 0x15, 0x88, 0xa1,
-	0x8f, 0x8f, 0xa1, 0x97, 0x65, 0x32, 0x02, 0x0b, 0x58, 0xd2, 0x8e, 0xfd, 0x38, 0x51, 0xfb, 0x65,
-	0x01, 0x4b, 0xda, 0x21, 0xbf, 0xe4, 0xa1, 0x6c, 0x50, 0x05, 0x8c, 0xb7, 0x3d, 0xe6, 0x59, 0x86,
-	0x61, 0x22, 0xea, 0xaa, 0x02, 0xf1, 0x94, 0xeb, 0x5d, 0xed, 0x79, 0x93, 0x8c, 0xcb, 0x19, 0xb0,
-	0x80, 0xd1, 0x2c, 0xb8, 0x07, 0x7b, 0x69, 0x3c, 0x89, 0xd4, 0xe4, 0xa7, 0x61, 0x9c, 0x2b, 0x58,
-	0x7d, 0x32, 0x49, 0x47, 0x9c, 0x82, 0x58, 0xad, 0xd5, 0x1b, 0xd0, 0x0e, 0x22, 0xcf, 0xcf, 0x83,
-	0x4b, 0x2e, 0x2d, 0x59, 0xc0, 0x18, 0xbf, 0x79, 0x30, 0xe6, 0x72, 0xf4, 0xa5, 0x67, 0xe4, 0x3f,
-	0x0b, 0x42, 0x4e, 0x71, 0x2d, 0x5f, 0x49, 0xc1, 0x94, 0xa2, 0xa2, 0x27, 0xcb, 0xa5, 0x59, 0x40,
-	0xce, 0x2f, 0x4d, 0xd8, 0x38, 0x4a, 0x78, 0xea, 0xe5, 0x5c, 0x2c, 0xea, 0xc7, 0xfe, 0x39, 0x1f,
-	0x7b, 0x4a, 0x85, 0x3b, 0x60, 0xc6, 0x09, 0x5d, 0x2e, 0xe3, 0x5d, 0x90, 0x8f, 0x12, 0xd7, 0x8c,
-	0x13, 0x52, 0xc2, 0xcb, 0x2e, 0xa4, 0x6d, 0xe9, 0x79, 0xe1, 0xd6, 0xbe, 0x01, 0xed, 0xa1, 0x97,
-	0x7b, 0xa7, 0x5e, 0xc6, 0x95, 0x4d, 0x15, 0x4c, 0x0b, 0x2e, 0xee, 0x83, 0xd2, 0xa2, 0x02, 0x20,
-	0x49, 0x74, 0x9b, 0xb4, 0xa6, 0x84, 0x90, 0xfb, 0x2c, 0x9c, 0x64, 0xe7, 0x64, 0xc6, 0xb6, 0x2b,
-	0x00, 0xd4, 0xa5, 0x88, 0xf9, 0xb6, 0x6c, 0x17, 0x03, 0x80, 0xb3, 0x34, 0x1e, 0x8b, 0xc2, 0x42,
-	0x0d, 0xa8, 0xed, 0x6a, 0x18, 0x45, 0x3f, 0x11, 0xeb, 0x0f, 0x94, 0x74, 0x81, 0x71, 0x72, 0x58,
-	0x7a, 0xf6, 0xae, 0x0c, 0xfb, 0xc7, 0x3c, 0xf7, 0xd8, 0x86, 0x66, 0x0e, 0x40, 0x73, 0x20, 0x45,
-	0x1a, 0xe3, 0x85, 0xd5, 0x43, 0x95, 0x1c, 0x4b, 0x2b, 0x39, 0xca, 0x82, 0x75, 0x0a, 0x71, 0x7a,
-	0x76, 0xde, 0x83, 0x75, 0xe9, 0x91, 0x67, 0xef, 0xe2, 0xad, 0x0b, 0x7d, 0x21, 0xc8, 0xe2, 0x7a,
-	0xe7, 0x4f, 0x06, 0xdc, 0x9a, 0x3a, 0xf6, 0xd2, 0xdf, 0x3f, 0xde, 0x87, 0x3a, 0x2e, 0x90, 0x7d,
-	0x8b, 0x52, 0xf3, 0x1e, 0xde, 0x31, 0x57, 0xe4, 0x0e, 0x02, 0x0f, 0xa2, 0x3c, 0xbd, 0x76, 0xe9,
-	0xc0, 0xc6, 0xa7, 0xd0, 0x29, 0x50, 0x28, 0xf7, 0x82, 0x5f, 0xab, 0xea, 0x7b, 0xc1, 0xaf, 0x71,
-	0xa2, 0xb8, 0xf4, 0xc2, 0x89, 0x30, 0x8d, 0x6c, 0xb0, 0x15, 0xc3, 0xba, 0x82, 0xfe, 0x81, 0xf9,
-	0xff, 0x86, 0xf3, 0x43, 0xe8, 0x3f, 0xf4, 0xa2, 0x61, 0x28, 0xe3, 0x51, 0x14, 0x05, 0x69, 0x82,
-	0x57, 0x35, 0x13, 0x74, 0x51, 0x0a, 0x51, 0x6f, 0x88, 0xc6, 0x3b, 0xd0, 0x39, 0x55, 0xed, 0x50,
-	0x1a, 0xbe, 0x44, 0x50, 0xcc, 0x3c, 0x0f, 0x33, 0xb9, 0xa6, 0xd2, 0xb3, 0x73, 0x0b, 0xd6, 0x0e,
-	0x78, 0x2e, 0xee, 0xde, 0x3b, 0x1b, 0xc9, 0x9b, 0x9d, 0x2d, 0x58, 0xaf, 0xa2, 0xa5, 0x71, 0x6d,
-	0xb0, 0xfc, 0xb3, 0xa2, 0xd5, 0xf8, 0x67, 0x23, 0xe7, 0x18, 0xee, 0x8a, 0x69, 0x69, 0x72, 0x8a,
-	0x2a, 0x60, 0xe9, 0xfb, 0x3c, 0x19, 0x7a, 0x39, 0x57, 0x2f, 0xb1, 0x0b, 0xeb, 0x99, 0xa0, 0xed,
-	0x9d, 0x8d, 0x4e, 0xe2, 0x71, 0x78, 0x9c, 0xa7, 0x41, 0xa4, 0x64, 0xcc, 0xa5, 0x39, 0x87, 0x30,
-	0x58, 0x24, 0x54, 0x2a, 0xd2, 0x87, 0x96, 0xfc, 0xf8, 0x23, 0xdd, 0xac, 0xc0, 0x59, 0x3f, 0x3b,
-	0x23, 0xd8, 0x38, 0xe0, 0xf9, 0xcc, 0xcc, 0x54, 0x96, 0x1d, 0xbc, 0xe3, 0xb3, 0xb2, 0x3d, 0x16,
-	0x30, 0xfb, 0x1f, 0xe8, 0x9d, 0x05, 0x61, 0xce, 0x53, 0xb9, 0x73, 0xcc, 0xc4, 0x7a, 0x85, 0xec,
-	0xfc, 0xcd, 0x02, 0x7b, 0xfa, 0x9a, 0xc2, 0x4f, 0xc6, 0xdc, 0xaa, 0x61, 0x56, 0xaa, 0x06, 0x83,
-	0xfa, 0x18, 0x0b, 0xbb, 0xcc, 0x19, 0x7c, 0x2e, 0x13, 0xad, 0xbe, 0x20, 0xd1, 0xb6, 0x60, 0x45,
-	0x4e, 0x7f, 0xb1, 0xda, 0x6b, 0xe4, 0x02, 0x31, 0x85, 0xc6, 0x81, 0x79, 0x0a, 0x45, 0xeb, 0x86,
-	0xa8, 0x37, 0xf3, 0x48, 0xda, 0x34, 0xde, 0xfa, 0x06, 0xd3, 0x78, 0x22, 0x08, 0xe2, 0x13, 0x95,
-	0x34, 0x59, 0x5b, 0x08, 0x9f, 0x43, 0x62, 0x6f, 0xc3, 0x6a, 0xc2, 0x23, 0x5c, 0xdc, 0x35, 0xfe,
-	0x0e, 0xf1, 0xcf, 0x12, 0xf0, 0x35, 0xa9, 0x55, 0x6a, 0xbc, 0x20, 0x5e, 0x73, 0x0a, 0x8d, 0x1b,
-	0x9c, 0x3f, 0xc9, 0xe3, 0x4b, 0xb5, 0xaa, 0x61, 0x32, 0x88, 0xe5, 0x7e, 0x06, 0x8f, 0x3a, 0x54,
-	0x70, 0x64, 0x90, 0x9e, 0xd0, 0x61, 0x86, 0xe0, 0xfc, 0xc6, 0x80, 0x5b, 0xa5, 0x83, 0xe9, 0xa3,
-	0xde, 0x0b, 0xf6, 0xde, 0x0d, 0x68, 0x67, 0xa9, 0x4f, 0x9c, 0xaa, 0x27, 0x2b, 0x98, 0x7a, 0x44,
-	0x96, 0x0b, 0x9a, 0x6c, 0x60, 0x0a, 0x7e, 0xb1, 0xd7, 0xfb, 0xd0, 0x1a, 0x57, 0x1b, 0xb3, 0x04,
-	0x9d, 0x3f, 0x18, 0xf0, 0xea, 0xdc, 0x78, 0xff, 0x37, 0x3e, 0x10, 0x43, 0x11, 0x14, 0x99, 0x2c,
-	0x93, 0x37, 0xef, 0x1f, 0x38, 0xc9, 0x7c, 0x08, 0x4b, 0x79, 0x69, 0x19, 0xae, 0x3e, 0x10, 0xbf,
-	0x52, 0x3d, 0xa8, 0x19, 0xcf, 0xad, 0xf2, 0x3b, 0x17, 0xf0, 0x4a, 0x45, 0xff, 0x4a, 0x4d, 0xdc,
-	0xa5, 0xf9, 0x1e, 0x79, 0xb9, 0xac, 0x8c, 0xb7, 0x35, 0xc1, 0x62, 0x9e, 0x26, 0xaa, 0x5b, 0xf0,
-	0x55, 0x52, 0xdc, 0xac, 0xa6, 0xb8, 0xf3, 0x6b, 0x13, 0x56, 0xa6, 0xae, 0x62, 0xcb, 0x60, 0x06,
-	0x43, 0xe9, 0x48, 0x33, 0x18, 0x2e, 0x4c, 0x57, 0xdd, 0xb9, 0xd6, 0x94, 0x73, 0xb1, 0x40, 0xa5,
-	0xfe, 0xbe, 0x97, 0x7b, 0xb2, 0xff, 0x2b, 0xb0, 0xe2, 0xf6, 0xc6, 0x94, 0xdb, 0xfb, 0xd0, 0x1a,
-	0x66, 0x39, 0x9d, 0x12, 0x59, 0xa9, 0x40, 0x2c, 0xed, 0x14, 0xe7, 0xf4, 0xa9, 0x4a, 0x4c, 0x54,
-	0x25, 0x82, 0xed, 0x14, 0x4b, 0x5d, 0xfb, 0x46, 0x9b, 0x48, 0xae, 0x62, 0x9e, 0xea, 0xc8, 0xa2,
-	0x84, 0xf3, 0x94, 0x16, 0x51, 0x50, 0x8d, 0xa8, 0xe7, 0x53, 0x05, 0x54, 0x3a, 0xe4, 0xa5, 0xe3,
-	0xe9, 0x2d, 0x35, 0x66, 0x8b, 0x50, 0x5a, 0xab, 0x46, 0x44, 0x65, 0xd2, 0xfe, 0xb9, 0x01, 0x77,
-	0x55, 0x33, 0x9e, 0x1f, 0x08, 0xf7, 0xb4, 0xe6, 0x38, 0x2b, 0x49, 0x36, 0x49, 0x9a, 0xcf, 0x3f,
-	0x0e, 0x43, 0xb1, 0x58, 0x99, 0x6a, 0x3e, 0x57, 0x98, 0x4a, 0x64, 0x58, 0x53, 0xc5, 0x7f, 0x9d,
-	0xb4, 0x7d, 0x24, 0x7e, 0x50, 0xa8, 0xbb, 0x02, 0x70, 0x3e, 0x85, 0xc1, 0x22, 0xbd, 0x5e, 0xd6,
-	0x1e, 0xce, 0x35, 0xdc, 0x15, 0x6d, 0xad, 0x14, 0xa5, 0x7e, 0x3e, 0x7a, 0x71, 0x6f, 0xaa, 0xf4,
-	0x7a, 0x73, 0xba, 0xd7, 0x17, 0x9f, 0x36, 0xe9, 0x73, 0xb9, 0xa5, 0x7f, 0xda, 0x44, 0xcc, 0xf6,
-	0x05, 0x34, 0xc5, 0x30, 0xc7, 0x96, 0xa0, 0xf3, 0x28, 0xa2, 0xf4, 0x3d, 0x4a, 0xec, 0x1a, 0x6b,
-	0x43, 0xfd, 0x38, 0x8f, 0x13, 0xdb, 0x60, 0x1d, 0x68, 0x3c, 0xc1, 0x69, 0xde, 0x36, 0x19, 0x40,
-	0x13, 0xab, 0xfd, 0x98, 0xdb, 0x16, 0xa2, 0x8f, 0x73, 0x2f, 0xcd, 0xed, 0x3a, 0xa2, 0x85, 0xfe,
-	0x76, 0x83, 0x2d, 0x03, 0x7c, 0x3c, 0xc9, 0x63, 0xc9, 0xd6, 0x44, 0xda, 0x3e, 0x0f, 0x79, 0xce,
-	0xed, 0xd6, 0xf6, 0x8f, 0xe8, 0xc8, 0x08, 0xc7, 0x87, 0x9e, 0xbc, 0x8b, 0x60, 0xbb, 0xc6, 0x5a,
-	0x60, 0x7d, 0xc6, 0xaf, 0x6c, 0x83, 0x75, 0xa1, 0xe5, 0x4e, 0xa2, 0x28, 0x88, 0x46, 0xe2, 0x3e,
-	0xba, 0x7a, 0x68, 0x5b, 0x48, 0x40, 0x85, 0x12, 0x3e, 0xb4, 0xeb, 0xac, 0x07, 0xed, 0x4f, 0xe4,
-	0xcf, 0x0e, 0x76, 0x03, 0x49, 0xc8, 0x86, 0x67, 0x9a, 0x48, 0xa2, 0xcb, 0x11, 0x6a, 0x21, 0x44,
-	0xa7, 0x10, 0x6a, 0x6f, 0x1f, 0x41, 0x5b, 0x6d, 0xae, 0x6c, 0x05, 0xba, 0x52, 0x07, 0x44, 0xd9,
-	0x35, 0x7c, 0x21, 0x1a, 0x36, 0x6c, 0x03, 0x5f, 0x1e, 0x77, 0x50, 0xdb, 0xc4, 0x27, 0x5c, 0x34,
-	0x6d, 0x8b, 0x0c, 0x72, 0x1d, 0xf9, 0x76, 0x1d, 0x19, 0x69, 0x61, 0xb1, 0x87, 0xdb, 0x8f, 0xa1,
-	0x45, 0x8f, 0x47, 0x38, 0x87, 0x2d, 0x4b, 0x79, 0x12, 0x63, 0xd7, 0xd0, 0xa6, 0x78, 0xbb, 0xe0,
-	0x36, 0xd0, 0x36, 0xf4, 0x3a, 0x02, 0x36, 0x51, 0x05, 0x61, 0x27, 0x81, 0xb0, 0xb6, 0x7f, 0x6c,
-	0x40, 0x5b, 0xad, 0x1a, 0x6c, 0x0d, 0x56, 0x94, 0x91, 0x24, 0x4a, 0x48, 0x3c, 0xe0, 0xb9, 0x40,
-	0xd8, 0x06, 0x5d, 0x50, 0x80, 0x26, 0xda, 0xd5, 0xe5, 0xe3, 0xf8, 0x92, 0x4b, 0x8c, 0x85, 0x57,
-	0xe2, 0x66, 0x2b, 0xe1, 0x3a, 0x1e, 0x40, 0x98, 0xaa, 0x8c, 0xdd, 0x60, 0xb7, 0x81, 0x21, 0xf8,
-	0x38, 0x18, 0x61, 0x24, 0x8b, 0xf9, 0x3f, 0xb3, 0x9b, 0xdb, 0x1f, 0x41, 0x5b, 0x8d, 0xd9, 0x9a,
-	0x1e, 0x0a, 0x55, 0xe8, 0x21, 0x10, 0xb6, 0x51, 0x5e, 0x2c, 0x31, 0xe6, 0xf6, 0x33, 0x5a, 0x4f,
-	0x71, 0x4a, 0xd5, 0x2c, 0x23, 0x31, 0x32, 0xbc, 0x2e, 0x82, 0x44, 0x3a, 0x9c, 0x27, 0xa1, 0xe7,
-	0x17, 0x01, 0x76, 0xc9, 0xd3, 0xdc, 0xb6, 0xf0, 0xf9, 0x51, 0xf4, 0x03, 0xee, 0x63, 0x84, 0xa1,
-	0x1b, 0x82, 0x2c, 0xb7, 0x1b, 0xdb, 0x87, 0xd0, 0x7d, 0xa6, 0x7a, 0xcc, 0x51, 0x82, 0x2f, 0xa0,
-	0x94, 0x2b, 0xb1, 0x76, 0x0d, 0xef, 0xa4, 0xe8, 0x2c, 0xb0, 0xb6, 0xc1, 0x56, 0x61, 0x09, 0xbd,
-	0x51, 0xa2, 0xcc, 0xed, 0xa7, 0xc0, 0x66, 0xab, 0x23, 0x1a, 0xad, 0x54, 0xd8, 0xae, 0xa1, 0x26,
-	0x9f, 0xf1, 0x2b, 0x7c, 0x26, 0x1f, 0x3e, 0x1a, 0x45, 0x71, 0xca, 0x89, 0xa6, 0x7c, 0x48, 0xdf,
-	0x17, 0x11, 0x61, 0x6d, 0x3f, 0x9b, 0xea, 0x23, 0x47, 0x89, 0x16, 0xee, 0x04, 0xdb, 0x35, 0x0a,
-	0x3e, 0x92, 0x22, 0x10, 0xd2, 0x80, 0x24, 0x46, 0x60, 0x4c, 0xbc, 0x68, 0x2f, 0xe4, 0x5e, 0x2a,
-	0x60, 0x6b, 0xf7, 0x1f, 0x4d, 0x68, 0x8a, 0xaa, 0xc0, 0x3e, 0x82, 0xae, 0xf6, 0x8b, 0x2f, 0xa3,
-	0x22, 0x3f, 0xfb, 0xfb, 0xf4, 0xc6, 0x7f, 0xcd, 0xe0, 0x45, 0x65, 0x72, 0x6a, 0xec, 0x43, 0x80,
-	0x72, 0xf1, 0x66, 0xb7, 0x68, 0x9a, 0x9b, 0x5e, 0xc4, 0x37, 0xfa, 0xf4, 0xc9, 0x66, 0xce, 0xaf,
-	0xd9, 0x4e, 0x8d, 0x7d, 0x07, 0x96, 0x64, 0xf9, 0x13, 0xa1, 0xc5, 0x06, 0xda, 0xda, 0x34, 0x67,
-	0xa5, 0xbe, 0x51, 0xd8, 0x27, 0x85, 0x30, 0x11, 0x3e, 0xac, 0x3f, 0x67, 0x07, 0x13, 0x62, 0x5e,
-	0x59, 0xb8, 0x9d, 0x39, 0x35, 0x76, 0x00, 0x5d, 0xb1, 0x43, 0x89, 0xa2, 0x7e, 0x07, 0x79, 0x17,
-	0x2d, 0x55, 0x37, 0x2a, 0xb4, 0x07, 0x3d, 0x7d, 0xed, 0x61, 0x64, 0xc9, 0x39, 0xfb, 0x91, 0x10,
-	0x32, 0x6f, 0x43, 0x72, 0x6a, 0xcc, 0x83, 0xdb, 0xf3, 0x97, 0x17, 0xf6, 0x7a, 0xf9, 0x6d, 0x79,
-	0xc1, 0xb6, 0xb4, 0xe1, 0xdc, 0xc4, 0x52, 0x5c, 0xf1, 0x3d, 0xe8, 0x17, 0x97, 0x17, 0x61, 0x2d,
-	0xa3, 0x62, 0x20, 0x55, 0x5b, 0xb0, 0xef, 0x6c, 0xbc, 0xb6, 0x90, 0x5e, 0x88, 0x3f, 0x81, 0xd5,
-	0x92, 0x21, 0x16, 0xe6, 0x63, 0x77, 0x67, 0xce, 0x55, 0xcc, 0x3a, 0x58, 0x44, 0x2e, 0xa4, 0x7e,
-	0xbf, 0xdc, 0xd8, 0xab, 0x92, 0x5f, 0xd7, 0x7d, 0x3b, 0x5f, 0xba, 0x73, 0x13, 0x4b, 0x71, 0xc3,
-	0x13, 0x58, 0xa9, 0xf4, 0x53, 0x25, 0xfb, 0xc6, 0x26, 0x7b, 0x53, 0x40, 0xdc, 0xef, 0x7f, 0xf1,
-	0xd5, 0xc0, 0xf8, 0xf2, 0xab, 0x81, 0xf1, 0xf7, 0xaf, 0x06, 0xc6, 0x4f, 0xbf, 0x1e, 0xd4, 0xbe,
-	0xfc, 0x7a, 0x50, 0xfb, 0xeb, 0xd7, 0x83, 0xda, 0x69, 0x93, 0xfe, 0x25, 0xf2, 0xbf, 0xff, 0x0a,
-	0x00, 0x00, 0xff, 0xff, 0x1d, 0x07, 0x2d, 0x0f, 0x37, 0x22, 0x00, 0x00,
+	// 3026 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0x03, 0xb5, 0x1a, 0xcb, 0x6e, 0x1c, 0x59,
+	0x35, 0xfd, 0x74, 0xf7, 0x69, 0x3f, 0xda, 0x65, 0x27, 0x74, 0x3c, 0x89, 0x27, 0x53, 0x41, 0x21,
+	0x63, 0x41, 0x44, 0xc2, 0xa0, 0x41, 0x23, 0x01, 0x33, 0xb1, 0x67, 0x32, 0x1e, 0x9c, 0x71, 0x52,
+	0x76, 0xc2, 0x0a, 0x89, 0x72, 0xf7, 0xb5, 0xd3, 0xb8, 0xba, 0xaa, 0x52, 0x55, 0x1d, 0xcb, 0x48,
+	0x88, 0x1d, 0x5b, 0xd8, 0x80, 0x04, 0x62, 0x03, 0x12, 0x4b, 0x58, 0xf0, 0x01, 0x2c, 0x61, 0x96,
+	0xa3, 0x59, 0xb1, 0x42, 0x08, 0x7e, 0x02, 0xb1, 0x40, 0x9c, 0xc7, 0xbd, 0x55, 0xb7, 0xfa, 0xe1,
+	0x4c, 0x90, 0x58, 0x58, 0xaa, 0xf3, 0xa8, 0x73, 0xcf, 0x3d, 0xef, 0x53, 0x6d, 0x58, 0x1e, 0x8c,
+	0xce, 0xa2, 0xe4, 0x54, 0x25, 0x77, 0xe2, 0x24, 0xca, 0x22, 0xa7, 0x1a, 0x1f, 0xb9, 0xb7, 0xc1,
+	0x79, 0x3c, 0x56, 0xc9, 0xf9, 0x41, 0xe6, 0x67, 0xe3, 0xd4, 0x53, 0xcf, 0xc7, 0x2a, 0xcd, 0x1c,
+	0x07, 0xea, 0xa1, 0x3f, 0x52, 0xbd, 0xca, 0x8d, 0xca, 0xed, 0xb6, 0xc7, 0xcf, 0x6e, 0x0c, 0xeb,
+	0xdb, 0xd1, 0x68, 0x14, 0x85, 0xdf, 0x65, 0x19, 0x9e, 0x4a, 0xe3, 0x28, 0x4c, 0x95, 0x73, 0x05,
+	0x9a, 0x89, 0x4a, 0xc7, 0x41, 0xc6, 0xdc, 0x2d, 0x4f, 0x43, 0x4e, 0x17, 0x6a, 0xa3, 0xf4, 0xa4,
+	0x57, 0x65, 0x11, 0xf4, 0x48, 0x9c, 0x69, 0x34, 0x4e, 0xfa, 0xaa, 0x57, 0x63, 0xa4, 0x86, 0x08,
+	0x2f, 0x7a, 0xf5, 0xea, 0x82, 0x17, 0xc8, 0xfd, 0x43, 0x05, 0xd6, 0x4a, 0xca, 0xbd, 0xf2, 0x89,
+	0x6f, 0xc1, 0xa2, 0x9c, 0x21, 0x12, 0xf8, 0xdc, 0xce, 0xbd, 0xee, 0x9d, 0xf8, 0xe8, 0xce, 0x81,
+	0x85, 0xf7, 0x4a, 0x5c, 0xce, 0xdb, 0xb0, 0x94, 0x8e, 0x8f, 0x0e, 0xfd, 0xf4, 0x54, 0xbf, 0x56,
+	0xbf, 0x51, 0xc3, 0xd7, 0x56, 0xf9, 0x35, 0x9b, 0xe0, 0x95, 0xf9, 0xdc, 0xdf, 0x55, 0xa0, 0xb3,
+	0xfd, 0x4c, 0xf5, 0x35, 0x4c, 0x8a, 0xc6, 0x7e, 0x9a, 0xaa, 0x81, 0x51, 0x54, 0x20, 0x67, 0x1d,
+	0x1a, 0x59, 0x94, 0xf9, 0x01, 0xab, 0xda, 0xf0, 0x04, 0x70, 0x36, 0x01, 0xd2, 0x71, 0xbf, 0xaf,
+	0xd2, 0xf4, 0x78, 0x1c, 0xb0, 0xaa, 0x0d, 0xcf, 0xc2, 0x90, 0xb4, 0x63, 0x7f, 0x18, 0xa0, 0xb4,
+	0x3a, 0xd3, 0x34, 0xe4, 0xf4, 0x60, 0xe1, 0xcc, 0x4f, 0xc2, 0x61, 0x78, 0xd2, 0x6b, 0x30, 0xc1,
+	0x80, 0xf4, 0xc6, 0x40, 0x65, 0xc8, 0xd5, 0x6b, 0x22, 0x61, 0xd1, 0xd3, 0x90, 0xfb, 0x9f, 0x0a,
+	0xc0, 0xce, 0x78, 0x14, 0x6b, 0x35, 0x6f, 0x40, 0x87, 0x35, 0x38, 0xf4, 0x8f, 0x02, 0x95, 0xb2,
+	0xae, 0x35, 0xcf, 0x46, 0x39, 0xb7, 0x61, 0xa5, 0x1f, 0x8d, 0xe2, 0x40, 0x65, 0x6a, 0xa0, 0xb9,
+	0x48, 0xf5, 0x8a, 0x37, 0x89, 0x76, 0xbe, 0x08, 0x4b, 0xc7, 0xc3, 0x70, 0x98, 0x3e, 0x53, 0x83,
+	0xfb, 0xe7, 0x99, 0x12, 0x93, 0x57, 0xbc, 0x32, 0xd2, 0x71, 0x61, 0xd1, 0x20, 0xbc, 0xe8, 0x2c,
+	0xe5, 0x0b, 0x55, 0xbc, 0x12, 0xce, 0xf9, 0x32, 0xac, 0x62, 0x28, 0x0e, 0x47, 0x7e, 0xa6, 0x0e,
+	0x49, 0x15, 0x66, 0x6c, 0x30, 0xe3, 0x34, 0x81, 0x7c, 0x7f, 0x14, 0xa7, 0x7c, 0xcf, 0x9a, 0x47,
+	0x8f, 0xce, 0x06, 0xb4, 0x30, 0xcc, 0x4f, 0x30, 0x36, 0xd2, 0xde, 0x02, 0x87, 0x44, 0x0e, 0xbb,
+	0x9f, 0xa0, 0x01, 0xf6, 0x22, 0x7f, 0xa0, 0x0d, 0x30, 0xa5, 0xb4, 0x98, 0x60, 0x42, 0x69, 0xf4,
+	0x0f, 0xdb, 0x44, 0x58, 0xaa, 0xcc, 0x62, 0x61, 0x4a, 0x07, 0xd6, 0xca, 0x07, 0xd2, 0xbb, 0x23,
+	0xb4, 0xfd, 0xfd, 0x61, 0x18, 0x44, 0x27, 0x3a, 0xcc, 0x2d, 0x8c, 0x73, 0x0b, 0x96, 0x0b, 0xe8,
+	0xc1, 0xe1, 0xee, 0x0e, 0xdf, 0xb4, 0xed, 0x4d, 0x60, 0xa7, 0xaf, 0xe9, 0xfe, 0xbc, 0x02, 0x4b,
+	0x07, 0xcf, 0xfc, 0x64, 0x80, 0x0e, 0x7f, 0x90, 0x44, 0xe3, 0x98, 0xbc, 0x9e, 0xf9, 0xc9, 0x89,
+	0xca, 0x74, 0xfa, 0x6a, 0x88, 0x92, 0x7a, 0x67, 0x67, 0x8f, 0x34, 0xaf, 0x51, 0x52, 0xd3, 0xb3,
+	0xdc, 0x3c, 0x49, 0xb3, 0xbd, 0xa8, 0xef, 0x67, 0xc3, 0x28, 0xd4, 0x8a, 0x97, 0x91, 0x9c, 0xb8,
+	0xe7, 0x61, 0x9f, 0x23, 0xaf, 0xc6, 0x89, 0xcb, 0x10, 0xdd, 0x78, 0x1c, 0x6a, 0x4a, 0x83, 0x29,
+	0x39, 0xec, 0xfe, 0xab, 0x01, 0x70, 0x80, 0x8f, 0x13, 0x31, 0xf6, 0xfe, 0x0b, 0x15, 0x66, 0xe5,
+	0x18, 0x13, 0x14, 0x09, 0x93, 0x90, 0x8b, 0x8d, 0x71, 0x73, 0xd8, 0xb9, 0x06, 0xed, 0x44, 0xf5,
+	0x91, 0x8d, 0x88, 0x35, 0x26, 0x16, 0x08, 0x8a, 0xa6, 0x91, 0x9f, 0x66, 0x2a, 0x29, 0x99, 0xb7,
+	0x84, 0x73, 0xb6, 0xa0, 0x6b, 0xc3, 0x0f, 0xb2, 0xe1, 0x40, 0x9b, 0x78, 0x0a, 0x4f, 0xf2, 0xf8,
+	0x12, 0x46, 0x5e, 0x53, 0xe4, 0xd9, 0x38, 0x92, 0x67, 0xc3, 0x2c, 0x4f, 0xa2, 0x6c, 0x0a, 0x4f,
+	0xf2, 0x8e, 0x82, 0xa8, 0x7f, 0x8a, 0x1e, 0x62, 0x07, 0xb4, 0xd8, 0x54, 0x25, 0x9c, 0xf3, 0x4d,
+	0xe8, 0x8e, 0x43, 0x0c, 0x95, 0x28, 0x78, 0xa1, 0x06, 0xec, 0xc7, 0xb4, 0xd7, 0xb6, 0xca, 0x8e,
+	0xed, 0x61, 0x6f, 0x8a, 0xd5, 0xf2, 0x10, 0x48, 0xa5, 0xd1, 0x1e, 0xc2, 0xb8, 0x3b, 0x62, 0x45,
+	0x0e, 0xcf, 0x63, 0xd5, 0xeb, 0x48, 0xdc, 0x15, 0x18, 0xe7, 0xab, 0xb0, 0x96, 0xaa, 0x7e, 0x14,
+	0x0e, 0xd2, 0xfb, 0xea, 0xd9, 0x30, 0x1c, 0x3c, 0x64, 0x5b, 0xf4, 0x16, 0xd9, 0xc4, 0xb3, 0x48,
+	0x14, 0x31, 0xac, 0x38, 0x6a, 0xbd, 0x7f, 0x16, 0x22, 0xef, 0x92, 0x44, 0x4c, 0x09, 0x49, 0xee,
+	0xc6, 0x57, 0x8f, 0x83, 0x61, 0x3f, 0x7b, 0x88, 0x25, 0x79, 0x99, 0x79, 0x6c, 0x14, 0xb9, 0x34,
+	0xcb, 0xd3, 0x7a, 0x45, 0x5c, 0x9a, 0x23, 0xf2, 0x60, 0xf0, 0xd0, 0x0c, 0x5d, 0x2b, 0x18, 0x3c,
+	0x3b, 0x18, 0x88, 0xb8, 0x6a, 0x07, 0x83, 0x27, 0xc1, 0x30, 0x8c, 0x0e, 0x8b, 0x3c, 0x75, 0x90,
+	0xa1, 0xee, 0x95, 0x70, 0xe4, 0xbc, 0x01, 0x96, 0xbf, 0xdd, 0x7d, 0x8b, 0x6f, 0x8d, 0xf9, 0xa6,
+	0xf0, 0xce, 0x5d, 0x68, 0xf7, 0xfd, 0x71, 0xea, 0x07, 0xc3, 0xec, 0xbc, 0xb7, 0xce, 0xfd, 0x63,
+	0x8d, 0x3c, 0xb2, 0x6d, 0x90, 0xba, 0x15, 0x14, 0x5c, 0xee, 0xef, 0x2b, 0xb0, 0x32, 0x41, 0x76,
+	0xee, 0x80, 0x63, 0x6e, 0x9f, 0x3e, 0x52, 0xc9, 0x01, 0x5b, 0x96, 0xd3, 0xa0, 0xe2, 0xcd, 0xa0,
+	0xd0, 0x35, 0x12, 0x15, 0x70, 0xfa, 0x1d, 0x0c, 0x7f, 0xa8, 0x74, 0x46, 0x94, 0x70, 0xe4, 0x5c,
+	0xe9, 0x94, 0x07, 0xa7, 0xea, 0x4c, 0x17, 0x5a, 0x0b, 0x43, 0x45, 0x85, 0x8d, 0xb6, 0x6d, 0xc4,
+	0x73, 0x66, 0xd4, 0xbc, 0x09, 0xac, 0xfb, 0xeb, 0x0a, 0x2c, 0xda, 0xed, 0xd0, 0x6a, 0xd4, 0x95,
+	0x39, 0x8d, 0xba, 0x6a, 0x37, 0x6a, 0xe7, 0xcd, 0xbc, 0x21, 0x4b, 0x83, 0xe5, 0x90, 0x7d, 0x94,
+	0x44, 0xd4, 0xb9, 0x3c, 0x26, 0xe4, 0x3d, 0xfa, 0x2e, 0x74, 0xe8, 0x0e, 0xe7, 0x79, 0x67, 0x25,
+	0xfe, 0x15, 0xe2, 0xf7, 0x0a, 0xb4, 0x67, 0xf3, 0xb8, 0x7f, 0xa9, 0x42, 0xc7, 0x22, 0x4e, 0xa5,
+	0x7b, 0xe5, 0x73, 0xa6, 0x7b, 0x75, 0x4e, 0xba, 0xdf, 0x30, 0x2a, 0x8d, 0x8f, 0x76, 0x86, 0x89,
+	0xae, 0x80, 0x36, 0x2a, 0xe7, 0x28, 0xd5, 0x17, 0x1b, 0x45, 0x0d, 0xd2, 0x02, 0xad, 0xea, 0x32,
+	0x89, 0xa6, 0x40, 0x60, 0xd4, 0xb6, 0x9f, 0xf5, 0x9f, 0x3d, 0x89, 0x75, 0xc2, 0x35, 0x39, 0x6b,
+	0x67, 0x50, 0x9c, 0xd7, 0xa1, 0x91, 0x66, 0xfe, 0x89, 0xe2, 0xea, 0xb2, 0x7c, 0xaf, 0xcd, 0xd5,
+	0x80, 0x10, 0x9e, 0xe0, 0x2d, 0xe3, 0xb7, 0x5e, 0x62, 0x7c, 0xf7, 0x8f, 0x35, 0xec, 0x15, 0xf6,
+	0xc4, 0x32, 0x6b, 0xd0, 0x2b, 0x4e, 0xac, 0xce, 0x39, 0xf1, 0x06, 0xd4, 0xc7, 0xe1, 0x50, 0x9c,
+	0xbd, 0x7c, 0x6f, 0x91, 0xe8, 0x4f, 0x10, 0xa6, 0x82, 0xe2, 0x31, 0xc5, 0xd2, 0xa9, 0xfe, 0xb2,
+	0x80, 0xc0, 0x0a, 0x54, 0x54, 0x33, 0xac, 0x1f, 0xd8, 0x74, 0x4e, 0xf3, 0xf6, 0x37, 0x8b, 0x84,
+	0x3a, 0xf3, 0x98, 0xc7, 0x55, 0xf9, 0xc3, 0x4b, 0x32, 0xe8, 0x7d, 0x09, 0x1a, 0x7d, 0x1a, 0xbc,
+	0xd8, 0x4a, 0x3a, 0xa0, 0xac, 0x49, 0x0c, 0xd9, 0x84, 0x8e, 0xe5, 0xab, 0x4e, 0x29, 0xae, 0x6d,
+	0xb5, 0x4c, 0x7c, 0xc5, 0x24, 0x84, 0x6c, 0x4c, 0x25, 0xae, 0x00, 0xc7, 0x03, 0xac, 0xc0, 0x39,
+	0x57, 0x31, 0x2e, 0x10, 0x17, 0x51, 0x89, 0x8b, 0xca, 0x2c, 0x97, 0x5c, 0xcd, 0x55, 0x74, 0x3c,
+	0xe2, 0x22, 0x2a, 0xce, 0xa0, 0xf0, 0x02, 0x2b, 0xc1, 0x40, 0xfa, 0x6b, 0x87, 0x79, 0xd7, 0x89,
+	0xf7, 0x69, 0x8e, 0xd5, 0x51, 0x6f, 0xf1, 0xdd, 0x6f, 0x61, 0x0a, 0x4a, 0xf8, 0x7f, 0x0b, 0x56,
+	0x4b, 0x3e, 0xdb, 0x1b, 0xa6, 0x6c, 0x60, 0x21, 0xa3, 0xe7, 0xe6, 0xcc, 0xa6, 0xe6, 0x7d, 0xac,
+	0x12, 0x6c, 0x89, 0xf7, 0x93, 0x24, 0x4a, 0xcc, 0x8c, 0x5c, 0xc9, 0x67, 0x64, 0xf7, 0x3a, 0xb4,
+	0xc9, 0x02, 0x17, 0x90, 0xe9, 0xea, 0xf3, 0xc8, 0x31, 0x96, 0x0e, 0xba, 0xf3, 0xe3, 0xbd, 0x39,
+	0x1c, 0xce, 0x3d, 0x58, 0x97, 0x41, 0x55, 0x92, 0xe0, 0x51, 0x94, 0x0e, 0xd9, 0x12, 0x92, 0x8e,
+	0x33, 0x69, 0x54, 0xfe, 0x15, 0x89, 0x43, 0xb1, 0x66, 0x94, 0x32, 0xb0, 0xfb, 0x75, 0x68, 0xd3,
+	0x89, 0x72, 0xdc, 0x6d, 0x68, 0x32, 0xc1, 0xd8, 0xa1, 0x9b, 0x3b, 0x41, 0x2b, 0xe4, 0x69, 0xba,
+	0xfb, 0x53, 0x9c, 0xcd, 0xa5, 0xc8, 0xc9, 0x9b, 0xaf, 0x5a, 0xe3, 0x6e, 0x94, 0x5e, 0x37, 0x55,
+	0xc2, 0x96, 0x78, 0x07, 0x80, 0xcb, 0x94, 0x30, 0xd4, 0x8b, 0xa0, 0x28, 0xb0, 0x9e, 0xc5, 0x41,
+	0x8e, 0x29, 0xa0, 0x19, 0xa6, 0xfd, 0x65, 0x15, 0x6d, 0x2b, 0x2e, 0x15, 0x96, 0xff, 0x53, 0xb2,
+	0xea, 0x7c, 0xaa, 0xdb, 0xf9, 0x74, 0xcb, 0xe4, 0x53, 0xa3, 0xb8, 0x46, 0x11, 0x45, 0x45, 0x3a,
+	0xdd, 0xd4, 0xe9, 0xd4, 0x64, 0xb6, 0x25, 0x93, 0x4e, 0x86, 0x4b, 0xb2, 0xe9, 0xa6, 0xce, 0xa6,
+	0x85, 0x82, 0x29, 0x0f, 0xa9, 0x3c, 0x99, 0x6e, 0xea, 0x64, 0x6a, 0x15, 0x4c, 0xb9, 0x9b, 0x4d,
+	0x2e, 0xdd, 0x5f, 0x80, 0x06, 0xbb, 0xd3, 0x7d, 0x07, 0xba, 0xb6, 0x69, 0x38, 0x27, 0x6e, 0x69,
+	0x62, 0x29, 0x14, 0x2c, 0x26, 0x4f, 0xbf, 0xfb, 0x1c, 0x96, 0x4a, 0xa5, 0x88, 0xfa, 0xe8, 0x30,
+	0xdd, 0xf6, 0x71, 0x60, 0x0a, 0xf2, 0x55, 0xcd, 0xc2, 0x58, 0x41, 0x56, 0x2d, 0x24, 0x6b, 0x11,
+	0xa5, 0x20, 0xb3, 0x16, 0xae, 0x5a, 0x69, 0xe1, 0xfa, 0x0c, 0x3b, 0xac, 0xfd, 0x02, 0xed, 0x6c,
+	0xf8, 0xb0, 0x1d, 0x0d, 0xc4, 0x9b, 0xb8, 0xb3, 0x69, 0x90, 0x42, 0x9f, 0x1e, 0x03, 0xdc, 0x14,
+	0x75, 0x04, 0xe6, 0xb0, 0xa6, 0x1d, 0xf4, 0xa3, 0xd8, 0xac, 0xd0, 0x39, 0xac, 0x69, 0x7b, 0xea,
+	0x85, 0x0a, 0x74, 0x83, 0xca, 0x61, 0x3a, 0xed, 0x21, 0x1e, 0x4d, 0x61, 0x22, 0x75, 0xd5, 0x80,
+	0xf4, 0x96, 0xe7, 0x9f, 0xd1, 0xb0, 0xa2, 0xf4, 0x98, 0x9b, 0xc3, 0x64, 0x16, 0x5a, 0xf5, 0x7d,
+	0x9c, 0x30, 0x43, 0x33, 0xdc, 0x5a, 0x18, 0xf7, 0x0c, 0x56, 0x1f, 0x8d, 0x71, 0xb3, 0xe0, 0x20,
+	0x36, 0x5f, 0x0e, 0x50, 0xe0, 0x30, 0xf4, 0xfb, 0xd9, 0xf0, 0x85, 0xd2, 0x96, 0xcc, 0x61, 0x8a,
+	0x5f, 0x5c, 0xdb, 0xcc, 0x2c, 0xc3, 0xcf, 0xc4, 0x7f, 0x8c, 0x05, 0x80, 0xe3, 0x5a, 0x5f, 0xc9,
+	0xc0, 0x9c, 0xa2, 0xd2, 0x93, 0xf5, 0x77, 0x01, 0x81, 0xdc, 0x5f, 0x55, 0x61, 0x63, 0x3f, 0x56,
+	0x09, 0x2e, 0x80, 0xf2, 0x2d, 0xe2, 0x00, 0x83, 0x71, 0xe4, 0x1b, 0x15, 0xae, 0x41, 0x35, 0x8a,
+	0xf9, 0x70, 0x1d, 0xef, 0x42, 0xde, 0x8f, 0x3d, 0xc4, 0xb3, 0x12, 0x18, 0x11, 0xda, 0xb6, 0xfc,
+	0x3c, 0xf7, 0xc3, 0x04, 0x2a, 0x87, 0xe5, 0xd8, 0x3f, 0xf2, 0xd1, 0x3a, 0xda, 0xa6, 0x06, 0xe6,
+	0x1d, 0x9e, 0x56, 0x5e, 0x6d, 0x51, 0x01, 0x58, 0x12, 0x9f, 0xa6, 0xad, 0xa9, 0x21, 0xe2, 0x3e,
+	0x0e, 0xc6, 0xe9, 0x33, 0x36, 0x63, 0xcb, 0x13, 0x80, 0x74, 0xc9, 0x63, 0xbe, 0xa5, 0xdb, 0x05,
+	0x5a, 0xfd, 0x38, 0x89, 0x46, 0x52, 0x58, 0xb8, 0x01, 0x61, 0x30, 0x16, 0x18, 0x43, 0x3f, 0x94,
+	0x0d, 0x0f, 0x0a, 0xba, 0x60, 0xdc, 0x0c, 0x96, 0x9e, 0xde, 0xd5, 0x61, 0xff, 0x10, 0xa3, 0x0f,
+	0x2f, 0x51, 0x98, 0x03, 0xc8, 0x1c, 0x44, 0xd1, 0xc6, 0x78, 0x69, 0xf5, 0x30, 0x25, 0xa7, 0x66,
+	0x95, 0x1c, 0x63, 0xc1, 0x3a, 0x87, 0x38, 0x3f, 0xbb, 0x6f, 0xc1, 0xba, 0xf6, 0xc8, 0xd3, 0xbb,
+	0x74, 0xea, 0x5c, 0x5f, 0x08, 0x59, 0x8e, 0x77, 0xff, 0x5c, 0x81, 0xcb, 0x13, 0xaf, 0xbd, 0xf2,
+	0x27, 0x9e, 0xb7, 0xa1, 0x4e, 0x3b, 0x32, 0x6a, 0x48, 0xa9, 0x79, 0x93, 0xce, 0x98, 0x29, 0xf2,
+	0x0e, 0x01, 0xef, 0x87, 0x59, 0x72, 0xee, 0xf1, 0x0b, 0x1b, 0x1f, 0x41, 0x3b, 0x47, 0x91, 0xdc,
+	0x53, 0x75, 0x6e, 0xaa, 0x2f, 0x3e, 0xd2, 0x44, 0x81, 0xed, 0x78, 0x2c, 0xa6, 0xd1, 0x0d, 0xb6,
+	0x64, 0x58, 0x4f, 0xe8, 0xef, 0x54, 0xbf, 0x51, 0x71, 0x7f, 0x04, 0xbd, 0x0f, 0xfd, 0x70, 0x10,
+	0xe8, 0x78, 0x94, 0xa2, 0xa0, 0x4d, 0xf0, 0x9a, 0x65, 0x82, 0x0e, 0x49, 0x61, 0xea, 0x05, 0xd1,
+	0x88, 0xfb, 0xcd, 0x91, 0x69, 0x87, 0xda, 0xf0, 0x05, 0x82, 0x63, 0xe6, 0x79, 0x90, 0xea, 0x4d,
+	0x9c, 0x9f, 0xdd, 0xcb, 0xb0, 0xf6, 0x40, 0x65, 0x72, 0xf6, 0xf6, 0xf1, 0x89, 0x3e, 0xd9, 0xbd,
+	0x0d, 0xeb, 0x65, 0xb4, 0x36, 0x2e, 0x5e, 0xb6, 0x7f, 0x9c, 0xb7, 0x1a, 0x7c, 0x74, 0x0f, 0xe0,
+	0xba, 0x4c, 0x4b, 0xe3, 0x23, 0x52, 0x81, 0x4a, 0xdf, 0x93, 0x18, 0x43, 0x5d, 0x99, 0x4b, 0x60,
+	0x13, 0x4f, 0x85, 0x86, 0x82, 0x0e, 0xa3, 0x51, 0x70, 0x90, 0x25, 0xf4, 0xc1, 0x49, 0x64, 0xcc,
+	0xa4, 0xb9, 0x7b, 0xb0, 0x39, 0x4f, 0xa8, 0x56, 0x04, 0xeb, 0x92, 0xfe, 0xbe, 0xa5, 0xdd, 0x6c,
+	0xc0, 0x69, 0x3f, 0xbb, 0x27, 0xb0, 0x81, 0x97, 0x99, 0x9a, 0x99, 0x8a, 0xb2, 0x43, 0x67, 0x7c,
+	0x5c, 0xb4, 0xc7, 0x1c, 0x76, 0xbe, 0x42, 0x1f, 0x9b, 0x02, 0x9c, 0xa5, 0xf5, 0xce, 0x31, 0x15,
+	0xeb, 0x25, 0xb2, 0xfb, 0xb7, 0x1a, 0x74, 0x27, 0x8f, 0xc9, 0xfd, 0x54, 0x99, 0x59, 0x35, 0xaa,
+	0xa5, 0xaa, 0x81, 0xbc, 0x23, 0x2a, 0xec, 0x3a, 0x67, 0xe8, 0xb9, 0x48, 0xb4, 0xfa, 0x9c, 0x44,
+	0xc3, 0x05, 0x42, 0x4f, 0x7f, 0x91, 0xd9, 0x6b, 0xf4, 0x02, 0x31, 0x81, 0xa6, 0x81, 0x79, 0x02,
+	0xc5, 0xeb, 0x86, 0xd4, 0x9b, 0x59, 0x24, 0x6b, 0x1a, 0x5f, 0xf8, 0x1c, 0xd3, 0x78, 0x2c, 0x04,
+	0xf9, 0x0a, 0xa7, 0x4d, 0xd6, 0x12, 0xe1, 0x33, 0x48, 0xf4, 0x99, 0x2e, 0x56, 0x21, 0x7d, 0x9b,
+	0xb0, 0xf8, 0xdb, 0xcc, 0x3f, 0x4d, 0xa0, 0x6b, 0x72, 0xab, 0xb4, 0x78, 0x41, 0xae, 0x39, 0x81,
+	0xa6, 0x0d, 0xae, 0x3f, 0xce, 0xa2, 0x17, 0x66, 0x55, 0xa3, 0x64, 0x90, 0xef, 0x17, 0x53, 0x78,
+	0xd2, 0xa1, 0x84, 0x63, 0x83, 0x2c, 0x8a, 0x0e, 0x53, 0x04, 0xf7, 0xb7, 0x58, 0x75, 0x0a, 0x07,
+	0xf3, 0x77, 0xcb, 0x97, 0xec, 0xbd, 0x18, 0x5d, 0x69, 0xd2, 0x67, 0x4e, 0xd3, 0x93, 0x0d, 0xcc,
+	0x3d, 0x22, 0xcd, 0x84, 0xa6, 0x1b, 0x98, 0x81, 0x5f, 0xee, 0x75, 0x4c, 0x80, 0x51, 0xb9, 0x31,
+	0x6b, 0xd0, 0xfd, 0x53, 0x05, 0x5e, 0x9b, 0x19, 0xef, 0xff, 0xc3, 0x37, 0x70, 0xc8, 0x83, 0x22,
+	0xd5, 0x65, 0xf2, 0xe2, 0xfd, 0x83, 0x26, 0x99, 0x6f, 0xc3, 0x52, 0x56, 0x58, 0x46, 0x99, 0x6f,
+	0xe0, 0x57, 0xcb, 0x2f, 0x5a, 0xc6, 0xf3, 0xca, 0xfc, 0xee, 0x29, 0x5c, 0x2d, 0xe9, 0x5f, 0xaa,
+	0x89, 0xf7, 0x78, 0xbe, 0x27, 0x5e, 0xa5, 0x2b, 0xe3, 0x15, 0x4b, 0xb0, 0xcc, 0xd3, 0x4c, 0xf5,
+	0x72, 0xbe, 0x52, 0x8a, 0x57, 0xcb, 0x29, 0xee, 0xfe, 0xa6, 0x0a, 0x2b, 0x13, 0x47, 0x39, 0xcb,
+	0x50, 0x1d, 0x0e, 0xb4, 0x23, 0xf1, 0x69, 0x6e, 0xba, 0xda, 0xce, 0xad, 0x4d, 0x38, 0x97, 0x0a,
+	0x54, 0xd2, 0xdf, 0xc1, 0x9e, 0xaf, 0xfb, 0xbf, 0x01, 0x4b, 0x6e, 0x6f, 0x4c, 0xb8, 0x1d, 0xdf,
+	0xc2, 0x67, 0x7e, 0x4b, 0xb2, 0xd2, 0x80, 0x54, 0xda, 0x39, 0xce, 0xf9, 0x6b, 0x9c, 0x4c, 0x54,
+	0x05, 0x02, 0x17, 0x08, 0xb3, 0xd4, 0xb5, 0x2e, 0xb4, 0x89, 0xe6, 0xca, 0xe7, 0xa9, 0xb6, 0x2e,
+	0x4a, 0x34, 0x4f, 0x59, 0x11, 0x05, 0xe5, 0x88, 0x7a, 0x3e, 0x51, 0x40, 0xb5, 0x43, 0x5e, 0x39,
+	0x9e, 0xde, 0x34, 0x63, 0xb6, 0x84, 0xd2, 0x5a, 0x39, 0x22, 0x4a, 0x93, 0xf6, 0x2f, 0x2a, 0x70,
+	0xdd, 0x34, 0xe3, 0xd9, 0x81, 0x70, 0xd3, 0x6a, 0x8e, 0xd3, 0x92, 0x74, 0x93, 0xe4, 0xf9, 0xfc,
+	0xbd, 0x20, 0x90, 0xc5, 0xaa, 0x6a, 0xe6, 0x73, 0x83, 0x29, 0x45, 0x46, 0x6d, 0xa2, 0xf8, 0xaf,
+	0xb3, 0xb6, 0xbb, 0xf2, 0x9b, 0x49, 0xdd, 0x13, 0xc0, 0xfd, 0x08, 0x36, 0xe7, 0xe9, 0xf5, 0xaa,
+	0xf6, 0x70, 0xcf, 0xe1, 0xba, 0xb4, 0xb5, 0x42, 0x94, 0xf9, 0x85, 0xec, 0xe5, 0xbd, 0xa9, 0xd4,
+	0xeb, 0xab, 0x93, 0xbd, 0x3e, 0xff, 0x7a, 0xcb, 0xbf, 0x08, 0xd4, 0xec, 0xaf, 0xb7, 0x84, 0xd9,
+	0x3a, 0x85, 0xa6, 0x0c, 0x73, 0xce, 0x12, 0xb4, 0x77, 0x43, 0x4e, 0xdf, 0xfd, 0xb8, 0x7b, 0xc9,
+	0x69, 0x41, 0xfd, 0x20, 0x8b, 0xe2, 0x6e, 0xc5, 0x69, 0x43, 0xe3, 0x11, 0x4d, 0xf3, 0xdd, 0xaa,
+	0x03, 0xd0, 0xa4, 0x6a, 0x3f, 0x52, 0xdd, 0x1a, 0xa1, 0x31, 0x96, 0x92, 0xac, 0x5b, 0x27, 0xb4,
+	0xe8, 0xdf, 0x6d, 0x60, 0xce, 0xc0, 0x7b, 0x58, 0x2f, 0x35, 0x5b, 0x93, 0x68, 0x3b, 0x8a, 0x7e,
+	0xde, 0xe9, 0x2e, 0x6c, 0xfd, 0x98, 0x5f, 0x39, 0xa1, 0xf1, 0x61, 0x51, 0x9f, 0xc5, 0x30, 0x1e,
+	0xb7, 0x00, 0xb5, 0x8f, 0xd5, 0x19, 0x9e, 0xd6, 0x81, 0x05, 0x6f, 0x1c, 0xd2, 0x6f, 0x4f, 0x72,
+	0x1e, 0x1f, 0x3d, 0xc0, 0xf3, 0x90, 0x40, 0x0a, 0xc5, 0x08, 0xd4, 0x9d, 0x45, 0x68, 0x7d, 0xa0,
+	0x7f, 0x59, 0xc1, 0x33, 0x91, 0x44, 0x6c, 0xf4, 0x4e, 0x93, 0x48, 0x7c, 0x38, 0x41, 0x0b, 0x04,
+	0xf1, 0x5b, 0x04, 0xb5, 0xb6, 0xf6, 0xa1, 0x65, 0x36, 0x57, 0x67, 0x05, 0x3a, 0x5a, 0x07, 0x42,
+	0xa1, 0x0a, 0x78, 0x21, 0x1e, 0x36, 0x50, 0x09, 0xbc, 0x3c, 0xed, 0xa0, 0xa8, 0x01, 0x3e, 0xd1,
+	0xa2, 0x89, 0xe7, 0x93, 0x41, 0x70, 0xba, 0xc6, 0xc3, 0x91, 0x91, 0x17, 0x96, 0xee, 0x60, 0xeb,
+	0x21, 0x6a, 0x4b, 0x8f, 0xfb, 0x34, 0x87, 0x2d, 0x6b, 0x79, 0x1a, 0x83, 0x22, 0xd1, 0xa6, 0x74,
+	0xba, 0x70, 0x57, 0xc8, 0x36, 0x7c, 0x1d, 0x81, 0xab, 0xa4, 0x82, 0xd8, 0x49, 0x10, 0xb5, 0xad,
+	0x9f, 0x54, 0x50, 0x5d, 0xbd, 0x6a, 0x38, 0x6b, 0xb0, 0x62, 0x8c, 0xa4, 0x51, 0x22, 0x11, 0x53,
+	0x50, 0x10, 0x28, 0x91, 0x0e, 0xc8, 0xc1, 0x2a, 0xd9, 0xd5, 0x53, 0x23, 0x6c, 0x56, 0x1a, 0x53,
+	0xa3, 0x23, 0x69, 0xb3, 0xd5, 0x70, 0x9d, 0x5e, 0x20, 0x98, 0xab, 0x0c, 0x5a, 0xee, 0x0a, 0x38,
+	0x04, 0x3e, 0x1c, 0x9e, 0x50, 0x24, 0xcb, 0xfc, 0x9f, 0x76, 0x9b, 0x5b, 0xef, 0x42, 0xcb, 0x8c,
+	0xd9, 0x96, 0x1e, 0x06, 0x95, 0xeb, 0x21, 0x08, 0xd4, 0x23, 0x3f, 0x58, 0x63, 0xaa, 0x5b, 0x4f,
+	0x79, 0x3d, 0xa5, 0x29, 0xd5, 0xb2, 0x8c, 0xc6, 0xe8, 0xf0, 0x3a, 0x1d, 0xc6, 0xda, 0xe1, 0x2a,
+	0x0e, 0xfc, 0x7e, 0x1e, 0x60, 0xd8, 0x6b, 0x33, 0x54, 0x1d, 0x9f, 0x77, 0xc3, 0x1f, 0xa8, 0x3e,
+	0x45, 0x18, 0xb9, 0x01, 0xf5, 0xec, 0x36, 0xb6, 0xf6, 0xa0, 0xf3, 0xd4, 0xf4, 0x98, 0x7d, 0xfa,
+	0xa5, 0xca, 0x31, 0xca, 0x15, 0x58, 0x94, 0x8f, 0x67, 0x72, 0x74, 0xe6, 0x58, 0x3c, 0x69, 0x15,
+	0x96, 0xc8, 0x1b, 0x05, 0xaa, 0xba, 0xf5, 0x18, 0x9c, 0xe9, 0xea, 0x48, 0x46, 0x2b, 0x14, 0x46,
+	0x61, 0xa8, 0x09, 0x06, 0x27, 0x3d, 0xb3, 0x0f, 0x77, 0x4f, 0xc2, 0x28, 0x51, 0x4c, 0x33, 0x3e,
+	0xe4, 0xef, 0x8b, 0x84, 0xa8, 0xe1, 0xc5, 0x57, 0x26, 0x2a, 0x90, 0x15, 0xee, 0x0c, 0xa3, 0x44,
+	0x0a, 0x3e, 0x96, 0x22, 0x08, 0x6d, 0x40, 0x16, 0x23, 0x98, 0x2a, 0x1d, 0xb4, 0x1d, 0x28, 0x3f,
+	0x11, 0xb8, 0x76, 0xef, 0xdf, 0x4d, 0x68, 0x4a, 0x55, 0x70, 0xde, 0x85, 0x8e, 0xf5, 0xa3, 0xb6,
+	0xc3, 0x45, 0x7e, 0xfa, 0x27, 0xf8, 0x8d, 0x2f, 0x4c, 0xe1, 0xa5, 0x32, 0xb9, 0x97, 0xb0, 0x37,
+	0x43, 0xb1, 0x78, 0x3b, 0x97, 0x79, 0x9a, 0x9b, 0x5c, 0xc4, 0x37, 0x7a, 0xfc, 0xc9, 0x66, 0xc6,
+	0x0f, 0xf6, 0x28, 0xe0, 0x3b, 0xb0, 0xa4, 0xcb, 0x9f, 0x84, 0x96, 0xb3, 0x69, 0xad, 0x4d, 0x33,
+	0x56, 0xea, 0x0b, 0x85, 0x7d, 0x90, 0x0b, 0x93, 0xf0, 0x71, 0x7a, 0x33, 0x76, 0x30, 0x11, 0x73,
+	0x75, 0xee, 0x76, 0x86, 0x72, 0x1e, 0x40, 0x47, 0x76, 0x28, 0x29, 0xea, 0xd7, 0x88, 0x77, 0xde,
+	0x52, 0x75, 0xa1, 0x42, 0xdb, 0xb0, 0x68, 0xaf, 0x3d, 0x0e, 0x5b, 0x72, 0xc6, 0x7e, 0x24, 0x42,
+	0x66, 0x6d, 0x48, 0x28, 0xc4, 0x87, 0x2b, 0xb3, 0x97, 0x17, 0xe7, 0x8d, 0xe2, 0xdb, 0xf2, 0x9c,
+	0x6d, 0x69, 0xc3, 0xbd, 0x88, 0x25, 0x3f, 0xe2, 0x7b, 0xd0, 0xcb, 0x0f, 0xcf, 0xc3, 0x5a, 0x47,
+	0xc5, 0xa6, 0x56, 0x6d, 0xce, 0xbe, 0xb3, 0xf1, 0xfa, 0x5c, 0x7a, 0x2e, 0xfe, 0x10, 0x56, 0x0b,
+	0x86, 0x48, 0xcc, 0xe7, 0x5c, 0x9f, 0x7a, 0xaf, 0x64, 0xd6, 0xcd, 0x79, 0xe4, 0x5c, 0xea, 0xf7,
+	0x8b, 0x8d, 0xbd, 0x2c, 0xf9, 0x0d, 0xdb, 0xb7, 0xb3, 0xa5, 0xbb, 0x17, 0xb1, 0xe4, 0x27, 0x3c,
+	0x82, 0x95, 0x52, 0x3f, 0x35, 0xb2, 0x2f, 0x6c, 0xb2, 0x17, 0x05, 0xc4, 0xfd, 0xde, 0x27, 0xff,
+	0xd8, 0xac, 0x7c, 0x8a, 0x7f, 0x7f, 0xc7, 0xbf, 0x9f, 0xfd, 0x73, 0xf3, 0xd2, 0xa7, 0xf8, 0xf7,
+	0x57, 0xfc, 0x3b, 0x6a, 0xf2, 0x3f, 0xc2, 0x7c, 0xed, 0xbf, 0x3a, 0x4c, 0xd1, 0x14, 0x1a, 0x23,
+	0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -4711,6 +4799,20 @@ func (m *SyncStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Causality != nil {
+		{
+			size, err := m.Causality.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintDmworker(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xa2
+	}
 	if m.DumpIOTotalBytes != 0 {
 		i = encodeVarintDmworker(dAtA, i, uint64(m.DumpIOTotalBytes))
 		i--
@@ -4849,6 +4951,51 @@ func (m *SyncStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *CausalityStatus) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CausalityStatus) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CausalityStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.TotalConflicts != 0 {
+		i = encodeVarintDmworker(dAtA, i, uint64(m.TotalConflicts))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.WorkerSkew != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.WorkerSkew))))
+		i--
+		dAtA[i] = 0x19
+	}
+	if m.RelationSize != 0 {
+		i = encodeVarintDmworker(dAtA, i, uint64(m.RelationSize))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.ConflictsPerSecond != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.ConflictsPerSecond))))
+		i--
+		dAtA[i] = 0x9
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *SourceStatus) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -7102,6 +7249,31 @@ func (m *SyncStatus) Size() (n int) {
 	if m.DumpIOTotalBytes != 0 {
 		n += 2 + sovDmworker(uint64(m.DumpIOTotalBytes))
 	}
+	if m.Causality != nil {
+		l = m.Causality.Size()
+		n += 2 + l + sovDmworker(uint64(l))
+	}
+	return n
+}
+
+func (m *CausalityStatus) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ConflictsPerSecond != 0 {
+		n += 9
+	}
+	if m.RelationSize != 0 {
+		n += 1 + sovDmworker(uint64(m.RelationSize))
+	}
+	if m.WorkerSkew != 0 {
+		n += 9
+	}
+	if m.TotalConflicts != 0 {
+		n += 1 + sovDmworker(uint64(m.TotalConflicts))
+	}
 	return n
 }
 
@@ -9716,6 +9888,152 @@ func (m *SyncStatus) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 20:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Causality", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Causality == nil {
+				m.Causality = &CausalityStatus{}
+			}
+			if err := m.Causality.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDmworker(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CausalityStatus) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDmworker
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CausalityStatus: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CausalityStatus: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConflictsPerSecond", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.ConflictsPerSecond = float64(math.Float64frombits(v))
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RelationSize", wireType)
+			}
+			m.RelationSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RelationSize |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WorkerSkew", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.WorkerSkew = float64(math.Float64frombits(v))
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TotalConflicts", wireType)
+			}
+			m.TotalConflicts = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TotalConflicts |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipDmworker(dAtA[iNdEx:])
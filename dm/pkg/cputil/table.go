@@ -51,6 +51,11 @@ func SyncerShardMeta(task string) string {
 	return task + "_syncer_sharding_meta"
 }
 
+// SyncerCausalityRelation returns syncer's persisted causality relation table name.
+func SyncerCausalityRelation(task string) string {
+	return task + "_syncer_causality_relation"
+}
+
 // SyncerOnlineDDL returns syncer's onlineddl checkpoint table name.
 func SyncerOnlineDDL(task string) string {
 	return task + "_onlineddl"
@@ -0,0 +1,86 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ha
+
+import (
+	"time"
+
+	"github.com/pingcap/tiflow/engine/pkg/promutil"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// used for the op label of openAPITaskTemplateOpDurationHist.
+const (
+	openAPITaskTemplateOpGet    = "get"
+	openAPITaskTemplateOpPut    = "put"
+	openAPITaskTemplateOpUpdate = "update"
+	openAPITaskTemplateOpDelete = "delete"
+)
+
+// used for the result label of metrics.
+const (
+	opResultSuccess = "success"
+	opResultFail    = "fail"
+)
+
+var (
+	f                                 = &promutil.PromFactory{}
+	openAPITaskTemplateOpDurationHist = f.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "dm",
+			Subsystem: "ha",
+			Name:      "openapi_task_template_op_duration_seconds",
+			Help:      "bucketed histogram of the duration (s) of openapi task template operations in etcd",
+			Buckets:   prometheus.ExponentialBuckets(0.0005, 2, 18),
+		}, []string{"op", "result"})
+	openAPITaskTemplateLegacyValueCounter = f.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "dm",
+			Subsystem: "ha",
+			Name:      "openapi_task_template_legacy_value_total",
+			Help:      "total number of openapi task templates read from etcd as plain JSON without checksum",
+		})
+	openAPITaskTemplateStorageSizeGauge = f.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "dm",
+			Subsystem: "ha",
+			Name:      "openapi_task_template_storage_bytes",
+			Help:      "total size (bytes) of the values of openapi task templates in etcd",
+		})
+	openAPITaskTemplateSizeHist = f.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: "dm",
+			Subsystem: "ha",
+			Name:      "openapi_task_template_size_bytes",
+			Help:      "bucketed histogram of the size (bytes) of the value of an openapi task template in etcd",
+			Buckets:   prometheus.ExponentialBuckets(256, 2, 14),
+		})
+)
+
+// RegisterMetrics registers metrics of HA.
+func RegisterMetrics(registry prometheus.Registerer) {
+	registry.MustRegister(openAPITaskTemplateOpDurationHist)
+	registry.MustRegister(openAPITaskTemplateLegacyValueCounter)
+	registry.MustRegister(openAPITaskTemplateStorageSizeGauge)
+	registry.MustRegister(openAPITaskTemplateSizeHist)
+}
+
+// observeOpenAPITaskTemplateOp observes the duration of an openapi task template operation started at startTime.
+func observeOpenAPITaskTemplateOp(op string, startTime time.Time, err error) {
+	result := opResultSuccess
+	if err != nil {
+		result = opResultFail
+	}
+	openAPITaskTemplateOpDurationHist.WithLabelValues(op, result).Observe(time.Since(startTime).Seconds())
+}
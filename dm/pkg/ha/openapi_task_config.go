@@ -14,16 +14,522 @@
 package ha
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/pingcap/tiflow/dm/common"
+	"github.com/pingcap/tiflow/dm/config"
 	"github.com/pingcap/tiflow/dm/openapi"
 	"github.com/pingcap/tiflow/dm/pkg/etcdutil"
+	"github.com/pingcap/tiflow/dm/pkg/log"
 	"github.com/pingcap/tiflow/dm/pkg/terror"
+	"go.etcd.io/etcd/api/v3/mvccpb"
 	clientv3 "go.etcd.io/etcd/client/v3"
 	"go.etcd.io/etcd/client/v3/clientv3util"
+	"go.uber.org/zap"
 )
 
+// OpenAPITaskTemplateEvent is the change event of an openapi task template.
+type OpenAPITaskTemplateEvent struct {
+	Name     string
+	Task     *openapi.Task // nil for delete events
+	IsDelete bool
+	// Revision is the etcd revision of the change, callers can resume watching from Revision+1.
+	Revision int64
+}
+
+// DefaultOpenAPITaskTemplateVersionsToKeep is the default max number of history versions kept for each openapi task
+// template, see WithOpenAPITaskTemplateVersionsToKeep.
+const DefaultOpenAPITaskTemplateVersionsToKeep = 10
+
+// openAPITaskTemplateCompressThreshold is the min size of task JSON to be compressed in the envelope.
+var openAPITaskTemplateCompressThreshold = 4 * 1024
+
+// OpenAPITaskTemplateOption is the option to write openapi task templates.
+type OpenAPITaskTemplateOption func(*openAPITaskTemplateOptions)
+
+type openAPITaskTemplateOptions struct {
+	envelope       bool
+	versionsToKeep int
+	validate       bool
+}
+
+func newOpenAPITaskTemplateOptions(opts []OpenAPITaskTemplateOption) *openAPITaskTemplateOptions {
+	o := &openAPITaskTemplateOptions{versionsToKeep: DefaultOpenAPITaskTemplateVersionsToKeep}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithOpenAPITaskTemplateEnvelope sets whether openapi task templates are written in the envelope, which has the
+// checksum, the metadata and the compressed task JSON, see openAPITaskTemplateEnvelopeHeader. it's disabled by
+// default, and task templates are written as plain JSON without metadata.
+// NOTE: dm-master of older versions can't read task templates written in the envelope, so enable it only after all
+// dm-masters are upgraded. before downgrading, disable it and put the task templates again.
+func WithOpenAPITaskTemplateEnvelope(enable bool) OpenAPITaskTemplateOption {
+	return func(o *openAPITaskTemplateOptions) {
+		o.envelope = enable
+	}
+}
+
+// WithOpenAPITaskTemplateVersionsToKeep sets the max number of history versions kept for each openapi task template,
+// older versions are removed when a new version is written. it's DefaultOpenAPITaskTemplateVersionsToKeep by default,
+// and non-positive keep is ignored.
+func WithOpenAPITaskTemplateVersionsToKeep(keep int) OpenAPITaskTemplateOption {
+	return func(o *openAPITaskTemplateOptions) {
+		if keep > 0 {
+			o.versionsToKeep = keep
+		}
+	}
+}
+
+// WithOpenAPITaskTemplateValidation sets whether openapi task templates are validated like
+// ValidateOpenAPITaskTemplate before they are written, the sources are not required to be enabled. it's disabled by
+// default, so legacy templates which can't pass the validation can still be written.
+func WithOpenAPITaskTemplateValidation(enable bool) OpenAPITaskTemplateOption {
+	return func(o *openAPITaskTemplateOptions) {
+		o.validate = enable
+	}
+}
+
+// OpenAPITaskTemplateMeta is the metadata of an openapi task template, it's written together with the task template in
+// the envelope.
+type OpenAPITaskTemplateMeta struct {
+	// ModifiedAt is the time the task template is put or updated, it's the local time of the writer.
+	ModifiedAt time.Time `json:"modified-at"`
+	// ModifiedBy is the author passed by the writer, it's empty if not passed.
+	ModifiedBy string `json:"modified-by,omitempty"`
+}
+
+// OpenAPITaskTemplateWithMeta is an openapi task template with its metadata.
+type OpenAPITaskTemplateWithMeta struct {
+	Task *openapi.Task
+	// Meta is nil if the task template is written as plain JSON, see WithOpenAPITaskTemplateEnvelope.
+	Meta *OpenAPITaskTemplateMeta
+}
+
+// openAPITaskTemplateEnvelopeHeader is the first byte of the task template written in the envelope, task templates
+// written as plain JSON never start with this byte. the envelope is laid out as follows:
+//
+//	header (1 byte) | version (1 byte) | checksum (4 bytes) | flags (1 byte) | metadata length (uvarint) |
+//	metadata JSON | task JSON
+//
+// the checksum is the CRC-32 (Castagnoli) of the data after it in big endian, so it's always written together with
+// the task template. the task JSON is gzip compressed if openAPITaskTemplateEnvelopeGzip is set in flags.
+const openAPITaskTemplateEnvelopeHeader byte = 0x01
+
+// openAPITaskTemplateEnvelopeVersion is the version of the envelope, it should be increased when the layout after
+// the version is changed, and envelopes of old versions should still be decoded.
+const openAPITaskTemplateEnvelopeVersion byte = 1
+
+// openAPITaskTemplateEnvelopeGzip is the flag of the envelope whose task JSON is gzip compressed.
+const openAPITaskTemplateEnvelopeGzip byte = 0x01
+
+const openAPITaskTemplateEnvelopeHeaderLen = 2 + crc32.Size
+
+var openAPITaskTemplateCRCTable = crc32.MakeTable(crc32.Castagnoli)
+
+// encodeOpenAPITaskTemplateValue encodes the task to the value stored in etcd, it's the plain JSON of task unless
+// envelope is true, then the task and its metadata are encoded in the envelope, and the task JSON is compressed by
+// gzip if it's larger than openAPITaskTemplateCompressThreshold.
+func encodeOpenAPITaskTemplateValue(task openapi.Task, meta OpenAPITaskTemplateMeta, envelope bool) (string, error) {
+	taskJSON, err := task.ToJSON()
+	if err != nil {
+		return "", err
+	}
+	if !envelope {
+		return string(taskJSON), nil
+	}
+	metaJSON, err := json.Marshal(meta)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	buf.Write(make([]byte, openAPITaskTemplateEnvelopeHeaderLen))
+	compress := len(taskJSON) >= openAPITaskTemplateCompressThreshold
+	var flags byte
+	if compress {
+		flags |= openAPITaskTemplateEnvelopeGzip
+	}
+	buf.WriteByte(flags)
+	buf.Write(binary.AppendUvarint(nil, uint64(len(metaJSON))))
+	buf.Write(metaJSON)
+	if !compress {
+		buf.Write(taskJSON)
+	} else {
+		w := gzip.NewWriter(&buf)
+		if _, err = w.Write(taskJSON); err != nil {
+			return "", err
+		}
+		if err = w.Close(); err != nil {
+			return "", err
+		}
+	}
+	value := buf.Bytes()
+	value[0] = openAPITaskTemplateEnvelopeHeader
+	value[1] = openAPITaskTemplateEnvelopeVersion
+	binary.BigEndian.PutUint32(value[2:openAPITaskTemplateEnvelopeHeaderLen],
+		crc32.Checksum(value[openAPITaskTemplateEnvelopeHeaderLen:], openAPITaskTemplateCRCTable))
+	return string(value), nil
+}
+
+// decodeOpenAPITaskTemplateValue decodes the value stored in etcd to task, both the envelope and plain JSON are
+// supported. the checksum of the envelope is verified, ErrOpenAPITaskConfigCorrupt is returned if it mismatches.
+func decodeOpenAPITaskTemplateValue(value []byte, task *openapi.Task) error {
+	_, err := decodeOpenAPITaskTemplateValueWithMeta(value, task)
+	return err
+}
+
+// decodeOpenAPITaskTemplateValueWithMeta is like decodeOpenAPITaskTemplateValue, and it also returns the metadata, which
+// is nil if the value is plain JSON.
+func decodeOpenAPITaskTemplateValueWithMeta(value []byte, task *openapi.Task) (*OpenAPITaskTemplateMeta, error) {
+	if len(value) == 0 || value[0] != openAPITaskTemplateEnvelopeHeader {
+		openAPITaskTemplateLegacyValueCounter.Inc()
+		return nil, task.FromJSON(value)
+	}
+
+	if len(value) < openAPITaskTemplateEnvelopeHeaderLen {
+		return nil, terror.ErrHAInvalidItem.Generate("openapi task template with truncated envelope")
+	}
+	if version := value[1]; version != openAPITaskTemplateEnvelopeVersion {
+		return nil, terror.ErrHAInvalidItem.Generate(fmt.Sprintf(
+			"unsupported version %d of openapi task template envelope, the latest supported version is %d",
+			version, openAPITaskTemplateEnvelopeVersion))
+	}
+	expected := binary.BigEndian.Uint32(value[2:openAPITaskTemplateEnvelopeHeaderLen])
+	value = value[openAPITaskTemplateEnvelopeHeaderLen:]
+	if actual := crc32.Checksum(value, openAPITaskTemplateCRCTable); actual != expected {
+		return nil, terror.ErrOpenAPITaskConfigCorrupt.Generate(expected, actual)
+	}
+	if len(value) == 0 {
+		return nil, terror.ErrHAInvalidItem.Generate("openapi task template with truncated envelope")
+	}
+	flags := value[0]
+	metaLen, n := binary.Uvarint(value[1:])
+	if n <= 0 || uint64(len(value)-1-n) < metaLen {
+		return nil, terror.ErrHAInvalidItem.Generate("openapi task template with truncated metadata")
+	}
+	meta := &OpenAPITaskTemplateMeta{}
+	if err := json.Unmarshal(value[1+n:1+n+int(metaLen)], meta); err != nil {
+		return nil, terror.ErrHAInvalidItem.Delegate(err, "decode openapi task template metadata")
+	}
+	value = value[1+n+int(metaLen):]
+
+	if flags&openAPITaskTemplateEnvelopeGzip == 0 {
+		return meta, task.FromJSON(value)
+	}
+	r, err := gzip.NewReader(bytes.NewReader(value))
+	if err != nil {
+		return nil, terror.ErrHAInvalidItem.Delegate(err, "decompress openapi task template")
+	}
+	defer r.Close()
+	taskJSON, err := io.ReadAll(r)
+	if err != nil {
+		return nil, terror.ErrHAInvalidItem.Delegate(err, "decompress openapi task template")
+	}
+	return meta, task.FromJSON(taskJSON)
+}
+
+const (
+	// maxPutOpenAPITaskTemplateRetry is the max retry times when the task template is written concurrently.
+	maxPutOpenAPITaskTemplateRetry = 3
+	// maxOpenAPITaskTemplatePurgeOps is the max number of deletions in one txn when purging, it's less than the
+	// default `max-txn-ops` of etcd.
+	maxOpenAPITaskTemplatePurgeOps = 100
+)
+
+// DefaultOpenAPITaskTemplateNamespace is the namespace of openapi task templates put without a namespace, they are
+// stored in the same keys as before namespaces are supported. an empty namespace is treated as the default one.
+// NOTE: only the *InNamespace functions support other namespaces, others only work in the default namespace.
+const DefaultOpenAPITaskTemplateNamespace = "default"
+
+func isDefaultOpenAPITaskTemplateNamespace(namespace string) bool {
+	return namespace == "" || namespace == DefaultOpenAPITaskTemplateNamespace
+}
+
+// openAPITaskTemplateKey returns the etcd key of the task template in namespace.
+func openAPITaskTemplateKey(namespace, taskName string) string {
+	if isDefaultOpenAPITaskTemplateNamespace(namespace) {
+		return common.OpenAPITaskTemplateKeyAdapter.Encode(taskName)
+	}
+	return common.OpenAPITaskTemplateNamespaceKeyAdapter.Encode(namespace, taskName)
+}
+
+// openAPITaskTemplatePrefix returns the etcd key prefix of all task templates in namespace.
+func openAPITaskTemplatePrefix(namespace string) string {
+	if isDefaultOpenAPITaskTemplateNamespace(namespace) {
+		return common.OpenAPITaskTemplateKeyAdapter.Path()
+	}
+	return common.OpenAPITaskTemplateNamespaceKeyAdapter.Encode(namespace)
+}
+
+// openAPITaskTemplateLabelsKey returns the etcd key of the labels of the task template in the default namespace.
+func openAPITaskTemplateLabelsKey(taskName string) string {
+	return common.OpenAPITaskTemplateLabelsKeyAdapter.Encode(taskName)
+}
+
+// openAPITaskTemplateVersionPrefix returns the etcd key prefix of all versions of the task template in namespace.
+func openAPITaskTemplateVersionPrefix(namespace, taskName string) string {
+	if isDefaultOpenAPITaskTemplateNamespace(namespace) {
+		return common.OpenAPITaskTemplateVersionKeyAdapter.Encode(taskName)
+	}
+	return common.OpenAPITaskTemplateNamespaceVersionKeyAdapter.Encode(namespace, taskName)
+}
+
+func encodeOpenAPITaskTemplateVersionKey(namespace, taskName string, version int64) string {
+	if isDefaultOpenAPITaskTemplateNamespace(namespace) {
+		return common.OpenAPITaskTemplateVersionKeyAdapter.Encode(taskName, fmt.Sprintf("%020d", version))
+	}
+	return common.OpenAPITaskTemplateNamespaceVersionKeyAdapter.Encode(namespace, taskName, fmt.Sprintf("%020d", version))
+}
+
+func decodeOpenAPITaskTemplateVersionKey(namespace, key string) (int64, error) {
+	adapter := common.OpenAPITaskTemplateVersionKeyAdapter
+	if !isDefaultOpenAPITaskTemplateNamespace(namespace) {
+		adapter = common.OpenAPITaskTemplateNamespaceVersionKeyAdapter
+	}
+	keys, err := adapter.Decode(key)
+	if err != nil {
+		return 0, err
+	}
+	// the version is always the last part of the key.
+	version, err := strconv.ParseInt(keys[len(keys)-1], 10, 64)
+	if err != nil {
+		return 0, terror.ErrDecodeEtcdKeyFail.Generate(err.Error())
+	}
+	return version, nil
+}
+
+// listOpenAPITaskTemplateVersions returns all version numbers of task-name in namespace in ascending order.
+func listOpenAPITaskTemplateVersions(ctx context.Context, cli *clientv3.Client, namespace, taskName string) ([]int64, error) {
+	resp, err := cli.Get(ctx, openAPITaskTemplateVersionPrefix(namespace, taskName), clientv3.WithPrefix(),
+		clientv3.WithKeysOnly(), clientv3.WithSort(clientv3.SortByKey, clientv3.SortAscend))
+	if err != nil {
+		return nil, terror.ErrHAFailTxnOperation.Delegate(err, "list openapi task template versions")
+	}
+	versions := make([]int64, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		version, err := decodeOpenAPITaskTemplateVersionKey(namespace, string(kv.Key))
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, version)
+	}
+	return versions, nil
+}
+
+// checkOpenAPITaskTemplateName checks the name of task template only contains alphanumeric, dash and underscore
+// characters, others like `/` and whitespace may break the key prefix scheme of task templates. it should be checked
+// before the key of a new task template is encoded. the templates stored before the check is added may have other
+// names which are valid for DM tasks like `task.v1`, so writing an existing key is not checked.
+func checkOpenAPITaskTemplateName(name string) error {
+	if name == "" {
+		return terror.ErrOpenAPITaskConfigInvalid.Generate(name, "`name` should not be empty")
+	}
+	for _, r := range name {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '-' || r == '_' {
+			continue
+		}
+		return terror.ErrOpenAPITaskConfigInvalid.Generate(name,
+			fmt.Sprintf("`name` contains invalid character %q, only alphanumeric, dash and underscore are allowed", r))
+	}
+	return nil
+}
+
+// OpenAPITaskTemplateProblem is a problem of an openapi task template found by ValidateOpenAPITaskTemplate.
+type OpenAPITaskTemplateProblem struct {
+	// Field is the JSON path of the field which has the problem, like `source_config.source_conf[0]`, "" means the
+	// problem is not of a single field.
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+
+	// err is the error returned by validateOpenAPITaskTemplate for the problem.
+	err error
+}
+
+// ValidateOpenAPITaskTemplate checks the task template in the same way as the task-start path does, and returns all
+// problems found instead of the first one, an empty result means the task template is valid. the checks which need
+// to connect to the upstream or downstream database are not run. an error is returned only if the source configs
+// can't be read from etcd.
+func ValidateOpenAPITaskTemplate(cli *clientv3.Client, task openapi.Task) ([]OpenAPITaskTemplateProblem, error) {
+	return collectOpenAPITaskTemplateProblems(cli, task, true)
+}
+
+// validateOpenAPITaskTemplate is ValidateOpenAPITaskTemplate which returns the first problem as an error, it's used
+// before putting task templates, so the sources are not required to be enabled.
+func validateOpenAPITaskTemplate(cli *clientv3.Client, task openapi.Task) error {
+	problems, err := collectOpenAPITaskTemplateProblems(cli, task, false)
+	if err != nil {
+		return err
+	}
+	if len(problems) > 0 {
+		return problems[0].err
+	}
+	return nil
+}
+
+// collectOpenAPITaskTemplateProblems returns the problems of the task template, forStart also checks the sources
+// are enabled like starting the task does.
+func collectOpenAPITaskTemplateProblems(
+	cli *clientv3.Client, task openapi.Task, forStart bool,
+) ([]OpenAPITaskTemplateProblem, error) {
+	var problems []OpenAPITaskTemplateProblem
+	addProblem := func(field, msg string) {
+		problems = append(problems, OpenAPITaskTemplateProblem{
+			Field:   field,
+			Message: msg,
+			err:     terror.ErrOpenAPITaskConfigInvalid.Generate(task.Name, msg),
+		})
+	}
+	addError := func(field string, err error) {
+		problems = append(problems, OpenAPITaskTemplateProblem{
+			Field:   field,
+			Message: err.Error(),
+			err:     terror.ErrOpenAPITaskConfigInvalid.Delegate(err, task.Name, err.Error()),
+		})
+	}
+
+	if task.Name == "" {
+		addProblem("name", "`name` should not be empty")
+	} else if err := checkOpenAPITaskTemplateName(task.Name); err != nil {
+		problems = append(problems, OpenAPITaskTemplateProblem{Field: "name", Message: err.Error(), err: err})
+	}
+	switch task.TaskMode {
+	case openapi.TaskTaskModeAll, openapi.TaskTaskModeFull, openapi.TaskTaskModeIncremental,
+		openapi.TaskTaskModeDump, openapi.TaskTaskModeLoad:
+	default:
+		addProblem("task_mode", fmt.Sprintf("`task_mode` %s is not supported", task.TaskMode))
+	}
+
+	sourceCfgs, _, err := GetSourceCfg(cli, "", 0)
+	if err != nil {
+		return nil, err
+	}
+	sourceCfgMap := make(map[string]*config.SourceConfig, len(task.SourceConfig.SourceConf))
+	for i, cfg := range task.SourceConfig.SourceConf {
+		field := fmt.Sprintf("source_config.source_conf[%d]", i)
+		sourceCfg, ok := sourceCfgs[cfg.SourceName]
+		if !ok {
+			addProblem(field, fmt.Sprintf("source %s in `source_config` not exists", cfg.SourceName))
+			continue
+		}
+		if forStart && !sourceCfg.Enable {
+			addProblem(field, fmt.Sprintf("source %s in `source_config` is not enabled", cfg.SourceName))
+		}
+		sourceCfgMap[cfg.SourceName] = sourceCfg
+	}
+
+	// task is passed by value, but Adjust only sets the pointer fields of it, so it won't affect the caller.
+	if err = task.Adjust(); err != nil {
+		addError("", err)
+		return problems, nil
+	}
+	// the sub task configs are converted one by one, so the problems of all sources are found.
+	toDBCfg := config.GetTargetDBCfgFromOpenAPITask(&task)
+	sourceConfs := task.SourceConfig.SourceConf
+	for i, cfg := range sourceConfs {
+		sourceCfg, ok := sourceCfgMap[cfg.SourceName]
+		if !ok {
+			continue
+		}
+		task.SourceConfig.SourceConf = sourceConfs[i : i+1]
+		if _, err = config.OpenAPITaskToSubTaskConfigs(&task, toDBCfg,
+			map[string]*config.SourceConfig{cfg.SourceName: sourceCfg}); err != nil {
+			addError(fmt.Sprintf("source_config.source_conf[%d]", i), err)
+		}
+	}
+	return problems, nil
+}
+
+// putOpenAPITaskTemplatesWithVersion puts the task templates in namespace and a new version of each of them in one txn
+// if cmps are satisfied, the oldest versions exceeding the versions to keep of o are removed in the same txn.
+// extraOps are also executed in the txn, they must not touch the keys of tasks. opts are used to put both the templates
+// and the new versions. author is written to the metadata of them. it returns false if cmps are not satisfied.
+func putOpenAPITaskTemplatesWithVersion(
+	ctx context.Context, cli *clientv3.Client, namespace string, tasks []openapi.Task, author string, cmps []clientv3.Cmp,
+	extraOps []clientv3.Op, opName string, o *openAPITaskTemplateOptions, opts ...clientv3.OpOption,
+) (bool, error) {
+	meta := OpenAPITaskTemplateMeta{ModifiedAt: time.Now(), ModifiedBy: author}
+	values := make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		if o.validate {
+			if err := validateOpenAPITaskTemplate(cli, task); err != nil {
+				return false, err
+			}
+		}
+		value, err := encodeOpenAPITaskTemplateValue(task, meta, o.envelope)
+		if err != nil {
+			return false, err // it should not happen.
+		}
+		values = append(values, value)
+	}
+
+	for i := 0; i < maxPutOpenAPITaskTemplateRetry; i++ {
+		var (
+			ops         = append([]clientv3.Op(nil), extraOps...)
+			versionCmps = make([]clientv3.Cmp, 0, len(tasks))
+			versionGets = make([]clientv3.Op, 0, len(tasks))
+		)
+		for j, task := range tasks {
+			versions, err := listOpenAPITaskTemplateVersions(ctx, cli, namespace, task.Name)
+			if err != nil {
+				return false, err
+			}
+			var newVersion int64 = 1
+			if len(versions) > 0 {
+				newVersion = versions[len(versions)-1] + 1
+			}
+			versionKey := encodeOpenAPITaskTemplateVersionKey(namespace, task.Name, newVersion)
+			ops = append(ops,
+				clientv3.OpPut(openAPITaskTemplateKey(namespace, task.Name), values[j], opts...),
+				clientv3.OpPut(versionKey, values[j], opts...),
+			)
+			if keep := o.versionsToKeep; len(versions)+1 > keep {
+				for _, version := range versions[:len(versions)+1-keep] {
+					ops = append(ops, clientv3.OpDelete(encodeOpenAPITaskTemplateVersionKey(namespace, task.Name, version)))
+				}
+			}
+			versionCmps = append(versionCmps, clientv3util.KeyMissing(versionKey))
+			versionGets = append(versionGets, clientv3.OpGet(versionKey, clientv3.WithCountOnly()))
+		}
+
+		resp, err := cli.Txn(ctx).If(append(versionCmps, cmps...)...).Then(ops...).Else(versionGets...).Commit()
+		if err != nil {
+			return false, terror.ErrHAFailTxnOperation.Delegate(err, opName)
+		}
+		if resp.Succeeded {
+			return true, nil
+		}
+		concurrentWrite := false
+		for _, r := range resp.Responses {
+			if r.GetResponseRange().Count > 0 {
+				concurrentWrite = true
+				break
+			}
+		}
+		// the new versions are not written by others, so cmps are not satisfied.
+		if !concurrentWrite {
+			return false, nil
+		}
+	}
+	return false, terror.ErrHAFailTxnOperation.Generate(opName + ": too many concurrent writes")
+}
+
 func openAPITaskFromResp(resp *clientv3.GetResponse) (*openapi.Task, error) {
 	task := &openapi.Task{}
 	if resp.Count == 0 {
@@ -32,103 +538,1317 @@ func openAPITaskFromResp(resp *clientv3.GetResponse) (*openapi.Task, error) {
 		// this should not happen.
 		return task, terror.ErrConfigMoreThanOne.Generate(resp.Count, "openapi.Task", "")
 	}
-	// we make sure only have one task config.
-	if err := task.FromJSON(resp.Kvs[0].Value); err != nil {
-		return task, err
+	// we make sure only have one task config.
+	if err := decodeOpenAPITaskTemplateValue(resp.Kvs[0].Value, task); err != nil {
+		return task, err
+	}
+	return task, nil
+}
+
+// PutOpenAPITaskTemplate puts the openapi task config of task-name.
+func PutOpenAPITaskTemplate(cli *clientv3.Client, task openapi.Task, overWrite bool, opts ...OpenAPITaskTemplateOption) error {
+	return PutOpenAPITaskTemplateInNamespace(cli, DefaultOpenAPITaskTemplateNamespace, task, overWrite, opts...)
+}
+
+// PutOpenAPITaskTemplateWithAuthor puts the openapi task config of task-name, and records author as the last modifier
+// in the metadata, which can be read by GetOpenAPITaskTemplateWithMeta. the metadata is only written in the envelope,
+// see WithOpenAPITaskTemplateEnvelope.
+func PutOpenAPITaskTemplateWithAuthor(
+	cli *clientv3.Client, task openapi.Task, overWrite bool, author string, opts ...OpenAPITaskTemplateOption,
+) error {
+	return putOpenAPITaskTemplate(cli, DefaultOpenAPITaskTemplateNamespace, task, overWrite, author, opts)
+}
+
+// PutOpenAPITaskTemplateInNamespace puts the openapi task config of task-name in namespace.
+func PutOpenAPITaskTemplateInNamespace(
+	cli *clientv3.Client, namespace string, task openapi.Task, overWrite bool, opts ...OpenAPITaskTemplateOption,
+) error {
+	return putOpenAPITaskTemplate(cli, namespace, task, overWrite, "", opts)
+}
+
+func putOpenAPITaskTemplate(
+	cli *clientv3.Client, namespace string, task openapi.Task, overWrite bool, author string, opts []OpenAPITaskTemplateOption,
+) (err error) {
+	startTime := time.Now()
+	defer func() {
+		observeOpenAPITaskTemplateOp(openAPITaskTemplateOpPut, startTime, err)
+	}()
+
+	return putOpenAPITaskTemplateBatch(cli, namespace, []openapi.Task{task}, overWrite, author, newOpenAPITaskTemplateOptions(opts))
+}
+
+// PutOpenAPITaskTemplateWithTTL puts the openapi task config of task-name with a lease of ttl seconds, the template
+// and its history versions put by this call are removed automatically after the lease expires, unless the lease is
+// kept alive by RefreshOpenAPITaskTemplateLease. putting or updating the template without TTL later makes it permanent.
+// NOTE: the lease is persisted in etcd, so restarting the cluster doesn't reset the lease and the template still
+// expires. but etcd renews all leases to their full TTL when a new leader is elected, so the template may live longer
+// than ttl seconds.
+func PutOpenAPITaskTemplateWithTTL(
+	cli *clientv3.Client, task openapi.Task, overWrite bool, ttl int64, opts ...OpenAPITaskTemplateOption,
+) (err error) {
+	startTime := time.Now()
+	defer func() {
+		observeOpenAPITaskTemplateOp(openAPITaskTemplateOpPut, startTime, err)
+	}()
+
+	if ttl <= 0 {
+		return terror.ErrHAInvalidItem.Generate(fmt.Sprintf("invalid TTL %d of openapi task template", ttl))
+	}
+	ctx, cancel := context.WithTimeout(cli.Ctx(), etcdutil.DefaultRequestTimeout)
+	defer cancel()
+	lease, err := cli.Grant(ctx, ttl)
+	if err != nil {
+		return terror.ErrHAFailLeaseOperation.Delegate(err, "failed to grant lease for openapi task template")
+	}
+
+	err = putOpenAPITaskTemplateBatch(cli, DefaultOpenAPITaskTemplateNamespace, []openapi.Task{task}, overWrite, "",
+		newOpenAPITaskTemplateOptions(opts), clientv3.WithLease(lease.ID))
+	if err != nil {
+		// the lease is not attached to any key, revoke it to avoid leaking.
+		if _, err2 := revokeLease(cli, lease.ID); err2 != nil {
+			log.L().Warn("fail to revoke lease", zap.String("task", task.Name), zap.Error(err2))
+		}
+	}
+	return err
+}
+
+// RefreshOpenAPITaskTemplateLease keeps alive the lease of the openapi task config of task-name which is put by
+// PutOpenAPITaskTemplateWithTTL, it returns the remaining TTL in seconds of the lease after refreshing.
+func RefreshOpenAPITaskTemplateLease(cli *clientv3.Client, taskName string) (int64, error) {
+	ctx, cancel := context.WithTimeout(cli.Ctx(), etcdutil.DefaultRequestTimeout)
+	defer cancel()
+
+	resp, err := cli.Get(ctx, openAPITaskTemplateKey(DefaultOpenAPITaskTemplateNamespace, taskName), clientv3.WithKeysOnly())
+	if err != nil {
+		return 0, terror.ErrHAFailTxnOperation.Delegate(err, "get openapi task template")
+	}
+	if resp.Count == 0 {
+		return 0, terror.ErrOpenAPITaskConfigNotExist.Generate(taskName)
+	}
+	leaseID := clientv3.LeaseID(resp.Kvs[0].Lease)
+	if leaseID == clientv3.NoLease {
+		return 0, terror.ErrHAInvalidItem.Generate(fmt.Sprintf("openapi task template %s is permanent without TTL", taskName))
+	}
+	keepAliveResp, err := cli.KeepAliveOnce(ctx, leaseID)
+	if err != nil {
+		return 0, terror.ErrHAFailLeaseOperation.Delegate(err, "failed to keepalive lease of openapi task template")
+	}
+	return keepAliveResp.TTL, nil
+}
+
+// PutOpenAPITaskTemplateBatch puts the openapi task configs in one txn, either all of them are put or none of them.
+// if overWrite is false and some of them already exist, ErrOpenAPITaskConfigExist with those task names is returned,
+// the revision and metadata of the first existing one are attached as fields, see OpenAPITaskTemplateFieldRevision.
+// NOTE: every task takes at least two operations in the txn, which is limited by `max-txn-ops` of etcd.
+func PutOpenAPITaskTemplateBatch(
+	cli *clientv3.Client, tasks []openapi.Task, overWrite bool, opts ...OpenAPITaskTemplateOption,
+) error {
+	return putOpenAPITaskTemplateBatch(cli, DefaultOpenAPITaskTemplateNamespace, tasks, overWrite, "",
+		newOpenAPITaskTemplateOptions(opts))
+}
+
+func putOpenAPITaskTemplateBatch(
+	cli *clientv3.Client, namespace string, tasks []openapi.Task, overWrite bool, author string,
+	o *openAPITaskTemplateOptions, opts ...clientv3.OpOption,
+) error {
+	ctx, cancel := context.WithTimeout(cli.Ctx(), etcdutil.DefaultRequestTimeout)
+	defer cancel()
+
+	names := make(map[string]struct{}, len(tasks))
+	cmps := make([]clientv3.Cmp, 0, len(tasks))
+	for _, task := range tasks {
+		// overwriting may write an existing task config, whose name is not checked. but an empty name is never valid.
+		if !overWrite || task.Name == "" {
+			if err := checkOpenAPITaskTemplateName(task.Name); err != nil {
+				return err
+			}
+		}
+		if _, ok := names[task.Name]; ok {
+			return terror.ErrHAInvalidItem.Generate(fmt.Sprintf("duplicate openapi task template %s in one batch", task.Name))
+		}
+		names[task.Name] = struct{}{}
+		if !overWrite {
+			cmps = append(cmps, clientv3util.KeyMissing(openAPITaskTemplateKey(namespace, task.Name)))
+		}
+	}
+	succeeded, err := putOpenAPITaskTemplatesWithVersion(ctx, cli, namespace, tasks, author, cmps, nil, "put openapi task template",
+		o, opts...)
+	if err != nil {
+		return err
+	}
+	if succeeded {
+		return nil
+	}
+
+	// user don't want to overwrite and some keys already exist.
+	gets := make([]clientv3.Op, 0, len(tasks))
+	for _, task := range tasks {
+		gets = append(gets, clientv3.OpGet(openAPITaskTemplateKey(namespace, task.Name)))
+	}
+	resp, err := cli.Txn(ctx).Then(gets...).Commit()
+	if err != nil {
+		return terror.ErrHAFailTxnOperation.Delegate(err, "put openapi task template")
+	}
+	var (
+		existNames = make([]string, 0, len(tasks))
+		fields     map[string]interface{}
+	)
+	for i, r := range resp.Responses {
+		kvs := r.GetResponseRange().Kvs
+		if len(kvs) == 0 {
+			continue
+		}
+		existNames = append(existNames, tasks[i].Name)
+		if fields == nil {
+			fields = openAPITaskTemplateExistFields(tasks[i].Name, kvs[0])
+		}
+	}
+	return terror.ErrOpenAPITaskConfigExist.WithFields(fields).Generate(strings.Join(existNames, ", "))
+}
+
+// ReplaceAllOpenAPITaskTemplates replaces all openapi task configs in the default namespace with tasks in one txn, so
+// readers and watchers see either all the old task configs or all the new ones, never a mix of them. the task configs
+// not in tasks are deleted with their history versions like DeleteOpenAPITaskTemplate, and tasks are put like
+// PutOpenAPITaskTemplateBatch with overwrite. the labels of all old task configs are deleted. an empty tasks deletes
+// all task configs.
+// NOTE: every old task config and every task take operations in the txn, which is limited by `max-txn-ops` of etcd.
+func ReplaceAllOpenAPITaskTemplates(cli *clientv3.Client, tasks []openapi.Task, opts ...OpenAPITaskTemplateOption) (err error) {
+	startTime := time.Now()
+	defer func() {
+		observeOpenAPITaskTemplateOp(openAPITaskTemplateOpPut, startTime, err)
+	}()
+
+	namespace := DefaultOpenAPITaskTemplateNamespace
+	newKeys := make(map[string]struct{}, len(tasks))
+	for _, task := range tasks {
+		key := openAPITaskTemplateKey(namespace, task.Name)
+		if _, ok := newKeys[key]; ok {
+			return terror.ErrHAInvalidItem.Generate(fmt.Sprintf("duplicate openapi task template %s in one batch", task.Name))
+		}
+		newKeys[key] = struct{}{}
+	}
+	ctx, cancel := context.WithTimeout(cli.Ctx(), etcdutil.DefaultRequestTimeout)
+	defer cancel()
+
+	prefix := openAPITaskTemplatePrefix(namespace)
+	for i := 0; i < maxPutOpenAPITaskTemplateRetry; i++ {
+		resp, err2 := cli.Get(ctx, prefix, clientv3.WithPrefix(), clientv3.WithKeysOnly())
+		if err2 != nil {
+			return terror.ErrHAFailTxnOperation.Delegate(err2, "replace all openapi task templates")
+		}
+		// a delete range can't overlap the puts in one txn, so the old task configs are deleted one by one. the
+		// labels are in another prefix, they can be deleted by one ranged delete.
+		ops := []clientv3.Op{clientv3.OpDelete(common.OpenAPITaskTemplateLabelsKeyAdapter.Path(), clientv3.WithPrefix())}
+		oldKeys := make(map[string]struct{}, len(resp.Kvs))
+		for _, kv := range resp.Kvs {
+			oldKeys[string(kv.Key)] = struct{}{}
+			if _, ok := newKeys[string(kv.Key)]; ok {
+				continue
+			}
+			keys, err3 := common.OpenAPITaskTemplateKeyAdapter.Decode(string(kv.Key))
+			if err3 != nil {
+				return err3
+			}
+			ops = append(ops, clientv3.OpDelete(string(kv.Key)),
+				clientv3.OpDelete(openAPITaskTemplateVersionPrefix(namespace, keys[0]), clientv3.WithPrefix()))
+		}
+		// only the names of inserted task configs are checked.
+		for _, task := range tasks {
+			if _, ok := oldKeys[openAPITaskTemplateKey(namespace, task.Name)]; ok {
+				continue
+			}
+			if err = checkOpenAPITaskTemplateName(task.Name); err != nil {
+				return err
+			}
+		}
+		// no task config is put or updated after they are read, deleted ones are fine since they are deleted anyway.
+		cmps := []clientv3.Cmp{clientv3.Compare(clientv3.ModRevision(prefix).WithPrefix(), "<", resp.Header.Revision+1)}
+		var succeeded bool
+		if len(tasks) == 0 {
+			txnResp, err3 := cli.Txn(ctx).If(cmps...).Then(ops...).Commit()
+			if err3 != nil {
+				return terror.ErrHAFailTxnOperation.Delegate(err3, "replace all openapi task templates")
+			}
+			succeeded = txnResp.Succeeded
+		} else {
+			succeeded, err2 = putOpenAPITaskTemplatesWithVersion(ctx, cli, namespace, tasks, "", cmps, ops,
+				"replace all openapi task templates", newOpenAPITaskTemplateOptions(opts))
+			if err2 != nil {
+				return err2
+			}
+		}
+		if succeeded {
+			return nil
+		}
+		// some task configs are written after they are read, retry.
+	}
+	return terror.ErrHAFailTxnOperation.Generate("replace all openapi task templates: too many concurrent writes")
+}
+
+// names of the fields attached to ErrOpenAPITaskConfigExist returned by putting openapi task templates without
+// overwrite, which describe the first existing template, so callers can tell whether to overwrite it.
+const (
+	OpenAPITaskTemplateFieldTask       = "task"
+	OpenAPITaskTemplateFieldRevision   = "revision"
+	OpenAPITaskTemplateFieldModifiedAt = "modified-at"
+	OpenAPITaskTemplateFieldModifiedBy = "modified-by"
+)
+
+// openAPITaskTemplateExistFields returns the fields of the existing openapi task template kv, the metadata fields are
+// absent if the template is written before the metadata is supported or can't be decoded.
+func openAPITaskTemplateExistFields(taskName string, kv *mvccpb.KeyValue) map[string]interface{} {
+	fields := map[string]interface{}{
+		OpenAPITaskTemplateFieldTask:     taskName,
+		OpenAPITaskTemplateFieldRevision: kv.ModRevision,
+	}
+	meta, err := decodeOpenAPITaskTemplateValueWithMeta(kv.Value, &openapi.Task{})
+	if err != nil {
+		log.L().Warn("fail to decode existing openapi task template", zap.String("task", taskName), zap.Error(err))
+		return fields
+	}
+	if meta != nil {
+		fields[OpenAPITaskTemplateFieldModifiedAt] = meta.ModifiedAt
+		fields[OpenAPITaskTemplateFieldModifiedBy] = meta.ModifiedBy
+	}
+	return fields
+}
+
+// UpdateOpenAPITaskTemplate updates the openapi task config by task-name.
+func UpdateOpenAPITaskTemplate(cli *clientv3.Client, task openapi.Task, opts ...OpenAPITaskTemplateOption) error {
+	return UpdateOpenAPITaskTemplateInNamespace(cli, DefaultOpenAPITaskTemplateNamespace, task, opts...)
+}
+
+// UpdateOpenAPITaskTemplateWithAuthor updates the openapi task config by task-name, and records author as the last
+// modifier in the metadata, which can be read by GetOpenAPITaskTemplateWithMeta. the metadata is only written in the
+// envelope, see WithOpenAPITaskTemplateEnvelope.
+func UpdateOpenAPITaskTemplateWithAuthor(
+	cli *clientv3.Client, task openapi.Task, author string, opts ...OpenAPITaskTemplateOption,
+) error {
+	return updateOpenAPITaskTemplate(cli, DefaultOpenAPITaskTemplateNamespace, task, author, opts)
+}
+
+// UpdateOpenAPITaskTemplateInNamespace updates the openapi task config by task-name in namespace.
+func UpdateOpenAPITaskTemplateInNamespace(
+	cli *clientv3.Client, namespace string, task openapi.Task, opts ...OpenAPITaskTemplateOption,
+) error {
+	return updateOpenAPITaskTemplate(cli, namespace, task, "", opts)
+}
+
+func updateOpenAPITaskTemplate(
+	cli *clientv3.Client, namespace string, task openapi.Task, author string, opts []OpenAPITaskTemplateOption,
+) (err error) {
+	startTime := time.Now()
+	defer func() {
+		observeOpenAPITaskTemplateOp(openAPITaskTemplateOpUpdate, startTime, err)
+	}()
+
+	ctx, cancel := context.WithTimeout(cli.Ctx(), etcdutil.DefaultRequestTimeout)
+	defer cancel()
+
+	cmps := []clientv3.Cmp{clientv3util.KeyExists(openAPITaskTemplateKey(namespace, task.Name))}
+	succeeded, err := putOpenAPITaskTemplatesWithVersion(ctx, cli, namespace, []openapi.Task{task}, author, cmps, nil,
+		"update openapi task template", newOpenAPITaskTemplateOptions(opts))
+	if err != nil {
+		return err
+	}
+	// user want to update a key not exists.
+	if !succeeded {
+		return terror.ErrOpenAPITaskConfigNotExist.Generate(task.Name)
+	}
+	return nil
+}
+
+// CompareAndUpdateOpenAPITaskTemplate updates the openapi task config by task-name only if its revision is still
+// revision, which is returned by GetOpenAPITaskTemplateWithRevision. otherwise, ErrOpenAPITaskConfigStale is returned.
+func CompareAndUpdateOpenAPITaskTemplate(
+	cli *clientv3.Client, task openapi.Task, revision int64, opts ...OpenAPITaskTemplateOption,
+) error {
+	ctx, cancel := context.WithTimeout(cli.Ctx(), etcdutil.DefaultRequestTimeout)
+	defer cancel()
+
+	key := openAPITaskTemplateKey(DefaultOpenAPITaskTemplateNamespace, task.Name)
+	cmps := []clientv3.Cmp{
+		clientv3util.KeyExists(key),
+		clientv3.Compare(clientv3.ModRevision(key), "=", revision),
+	}
+	succeeded, err := putOpenAPITaskTemplatesWithVersion(ctx, cli, DefaultOpenAPITaskTemplateNamespace, []openapi.Task{task}, "", cmps, nil,
+		"compare and update openapi task template", newOpenAPITaskTemplateOptions(opts))
+	if err != nil {
+		return err
+	}
+	if succeeded {
+		return nil
+	}
+
+	resp, err := cli.Get(ctx, key, clientv3.WithKeysOnly())
+	if err != nil {
+		return terror.ErrHAFailTxnOperation.Delegate(err, "compare and update openapi task template")
+	}
+	if resp.Count == 0 {
+		return terror.ErrOpenAPITaskConfigNotExist.Generate(task.Name)
+	}
+	return terror.ErrOpenAPITaskConfigStale.Generate(task.Name, revision, resp.Kvs[0].ModRevision)
+}
+
+// OpenAPITaskTemplateSliceMergeMode decides how slice fields are merged by MergeOpenAPITaskTemplate.
+type OpenAPITaskTemplateSliceMergeMode int
+
+const (
+	// OpenAPITaskTemplateSliceReplace replaces the stored slice with the incoming one if it's not empty.
+	OpenAPITaskTemplateSliceReplace OpenAPITaskTemplateSliceMergeMode = iota
+	// OpenAPITaskTemplateSliceAppend appends the incoming slice to the stored one.
+	OpenAPITaskTemplateSliceAppend
+)
+
+// MergeOpenAPITaskTemplate patches the non-zero fields of task onto the stored openapi task config of task-name,
+// other fields keep their stored values. if the task config does not exist, task is put as is. unlike
+// PutOpenAPITaskTemplate with overWrite, it's used to update some fields without losing others.
+// the fields are merged recursively as follows:
+//   - a value field is set if it's not zero, so it can't be reset to the zero value by merging.
+//   - a pointer field is kept if it's nil, otherwise it's merged recursively if it points to a struct or a slice,
+//     or set to the incoming one. use pointer fields such as `*bool` to set a zero value.
+//   - a slice field is kept if it's empty, otherwise it's replaced or appended according to sliceMode.
+//   - a map field is updated with the incoming entries.
+//
+// the stored task config is only overwritten if it's not modified since it's read, so no concurrent update is lost.
+func MergeOpenAPITaskTemplate(
+	cli *clientv3.Client, task openapi.Task, sliceMode OpenAPITaskTemplateSliceMergeMode, opts ...OpenAPITaskTemplateOption,
+) (err error) {
+	startTime := time.Now()
+	defer func() {
+		observeOpenAPITaskTemplateOp(openAPITaskTemplateOpUpdate, startTime, err)
+	}()
+
+	ctx, cancel := context.WithTimeout(cli.Ctx(), etcdutil.DefaultRequestTimeout)
+	defer cancel()
+
+	key := openAPITaskTemplateKey(DefaultOpenAPITaskTemplateNamespace, task.Name)
+	o := newOpenAPITaskTemplateOptions(opts)
+	for i := 0; i < maxPutOpenAPITaskTemplateRetry; i++ {
+		stored, revision, err2 := getOpenAPITaskTemplateWithRevision(cli, DefaultOpenAPITaskTemplateNamespace, task.Name)
+		if err2 != nil {
+			return err2
+		}
+		merged := task
+		cmps := []clientv3.Cmp{clientv3util.KeyMissing(key)}
+		if stored == nil {
+			if err = checkOpenAPITaskTemplateName(task.Name); err != nil {
+				return err
+			}
+		} else {
+			merged = *stored
+			mergeOpenAPITaskTemplateValue(reflect.ValueOf(&merged).Elem(), reflect.ValueOf(task), sliceMode)
+			cmps = []clientv3.Cmp{
+				clientv3util.KeyExists(key),
+				clientv3.Compare(clientv3.ModRevision(key), "=", revision),
+			}
+		}
+		succeeded, err2 := putOpenAPITaskTemplatesWithVersion(ctx, cli, DefaultOpenAPITaskTemplateNamespace,
+			[]openapi.Task{merged}, "", cmps, nil, "merge openapi task template", o)
+		if err2 != nil {
+			return err2
+		}
+		if succeeded {
+			return nil
+		}
+		// the task config is modified after it's read, merge again with the latest one.
+	}
+	return terror.ErrHAFailTxnOperation.Generate("merge openapi task template: too many concurrent writes")
+}
+
+// mergeOpenAPITaskTemplateValue merges src onto dst, see MergeOpenAPITaskTemplate for the rules. dst must be settable.
+func mergeOpenAPITaskTemplateValue(dst, src reflect.Value, sliceMode OpenAPITaskTemplateSliceMergeMode) {
+	switch src.Kind() {
+	case reflect.Struct:
+		for i := 0; i < src.NumField(); i++ {
+			if dst.Field(i).CanSet() {
+				mergeOpenAPITaskTemplateValue(dst.Field(i), src.Field(i), sliceMode)
+			}
+		}
+	case reflect.Ptr:
+		if src.IsNil() {
+			return
+		}
+		if dst.IsNil() {
+			dst.Set(src)
+			return
+		}
+		switch src.Elem().Kind() {
+		case reflect.Struct, reflect.Slice:
+			// copy the pointed value, so src is never modified by merging.
+			elem := reflect.New(dst.Elem().Type())
+			elem.Elem().Set(dst.Elem())
+			mergeOpenAPITaskTemplateValue(elem.Elem(), src.Elem(), sliceMode)
+			dst.Set(elem)
+		default:
+			dst.Set(src)
+		}
+	case reflect.Slice:
+		if src.Len() == 0 {
+			return
+		}
+		if sliceMode == OpenAPITaskTemplateSliceAppend {
+			dst.Set(reflect.AppendSlice(dst, src))
+			return
+		}
+		dst.Set(src)
+	case reflect.Map:
+		if src.Len() == 0 {
+			return
+		}
+		merged := reflect.MakeMapWithSize(src.Type(), dst.Len()+src.Len())
+		for _, k := range dst.MapKeys() {
+			merged.SetMapIndex(k, dst.MapIndex(k))
+		}
+		for _, k := range src.MapKeys() {
+			merged.SetMapIndex(k, src.MapIndex(k))
+		}
+		dst.Set(merged)
+	default:
+		if !src.IsZero() {
+			dst.Set(src)
+		}
+	}
+}
+
+// RenameOpenAPITaskTemplate renames the openapi task config of oldName to newName in one txn, so there's no window
+// where neither of them exists. the `name` in the task config is changed to newName and a new version of newName is
+// written like PutOpenAPITaskTemplate, while the history versions of oldName are deleted like DeleteOpenAPITaskTemplate.
+// the labels are moved together, and the lease of the task config put with TTL is kept. it fails if oldName doesn't
+// exist or newName already exists.
+func RenameOpenAPITaskTemplate(cli *clientv3.Client, oldName, newName string, opts ...OpenAPITaskTemplateOption) (err error) {
+	startTime := time.Now()
+	defer func() {
+		observeOpenAPITaskTemplateOp(openAPITaskTemplateOpUpdate, startTime, err)
+	}()
+
+	if err = checkOpenAPITaskTemplateName(newName); err != nil {
+		return err
+	}
+	if oldName == newName {
+		return terror.ErrHAInvalidItem.Generate(fmt.Sprintf("rename openapi task template %s to itself", oldName))
 	}
-	return task, nil
+	ctx, cancel := context.WithTimeout(cli.Ctx(), etcdutil.DefaultRequestTimeout)
+	defer cancel()
+
+	namespace := DefaultOpenAPITaskTemplateNamespace
+	oldKey, newKey := openAPITaskTemplateKey(namespace, oldName), openAPITaskTemplateKey(namespace, newName)
+	oldLabelsKey, newLabelsKey := openAPITaskTemplateLabelsKey(oldName), openAPITaskTemplateLabelsKey(newName)
+	o := newOpenAPITaskTemplateOptions(opts)
+	for i := 0; i < maxPutOpenAPITaskTemplateRetry; i++ {
+		resp, err2 := cli.Txn(ctx).Then(clientv3.OpGet(oldKey), clientv3.OpGet(oldLabelsKey)).Commit()
+		if err2 != nil {
+			return terror.ErrHAFailTxnOperation.Delegate(err2, "rename openapi task template")
+		}
+		templateResp, labelsResp := resp.Responses[0].GetResponseRange(), resp.Responses[1].GetResponseRange()
+		if templateResp.Count == 0 {
+			return terror.ErrOpenAPITaskConfigNotExist.Generate(oldName)
+		}
+		kv := templateResp.Kvs[0]
+		task := openapi.Task{}
+		if err2 = decodeOpenAPITaskTemplateValue(kv.Value, &task); err2 != nil {
+			return err2
+		}
+		task.Name = newName
+		value, err2 := encodeOpenAPITaskTemplateValue(task, OpenAPITaskTemplateMeta{ModifiedAt: time.Now()}, o.envelope)
+		if err2 != nil {
+			return err2 // it should not happen.
+		}
+
+		versions, err2 := listOpenAPITaskTemplateVersions(ctx, cli, namespace, newName)
+		if err2 != nil {
+			return err2
+		}
+		var newVersion int64 = 1
+		if len(versions) > 0 {
+			newVersion = versions[len(versions)-1] + 1
+		}
+		versionKey := encodeOpenAPITaskTemplateVersionKey(namespace, newName, newVersion)
+		var opts []clientv3.OpOption
+		if kv.Lease != 0 {
+			opts = append(opts, clientv3.WithLease(clientv3.LeaseID(kv.Lease)))
+		}
+		ops := []clientv3.Op{
+			clientv3.OpPut(newKey, value, opts...),
+			clientv3.OpPut(versionKey, value, opts...),
+			clientv3.OpDelete(oldKey),
+			clientv3.OpDelete(openAPITaskTemplateVersionPrefix(namespace, oldName), clientv3.WithPrefix()),
+		}
+		// the labels left by an expired task config of newName are removed if oldName has no labels.
+		var labelsRevision int64
+		if labelsResp.Count > 0 {
+			labelsKV := labelsResp.Kvs[0]
+			labelsRevision = labelsKV.ModRevision
+			ops = append(ops, clientv3.OpPut(newLabelsKey, string(labelsKV.Value), opts...), clientv3.OpDelete(oldLabelsKey))
+		} else {
+			ops = append(ops, clientv3.OpDelete(newLabelsKey))
+		}
+		if keep := o.versionsToKeep; len(versions)+1 > keep {
+			for _, version := range versions[:len(versions)+1-keep] {
+				ops = append(ops, clientv3.OpDelete(encodeOpenAPITaskTemplateVersionKey(namespace, newName, version)))
+			}
+		}
+
+		txnResp, err2 := cli.Txn(ctx).
+			If(
+				clientv3.Compare(clientv3.ModRevision(oldKey), "=", kv.ModRevision),
+				clientv3.Compare(clientv3.ModRevision(oldLabelsKey), "=", labelsRevision),
+				clientv3util.KeyMissing(newKey),
+				clientv3util.KeyMissing(versionKey),
+			).
+			Then(ops...).
+			Else(clientv3.OpGet(newKey, clientv3.WithCountOnly())).
+			Commit()
+		if err2 != nil {
+			return terror.ErrHAFailTxnOperation.Delegate(err2, "rename openapi task template")
+		}
+		if txnResp.Succeeded {
+			return nil
+		}
+		if txnResp.Responses[0].GetResponseRange().Count > 0 {
+			return terror.ErrOpenAPITaskConfigExist.Generate(newName)
+		}
+		// the task config or labels of oldName or the versions of newName are written concurrently, retry.
+	}
+	return terror.ErrHAFailTxnOperation.Generate("rename openapi task template: too many concurrent writes")
 }
 
-// PutOpenAPITaskTemplate puts the openapi task config of task-name.
-func PutOpenAPITaskTemplate(cli *clientv3.Client, task openapi.Task, overWrite bool) error {
+// DeleteOpenAPITaskTemplate deletes the openapi task config of task-name, its labels and history versions in one txn.
+func DeleteOpenAPITaskTemplate(cli *clientv3.Client, taskName string) error {
+	return DeleteOpenAPITaskTemplateInNamespace(cli, DefaultOpenAPITaskTemplateNamespace, taskName)
+}
+
+// DeleteOpenAPITaskTemplateInNamespace deletes the openapi task config of task-name in namespace.
+func DeleteOpenAPITaskTemplateInNamespace(cli *clientv3.Client, namespace, taskName string) (err error) {
+	startTime := time.Now()
+	defer func() {
+		observeOpenAPITaskTemplateOp(openAPITaskTemplateOpDelete, startTime, err)
+	}()
+
 	ctx, cancel := context.WithTimeout(cli.Ctx(), etcdutil.DefaultRequestTimeout)
 	defer cancel()
+	ops := []clientv3.Op{
+		clientv3.OpDelete(openAPITaskTemplateKey(namespace, taskName)),
+		clientv3.OpDelete(openAPITaskTemplateVersionPrefix(namespace, taskName), clientv3.WithPrefix()),
+	}
+	// labels are only supported in the default namespace.
+	if isDefaultOpenAPITaskTemplateNamespace(namespace) {
+		ops = append(ops, clientv3.OpDelete(openAPITaskTemplateLabelsKey(taskName)))
+	}
+	if _, err := cli.Txn(ctx).Then(ops...).Commit(); err != nil {
+		return terror.ErrHAFailTxnOperation.Delegate(err, "delete openapi task template")
+	}
+	return nil
+}
 
-	key := common.OpenAPITaskTemplateKeyAdapter.Encode(task.Name)
-	taskJSON, err := task.ToJSON()
+// DeleteOpenAPITaskTemplateBatch deletes the openapi task configs of task-names in one txn, and returns the names whose
+// task configs don't exist. like DeleteOpenAPITaskTemplate, their labels and history versions are deleted.
+// NOTE: every task takes three operations in the txn, which is limited by `max-txn-ops` of etcd.
+func DeleteOpenAPITaskTemplateBatch(cli *clientv3.Client, taskNames []string) (notExistNames []string, err error) {
+	startTime := time.Now()
+	defer func() {
+		observeOpenAPITaskTemplateOp(openAPITaskTemplateOpDelete, startTime, err)
+	}()
+
+	names := make(map[string]struct{}, len(taskNames))
+	ops := make([]clientv3.Op, 0, 3*len(taskNames))
+	for _, taskName := range taskNames {
+		if _, ok := names[taskName]; ok {
+			return nil, terror.ErrHAInvalidItem.Generate(fmt.Sprintf("duplicate openapi task template %s in one batch", taskName))
+		}
+		names[taskName] = struct{}{}
+		ops = append(ops, clientv3.OpDelete(openAPITaskTemplateKey(DefaultOpenAPITaskTemplateNamespace, taskName)))
+	}
+	// the responses of task configs are still in the order of taskNames.
+	for _, taskName := range taskNames {
+		ops = append(ops, clientv3.OpDelete(openAPITaskTemplateLabelsKey(taskName)),
+			clientv3.OpDelete(openAPITaskTemplateVersionPrefix(DefaultOpenAPITaskTemplateNamespace, taskName), clientv3.WithPrefix()))
+	}
+
+	ctx, cancel := context.WithTimeout(cli.Ctx(), etcdutil.DefaultRequestTimeout)
+	defer cancel()
+	resp, err := cli.Txn(ctx).Then(ops...).Commit()
 	if err != nil {
-		return err // it should not happen.
+		return nil, terror.ErrHAFailTxnOperation.Delegate(err, "delete openapi task template")
+	}
+	for i, r := range resp.Responses[:len(taskNames)] {
+		if r.GetResponseDeleteRange().Deleted == 0 {
+			notExistNames = append(notExistNames, taskNames[i])
+		}
 	}
-	txn := cli.Txn(ctx)
-	if !overWrite {
-		txn = txn.If(clientv3util.KeyMissing(key))
+	return notExistNames, nil
+}
+
+// DeleteOpenAPITaskTemplateByPrefix deletes the openapi task configs whose task-names start with prefix by one ranged
+// delete, and returns the number of deleted task configs. an empty prefix matches all task configs, so it's rejected
+// unless deleteAll is true. like DeleteOpenAPITaskTemplate, their labels and history versions are deleted in the same
+// txn.
+func DeleteOpenAPITaskTemplateByPrefix(cli *clientv3.Client, prefix string, deleteAll bool) (deleted int64, err error) {
+	if prefix == "" && !deleteAll {
+		return 0, terror.ErrHAInvalidItem.Generate("empty prefix deletes all openapi task templates, deleteAll should be set")
 	}
-	resp, err := txn.Then(clientv3.OpPut(key, string(taskJSON))).Commit()
+	startTime := time.Now()
+	defer func() {
+		observeOpenAPITaskTemplateOp(openAPITaskTemplateOpDelete, startTime, err)
+	}()
+
+	ctx, cancel := context.WithTimeout(cli.Ctx(), etcdutil.DefaultRequestTimeout)
+	defer cancel()
+	// task-name is hex encoded in the key, which keeps the prefix of task-name.
+	hexPrefix := hex.EncodeToString([]byte(prefix))
+	resp, err := cli.Txn(ctx).Then(
+		clientv3.OpDelete(common.OpenAPITaskTemplateKeyAdapter.Path()+hexPrefix, clientv3.WithPrefix()),
+		clientv3.OpDelete(common.OpenAPITaskTemplateLabelsKeyAdapter.Path()+hexPrefix, clientv3.WithPrefix()),
+		clientv3.OpDelete(common.OpenAPITaskTemplateVersionKeyAdapter.Path()+hexPrefix, clientv3.WithPrefix()),
+	).Commit()
 	if err != nil {
-		return terror.ErrHAFailTxnOperation.Delegate(err, "put openapi task template")
+		return 0, terror.ErrHAFailTxnOperation.Delegate(err, "delete openapi task template")
 	}
-	// user don't want to overwrite and key already exists.
-	if !overWrite && !resp.Succeeded {
-		return terror.ErrOpenAPITaskConfigExist.Generate(task.Name)
+	return resp.Responses[0].GetResponseDeleteRange().Deleted, nil
+}
+
+// SoftDeleteOpenAPITaskTemplate moves the openapi task config of task-name to the recycle bin, it can be restored by
+// RestoreOpenAPITaskTemplate until it's purged by PurgeDeletedOpenAPITaskTemplate. like DeleteOpenAPITaskTemplate, the
+// labels and history versions are deleted in the same txn, they are not restored. it fails if task-name doesn't exist.
+func SoftDeleteOpenAPITaskTemplate(cli *clientv3.Client, taskName string) (err error) {
+	startTime := time.Now()
+	defer func() {
+		observeOpenAPITaskTemplateOp(openAPITaskTemplateOpDelete, startTime, err)
+	}()
+
+	ctx, cancel := context.WithTimeout(cli.Ctx(), etcdutil.DefaultRequestTimeout)
+	defer cancel()
+
+	namespace := DefaultOpenAPITaskTemplateNamespace
+	key := openAPITaskTemplateKey(namespace, taskName)
+	for i := 0; i < maxPutOpenAPITaskTemplateRetry; i++ {
+		resp, err2 := cli.Get(ctx, key)
+		if err2 != nil {
+			return terror.ErrHAFailTxnOperation.Delegate(err2, "soft delete openapi task template")
+		}
+		if resp.Count == 0 {
+			return terror.ErrOpenAPITaskConfigNotExist.Generate(taskName)
+		}
+		kv := resp.Kvs[0]
+		deletedKey := common.OpenAPITaskTemplateDeletedKeyAdapter.Encode(taskName, fmt.Sprintf("%020d", time.Now().UnixNano()))
+		txnResp, err2 := cli.Txn(ctx).
+			If(clientv3.Compare(clientv3.ModRevision(key), "=", kv.ModRevision)).
+			Then(
+				clientv3.OpDelete(key),
+				clientv3.OpPut(deletedKey, string(kv.Value)),
+				clientv3.OpDelete(openAPITaskTemplateLabelsKey(taskName)),
+				clientv3.OpDelete(openAPITaskTemplateVersionPrefix(namespace, taskName), clientv3.WithPrefix()),
+			).
+			Commit()
+		if err2 != nil {
+			return terror.ErrHAFailTxnOperation.Delegate(err2, "soft delete openapi task template")
+		}
+		if txnResp.Succeeded {
+			return nil
+		}
+		// the task config is modified after it's read, retry.
 	}
-	return nil
+	return terror.ErrHAFailTxnOperation.Generate("soft delete openapi task template: too many concurrent writes")
 }
 
-// UpdateOpenAPITaskTemplate updates the openapi task config by task-name.
-func UpdateOpenAPITaskTemplate(cli *clientv3.Client, task openapi.Task) error {
+// RestoreOpenAPITaskTemplate restores the latest soft-deleted openapi task config of task-name, a new version of it is
+// written like PutOpenAPITaskTemplate.
+func RestoreOpenAPITaskTemplate(cli *clientv3.Client, taskName string, opts ...OpenAPITaskTemplateOption) (err error) {
+	startTime := time.Now()
+	defer func() {
+		observeOpenAPITaskTemplateOp(openAPITaskTemplateOpPut, startTime, err)
+	}()
+
 	ctx, cancel := context.WithTimeout(cli.Ctx(), etcdutil.DefaultRequestTimeout)
 	defer cancel()
 
-	key := common.OpenAPITaskTemplateKeyAdapter.Encode(task.Name)
-	taskJSON, err := task.ToJSON()
+	resp, err := cli.Get(ctx, common.OpenAPITaskTemplateDeletedKeyAdapter.Encode(taskName), clientv3.WithPrefix(),
+		clientv3.WithSort(clientv3.SortByKey, clientv3.SortDescend), clientv3.WithLimit(1))
 	if err != nil {
-		return err // it should not happen.
+		return terror.ErrHAFailTxnOperation.Delegate(err, "restore openapi task template")
+	}
+	if resp.Count == 0 {
+		return terror.ErrOpenAPITaskConfigNotExist.Generate(taskName)
+	}
+	kv := resp.Kvs[0]
+	task := openapi.Task{}
+	if err = decodeOpenAPITaskTemplateValue(kv.Value, &task); err != nil {
+		return err
 	}
-	txn := cli.Txn(ctx).If(clientv3util.KeyExists(key)).Then(clientv3.OpPut(key, string(taskJSON)))
-	resp, err := txn.Commit()
+	namespace := DefaultOpenAPITaskTemplateNamespace
+	cmps := []clientv3.Cmp{
+		clientv3util.KeyMissing(openAPITaskTemplateKey(namespace, taskName)),
+		clientv3util.KeyExists(string(kv.Key)),
+	}
+	succeeded, err := putOpenAPITaskTemplatesWithVersion(ctx, cli, namespace, []openapi.Task{task}, "", cmps,
+		[]clientv3.Op{clientv3.OpDelete(string(kv.Key))}, "restore openapi task template", newOpenAPITaskTemplateOptions(opts))
 	if err != nil {
-		return terror.ErrHAFailTxnOperation.Delegate(err, "update openapi task template")
+		return err
 	}
-	// user want to update a key not exists.
-	if !resp.Succeeded {
-		return terror.ErrOpenAPITaskConfigNotExist.Generate(task.Name)
+	if !succeeded {
+		return terror.ErrOpenAPITaskConfigExist.Generate(taskName)
 	}
 	return nil
 }
 
-// DeleteOpenAPITaskTemplate deletes the openapi task config of task-name.
-func DeleteOpenAPITaskTemplate(cli *clientv3.Client, taskName string) error {
+// PurgeDeletedOpenAPITaskTemplate removes the soft-deleted openapi task configs which are deleted before olderThan ago.
+func PurgeDeletedOpenAPITaskTemplate(cli *clientv3.Client, olderThan time.Duration) error {
 	ctx, cancel := context.WithTimeout(cli.Ctx(), etcdutil.DefaultRequestTimeout)
 	defer cancel()
-	if _, err := cli.Delete(ctx, common.OpenAPITaskTemplateKeyAdapter.Encode(taskName)); err != nil {
-		return terror.ErrHAFailTxnOperation.Delegate(err, "delete openapi task template")
+
+	resp, err := cli.Get(ctx, common.OpenAPITaskTemplateDeletedKeyAdapter.Path(), clientv3.WithPrefix(), clientv3.WithKeysOnly())
+	if err != nil {
+		return terror.ErrHAFailTxnOperation.Delegate(err, "purge deleted openapi task template")
+	}
+	deadline := time.Now().Add(-olderThan).UnixNano()
+	ops := make([]clientv3.Op, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		keys, err2 := common.OpenAPITaskTemplateDeletedKeyAdapter.Decode(string(kv.Key))
+		if err2 != nil {
+			return err2
+		}
+		deletedTime, err2 := strconv.ParseInt(keys[1], 10, 64)
+		if err2 != nil {
+			return terror.ErrDecodeEtcdKeyFail.Generate(err2.Error())
+		}
+		if deletedTime < deadline {
+			ops = append(ops, clientv3.OpDelete(string(kv.Key)))
+		}
+	}
+	for len(ops) > 0 {
+		n := len(ops)
+		if n > maxOpenAPITaskTemplatePurgeOps {
+			n = maxOpenAPITaskTemplatePurgeOps
+		}
+		if _, err = cli.Txn(ctx).Then(ops[:n]...).Commit(); err != nil {
+			return terror.ErrHAFailTxnOperation.Delegate(err, "purge deleted openapi task template")
+		}
+		ops = ops[n:]
 	}
 	return nil
 }
 
 // GetOpenAPITaskTemplate gets the openapi task config of task-name.
 func GetOpenAPITaskTemplate(cli *clientv3.Client, taskName string) (*openapi.Task, error) {
+	return GetOpenAPITaskTemplateInNamespace(cli, DefaultOpenAPITaskTemplateNamespace, taskName)
+}
+
+// LookupOpenAPITaskTemplate gets the openapi task config of task-name like GetOpenAPITaskTemplate, but reports whether
+// it exists by found rather than a nil task.
+func LookupOpenAPITaskTemplate(cli *clientv3.Client, taskName string) (task *openapi.Task, found bool, err error) {
+	task, err = GetOpenAPITaskTemplate(cli, taskName)
+	if err != nil {
+		return nil, false, err
+	}
+	return task, task != nil, nil
+}
+
+// GetOpenAPITaskTemplateInNamespace gets the openapi task config of task-name in namespace.
+func GetOpenAPITaskTemplateInNamespace(cli *clientv3.Client, namespace, taskName string) (task *openapi.Task, err error) {
+	startTime := time.Now()
+	defer func() {
+		observeOpenAPITaskTemplateOp(openAPITaskTemplateOpGet, startTime, err)
+	}()
+
+	task, _, err = getOpenAPITaskTemplateWithRevision(cli, namespace, taskName)
+	return task, err
+}
+
+// GetOpenAPITaskTemplateWithRevision gets the openapi task config of task-name and its revision, the revision can be
+// used in CompareAndUpdateOpenAPITaskTemplate. the revision is 0 if the task config does not exist.
+func GetOpenAPITaskTemplateWithRevision(cli *clientv3.Client, taskName string) (*openapi.Task, int64, error) {
+	return getOpenAPITaskTemplateWithRevision(cli, DefaultOpenAPITaskTemplateNamespace, taskName)
+}
+
+func getOpenAPITaskTemplateWithRevision(cli *clientv3.Client, namespace, taskName string) (*openapi.Task, int64, error) {
 	ctx, cancel := context.WithTimeout(cli.Ctx(), etcdutil.DefaultRequestTimeout)
 	defer cancel()
 
-	var (
-		task *openapi.Task
-		resp *clientv3.GetResponse
-		err  error
-	)
-	resp, err = cli.Get(ctx, common.OpenAPITaskTemplateKeyAdapter.Encode(taskName))
+	resp, err := cli.Get(ctx, openAPITaskTemplateKey(namespace, taskName))
 	if err != nil {
-		return task, terror.ErrHAFailTxnOperation.Delegate(err, "get openapi task template")
+		return nil, 0, terror.ErrHAFailTxnOperation.Delegate(err, "get openapi task template")
 	}
-	return openAPITaskFromResp(resp)
+	task, err := openAPITaskFromResp(resp)
+	if err != nil || task == nil {
+		return task, 0, err
+	}
+	return task, resp.Kvs[0].ModRevision, nil
+}
+
+// GetOpenAPITaskTemplateWithMeta gets the openapi task config of task-name with its metadata, it returns nil if the task
+// config does not exist.
+func GetOpenAPITaskTemplateWithMeta(cli *clientv3.Client, taskName string) (ret *OpenAPITaskTemplateWithMeta, err error) {
+	startTime := time.Now()
+	defer func() {
+		observeOpenAPITaskTemplateOp(openAPITaskTemplateOpGet, startTime, err)
+	}()
+
+	ctx, cancel := context.WithTimeout(cli.Ctx(), etcdutil.DefaultRequestTimeout)
+	defer cancel()
+
+	resp, err := cli.Get(ctx, openAPITaskTemplateKey(DefaultOpenAPITaskTemplateNamespace, taskName))
+	if err != nil {
+		return nil, terror.ErrHAFailTxnOperation.Delegate(err, "get openapi task template")
+	}
+	if resp.Count == 0 {
+		return nil, nil
+	}
+	task := &openapi.Task{}
+	meta, err := decodeOpenAPITaskTemplateValueWithMeta(resp.Kvs[0].Value, task)
+	if err != nil {
+		return nil, err
+	}
+	return &OpenAPITaskTemplateWithMeta{Task: task, Meta: meta}, nil
 }
 
-// GetAllOpenAPITaskTemplate gets all openapi task config s.
+// GetAllOpenAPITaskTemplate gets all openapi task config s, sorted by task name.
 func GetAllOpenAPITaskTemplate(cli *clientv3.Client) ([]*openapi.Task, error) {
+	return GetAllOpenAPITaskTemplateInNamespace(cli, DefaultOpenAPITaskTemplateNamespace)
+}
+
+// GetAllOpenAPITaskTemplateInNamespace gets all openapi task configs in namespace, sorted by task name so the
+// result is deterministic regardless of how the names are encoded in etcd keys.
+func GetAllOpenAPITaskTemplateInNamespace(cli *clientv3.Client, namespace string) ([]*openapi.Task, error) {
 	ctx, cancel := context.WithTimeout(cli.Ctx(), etcdutil.DefaultRequestTimeout)
 	defer cancel()
 
-	resp, err := cli.Get(ctx, common.OpenAPITaskTemplateKeyAdapter.Path(), clientv3.WithPrefix())
+	resp, err := cli.Get(ctx, openAPITaskTemplatePrefix(namespace), clientv3.WithPrefix())
 	if err != nil {
 		return nil, terror.ErrHAFailTxnOperation.Delegate(err, "get all openapi task templates")
 	}
 	tasks := make([]*openapi.Task, resp.Count)
 	for i, kv := range resp.Kvs {
 		t := &openapi.Task{}
-		if err := t.FromJSON(kv.Value); err != nil {
+		if err := decodeOpenAPITaskTemplateValue(kv.Value, t); err != nil {
 			return nil, err
 		}
 		tasks[i] = t
 	}
+	sort.Slice(tasks, func(i, j int) bool {
+		return tasks[i].Name < tasks[j].Name
+	})
+	return tasks, nil
+}
+
+// GetOpenAPITaskTemplatesByNames gets the openapi task configs of task-names in one txn, and returns them keyed by
+// task-name. the names whose task configs don't exist are omitted, and duplicate names are fetched once.
+// NOTE: every task takes one operation in the txn, which is limited by `max-txn-ops` of etcd.
+func GetOpenAPITaskTemplatesByNames(cli *clientv3.Client, taskNames []string) (tasks map[string]*openapi.Task, err error) {
+	startTime := time.Now()
+	defer func() {
+		observeOpenAPITaskTemplateOp(openAPITaskTemplateOpGet, startTime, err)
+	}()
+
+	names := make([]string, 0, len(taskNames))
+	ops := make([]clientv3.Op, 0, len(taskNames))
+	seen := make(map[string]struct{}, len(taskNames))
+	for _, taskName := range taskNames {
+		if _, ok := seen[taskName]; ok {
+			continue
+		}
+		seen[taskName] = struct{}{}
+		names = append(names, taskName)
+		ops = append(ops, clientv3.OpGet(openAPITaskTemplateKey(DefaultOpenAPITaskTemplateNamespace, taskName)))
+	}
+	tasks = make(map[string]*openapi.Task, len(names))
+	if len(ops) == 0 {
+		return tasks, nil
+	}
+
+	ctx, cancel := context.WithTimeout(cli.Ctx(), etcdutil.DefaultRequestTimeout)
+	defer cancel()
+	resp, err := cli.Txn(ctx).Then(ops...).Commit()
+	if err != nil {
+		return nil, terror.ErrHAFailTxnOperation.Delegate(err, "get openapi task templates")
+	}
+	for i, r := range resp.Responses {
+		task, err2 := openAPITaskFromResp((*clientv3.GetResponse)(r.GetResponseRange()))
+		if err2 != nil {
+			return nil, err2
+		}
+		if task != nil {
+			tasks[names[i]] = task
+		}
+	}
 	return tasks, nil
 }
+
+// CountOpenAPITaskTemplates returns the number of openapi task configs by a count-only query, values are not fetched.
+// soft-deleted task configs are stored under another prefix, so they are not counted.
+func CountOpenAPITaskTemplates(cli *clientv3.Client) (int64, error) {
+	ctx, cancel := context.WithTimeout(cli.Ctx(), etcdutil.DefaultRequestTimeout)
+	defer cancel()
+
+	resp, err := cli.Get(ctx, openAPITaskTemplatePrefix(DefaultOpenAPITaskTemplateNamespace), clientv3.WithPrefix(),
+		clientv3.WithCountOnly())
+	if err != nil {
+		return 0, terror.ErrHAFailTxnOperation.Delegate(err, "count openapi task templates")
+	}
+	return resp.Count, nil
+}
+
+// CollectOpenAPITaskTemplateSizeMetrics sums the size of the values of all openapi task configs in etcd, sets it to
+// the storage size gauge and observes the size of every value into the size histogram. it returns the total size.
+// NOTE: the histogram is observed on every call, so it should be called periodically with a fixed interval.
+func CollectOpenAPITaskTemplateSizeMetrics(cli *clientv3.Client) (int64, error) {
+	ctx, cancel := context.WithTimeout(cli.Ctx(), etcdutil.DefaultRequestTimeout)
+	defer cancel()
+
+	resp, err := cli.Get(ctx, openAPITaskTemplatePrefix(DefaultOpenAPITaskTemplateNamespace), clientv3.WithPrefix())
+	if err != nil {
+		return 0, terror.ErrHAFailTxnOperation.Delegate(err, "collect size of openapi task templates")
+	}
+	var total int64
+	for _, kv := range resp.Kvs {
+		size := len(kv.Value)
+		openAPITaskTemplateSizeHist.Observe(float64(size))
+		total += int64(size)
+	}
+	openAPITaskTemplateStorageSizeGauge.Set(float64(total))
+	return total, nil
+}
+
+// GetOpenAPITaskTemplatesByMode gets all openapi task configs whose `task_mode` is mode, sorted by task name.
+// NOTE: the task mode is not a part of the etcd key, so all task configs are read and decoded.
+func GetOpenAPITaskTemplatesByMode(cli *clientv3.Client, mode openapi.TaskTaskMode) ([]*openapi.Task, error) {
+	tasks, err := GetAllOpenAPITaskTemplate(cli)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]*openapi.Task, 0, len(tasks))
+	for _, task := range tasks {
+		if task.TaskMode == mode {
+			ret = append(ret, task)
+		}
+	}
+	// tasks are already sorted by task name.
+	return ret, nil
+}
+
+// SetOpenAPITaskTemplateLabels replaces the labels of the openapi task config of task-name, empty labels remove all
+// labels. the labels are stored in a sibling key of the task config and share its lease if it's put with TTL.
+func SetOpenAPITaskTemplateLabels(cli *clientv3.Client, taskName string, labels map[string]string) error {
+	for k := range labels {
+		if k == "" {
+			return terror.ErrHAInvalidItem.Generate(fmt.Sprintf("empty label key of openapi task template %s", taskName))
+		}
+	}
+	var value []byte
+	if len(labels) > 0 {
+		var err error
+		if value, err = json.Marshal(labels); err != nil {
+			return terror.ErrHAInvalidItem.Delegate(err, "marshal labels of openapi task template")
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(cli.Ctx(), etcdutil.DefaultRequestTimeout)
+	defer cancel()
+
+	key, labelsKey := openAPITaskTemplateKey(DefaultOpenAPITaskTemplateNamespace, taskName), openAPITaskTemplateLabelsKey(taskName)
+	for i := 0; i < maxPutOpenAPITaskTemplateRetry; i++ {
+		resp, err := cli.Get(ctx, key, clientv3.WithKeysOnly())
+		if err != nil {
+			return terror.ErrHAFailTxnOperation.Delegate(err, "set labels of openapi task template")
+		}
+		if resp.Count == 0 {
+			return terror.ErrOpenAPITaskConfigNotExist.Generate(taskName)
+		}
+		kv := resp.Kvs[0]
+		op := clientv3.OpDelete(labelsKey)
+		if len(labels) > 0 {
+			var opts []clientv3.OpOption
+			if kv.Lease != int64(clientv3.NoLease) {
+				opts = append(opts, clientv3.WithLease(clientv3.LeaseID(kv.Lease)))
+			}
+			op = clientv3.OpPut(labelsKey, string(value), opts...)
+		}
+		txnResp, err := cli.Txn(ctx).
+			If(clientv3.Compare(clientv3.ModRevision(key), "=", kv.ModRevision)).
+			Then(op).
+			Commit()
+		if err != nil {
+			return terror.ErrHAFailTxnOperation.Delegate(err, "set labels of openapi task template")
+		}
+		if txnResp.Succeeded {
+			return nil
+		}
+		// the task config is modified or deleted concurrently, retry.
+	}
+	return terror.ErrHAFailTxnOperation.Generate("set labels of openapi task template: too many concurrent writes")
+}
+
+// GetOpenAPITaskTemplateLabels gets the labels of the openapi task config of task-name, it returns nil if the task
+// config has no labels.
+func GetOpenAPITaskTemplateLabels(cli *clientv3.Client, taskName string) (map[string]string, error) {
+	ctx, cancel := context.WithTimeout(cli.Ctx(), etcdutil.DefaultRequestTimeout)
+	defer cancel()
+
+	resp, err := cli.Get(ctx, openAPITaskTemplateLabelsKey(taskName))
+	if err != nil {
+		return nil, terror.ErrHAFailTxnOperation.Delegate(err, "get labels of openapi task template")
+	}
+	if resp.Count == 0 {
+		return nil, nil
+	}
+	var labels map[string]string
+	if err := json.Unmarshal(resp.Kvs[0].Value, &labels); err != nil {
+		return nil, terror.ErrHAInvalidItem.Delegate(err, "unmarshal labels of openapi task template")
+	}
+	return labels, nil
+}
+
+// ListOpenAPITaskTemplatesByLabel gets all openapi task configs which have the label key=value, sorted by task name.
+// the labels and the task configs are read in one txn, so they are consistent with each other.
+func ListOpenAPITaskTemplatesByLabel(cli *clientv3.Client, key, value string) ([]*openapi.Task, error) {
+	ctx, cancel := context.WithTimeout(cli.Ctx(), etcdutil.DefaultRequestTimeout)
+	defer cancel()
+
+	resp, err := cli.Txn(ctx).Then(
+		clientv3.OpGet(common.OpenAPITaskTemplateLabelsKeyAdapter.Path(), clientv3.WithPrefix()),
+		clientv3.OpGet(openAPITaskTemplatePrefix(DefaultOpenAPITaskTemplateNamespace), clientv3.WithPrefix()),
+	).Commit()
+	if err != nil {
+		return nil, terror.ErrHAFailTxnOperation.Delegate(err, "list openapi task templates by label")
+	}
+
+	matched := make(map[string]struct{})
+	for _, kv := range resp.Responses[0].GetResponseRange().Kvs {
+		keys, err2 := common.OpenAPITaskTemplateLabelsKeyAdapter.Decode(string(kv.Key))
+		if err2 != nil {
+			return nil, err2
+		}
+		var labels map[string]string
+		if err2 = json.Unmarshal(kv.Value, &labels); err2 != nil {
+			return nil, terror.ErrHAInvalidItem.Delegate(err2, "unmarshal labels of openapi task template")
+		}
+		if v, ok := labels[key]; ok && v == value {
+			matched[keys[0]] = struct{}{}
+		}
+	}
+
+	// the keys are sorted by the hex encoded task name, which keeps the order of task names.
+	ret := make([]*openapi.Task, 0, len(matched))
+	for _, kv := range resp.Responses[1].GetResponseRange().Kvs {
+		keys, err2 := common.OpenAPITaskTemplateKeyAdapter.Decode(string(kv.Key))
+		if err2 != nil {
+			return nil, err2
+		}
+		if _, ok := matched[keys[0]]; !ok {
+			continue
+		}
+		t := &openapi.Task{}
+		if err2 = decodeOpenAPITaskTemplateValue(kv.Value, t); err2 != nil {
+			return nil, err2
+		}
+		ret = append(ret, t)
+	}
+	return ret, nil
+}
+
+// openAPITaskTemplatePageToken is the continue token of ListOpenAPITaskTemplatePage.
+type openAPITaskTemplatePageToken struct {
+	// Revision is the etcd revision of the first page, all pages are read at this revision.
+	Revision int64 `json:"revision"`
+	// LastKey is the last etcd key of the previous page.
+	LastKey string `json:"last-key"`
+}
+
+// ListOpenAPITaskTemplatePage lists at most pageSize openapi task configs after the position of continueToken, and
+// returns the continue token of the next page, it's empty if there are no more task configs.
+// an empty continueToken means listing from the first page. all pages are read at the revision of the first page,
+// so the result is stable across concurrent modifications unless the revision has been compacted.
+func ListOpenAPITaskTemplatePage(cli *clientv3.Client, pageSize int64, continueToken string) ([]*openapi.Task, string, error) {
+	if pageSize <= 0 {
+		return nil, "", terror.ErrHAInvalidItem.Generate(fmt.Sprintf("invalid page size %d", pageSize))
+	}
+
+	prefix := common.OpenAPITaskTemplateKeyAdapter.Path()
+	var token openAPITaskTemplatePageToken
+	startKey := prefix
+	if continueToken != "" {
+		data, err := base64.RawURLEncoding.DecodeString(continueToken)
+		if err == nil {
+			err = json.Unmarshal(data, &token)
+		}
+		if err != nil {
+			return nil, "", terror.ErrHAInvalidItem.Delegate(err, "invalid continue token of openapi task template")
+		}
+		// the smallest key after LastKey.
+		startKey = token.LastKey + "\x00"
+	}
+
+	ctx, cancel := context.WithTimeout(cli.Ctx(), etcdutil.DefaultRequestTimeout)
+	defer cancel()
+
+	resp, err := cli.Get(ctx, startKey, clientv3.WithRange(clientv3.GetPrefixRangeEnd(prefix)),
+		clientv3.WithRev(token.Revision), clientv3.WithLimit(pageSize),
+		clientv3.WithSort(clientv3.SortByKey, clientv3.SortAscend))
+	if err != nil {
+		return nil, "", terror.ErrHAFailTxnOperation.Delegate(err, "list openapi task templates")
+	}
+	tasks := make([]*openapi.Task, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		t := &openapi.Task{}
+		if err := decodeOpenAPITaskTemplateValue(kv.Value, t); err != nil {
+			return nil, "", err
+		}
+		tasks = append(tasks, t)
+	}
+	if !resp.More || len(resp.Kvs) == 0 {
+		return tasks, "", nil
+	}
+
+	if token.Revision == 0 {
+		token.Revision = resp.Header.Revision
+	}
+	token.LastKey = string(resp.Kvs[len(resp.Kvs)-1].Key)
+	data, err := json.Marshal(token)
+	if err != nil {
+		return nil, "", terror.ErrHAInvalidItem.Delegate(err, "marshal continue token of openapi task template")
+	}
+	return tasks, base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// GetOpenAPITaskTemplateVersion gets the openapi task config of task-name in the specified version.
+// it returns nil if the version does not exist or has been removed.
+func GetOpenAPITaskTemplateVersion(cli *clientv3.Client, taskName string, version int64) (*openapi.Task, error) {
+	ctx, cancel := context.WithTimeout(cli.Ctx(), etcdutil.DefaultRequestTimeout)
+	defer cancel()
+
+	resp, err := cli.Get(ctx, encodeOpenAPITaskTemplateVersionKey(DefaultOpenAPITaskTemplateNamespace, taskName, version))
+	if err != nil {
+		return nil, terror.ErrHAFailTxnOperation.Delegate(err, "get openapi task template version")
+	}
+	return openAPITaskFromResp(resp)
+}
+
+// ListOpenAPITaskTemplateVersions lists all kept versions of the openapi task config of task-name in ascending order.
+func ListOpenAPITaskTemplateVersions(cli *clientv3.Client, taskName string) ([]int64, error) {
+	ctx, cancel := context.WithTimeout(cli.Ctx(), etcdutil.DefaultRequestTimeout)
+	defer cancel()
+
+	return listOpenAPITaskTemplateVersions(ctx, cli, DefaultOpenAPITaskTemplateNamespace, taskName)
+}
+
+// WatchOpenAPITaskTemplate watches PUT & DELETE operations for openapi task templates.
+// revision 0 means watching from the current revision.
+func WatchOpenAPITaskTemplate(ctx context.Context, cli *clientv3.Client, revision int64,
+	outCh chan<- OpenAPITaskTemplateEvent, errCh chan<- error,
+) {
+	wCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	ch := cli.Watch(wCtx, common.OpenAPITaskTemplateKeyAdapter.Path(), clientv3.WithPrefix(), clientv3.WithRev(revision))
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case resp, ok := <-ch:
+			if !ok {
+				return
+			}
+			if resp.Canceled {
+				select {
+				case errCh <- terror.ErrHAFailWatchEtcd.Delegate(resp.Err(), "watch openapi task template canceled"):
+				case <-ctx.Done():
+				}
+				return
+			}
+
+			for _, ev := range resp.Events {
+				var (
+					event OpenAPITaskTemplateEvent
+					err   error
+					keys  []string
+				)
+
+				switch ev.Type {
+				case mvccpb.PUT, mvccpb.DELETE:
+					event.Revision = ev.Kv.ModRevision
+					keys, err = common.OpenAPITaskTemplateKeyAdapter.Decode(string(ev.Kv.Key))
+					if err == nil {
+						event.Name = keys[0]
+						if ev.Type == mvccpb.PUT {
+							event.Task = &openapi.Task{}
+							err = decodeOpenAPITaskTemplateValue(ev.Kv.Value, event.Task)
+						} else {
+							event.IsDelete = true
+						}
+					}
+				default:
+					// this should not happen.
+					err = fmt.Errorf("unsupported ectd event type %v", ev.Type)
+				}
+
+				if err != nil {
+					select {
+					case errCh <- err:
+					case <-ctx.Done():
+						return
+					}
+				} else {
+					select {
+					case outCh <- event:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}
+	}
+}
+
+// openAPITaskTemplateBundleVersion is the current schema version of the openapi task template bundle, it should be
+// increased when the format of bundle is changed, and bundles of old versions should be migrated in
+// migrateOpenAPITaskTemplateBundle.
+const openAPITaskTemplateBundleVersion = 1
+
+// openAPITaskTemplateBundle is the document of all openapi task templates used to export and import.
+type openAPITaskTemplateBundle struct {
+	SchemaVersion int            `json:"schema-version"`
+	Templates     []openapi.Task `json:"templates"`
+}
+
+// migrateOpenAPITaskTemplateBundle migrates the bundle of old schema version to the current one.
+func migrateOpenAPITaskTemplateBundle(bundle *openAPITaskTemplateBundle) error {
+	switch bundle.SchemaVersion {
+	case openAPITaskTemplateBundleVersion:
+		return nil
+	default:
+		return terror.ErrHAInvalidItem.Generate(fmt.Sprintf(
+			"unsupported schema version %d of openapi task template bundle, the latest supported version is %d",
+			bundle.SchemaVersion, openAPITaskTemplateBundleVersion))
+	}
+}
+
+// ExportOpenAPITaskTemplates exports all openapi task templates as a versioned JSON bundle.
+func ExportOpenAPITaskTemplates(cli *clientv3.Client) ([]byte, error) {
+	tasks, err := GetAllOpenAPITaskTemplate(cli)
+	if err != nil {
+		return nil, err
+	}
+	bundle := openAPITaskTemplateBundle{
+		SchemaVersion: openAPITaskTemplateBundleVersion,
+		Templates:     make([]openapi.Task, 0, len(tasks)),
+	}
+	for _, task := range tasks {
+		bundle.Templates = append(bundle.Templates, *task)
+	}
+	return json.Marshal(bundle)
+}
+
+// ImportOpenAPITaskTemplates imports the openapi task templates from the bundle exported by ExportOpenAPITaskTemplates,
+// all templates are put in one txn, see PutOpenAPITaskTemplateBatch for the meanings of overWrite.
+func ImportOpenAPITaskTemplates(cli *clientv3.Client, data []byte, overWrite bool, opts ...OpenAPITaskTemplateOption) error {
+	var bundle openAPITaskTemplateBundle
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		return terror.ErrHAInvalidItem.Delegate(err, "invalid openapi task template bundle")
+	}
+	if err := migrateOpenAPITaskTemplateBundle(&bundle); err != nil {
+		return err
+	}
+	if len(bundle.Templates) == 0 {
+		return nil
+	}
+	return PutOpenAPITaskTemplateBatch(cli, bundle.Templates, overWrite, opts...)
+}
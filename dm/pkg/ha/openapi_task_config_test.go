@@ -14,14 +14,43 @@
 package ha
 
 import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"sort"
+	"strings"
+	"time"
+
 	"github.com/pingcap/check"
+	"github.com/pingcap/tiflow/dm/common"
+	"github.com/pingcap/tiflow/dm/config"
 	"github.com/pingcap/tiflow/dm/openapi"
 	"github.com/pingcap/tiflow/dm/openapi/fixtures"
 	"github.com/pingcap/tiflow/dm/pkg/terror"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	clientv3 "go.etcd.io/etcd/client/v3"
 )
 
+// putSourceCfgForOpenAPITaskTest puts the source configs referenced by openapi task fixtures, which are required by
+// the validation of openapi task templates.
+func putSourceCfgForOpenAPITaskTest(c *check.C) {
+	cfg, err := config.LoadFromFile(sourceSampleFilePath)
+	c.Assert(err, check.IsNil)
+	c.Assert(cfg.From.Security.LoadTLSContent(), check.IsNil)
+	for _, sourceID := range []string{"mysql-replica-01", "mysql-replica-02", "mysql-replica-03"} {
+		sourceCfg := cfg.Clone()
+		sourceCfg.SourceID = sourceID
+		_, err = PutSourceCfg(etcdTestCli, sourceCfg)
+		c.Assert(err, check.IsNil)
+	}
+}
+
 func (t *testForEtcd) TestOpenAPITaskConfigEtcd(c *check.C) {
 	defer clearTestInfoOperation(c)
+	putSourceCfgForOpenAPITaskTest(c)
 
 	task1, err := fixtures.GenNoShardOpenAPITaskForTest()
 	task1.Name = "test-1"
@@ -55,9 +84,18 @@ func (t *testForEtcd) TestOpenAPITaskConfigEtcd(c *check.C) {
 	c.Assert(err, check.IsNil)
 	c.Assert(*task2InEtcd, check.DeepEquals, task2)
 
+	// the task configs are sorted by name.
+	task3, err := fixtures.GenNoShardOpenAPITaskForTest()
+	c.Assert(err, check.IsNil)
+	task3.Name = "test-0"
+	c.Assert(PutOpenAPITaskTemplate(etcdTestCli, task3, false), check.IsNil)
 	tasks, err = GetAllOpenAPITaskTemplate(etcdTestCli)
 	c.Assert(err, check.IsNil)
-	c.Assert(tasks, check.HasLen, 2)
+	c.Assert(tasks, check.HasLen, 3)
+	c.Assert(tasks[0].Name, check.Equals, task3.Name)
+	c.Assert(*tasks[1], check.DeepEquals, task1)
+	c.Assert(*tasks[2], check.DeepEquals, task2)
+	c.Assert(DeleteOpenAPITaskTemplate(etcdTestCli, task3.Name), check.IsNil)
 
 	// put openapi task config again without overwrite will fail
 	c.Assert(terror.ErrOpenAPITaskConfigExist.Equal(PutOpenAPITaskTemplate(etcdTestCli, task1, false)), check.IsTrue)
@@ -70,7 +108,7 @@ func (t *testForEtcd) TestOpenAPITaskConfigEtcd(c *check.C) {
 	c.Assert(*task1InEtcd, check.DeepEquals, task1)
 
 	// put task config that not exist will fail
-	task3, err := fixtures.GenNoShardOpenAPITaskForTest()
+	task3, err = fixtures.GenNoShardOpenAPITaskForTest()
 	c.Assert(err, check.IsNil)
 	task3.Name = "test-3"
 	c.Assert(terror.ErrOpenAPITaskConfigNotExist.Equal(UpdateOpenAPITaskTemplate(etcdTestCli, task3)), check.IsTrue)
@@ -88,3 +126,1491 @@ func (t *testForEtcd) TestOpenAPITaskConfigEtcd(c *check.C) {
 	c.Assert(err, check.IsNil)
 	c.Assert(tasks, check.HasLen, 1)
 }
+
+func (t *testForEtcd) TestOpenAPITaskConfigEtcdShardModes(c *check.C) {
+	defer clearTestInfoOperation(c)
+	putSourceCfgForOpenAPITaskTest(c)
+
+	for name, gen := range map[string]func() (openapi.Task, error){
+		"strict-optimistic": fixtures.GenShardAndFilterOpenAPITaskForTest,
+		"optimistic":        fixtures.GenOptimisticShardAndFilterOpenAPITaskForTest,
+		"pessimistic":       fixtures.GenPessimisticShardAndFilterOpenAPITaskForTest,
+		"multi-source":      fixtures.GenMultiSourceShardAndFilterOpenAPITaskForTest,
+	} {
+		task, err := gen()
+		c.Assert(err, check.IsNil)
+		task.Name = name
+		c.Assert(PutOpenAPITaskTemplate(etcdTestCli, task, false), check.IsNil)
+		taskInEtcd, err := GetOpenAPITaskTemplate(etcdTestCli, task.Name)
+		c.Assert(err, check.IsNil)
+		c.Assert(*taskInEtcd, check.DeepEquals, task)
+	}
+
+	task, err := GetOpenAPITaskTemplate(etcdTestCli, "pessimistic")
+	c.Assert(err, check.IsNil)
+	c.Assert(*task.ShardMode, check.Equals, openapi.TaskShardModePessimistic)
+	c.Assert(task.StrictOptimisticShardMode, check.IsNil)
+	task, err = GetOpenAPITaskTemplate(etcdTestCli, "optimistic")
+	c.Assert(err, check.IsNil)
+	c.Assert(*task.ShardMode, check.Equals, openapi.TaskShardModeOptimistic)
+	c.Assert(*task.StrictOptimisticShardMode, check.IsFalse)
+	task, err = GetOpenAPITaskTemplate(etcdTestCli, "multi-source")
+	c.Assert(err, check.IsNil)
+	c.Assert(task.SourceConfig.SourceConf, check.HasLen, 3)
+	c.Assert(task.TableMigrateRule, check.HasLen, 3)
+
+	tasks, err := GetAllOpenAPITaskTemplate(etcdTestCli)
+	c.Assert(err, check.IsNil)
+	c.Assert(tasks, check.HasLen, 4)
+}
+
+func (t *testForEtcd) TestOpenAPITaskConfigVersion(c *check.C) {
+	defer clearTestInfoOperation(c)
+	putSourceCfgForOpenAPITaskTest(c)
+
+	keep := WithOpenAPITaskTemplateVersionsToKeep(2)
+
+	task1, err := fixtures.GenNoShardOpenAPITaskForTest()
+	c.Assert(err, check.IsNil)
+	task1.Name = "test-1"
+
+	versions, err := ListOpenAPITaskTemplateVersions(etcdTestCli, task1.Name)
+	c.Assert(err, check.IsNil)
+	c.Assert(versions, check.HasLen, 0)
+
+	// every put and update creates a new version.
+	c.Assert(PutOpenAPITaskTemplate(etcdTestCli, task1, false, keep), check.IsNil)
+	versions, err = ListOpenAPITaskTemplateVersions(etcdTestCli, task1.Name)
+	c.Assert(err, check.IsNil)
+	c.Assert(versions, check.DeepEquals, []int64{1})
+
+	// failed put doesn't create a new version.
+	c.Assert(terror.ErrOpenAPITaskConfigExist.Equal(PutOpenAPITaskTemplate(etcdTestCli, task1, false, keep)), check.IsTrue)
+	versions, err = ListOpenAPITaskTemplateVersions(etcdTestCli, task1.Name)
+	c.Assert(err, check.IsNil)
+	c.Assert(versions, check.DeepEquals, []int64{1})
+
+	task1Full := task1
+	task1Full.TaskMode = openapi.TaskTaskModeFull
+	c.Assert(UpdateOpenAPITaskTemplate(etcdTestCli, task1Full, keep), check.IsNil)
+	versions, err = ListOpenAPITaskTemplateVersions(etcdTestCli, task1.Name)
+	c.Assert(err, check.IsNil)
+	c.Assert(versions, check.DeepEquals, []int64{1, 2})
+
+	task1InEtcd, err := GetOpenAPITaskTemplateVersion(etcdTestCli, task1.Name, 1)
+	c.Assert(err, check.IsNil)
+	c.Assert(*task1InEtcd, check.DeepEquals, task1)
+	task1InEtcd, err = GetOpenAPITaskTemplateVersion(etcdTestCli, task1.Name, 2)
+	c.Assert(err, check.IsNil)
+	c.Assert(*task1InEtcd, check.DeepEquals, task1Full)
+
+	// the oldest version is removed when exceeding the limit.
+	task1Incremental := task1
+	task1Incremental.TaskMode = openapi.TaskTaskModeIncremental
+	c.Assert(PutOpenAPITaskTemplate(etcdTestCli, task1Incremental, true, keep), check.IsNil)
+	versions, err = ListOpenAPITaskTemplateVersions(etcdTestCli, task1.Name)
+	c.Assert(err, check.IsNil)
+	c.Assert(versions, check.DeepEquals, []int64{2, 3})
+	task1InEtcd, err = GetOpenAPITaskTemplateVersion(etcdTestCli, task1.Name, 1)
+	c.Assert(err, check.IsNil)
+	c.Assert(task1InEtcd, check.IsNil)
+
+	// versions are removed by delete, so putting again starts from the first version.
+	c.Assert(DeleteOpenAPITaskTemplate(etcdTestCli, task1.Name), check.IsNil)
+	versions, err = ListOpenAPITaskTemplateVersions(etcdTestCli, task1.Name)
+	c.Assert(err, check.IsNil)
+	c.Assert(versions, check.HasLen, 0)
+	c.Assert(PutOpenAPITaskTemplate(etcdTestCli, task1, false), check.IsNil)
+	versions, err = ListOpenAPITaskTemplateVersions(etcdTestCli, task1.Name)
+	c.Assert(err, check.IsNil)
+	c.Assert(versions, check.DeepEquals, []int64{1})
+
+	// all versions are kept by default until exceeding DefaultOpenAPITaskTemplateVersionsToKeep.
+	for i := 0; i < DefaultOpenAPITaskTemplateVersionsToKeep; i++ {
+		c.Assert(UpdateOpenAPITaskTemplate(etcdTestCli, task1), check.IsNil)
+	}
+	versions, err = ListOpenAPITaskTemplateVersions(etcdTestCli, task1.Name)
+	c.Assert(err, check.IsNil)
+	c.Assert(versions, check.HasLen, DefaultOpenAPITaskTemplateVersionsToKeep)
+	c.Assert(versions[0], check.Equals, int64(2))
+}
+
+func (t *testForEtcd) TestWatchOpenAPITaskTemplate(c *check.C) {
+	defer clearTestInfoOperation(c)
+	putSourceCfgForOpenAPITaskTest(c)
+
+	watchTimeout := 2 * time.Second
+
+	task1, err := fixtures.GenNoShardOpenAPITaskForTest()
+	c.Assert(err, check.IsNil)
+	task1.Name = "test-1"
+
+	resp, err := etcdTestCli.Get(context.Background(), common.OpenAPITaskTemplateKeyAdapter.Path(), clientv3.WithPrefix())
+	c.Assert(err, check.IsNil)
+	rev := resp.Header.Revision
+
+	c.Assert(PutOpenAPITaskTemplate(etcdTestCli, task1, false), check.IsNil)
+	c.Assert(DeleteOpenAPITaskTemplate(etcdTestCli, task1.Name), check.IsNil)
+
+	eventCh := make(chan OpenAPITaskTemplateEvent, 10)
+	errCh := make(chan error, 10)
+	ctx, cancel := context.WithTimeout(context.Background(), watchTimeout)
+	WatchOpenAPITaskTemplate(ctx, etcdTestCli, rev+1, eventCh, errCh)
+	cancel()
+	close(eventCh)
+	close(errCh)
+	c.Assert(len(errCh), check.Equals, 0)
+	c.Assert(len(eventCh), check.Equals, 2)
+	putEvent := <-eventCh
+	c.Assert(putEvent.Name, check.Equals, task1.Name)
+	c.Assert(putEvent.IsDelete, check.IsFalse)
+	c.Assert(*putEvent.Task, check.DeepEquals, task1)
+	c.Assert(putEvent.Revision, check.Greater, rev)
+	deleteEvent := <-eventCh
+	c.Assert(deleteEvent.Name, check.Equals, task1.Name)
+	c.Assert(deleteEvent.IsDelete, check.IsTrue)
+	c.Assert(deleteEvent.Task, check.IsNil)
+	c.Assert(deleteEvent.Revision, check.Greater, putEvent.Revision)
+}
+
+func (t *testForEtcd) TestPutOpenAPITaskTemplateBatch(c *check.C) {
+	defer clearTestInfoOperation(c)
+	putSourceCfgForOpenAPITaskTest(c)
+
+	task1, err := fixtures.GenNoShardOpenAPITaskForTest()
+	c.Assert(err, check.IsNil)
+	task1.Name = "test-1"
+	task2, err := fixtures.GenShardAndFilterOpenAPITaskForTest()
+	c.Assert(err, check.IsNil)
+	task2.Name = "test-2"
+	task3, err := fixtures.GenNoShardOpenAPITaskForTest()
+	c.Assert(err, check.IsNil)
+	task3.Name = "test-3"
+
+	c.Assert(PutOpenAPITaskTemplateBatch(etcdTestCli, []openapi.Task{task1, task2}, false), check.IsNil)
+	tasks, err := GetAllOpenAPITaskTemplate(etcdTestCli)
+	c.Assert(err, check.IsNil)
+	c.Assert(tasks, check.HasLen, 2)
+
+	// task2 conflicts, so task3 is not written either.
+	err = PutOpenAPITaskTemplateBatch(etcdTestCli, []openapi.Task{task3, task2}, false)
+	c.Assert(terror.ErrOpenAPITaskConfigExist.Equal(err), check.IsTrue)
+	c.Assert(err, check.ErrorMatches, ".*test-2.*")
+	task3InEtcd, err := GetOpenAPITaskTemplate(etcdTestCli, task3.Name)
+	c.Assert(err, check.IsNil)
+	c.Assert(task3InEtcd, check.IsNil)
+	versions, err := ListOpenAPITaskTemplateVersions(etcdTestCli, task2.Name)
+	c.Assert(err, check.IsNil)
+	c.Assert(versions, check.DeepEquals, []int64{1})
+
+	// duplicate names in one batch.
+	err = PutOpenAPITaskTemplateBatch(etcdTestCli, []openapi.Task{task3, task3}, true)
+	c.Assert(terror.ErrHAInvalidItem.Equal(err), check.IsTrue)
+
+	// overwrite mode writes all of them.
+	task2.TaskMode = openapi.TaskTaskModeFull
+	c.Assert(PutOpenAPITaskTemplateBatch(etcdTestCli, []openapi.Task{task3, task2}, true), check.IsNil)
+	task2InEtcd, err := GetOpenAPITaskTemplate(etcdTestCli, task2.Name)
+	c.Assert(err, check.IsNil)
+	c.Assert(*task2InEtcd, check.DeepEquals, task2)
+	tasks, err = GetAllOpenAPITaskTemplate(etcdTestCli)
+	c.Assert(err, check.IsNil)
+	c.Assert(tasks, check.HasLen, 3)
+}
+
+func (t *testForEtcd) TestCompareAndUpdateOpenAPITaskTemplate(c *check.C) {
+	defer clearTestInfoOperation(c)
+	putSourceCfgForOpenAPITaskTest(c)
+
+	task1, err := fixtures.GenNoShardOpenAPITaskForTest()
+	c.Assert(err, check.IsNil)
+	task1.Name = "test-1"
+
+	// update a not exist task config.
+	err = CompareAndUpdateOpenAPITaskTemplate(etcdTestCli, task1, 0)
+	c.Assert(terror.ErrOpenAPITaskConfigNotExist.Equal(err), check.IsTrue)
+	_, rev, err := GetOpenAPITaskTemplateWithRevision(etcdTestCli, task1.Name)
+	c.Assert(err, check.IsNil)
+	c.Assert(rev, check.Equals, int64(0))
+
+	c.Assert(PutOpenAPITaskTemplate(etcdTestCli, task1, false), check.IsNil)
+	task1InEtcd, rev1, err := GetOpenAPITaskTemplateWithRevision(etcdTestCli, task1.Name)
+	c.Assert(err, check.IsNil)
+	c.Assert(*task1InEtcd, check.DeepEquals, task1)
+	c.Assert(rev1, check.Greater, int64(0))
+
+	// update with the latest revision.
+	task1.TaskMode = openapi.TaskTaskModeFull
+	c.Assert(CompareAndUpdateOpenAPITaskTemplate(etcdTestCli, task1, rev1), check.IsNil)
+	task1InEtcd, rev2, err := GetOpenAPITaskTemplateWithRevision(etcdTestCli, task1.Name)
+	c.Assert(err, check.IsNil)
+	c.Assert(*task1InEtcd, check.DeepEquals, task1)
+	c.Assert(rev2, check.Greater, rev1)
+
+	// update with a stale revision.
+	task1.TaskMode = openapi.TaskTaskModeAll
+	err = CompareAndUpdateOpenAPITaskTemplate(etcdTestCli, task1, rev1)
+	c.Assert(terror.ErrOpenAPITaskConfigStale.Equal(err), check.IsTrue)
+	task1InEtcd, err = GetOpenAPITaskTemplate(etcdTestCli, task1.Name)
+	c.Assert(err, check.IsNil)
+	c.Assert(task1InEtcd.TaskMode, check.Equals, openapi.TaskTaskModeFull)
+}
+
+func (t *testForEtcd) TestMergeOpenAPITaskTemplate(c *check.C) {
+	defer clearTestInfoOperation(c)
+	putSourceCfgForOpenAPITaskTest(c)
+
+	task1, err := fixtures.GenNoShardOpenAPITaskForTest()
+	c.Assert(err, check.IsNil)
+	task1.Name = "test-1"
+
+	// merge a not exist task config puts it as is.
+	c.Assert(MergeOpenAPITaskTemplate(etcdTestCli, task1, OpenAPITaskTemplateSliceReplace), check.IsNil)
+	task1InEtcd, err := GetOpenAPITaskTemplate(etcdTestCli, task1.Name)
+	c.Assert(err, check.IsNil)
+	c.Assert(*task1InEtcd, check.DeepEquals, task1)
+
+	// only non-zero fields are patched, others keep the stored values.
+	metaSchema := "dm_meta_patched"
+	items := []string{config.DumpPrivilegeChecking}
+	patch := openapi.Task{
+		Name:                task1.Name,
+		IgnoreCheckingItems: &items,
+		MetaSchema:          &metaSchema,
+		TargetConfig:        openapi.TaskTargetDataBase{Host: "127.0.0.2"},
+	}
+	c.Assert(MergeOpenAPITaskTemplate(etcdTestCli, patch, OpenAPITaskTemplateSliceReplace), check.IsNil)
+	expected, err := GetOpenAPITaskTemplate(etcdTestCli, task1.Name)
+	c.Assert(err, check.IsNil)
+	c.Assert(expected.TargetConfig.Host, check.Equals, "127.0.0.2")
+	c.Assert(expected.TargetConfig.Port, check.Equals, task1.TargetConfig.Port)
+	c.Assert(expected.TargetConfig.Security, check.DeepEquals, task1.TargetConfig.Security)
+	c.Assert(*expected.IgnoreCheckingItems, check.DeepEquals, items)
+	c.Assert(*expected.MetaSchema, check.Equals, metaSchema)
+	c.Assert(expected.TableMigrateRule, check.DeepEquals, task1.TableMigrateRule)
+	expected.TargetConfig.Host = task1.TargetConfig.Host
+	expected.IgnoreCheckingItems = task1.IgnoreCheckingItems
+	expected.MetaSchema = task1.MetaSchema
+	c.Assert(*expected, check.DeepEquals, task1)
+
+	// slices are replaced or appended.
+	items2 := []string{config.ReplicationPrivilegeChecking}
+	patch = openapi.Task{Name: task1.Name, IgnoreCheckingItems: &items2}
+	c.Assert(MergeOpenAPITaskTemplate(etcdTestCli, patch, OpenAPITaskTemplateSliceAppend), check.IsNil)
+	task1InEtcd, err = GetOpenAPITaskTemplate(etcdTestCli, task1.Name)
+	c.Assert(err, check.IsNil)
+	c.Assert(*task1InEtcd.IgnoreCheckingItems, check.DeepEquals, []string{config.DumpPrivilegeChecking, config.ReplicationPrivilegeChecking})
+	c.Assert(MergeOpenAPITaskTemplate(etcdTestCli, patch, OpenAPITaskTemplateSliceReplace), check.IsNil)
+	task1InEtcd, err = GetOpenAPITaskTemplate(etcdTestCli, task1.Name)
+	c.Assert(err, check.IsNil)
+	c.Assert(*task1InEtcd.IgnoreCheckingItems, check.DeepEquals, items2)
+	// the patch is not modified.
+	c.Assert(items2, check.DeepEquals, []string{config.ReplicationPrivilegeChecking})
+
+	// the merged task config is validated.
+	patch = openapi.Task{Name: task1.Name, TaskMode: "not-exist"}
+	err = MergeOpenAPITaskTemplate(etcdTestCli, patch, OpenAPITaskTemplateSliceReplace, WithOpenAPITaskTemplateValidation(true))
+	c.Assert(terror.ErrOpenAPITaskConfigInvalid.Equal(err), check.IsTrue)
+	task1InEtcd, err = GetOpenAPITaskTemplate(etcdTestCli, task1.Name)
+	c.Assert(err, check.IsNil)
+	c.Assert(task1InEtcd.TaskMode, check.Equals, task1.TaskMode)
+}
+
+func (t *testForEtcd) TestGetOpenAPITaskTemplatesByMode(c *check.C) {
+	defer clearTestInfoOperation(c)
+	putSourceCfgForOpenAPITaskTest(c)
+
+	tasks, err := GetOpenAPITaskTemplatesByMode(etcdTestCli, openapi.TaskTaskModeAll)
+	c.Assert(err, check.IsNil)
+	c.Assert(tasks, check.HasLen, 0)
+
+	task, err := fixtures.GenNoShardOpenAPITaskForTest()
+	c.Assert(err, check.IsNil)
+	modes := map[string]openapi.TaskTaskMode{
+		"test-3": openapi.TaskTaskModeAll,
+		"test-1": openapi.TaskTaskModeAll,
+		"test-2": openapi.TaskTaskModeFull,
+		"test-4": openapi.TaskTaskModeIncremental,
+	}
+	for name, mode := range modes {
+		task.Name = name
+		task.TaskMode = mode
+		c.Assert(PutOpenAPITaskTemplate(etcdTestCli, task, false), check.IsNil)
+	}
+
+	cases := []struct {
+		mode  openapi.TaskTaskMode
+		names []string
+	}{
+		{openapi.TaskTaskModeAll, []string{"test-1", "test-3"}},
+		{openapi.TaskTaskModeFull, []string{"test-2"}},
+		{openapi.TaskTaskModeIncremental, []string{"test-4"}},
+		{openapi.TaskTaskModeDump, []string{}},
+	}
+	for _, cs := range cases {
+		tasks, err = GetOpenAPITaskTemplatesByMode(etcdTestCli, cs.mode)
+		c.Assert(err, check.IsNil)
+		names := make([]string, 0, len(tasks))
+		for _, task := range tasks {
+			c.Assert(task.TaskMode, check.Equals, cs.mode)
+			names = append(names, task.Name)
+		}
+		c.Assert(names, check.DeepEquals, cs.names)
+	}
+}
+
+func (t *testForEtcd) TestListOpenAPITaskTemplatePage(c *check.C) {
+	defer clearTestInfoOperation(c)
+	putSourceCfgForOpenAPITaskTest(c)
+
+	tasks, token, err := ListOpenAPITaskTemplatePage(etcdTestCli, 2, "")
+	c.Assert(err, check.IsNil)
+	c.Assert(tasks, check.HasLen, 0)
+	c.Assert(token, check.Equals, "")
+
+	for i := 1; i <= 5; i++ {
+		task, err2 := fixtures.GenNoShardOpenAPITaskForTest()
+		c.Assert(err2, check.IsNil)
+		task.Name = fmt.Sprintf("test-%d", i)
+		c.Assert(PutOpenAPITaskTemplate(etcdTestCli, task, false), check.IsNil)
+	}
+
+	tasks, token, err = ListOpenAPITaskTemplatePage(etcdTestCli, 2, "")
+	c.Assert(err, check.IsNil)
+	c.Assert(tasks, check.HasLen, 2)
+	c.Assert(token, check.Not(check.Equals), "")
+	names := []string{tasks[0].Name, tasks[1].Name}
+
+	// task put after the first page is not listed.
+	task6, err := fixtures.GenNoShardOpenAPITaskForTest()
+	c.Assert(err, check.IsNil)
+	task6.Name = "test-6"
+	c.Assert(PutOpenAPITaskTemplate(etcdTestCli, task6, false), check.IsNil)
+
+	for token != "" {
+		tasks, token, err = ListOpenAPITaskTemplatePage(etcdTestCli, 2, token)
+		c.Assert(err, check.IsNil)
+		for _, task := range tasks {
+			names = append(names, task.Name)
+		}
+	}
+	c.Assert(names, check.DeepEquals, []string{"test-1", "test-2", "test-3", "test-4", "test-5"})
+
+	_, _, err = ListOpenAPITaskTemplatePage(etcdTestCli, 2, "invalid token")
+	c.Assert(terror.ErrHAInvalidItem.Equal(err), check.IsTrue)
+	_, _, err = ListOpenAPITaskTemplatePage(etcdTestCli, 0, "")
+	c.Assert(terror.ErrHAInvalidItem.Equal(err), check.IsTrue)
+}
+
+func (t *testForEtcd) TestOpenAPITaskTemplateCompress(c *check.C) {
+	defer clearTestInfoOperation(c)
+	putSourceCfgForOpenAPITaskTest(c)
+
+	oldThreshold := openAPITaskTemplateCompressThreshold
+	defer func() {
+		openAPITaskTemplateCompressThreshold = oldThreshold
+	}()
+
+	task1, err := fixtures.GenNoShardOpenAPITaskForTest()
+	c.Assert(err, check.IsNil)
+	task1.Name = "test-1"
+	task2, err := fixtures.GenShardAndFilterOpenAPITaskForTest()
+	c.Assert(err, check.IsNil)
+	task2.Name = "test-2"
+	key1 := common.OpenAPITaskTemplateKeyAdapter.Encode(task1.Name)
+	key2 := common.OpenAPITaskTemplateKeyAdapter.Encode(task2.Name)
+	task1JSON, err := task1.ToJSON()
+	c.Assert(err, check.IsNil)
+	envelope := WithOpenAPITaskTemplateEnvelope(true)
+
+	// task template is written as plain JSON by default, even if it's large.
+	openAPITaskTemplateCompressThreshold = 0
+	c.Assert(PutOpenAPITaskTemplate(etcdTestCli, task1, false), check.IsNil)
+	resp, err := etcdTestCli.Get(context.Background(), key1)
+	c.Assert(err, check.IsNil)
+	c.Assert(resp.Kvs[0].Value, check.DeepEquals, task1JSON)
+
+	// small task template is not compressed in the envelope.
+	openAPITaskTemplateCompressThreshold = oldThreshold
+	c.Assert(PutOpenAPITaskTemplate(etcdTestCli, task1, true, envelope), check.IsNil)
+	resp, err = etcdTestCli.Get(context.Background(), key1)
+	c.Assert(err, check.IsNil)
+	c.Assert(resp.Kvs[0].Value[0], check.Equals, openAPITaskTemplateEnvelopeHeader)
+	c.Assert(bytes.Contains(resp.Kvs[0].Value, []byte(`"table_migrate_rule"`)), check.IsTrue)
+
+	// large task template is compressed in the envelope.
+	openAPITaskTemplateCompressThreshold = 0
+	c.Assert(PutOpenAPITaskTemplate(etcdTestCli, task2, false, envelope), check.IsNil)
+	resp, err = etcdTestCli.Get(context.Background(), key2)
+	c.Assert(err, check.IsNil)
+	c.Assert(resp.Kvs[0].Value[0], check.Equals, openAPITaskTemplateEnvelopeHeader)
+	c.Assert(bytes.Contains(resp.Kvs[0].Value, []byte(`"table_migrate_rule"`)), check.IsFalse)
+	task2JSON, err := task2.ToJSON()
+	c.Assert(err, check.IsNil)
+	c.Assert(len(resp.Kvs[0].Value), check.Less, len(task2JSON))
+
+	task2InEtcd, err := GetOpenAPITaskTemplate(etcdTestCli, task2.Name)
+	c.Assert(err, check.IsNil)
+	c.Assert(*task2InEtcd, check.DeepEquals, task2)
+	task2InEtcd, err = GetOpenAPITaskTemplateVersion(etcdTestCli, task2.Name, 1)
+	c.Assert(err, check.IsNil)
+	c.Assert(*task2InEtcd, check.DeepEquals, task2)
+	tasks, err := GetAllOpenAPITaskTemplate(etcdTestCli)
+	c.Assert(err, check.IsNil)
+	c.Assert(tasks, check.HasLen, 2)
+
+	// task template stored as plain JSON can be read.
+	_, err = etcdTestCli.Put(context.Background(), key1, string(task1JSON))
+	c.Assert(err, check.IsNil)
+	task1InEtcd, err := GetOpenAPITaskTemplate(etcdTestCli, task1.Name)
+	c.Assert(err, check.IsNil)
+	c.Assert(*task1InEtcd, check.DeepEquals, task1)
+
+	// broken compressed value with the right checksum.
+	broken := []byte{openAPITaskTemplateEnvelopeGzip, 2, '{', '}', 'x'}
+	value := append([]byte{openAPITaskTemplateEnvelopeHeader, openAPITaskTemplateEnvelopeVersion, 0, 0, 0, 0}, broken...)
+	binary.BigEndian.PutUint32(value[2:openAPITaskTemplateEnvelopeHeaderLen], crc32.Checksum(broken, openAPITaskTemplateCRCTable))
+	_, err = etcdTestCli.Put(context.Background(), key1, string(value))
+	c.Assert(err, check.IsNil)
+	_, err = GetOpenAPITaskTemplate(etcdTestCli, task1.Name)
+	c.Assert(terror.ErrHAInvalidItem.Equal(err), check.IsTrue)
+}
+
+func (t *testForEtcd) TestOpenAPITaskTemplateMeta(c *check.C) {
+	defer clearTestInfoOperation(c)
+	putSourceCfgForOpenAPITaskTest(c)
+
+	task1, err := fixtures.GenNoShardOpenAPITaskForTest()
+	c.Assert(err, check.IsNil)
+	task1.Name = "test-1"
+	key1 := common.OpenAPITaskTemplateKeyAdapter.Encode(task1.Name)
+	envelope := WithOpenAPITaskTemplateEnvelope(true)
+
+	ret, err := GetOpenAPITaskTemplateWithMeta(etcdTestCli, task1.Name)
+	c.Assert(err, check.IsNil)
+	c.Assert(ret, check.IsNil)
+
+	// put with author.
+	before := time.Now()
+	c.Assert(PutOpenAPITaskTemplateWithAuthor(etcdTestCli, task1, false, "alice", envelope), check.IsNil)
+	ret, err = GetOpenAPITaskTemplateWithMeta(etcdTestCli, task1.Name)
+	c.Assert(err, check.IsNil)
+	c.Assert(*ret.Task, check.DeepEquals, task1)
+	c.Assert(ret.Meta.ModifiedBy, check.Equals, "alice")
+	c.Assert(ret.Meta.ModifiedAt.Before(before), check.IsFalse)
+	c.Assert(ret.Meta.ModifiedAt.After(time.Now()), check.IsFalse)
+	firstModifiedAt := ret.Meta.ModifiedAt
+
+	// putting without overwrite fails with the revision and metadata of the existing one.
+	_, revision, err := GetOpenAPITaskTemplateWithRevision(etcdTestCli, task1.Name)
+	c.Assert(err, check.IsNil)
+	err = PutOpenAPITaskTemplateWithAuthor(etcdTestCli, task1, false, "bob", envelope)
+	c.Assert(terror.ErrOpenAPITaskConfigExist.Equal(err), check.IsTrue)
+	c.Assert(err.(*terror.Error).Fields(), check.DeepEquals, map[string]interface{}{
+		OpenAPITaskTemplateFieldTask:       task1.Name,
+		OpenAPITaskTemplateFieldRevision:   revision,
+		OpenAPITaskTemplateFieldModifiedAt: firstModifiedAt,
+		OpenAPITaskTemplateFieldModifiedBy: "alice",
+	})
+
+	// the raw task is still returned by GetOpenAPITaskTemplate.
+	task1InEtcd, err := GetOpenAPITaskTemplate(etcdTestCli, task1.Name)
+	c.Assert(err, check.IsNil)
+	c.Assert(*task1InEtcd, check.DeepEquals, task1)
+
+	// update with author.
+	task1.TaskMode = openapi.TaskTaskModeFull
+	c.Assert(UpdateOpenAPITaskTemplateWithAuthor(etcdTestCli, task1, "bob", envelope), check.IsNil)
+	ret, err = GetOpenAPITaskTemplateWithMeta(etcdTestCli, task1.Name)
+	c.Assert(err, check.IsNil)
+	c.Assert(*ret.Task, check.DeepEquals, task1)
+	c.Assert(ret.Meta.ModifiedBy, check.Equals, "bob")
+	c.Assert(ret.Meta.ModifiedAt.Before(firstModifiedAt), check.IsFalse)
+	notExist := task1
+	notExist.Name = "not-exist"
+	err = UpdateOpenAPITaskTemplateWithAuthor(etcdTestCli, notExist, "bob", envelope)
+	c.Assert(terror.ErrOpenAPITaskConfigNotExist.Equal(err), check.IsTrue)
+
+	// the history version keeps its metadata.
+	resp, err := etcdTestCli.Get(context.Background(), encodeOpenAPITaskTemplateVersionKey("", task1.Name, 1))
+	c.Assert(err, check.IsNil)
+	meta, err := decodeOpenAPITaskTemplateValueWithMeta(resp.Kvs[0].Value, &openapi.Task{})
+	c.Assert(err, check.IsNil)
+	c.Assert(meta.ModifiedBy, check.Equals, "alice")
+
+	// put without author.
+	c.Assert(PutOpenAPITaskTemplate(etcdTestCli, task1, true, envelope), check.IsNil)
+	ret, err = GetOpenAPITaskTemplateWithMeta(etcdTestCli, task1.Name)
+	c.Assert(err, check.IsNil)
+	c.Assert(ret.Meta.ModifiedBy, check.Equals, "")
+	c.Assert(ret.Meta.ModifiedAt.IsZero(), check.IsFalse)
+
+	// plain JSON has no metadata.
+	task1JSON, err := task1.ToJSON()
+	c.Assert(err, check.IsNil)
+	_, err = etcdTestCli.Put(context.Background(), key1, string(task1JSON))
+	c.Assert(err, check.IsNil)
+	ret, err = GetOpenAPITaskTemplateWithMeta(etcdTestCli, task1.Name)
+	c.Assert(err, check.IsNil)
+	c.Assert(*ret.Task, check.DeepEquals, task1)
+	c.Assert(ret.Meta, check.IsNil)
+	err = PutOpenAPITaskTemplate(etcdTestCli, task1, false)
+	c.Assert(terror.ErrOpenAPITaskConfigExist.Equal(err), check.IsTrue)
+	fields := err.(*terror.Error).Fields()
+	c.Assert(fields, check.HasLen, 2)
+	c.Assert(fields[OpenAPITaskTemplateFieldTask], check.Equals, task1.Name)
+
+	// the metadata is not written without the envelope.
+	c.Assert(PutOpenAPITaskTemplateWithAuthor(etcdTestCli, task1, true, "alice"), check.IsNil)
+	ret, err = GetOpenAPITaskTemplateWithMeta(etcdTestCli, task1.Name)
+	c.Assert(err, check.IsNil)
+	c.Assert(*ret.Task, check.DeepEquals, task1)
+	c.Assert(ret.Meta, check.IsNil)
+}
+
+func (t *testForEtcd) TestOpenAPITaskTemplateChecksum(c *check.C) {
+	defer clearTestInfoOperation(c)
+	putSourceCfgForOpenAPITaskTest(c)
+
+	task1, err := fixtures.GenNoShardOpenAPITaskForTest()
+	c.Assert(err, check.IsNil)
+	task1.Name = "test-1"
+	key1 := common.OpenAPITaskTemplateKeyAdapter.Encode(task1.Name)
+	envelope := WithOpenAPITaskTemplateEnvelope(true)
+
+	c.Assert(PutOpenAPITaskTemplate(etcdTestCli, task1, false, envelope), check.IsNil)
+	resp, err := etcdTestCli.Get(context.Background(), key1)
+	c.Assert(err, check.IsNil)
+	value := resp.Kvs[0].Value
+	task1InEtcd, err := GetOpenAPITaskTemplate(etcdTestCli, task1.Name)
+	c.Assert(err, check.IsNil)
+	c.Assert(*task1InEtcd, check.DeepEquals, task1)
+
+	// a corrupted value which can still be decoded is detected.
+	corrupted := append([]byte{}, value...)
+	idx := bytes.Index(corrupted, []byte(`"root"`))
+	c.Assert(idx, check.Greater, 0)
+	corrupted[idx+1] = 'R'
+	_, err = etcdTestCli.Put(context.Background(), key1, string(corrupted))
+	c.Assert(err, check.IsNil)
+	_, err = GetOpenAPITaskTemplate(etcdTestCli, task1.Name)
+	c.Assert(terror.ErrOpenAPITaskConfigCorrupt.Equal(err), check.IsTrue)
+	_, err = GetAllOpenAPITaskTemplate(etcdTestCli)
+	c.Assert(terror.ErrOpenAPITaskConfigCorrupt.Equal(err), check.IsTrue)
+
+	// truncated checksum.
+	_, err = etcdTestCli.Put(context.Background(), key1, string(value[:2]))
+	c.Assert(err, check.IsNil)
+	_, err = GetOpenAPITaskTemplate(etcdTestCli, task1.Name)
+	c.Assert(terror.ErrHAInvalidItem.Equal(err), check.IsTrue)
+
+	// unsupported version of the envelope.
+	unsupported := append([]byte{}, value...)
+	unsupported[1] = openAPITaskTemplateEnvelopeVersion + 1
+	_, err = etcdTestCli.Put(context.Background(), key1, string(unsupported))
+	c.Assert(err, check.IsNil)
+	_, err = GetOpenAPITaskTemplate(etcdTestCli, task1.Name)
+	c.Assert(terror.ErrHAInvalidItem.Equal(err), check.IsTrue)
+
+	// plain JSON without checksum is accepted and counted.
+	task1JSON, err := task1.ToJSON()
+	c.Assert(err, check.IsNil)
+	_, err = etcdTestCli.Put(context.Background(), key1, string(task1JSON))
+	c.Assert(err, check.IsNil)
+	legacyCount := readCounterValue(c, openAPITaskTemplateLegacyValueCounter)
+	task1InEtcd, err = GetOpenAPITaskTemplate(etcdTestCli, task1.Name)
+	c.Assert(err, check.IsNil)
+	c.Assert(*task1InEtcd, check.DeepEquals, task1)
+	c.Assert(readCounterValue(c, openAPITaskTemplateLegacyValueCounter), check.Equals, legacyCount+1)
+
+	// the plain JSON is migrated by putting it again in the envelope.
+	c.Assert(PutOpenAPITaskTemplate(etcdTestCli, *task1InEtcd, true, envelope), check.IsNil)
+	_, err = GetOpenAPITaskTemplate(etcdTestCli, task1.Name)
+	c.Assert(err, check.IsNil)
+	c.Assert(readCounterValue(c, openAPITaskTemplateLegacyValueCounter), check.Equals, legacyCount+1)
+}
+
+func (t *testForEtcd) TestCollectOpenAPITaskTemplateSizeMetrics(c *check.C) {
+	defer clearTestInfoOperation(c)
+	putSourceCfgForOpenAPITaskTest(c)
+
+	total, err := CollectOpenAPITaskTemplateSizeMetrics(etcdTestCli)
+	c.Assert(err, check.IsNil)
+	c.Assert(total, check.Equals, int64(0))
+	c.Assert(readGaugeValue(c, openAPITaskTemplateStorageSizeGauge), check.Equals, float64(0))
+
+	task1, err := fixtures.GenNoShardOpenAPITaskForTest()
+	c.Assert(err, check.IsNil)
+	task1.Name = "test-1"
+	task2, err := fixtures.GenShardAndFilterOpenAPITaskForTest()
+	c.Assert(err, check.IsNil)
+	task2.Name = "test-2"
+	c.Assert(PutOpenAPITaskTemplateBatch(etcdTestCli, []openapi.Task{task1, task2}, false), check.IsNil)
+
+	var expected int64
+	for _, name := range []string{task1.Name, task2.Name} {
+		resp, err2 := etcdTestCli.Get(context.Background(), openAPITaskTemplateKey(DefaultOpenAPITaskTemplateNamespace, name))
+		c.Assert(err2, check.IsNil)
+		c.Assert(resp.Kvs, check.HasLen, 1)
+		expected += int64(len(resp.Kvs[0].Value))
+	}
+
+	m := &dto.Metric{}
+	c.Assert(openAPITaskTemplateSizeHist.Write(m), check.IsNil)
+	sampleCount := m.GetHistogram().GetSampleCount()
+	total, err = CollectOpenAPITaskTemplateSizeMetrics(etcdTestCli)
+	c.Assert(err, check.IsNil)
+	c.Assert(total, check.Equals, expected)
+	c.Assert(readGaugeValue(c, openAPITaskTemplateStorageSizeGauge), check.Equals, float64(expected))
+	c.Assert(openAPITaskTemplateSizeHist.Write(m), check.IsNil)
+	c.Assert(m.GetHistogram().GetSampleCount(), check.Equals, sampleCount+2)
+
+	// the gauge is decreased after deleting.
+	c.Assert(DeleteOpenAPITaskTemplate(etcdTestCli, task2.Name), check.IsNil)
+	total, err = CollectOpenAPITaskTemplateSizeMetrics(etcdTestCli)
+	c.Assert(err, check.IsNil)
+	c.Assert(total < expected, check.IsTrue)
+	c.Assert(readGaugeValue(c, openAPITaskTemplateStorageSizeGauge), check.Equals, float64(total))
+}
+
+func readGaugeValue(c *check.C, gauge prometheus.Gauge) float64 {
+	m := &dto.Metric{}
+	c.Assert(gauge.Write(m), check.IsNil)
+	return m.GetGauge().GetValue()
+}
+
+func readCounterValue(c *check.C, counter prometheus.Counter) float64 {
+	m := &dto.Metric{}
+	c.Assert(counter.Write(m), check.IsNil)
+	return m.GetCounter().GetValue()
+}
+
+func (t *testForEtcd) TestSoftDeleteOpenAPITaskTemplate(c *check.C) {
+	defer clearTestInfoOperation(c)
+	putSourceCfgForOpenAPITaskTest(c)
+
+	task1, err := fixtures.GenNoShardOpenAPITaskForTest()
+	c.Assert(err, check.IsNil)
+	task1.Name = "test-1"
+	task2, err := fixtures.GenShardAndFilterOpenAPITaskForTest()
+	c.Assert(err, check.IsNil)
+	task2.Name = "test-2"
+	c.Assert(PutOpenAPITaskTemplateBatch(etcdTestCli, []openapi.Task{task1, task2}, false), check.IsNil)
+
+	// restore a task config which is not deleted.
+	c.Assert(terror.ErrOpenAPITaskConfigNotExist.Equal(RestoreOpenAPITaskTemplate(etcdTestCli, task1.Name)), check.IsTrue)
+
+	// soft-deleted task configs are excluded, and their history versions are deleted.
+	c.Assert(SoftDeleteOpenAPITaskTemplate(etcdTestCli, task1.Name), check.IsNil)
+	task1InEtcd, err := GetOpenAPITaskTemplate(etcdTestCli, task1.Name)
+	c.Assert(err, check.IsNil)
+	c.Assert(task1InEtcd, check.IsNil)
+	tasks, err := GetAllOpenAPITaskTemplate(etcdTestCli)
+	c.Assert(err, check.IsNil)
+	c.Assert(tasks, check.HasLen, 1)
+	versions, err := ListOpenAPITaskTemplateVersions(etcdTestCli, task1.Name)
+	c.Assert(err, check.IsNil)
+	c.Assert(versions, check.HasLen, 0)
+	// soft delete a not exist task config.
+	c.Assert(terror.ErrOpenAPITaskConfigNotExist.Equal(SoftDeleteOpenAPITaskTemplate(etcdTestCli, task1.Name)), check.IsTrue)
+
+	// can't restore if a task config with the same name is put again.
+	task1New := task2
+	task1New.Name = task1.Name
+	c.Assert(PutOpenAPITaskTemplate(etcdTestCli, task1New, false), check.IsNil)
+	c.Assert(terror.ErrOpenAPITaskConfigExist.Equal(RestoreOpenAPITaskTemplate(etcdTestCli, task1.Name)), check.IsTrue)
+	c.Assert(DeleteOpenAPITaskTemplate(etcdTestCli, task1.Name), check.IsNil)
+
+	// restore the soft-deleted task config, a new version is written.
+	c.Assert(RestoreOpenAPITaskTemplate(etcdTestCli, task1.Name), check.IsNil)
+	task1InEtcd, err = GetOpenAPITaskTemplate(etcdTestCli, task1.Name)
+	c.Assert(err, check.IsNil)
+	c.Assert(*task1InEtcd, check.DeepEquals, task1)
+	versions, err = ListOpenAPITaskTemplateVersions(etcdTestCli, task1.Name)
+	c.Assert(err, check.IsNil)
+	c.Assert(versions, check.DeepEquals, []int64{1})
+	c.Assert(terror.ErrOpenAPITaskConfigNotExist.Equal(RestoreOpenAPITaskTemplate(etcdTestCli, task1.Name)), check.IsTrue)
+
+	// purge soft-deleted task configs.
+	c.Assert(SoftDeleteOpenAPITaskTemplate(etcdTestCli, task1.Name), check.IsNil)
+	c.Assert(SoftDeleteOpenAPITaskTemplate(etcdTestCli, task2.Name), check.IsNil)
+	c.Assert(PurgeDeletedOpenAPITaskTemplate(etcdTestCli, time.Hour), check.IsNil)
+	c.Assert(RestoreOpenAPITaskTemplate(etcdTestCli, task2.Name), check.IsNil)
+	c.Assert(SoftDeleteOpenAPITaskTemplate(etcdTestCli, task2.Name), check.IsNil)
+	c.Assert(PurgeDeletedOpenAPITaskTemplate(etcdTestCli, 0), check.IsNil)
+	c.Assert(terror.ErrOpenAPITaskConfigNotExist.Equal(RestoreOpenAPITaskTemplate(etcdTestCli, task1.Name)), check.IsTrue)
+	c.Assert(terror.ErrOpenAPITaskConfigNotExist.Equal(RestoreOpenAPITaskTemplate(etcdTestCli, task2.Name)), check.IsTrue)
+}
+
+func (t *testForEtcd) TestValidateOpenAPITaskTemplate(c *check.C) {
+	defer clearTestInfoOperation(c)
+	putSourceCfgForOpenAPITaskTest(c)
+
+	task1, err := fixtures.GenNoShardOpenAPITaskForTest()
+	c.Assert(err, check.IsNil)
+	task2, err := fixtures.GenShardAndFilterOpenAPITaskForTest()
+	c.Assert(err, check.IsNil)
+	task2.Name = "test-2"
+	validate := WithOpenAPITaskTemplateValidation(true)
+	c.Assert(PutOpenAPITaskTemplateBatch(etcdTestCli, []openapi.Task{task1, task2}, false, validate), check.IsNil)
+
+	// empty name.
+	task3, err := fixtures.GenNoShardOpenAPITaskForTest()
+	c.Assert(err, check.IsNil)
+	task3.Name = ""
+	err = PutOpenAPITaskTemplate(etcdTestCli, task3, false, validate)
+	c.Assert(terror.ErrOpenAPITaskConfigInvalid.Equal(err), check.IsTrue)
+	c.Assert(err, check.ErrorMatches, ".*`name` should not be empty.*")
+
+	// bad task mode.
+	task3.Name = "test-3"
+	task3.TaskMode = "bad-mode"
+	err = PutOpenAPITaskTemplate(etcdTestCli, task3, false, validate)
+	c.Assert(terror.ErrOpenAPITaskConfigInvalid.Equal(err), check.IsTrue)
+	c.Assert(err, check.ErrorMatches, ".*`task_mode` bad-mode is not supported.*")
+
+	// source not exists.
+	task3.TaskMode = openapi.TaskTaskModeAll
+	task3.SourceConfig.SourceConf[0].SourceName = "mysql-replica-not-exist"
+	err = PutOpenAPITaskTemplate(etcdTestCli, task3, false, validate)
+	c.Assert(terror.ErrOpenAPITaskConfigInvalid.Equal(err), check.IsTrue)
+	c.Assert(err, check.ErrorMatches, ".*source mysql-replica-not-exist in `source_config` not exists.*")
+
+	// update is validated too.
+	task1.TaskMode = "bad-mode"
+	err = UpdateOpenAPITaskTemplate(etcdTestCli, task1, validate)
+	c.Assert(terror.ErrOpenAPITaskConfigInvalid.Equal(err), check.IsTrue)
+	task1InEtcd, err := GetOpenAPITaskTemplate(etcdTestCli, task1.Name)
+	c.Assert(err, check.IsNil)
+	c.Assert(task1InEtcd.TaskMode, check.Equals, openapi.TaskTaskModeAll)
+
+	// nothing is put for invalid templates.
+	task3InEtcd, err := GetOpenAPITaskTemplate(etcdTestCli, task3.Name)
+	c.Assert(err, check.IsNil)
+	c.Assert(task3InEtcd, check.IsNil)
+
+	// legacy templates can be put without the validation, which is disabled by default.
+	c.Assert(PutOpenAPITaskTemplate(etcdTestCli, task3, false), check.IsNil)
+	task3InEtcd, err = GetOpenAPITaskTemplate(etcdTestCli, task3.Name)
+	c.Assert(err, check.IsNil)
+	c.Assert(*task3InEtcd, check.DeepEquals, task3)
+}
+
+func (t *testForEtcd) TestValidateOpenAPITaskTemplateProblems(c *check.C) {
+	defer clearTestInfoOperation(c)
+	putSourceCfgForOpenAPITaskTest(c)
+
+	task, err := fixtures.GenShardAndFilterOpenAPITaskForTest()
+	c.Assert(err, check.IsNil)
+	problems, err := ValidateOpenAPITaskTemplate(etcdTestCli, task)
+	c.Assert(err, check.IsNil)
+	c.Assert(problems, check.HasLen, 0)
+
+	// a disabled source can't be started, but the task template can still be put.
+	cfg, err := config.LoadFromFile(sourceSampleFilePath)
+	c.Assert(err, check.IsNil)
+	c.Assert(cfg.From.Security.LoadTLSContent(), check.IsNil)
+	cfg.SourceID = "mysql-replica-02"
+	cfg.Enable = false
+	_, err = PutSourceCfg(etcdTestCli, cfg)
+	c.Assert(err, check.IsNil)
+	problems, err = ValidateOpenAPITaskTemplate(etcdTestCli, task)
+	c.Assert(err, check.IsNil)
+	c.Assert(problems, check.HasLen, 1)
+	c.Assert(problems[0].Field, check.Equals, "source_config.source_conf[1]")
+	c.Assert(problems[0].Message, check.Equals, "source mysql-replica-02 in `source_config` is not enabled")
+	validate := WithOpenAPITaskTemplateValidation(true)
+	c.Assert(PutOpenAPITaskTemplate(etcdTestCli, task, false, validate), check.IsNil)
+
+	// all problems are returned at once.
+	shardMode := openapi.TaskShardModePessimistic
+	task.ShardMode = &shardMode
+	task.SourceConfig.SourceConf = append(task.SourceConfig.SourceConf,
+		openapi.TaskSourceConf{SourceName: "mysql-replica-not-exist"})
+	problems, err = ValidateOpenAPITaskTemplate(etcdTestCli, task)
+	c.Assert(err, check.IsNil)
+	c.Assert(problems, check.HasLen, 4)
+	c.Assert(problems[0].Field, check.Equals, "source_config.source_conf[1]")
+	c.Assert(problems[1].Field, check.Equals, "source_config.source_conf[2]")
+	c.Assert(problems[1].Message, check.Equals, "source mysql-replica-not-exist in `source_config` not exists")
+	for i, problem := range problems[2:] {
+		c.Assert(problem.Field, check.Equals, fmt.Sprintf("source_config.source_conf[%d]", i))
+		c.Assert(problem.Message, check.Matches, ".*cannot enable `strict-optimistic-shard-mode` while `shard-mode` is not `optimistic`.*")
+	}
+	// the task template is not changed.
+	c.Assert(task.SourceConfig.SourceConf, check.HasLen, 3)
+
+	// putting the task template returns the first problem.
+	err = PutOpenAPITaskTemplate(etcdTestCli, task, true, validate)
+	c.Assert(terror.ErrOpenAPITaskConfigInvalid.Equal(err), check.IsTrue)
+	c.Assert(err, check.ErrorMatches, ".*source mysql-replica-not-exist in `source_config` not exists.*")
+}
+
+func (t *testForEtcd) TestExportImportOpenAPITaskTemplates(c *check.C) {
+	defer clearTestInfoOperation(c)
+	putSourceCfgForOpenAPITaskTest(c)
+
+	// export without any templates.
+	data, err := ExportOpenAPITaskTemplates(etcdTestCli)
+	c.Assert(err, check.IsNil)
+	c.Assert(string(data), check.Equals, `{"schema-version":1,"templates":[]}`)
+
+	task1, err := fixtures.GenNoShardOpenAPITaskForTest()
+	c.Assert(err, check.IsNil)
+	task1.Name = "test-1"
+	task2, err := fixtures.GenShardAndFilterOpenAPITaskForTest()
+	c.Assert(err, check.IsNil)
+	task2.Name = "test-2"
+	c.Assert(PutOpenAPITaskTemplateBatch(etcdTestCli, []openapi.Task{task1, task2}, false), check.IsNil)
+
+	data, err = ExportOpenAPITaskTemplates(etcdTestCli)
+	c.Assert(err, check.IsNil)
+
+	// import into a fresh cluster.
+	clearTestInfoOperation(c)
+	putSourceCfgForOpenAPITaskTest(c)
+	c.Assert(ImportOpenAPITaskTemplates(etcdTestCli, data, false), check.IsNil)
+	tasks, err := GetAllOpenAPITaskTemplate(etcdTestCli)
+	c.Assert(err, check.IsNil)
+	c.Assert(tasks, check.HasLen, 2)
+	c.Assert(*tasks[0], check.DeepEquals, task1)
+	c.Assert(*tasks[1], check.DeepEquals, task2)
+
+	// import again without overwrite.
+	err = ImportOpenAPITaskTemplates(etcdTestCli, data, false)
+	c.Assert(terror.ErrOpenAPITaskConfigExist.Equal(err), check.IsTrue)
+	c.Assert(err, check.ErrorMatches, ".*test-1, test-2.*")
+
+	// import again with overwrite.
+	c.Assert(ImportOpenAPITaskTemplates(etcdTestCli, data, true), check.IsNil)
+	versions, err := ListOpenAPITaskTemplateVersions(etcdTestCli, task1.Name)
+	c.Assert(err, check.IsNil)
+	c.Assert(versions, check.DeepEquals, []int64{1, 2})
+
+	// invalid bundles.
+	err = ImportOpenAPITaskTemplates(etcdTestCli, []byte("not a json"), false)
+	c.Assert(terror.ErrHAInvalidItem.Equal(err), check.IsTrue)
+	err = ImportOpenAPITaskTemplates(etcdTestCli, []byte(`{"schema-version":100,"templates":[]}`), false)
+	c.Assert(terror.ErrHAInvalidItem.Equal(err), check.IsTrue)
+	c.Assert(err, check.ErrorMatches, ".*unsupported schema version 100.*")
+}
+
+func (t *testForEtcd) TestOpenAPITaskTemplateNamespace(c *check.C) {
+	defer clearTestInfoOperation(c)
+	putSourceCfgForOpenAPITaskTest(c)
+
+	task1, err := fixtures.GenNoShardOpenAPITaskForTest()
+	c.Assert(err, check.IsNil)
+	task2, err := fixtures.GenShardAndFilterOpenAPITaskForTest()
+	c.Assert(err, check.IsNil)
+	task2.Name = task1.Name
+	ns1, ns2 := "team-1", "team-2"
+
+	// templates with the same name in different namespaces don't collide.
+	c.Assert(PutOpenAPITaskTemplate(etcdTestCli, task1, false), check.IsNil)
+	c.Assert(PutOpenAPITaskTemplateInNamespace(etcdTestCli, ns1, task2, false), check.IsNil)
+	err = PutOpenAPITaskTemplateInNamespace(etcdTestCli, ns1, task2, false)
+	c.Assert(terror.ErrOpenAPITaskConfigExist.Equal(err), check.IsTrue)
+
+	taskInEtcd, err := GetOpenAPITaskTemplate(etcdTestCli, task1.Name)
+	c.Assert(err, check.IsNil)
+	c.Assert(*taskInEtcd, check.DeepEquals, task1)
+	taskInEtcd, err = GetOpenAPITaskTemplateInNamespace(etcdTestCli, ns1, task1.Name)
+	c.Assert(err, check.IsNil)
+	c.Assert(*taskInEtcd, check.DeepEquals, task2)
+	taskInEtcd, err = GetOpenAPITaskTemplateInNamespace(etcdTestCli, ns2, task1.Name)
+	c.Assert(err, check.IsNil)
+	c.Assert(taskInEtcd, check.IsNil)
+
+	// un-namespaced templates are in the default namespace.
+	for _, ns := range []string{"", DefaultOpenAPITaskTemplateNamespace} {
+		taskInEtcd, err = GetOpenAPITaskTemplateInNamespace(etcdTestCli, ns, task1.Name)
+		c.Assert(err, check.IsNil)
+		c.Assert(*taskInEtcd, check.DeepEquals, task1)
+	}
+	tasks, err := GetAllOpenAPITaskTemplate(etcdTestCli)
+	c.Assert(err, check.IsNil)
+	c.Assert(tasks, check.HasLen, 1)
+	c.Assert(*tasks[0], check.DeepEquals, task1)
+	tasks, err = GetAllOpenAPITaskTemplateInNamespace(etcdTestCli, ns1)
+	c.Assert(err, check.IsNil)
+	c.Assert(tasks, check.HasLen, 1)
+	c.Assert(*tasks[0], check.DeepEquals, task2)
+	tasks, err = GetAllOpenAPITaskTemplateInNamespace(etcdTestCli, ns2)
+	c.Assert(err, check.IsNil)
+	c.Assert(tasks, check.HasLen, 0)
+
+	// update and delete only affect the namespace.
+	err = UpdateOpenAPITaskTemplateInNamespace(etcdTestCli, ns2, task2)
+	c.Assert(terror.ErrOpenAPITaskConfigNotExist.Equal(err), check.IsTrue)
+	task2.TaskMode = openapi.TaskTaskModeIncremental
+	c.Assert(UpdateOpenAPITaskTemplateInNamespace(etcdTestCli, ns1, task2), check.IsNil)
+	taskInEtcd, err = GetOpenAPITaskTemplateInNamespace(etcdTestCli, ns1, task1.Name)
+	c.Assert(err, check.IsNil)
+	c.Assert(*taskInEtcd, check.DeepEquals, task2)
+	versions, err := ListOpenAPITaskTemplateVersions(etcdTestCli, task1.Name)
+	c.Assert(err, check.IsNil)
+	c.Assert(versions, check.DeepEquals, []int64{1})
+
+	c.Assert(DeleteOpenAPITaskTemplateInNamespace(etcdTestCli, ns1, task1.Name), check.IsNil)
+	taskInEtcd, err = GetOpenAPITaskTemplateInNamespace(etcdTestCli, ns1, task1.Name)
+	c.Assert(err, check.IsNil)
+	c.Assert(taskInEtcd, check.IsNil)
+	taskInEtcd, err = GetOpenAPITaskTemplate(etcdTestCli, task1.Name)
+	c.Assert(err, check.IsNil)
+	c.Assert(*taskInEtcd, check.DeepEquals, task1)
+}
+
+func (t *testForEtcd) TestOpenAPITaskTemplateTTL(c *check.C) {
+	defer clearTestInfoOperation(c)
+	putSourceCfgForOpenAPITaskTest(c)
+
+	task1, err := fixtures.GenNoShardOpenAPITaskForTest()
+	c.Assert(err, check.IsNil)
+	task1.Name = "test-1"
+	task2, err := fixtures.GenShardAndFilterOpenAPITaskForTest()
+	c.Assert(err, check.IsNil)
+	task2.Name = "test-2"
+	key1 := common.OpenAPITaskTemplateKeyAdapter.Encode(task1.Name)
+
+	err = PutOpenAPITaskTemplateWithTTL(etcdTestCli, task1, false, 0)
+	c.Assert(terror.ErrHAInvalidItem.Equal(err), check.IsTrue)
+
+	var ttl int64 = 10
+	c.Assert(PutOpenAPITaskTemplateWithTTL(etcdTestCli, task1, false, ttl), check.IsNil)
+	c.Assert(PutOpenAPITaskTemplate(etcdTestCli, task2, false), check.IsNil)
+	resp, err := etcdTestCli.Get(context.Background(), key1)
+	c.Assert(err, check.IsNil)
+	c.Assert(resp.Kvs, check.HasLen, 1)
+	leaseID := clientv3.LeaseID(resp.Kvs[0].Lease)
+	c.Assert(leaseID, check.Not(check.Equals), clientv3.NoLease)
+	taskInEtcd, err := GetOpenAPITaskTemplate(etcdTestCli, task1.Name)
+	c.Assert(err, check.IsNil)
+	c.Assert(*taskInEtcd, check.DeepEquals, task1)
+
+	// refresh the lease.
+	remainingTTL, err := RefreshOpenAPITaskTemplateLease(etcdTestCli, task1.Name)
+	c.Assert(err, check.IsNil)
+	c.Assert(remainingTTL, check.Equals, ttl)
+	_, err = RefreshOpenAPITaskTemplateLease(etcdTestCli, task2.Name)
+	c.Assert(terror.ErrHAInvalidItem.Equal(err), check.IsTrue)
+	_, err = RefreshOpenAPITaskTemplateLease(etcdTestCli, "not-exist")
+	c.Assert(terror.ErrOpenAPITaskConfigNotExist.Equal(err), check.IsTrue)
+
+	// failed put doesn't attach lease to the existing template.
+	err = PutOpenAPITaskTemplateWithTTL(etcdTestCli, task2, false, ttl)
+	c.Assert(terror.ErrOpenAPITaskConfigExist.Equal(err), check.IsTrue)
+	_, err = RefreshOpenAPITaskTemplateLease(etcdTestCli, task2.Name)
+	c.Assert(terror.ErrHAInvalidItem.Equal(err), check.IsTrue)
+
+	// the template and its version are removed after the lease expires, revoke it to simulate expiry.
+	_, err = etcdTestCli.Revoke(context.Background(), leaseID)
+	c.Assert(err, check.IsNil)
+	taskInEtcd, err = GetOpenAPITaskTemplate(etcdTestCli, task1.Name)
+	c.Assert(err, check.IsNil)
+	c.Assert(taskInEtcd, check.IsNil)
+	versions, err := ListOpenAPITaskTemplateVersions(etcdTestCli, task1.Name)
+	c.Assert(err, check.IsNil)
+	c.Assert(versions, check.HasLen, 0)
+	taskInEtcd, err = GetOpenAPITaskTemplate(etcdTestCli, task2.Name)
+	c.Assert(err, check.IsNil)
+	c.Assert(*taskInEtcd, check.DeepEquals, task2)
+
+	// put without TTL makes it permanent.
+	c.Assert(PutOpenAPITaskTemplateWithTTL(etcdTestCli, task1, false, ttl), check.IsNil)
+	c.Assert(PutOpenAPITaskTemplate(etcdTestCli, task1, true), check.IsNil)
+	_, err = RefreshOpenAPITaskTemplateLease(etcdTestCli, task1.Name)
+	c.Assert(terror.ErrHAInvalidItem.Equal(err), check.IsTrue)
+}
+
+func (t *testForEtcd) TestOpenAPITaskTemplateName(c *check.C) {
+	defer clearTestInfoOperation(c)
+	putSourceCfgForOpenAPITaskTest(c)
+
+	task1, err := fixtures.GenNoShardOpenAPITaskForTest()
+	c.Assert(err, check.IsNil)
+	task2, err := fixtures.GenNoShardOpenAPITaskForTest()
+	c.Assert(err, check.IsNil)
+
+	for _, name := range []string{"a/b", "test/", "a b", " test", "test\t", "测试", "test-é", "test.1"} {
+		task1.Name = name
+		err = PutOpenAPITaskTemplate(etcdTestCli, task1, false)
+		c.Assert(terror.ErrOpenAPITaskConfigInvalid.Equal(err), check.IsTrue, check.Commentf("%q", name))
+		c.Assert(err, check.ErrorMatches, ".*`name` contains invalid character.*")
+		c.Assert(terror.ErrOpenAPITaskConfigInvalid.Equal(
+			MergeOpenAPITaskTemplate(etcdTestCli, task1, OpenAPITaskTemplateSliceReplace)), check.IsTrue)
+		c.Assert(terror.ErrOpenAPITaskConfigInvalid.Equal(
+			ReplaceAllOpenAPITaskTemplates(etcdTestCli, []openapi.Task{task1})), check.IsTrue)
+		// the names of existing task configs are not checked, so they are updated as usual.
+		c.Assert(terror.ErrOpenAPITaskConfigNotExist.Equal(UpdateOpenAPITaskTemplate(etcdTestCli, task1)), check.IsTrue)
+		c.Assert(terror.ErrOpenAPITaskConfigNotExist.Equal(
+			CompareAndUpdateOpenAPITaskTemplate(etcdTestCli, task1, 1)), check.IsTrue)
+		// the whole batch is rejected.
+		task2.Name = "test-2"
+		err = PutOpenAPITaskTemplateBatch(etcdTestCli, []openapi.Task{task2, task1}, false)
+		c.Assert(terror.ErrOpenAPITaskConfigInvalid.Equal(err), check.IsTrue)
+	}
+	task1.Name = ""
+	err = PutOpenAPITaskTemplate(etcdTestCli, task1, true)
+	c.Assert(err, check.ErrorMatches, ".*`name` should not be empty.*")
+	tasks, err := GetAllOpenAPITaskTemplate(etcdTestCli)
+	c.Assert(err, check.IsNil)
+	c.Assert(tasks, check.HasLen, 0)
+
+	// valid names.
+	for _, name := range []string{"test", "test-1", "test_1", "Test-Task_01", "41f5adfd9e2b"} {
+		task1.Name = name
+		c.Assert(PutOpenAPITaskTemplate(etcdTestCli, task1, false), check.IsNil)
+		c.Assert(UpdateOpenAPITaskTemplate(etcdTestCli, task1), check.IsNil)
+	}
+	tasks, err = GetAllOpenAPITaskTemplate(etcdTestCli)
+	c.Assert(err, check.IsNil)
+	c.Assert(tasks, check.HasLen, 5)
+}
+
+func (t *testForEtcd) TestUpdateLegacyOpenAPITaskTemplateName(c *check.C) {
+	defer clearTestInfoOperation(c)
+	putSourceCfgForOpenAPITaskTest(c)
+
+	// `task.v1` is a valid DM task name, the template is stored before the name is checked.
+	task1, err := fixtures.GenNoShardOpenAPITaskForTest()
+	c.Assert(err, check.IsNil)
+	task1.Name = "task.v1"
+	value, err := encodeOpenAPITaskTemplateValue(task1, OpenAPITaskTemplateMeta{}, false)
+	c.Assert(err, check.IsNil)
+	_, err = etcdTestCli.Put(context.Background(), openAPITaskTemplateKey(DefaultOpenAPITaskTemplateNamespace, task1.Name), value)
+	c.Assert(err, check.IsNil)
+
+	task1.TaskMode = openapi.TaskTaskModeFull
+	c.Assert(UpdateOpenAPITaskTemplate(etcdTestCli, task1), check.IsNil)
+	c.Assert(UpdateOpenAPITaskTemplateWithAuthor(etcdTestCli, task1, "alice"), check.IsNil)
+	c.Assert(MergeOpenAPITaskTemplate(etcdTestCli, openapi.Task{Name: task1.Name, TaskMode: openapi.TaskTaskModeIncremental},
+		OpenAPITaskTemplateSliceReplace), check.IsNil)
+	task1.TaskMode = openapi.TaskTaskModeIncremental
+	_, revision, err := GetOpenAPITaskTemplateWithRevision(etcdTestCli, task1.Name)
+	c.Assert(err, check.IsNil)
+	task1.TaskMode = openapi.TaskTaskModeAll
+	c.Assert(CompareAndUpdateOpenAPITaskTemplate(etcdTestCli, task1, revision), check.IsNil)
+	c.Assert(PutOpenAPITaskTemplate(etcdTestCli, task1, true), check.IsNil)
+	c.Assert(ReplaceAllOpenAPITaskTemplates(etcdTestCli, []openapi.Task{task1}), check.IsNil)
+	taskInEtcd, err := GetOpenAPITaskTemplate(etcdTestCli, task1.Name)
+	c.Assert(err, check.IsNil)
+	c.Assert(*taskInEtcd, check.DeepEquals, task1)
+
+	// but it can't be created again, or be the target of renaming.
+	c.Assert(terror.ErrOpenAPITaskConfigInvalid.Equal(RenameOpenAPITaskTemplate(etcdTestCli, task1.Name, "task.v2")), check.IsTrue)
+	c.Assert(DeleteOpenAPITaskTemplate(etcdTestCli, task1.Name), check.IsNil)
+	c.Assert(terror.ErrOpenAPITaskConfigInvalid.Equal(PutOpenAPITaskTemplate(etcdTestCli, task1, false)), check.IsTrue)
+}
+
+func (t *testForEtcd) TestCountOpenAPITaskTemplates(c *check.C) {
+	defer clearTestInfoOperation(c)
+	putSourceCfgForOpenAPITaskTest(c)
+
+	count, err := CountOpenAPITaskTemplates(etcdTestCli)
+	c.Assert(err, check.IsNil)
+	c.Assert(count, check.Equals, int64(0))
+
+	task1, err := fixtures.GenNoShardOpenAPITaskForTest()
+	c.Assert(err, check.IsNil)
+	task1.Name = "test-1"
+	task2, err := fixtures.GenShardAndFilterOpenAPITaskForTest()
+	c.Assert(err, check.IsNil)
+	task2.Name = "test-2"
+	c.Assert(PutOpenAPITaskTemplateBatch(etcdTestCli, []openapi.Task{task1, task2}, false), check.IsNil)
+	// history versions and templates in other namespaces are not counted.
+	c.Assert(UpdateOpenAPITaskTemplate(etcdTestCli, task1), check.IsNil)
+	c.Assert(PutOpenAPITaskTemplateInNamespace(etcdTestCli, "ns", task1, false), check.IsNil)
+	count, err = CountOpenAPITaskTemplates(etcdTestCli)
+	c.Assert(err, check.IsNil)
+	c.Assert(count, check.Equals, int64(2))
+
+	// soft-deleted templates are not counted.
+	c.Assert(SoftDeleteOpenAPITaskTemplate(etcdTestCli, task1.Name), check.IsNil)
+	count, err = CountOpenAPITaskTemplates(etcdTestCli)
+	c.Assert(err, check.IsNil)
+	c.Assert(count, check.Equals, int64(1))
+	c.Assert(DeleteOpenAPITaskTemplate(etcdTestCli, task2.Name), check.IsNil)
+	count, err = CountOpenAPITaskTemplates(etcdTestCli)
+	c.Assert(err, check.IsNil)
+	c.Assert(count, check.Equals, int64(0))
+}
+
+func (t *testForEtcd) TestLookupOpenAPITaskTemplate(c *check.C) {
+	defer clearTestInfoOperation(c)
+	putSourceCfgForOpenAPITaskTest(c)
+
+	task, err := fixtures.GenNoShardOpenAPITaskForTest()
+	c.Assert(err, check.IsNil)
+	got, found, err := LookupOpenAPITaskTemplate(etcdTestCli, task.Name)
+	c.Assert(err, check.IsNil)
+	c.Assert(found, check.IsFalse)
+	c.Assert(got, check.IsNil)
+
+	c.Assert(PutOpenAPITaskTemplate(etcdTestCli, task, false), check.IsNil)
+	got, found, err = LookupOpenAPITaskTemplate(etcdTestCli, task.Name)
+	c.Assert(err, check.IsNil)
+	c.Assert(found, check.IsTrue)
+	c.Assert(*got, check.DeepEquals, task)
+
+	// the template may be deleted between listing and getting.
+	c.Assert(DeleteOpenAPITaskTemplate(etcdTestCli, task.Name), check.IsNil)
+	got, found, err = LookupOpenAPITaskTemplate(etcdTestCli, task.Name)
+	c.Assert(err, check.IsNil)
+	c.Assert(found, check.IsFalse)
+	c.Assert(got, check.IsNil)
+}
+
+func (t *testForEtcd) TestRenameOpenAPITaskTemplate(c *check.C) {
+	defer clearTestInfoOperation(c)
+	putSourceCfgForOpenAPITaskTest(c)
+
+	task1, err := fixtures.GenNoShardOpenAPITaskForTest()
+	c.Assert(err, check.IsNil)
+	task1.Name = "test-1"
+	task2, err := fixtures.GenShardAndFilterOpenAPITaskForTest()
+	c.Assert(err, check.IsNil)
+	task2.Name = "test-2"
+	c.Assert(PutOpenAPITaskTemplateBatch(etcdTestCli, []openapi.Task{task1, task2}, false), check.IsNil)
+
+	// the old one is missing.
+	err = RenameOpenAPITaskTemplate(etcdTestCli, "not-exist", "test-3")
+	c.Assert(terror.ErrOpenAPITaskConfigNotExist.Equal(err), check.IsTrue)
+	// the new one already exists, nothing is changed.
+	err = RenameOpenAPITaskTemplate(etcdTestCli, task1.Name, task2.Name)
+	c.Assert(terror.ErrOpenAPITaskConfigExist.Equal(err), check.IsTrue)
+	err = RenameOpenAPITaskTemplate(etcdTestCli, task1.Name, task1.Name)
+	c.Assert(terror.ErrHAInvalidItem.Equal(err), check.IsTrue)
+	err = RenameOpenAPITaskTemplate(etcdTestCli, task1.Name, "test/3")
+	c.Assert(terror.ErrOpenAPITaskConfigInvalid.Equal(err), check.IsTrue)
+	tasks, err := GetAllOpenAPITaskTemplate(etcdTestCli)
+	c.Assert(err, check.IsNil)
+	c.Assert(tasks, check.HasLen, 2)
+	c.Assert(*tasks[0], check.DeepEquals, task1)
+	c.Assert(*tasks[1], check.DeepEquals, task2)
+
+	c.Assert(RenameOpenAPITaskTemplate(etcdTestCli, task1.Name, "test-3"), check.IsNil)
+	taskInEtcd, err := GetOpenAPITaskTemplate(etcdTestCli, task1.Name)
+	c.Assert(err, check.IsNil)
+	c.Assert(taskInEtcd, check.IsNil)
+	taskInEtcd, err = GetOpenAPITaskTemplate(etcdTestCli, "test-3")
+	c.Assert(err, check.IsNil)
+	task3 := task1
+	task3.Name = "test-3"
+	c.Assert(*taskInEtcd, check.DeepEquals, task3)
+	// the history versions of the old one are deleted, and a version of the new one is written.
+	versions, err := ListOpenAPITaskTemplateVersions(etcdTestCli, task1.Name)
+	c.Assert(err, check.IsNil)
+	c.Assert(versions, check.HasLen, 0)
+	versions, err = ListOpenAPITaskTemplateVersions(etcdTestCli, task3.Name)
+	c.Assert(err, check.IsNil)
+	c.Assert(versions, check.DeepEquals, []int64{1})
+
+	// the renamed one is missing now.
+	err = RenameOpenAPITaskTemplate(etcdTestCli, task1.Name, "test-4")
+	c.Assert(terror.ErrOpenAPITaskConfigNotExist.Equal(err), check.IsTrue)
+	count, err := CountOpenAPITaskTemplates(etcdTestCli)
+	c.Assert(err, check.IsNil)
+	c.Assert(count, check.Equals, int64(2))
+}
+
+func (t *testForEtcd) TestOpenAPITaskTemplateLabels(c *check.C) {
+	defer clearTestInfoOperation(c)
+	putSourceCfgForOpenAPITaskTest(c)
+
+	task1, err := fixtures.GenNoShardOpenAPITaskForTest()
+	c.Assert(err, check.IsNil)
+	task1.Name = "test-1"
+	task2, err := fixtures.GenShardAndFilterOpenAPITaskForTest()
+	c.Assert(err, check.IsNil)
+	task2.Name = "test-2"
+	task3 := task1
+	task3.Name = "test-3"
+	c.Assert(PutOpenAPITaskTemplateBatch(etcdTestCli, []openapi.Task{task1, task2, task3}, false), check.IsNil)
+
+	// labels of a missing task config can't be set.
+	err = SetOpenAPITaskTemplateLabels(etcdTestCli, "not-exist", map[string]string{"env": "prod"})
+	c.Assert(terror.ErrOpenAPITaskConfigNotExist.Equal(err), check.IsTrue)
+	err = SetOpenAPITaskTemplateLabels(etcdTestCli, task1.Name, map[string]string{"": "prod"})
+	c.Assert(terror.ErrHAInvalidItem.Equal(err), check.IsTrue)
+	labels, err := GetOpenAPITaskTemplateLabels(etcdTestCli, task1.Name)
+	c.Assert(err, check.IsNil)
+	c.Assert(labels, check.IsNil)
+
+	c.Assert(SetOpenAPITaskTemplateLabels(etcdTestCli, task3.Name, map[string]string{"env": "prod"}), check.IsNil)
+	c.Assert(SetOpenAPITaskTemplateLabels(etcdTestCli, task2.Name, map[string]string{"env": "test"}), check.IsNil)
+	c.Assert(SetOpenAPITaskTemplateLabels(etcdTestCli, task1.Name, map[string]string{"env": "prod", "team": "a"}), check.IsNil)
+	labels, err = GetOpenAPITaskTemplateLabels(etcdTestCli, task1.Name)
+	c.Assert(err, check.IsNil)
+	c.Assert(labels, check.DeepEquals, map[string]string{"env": "prod", "team": "a"})
+
+	tasks, err := ListOpenAPITaskTemplatesByLabel(etcdTestCli, "env", "prod")
+	c.Assert(err, check.IsNil)
+	c.Assert(tasks, check.HasLen, 2)
+	c.Assert(*tasks[0], check.DeepEquals, task1)
+	c.Assert(*tasks[1], check.DeepEquals, task3)
+	tasks, err = ListOpenAPITaskTemplatesByLabel(etcdTestCli, "team", "b")
+	c.Assert(err, check.IsNil)
+	c.Assert(tasks, check.HasLen, 0)
+
+	// the labels are replaced, and empty labels remove all of them.
+	c.Assert(SetOpenAPITaskTemplateLabels(etcdTestCli, task2.Name, map[string]string{"team": "a"}), check.IsNil)
+	tasks, err = ListOpenAPITaskTemplatesByLabel(etcdTestCli, "team", "a")
+	c.Assert(err, check.IsNil)
+	c.Assert(tasks, check.HasLen, 2)
+	c.Assert(*tasks[0], check.DeepEquals, task1)
+	c.Assert(*tasks[1], check.DeepEquals, task2)
+	c.Assert(SetOpenAPITaskTemplateLabels(etcdTestCli, task2.Name, nil), check.IsNil)
+	labels, err = GetOpenAPITaskTemplateLabels(etcdTestCli, task2.Name)
+	c.Assert(err, check.IsNil)
+	c.Assert(labels, check.IsNil)
+
+	// the labels are moved by renaming.
+	c.Assert(RenameOpenAPITaskTemplate(etcdTestCli, task1.Name, "test-4"), check.IsNil)
+	labels, err = GetOpenAPITaskTemplateLabels(etcdTestCli, task1.Name)
+	c.Assert(err, check.IsNil)
+	c.Assert(labels, check.IsNil)
+	labels, err = GetOpenAPITaskTemplateLabels(etcdTestCli, "test-4")
+	c.Assert(err, check.IsNil)
+	c.Assert(labels, check.DeepEquals, map[string]string{"env": "prod", "team": "a"})
+
+	// the labels are deleted with the task config, a new task config of the same name has no labels.
+	c.Assert(DeleteOpenAPITaskTemplate(etcdTestCli, task3.Name), check.IsNil)
+	labels, err = GetOpenAPITaskTemplateLabels(etcdTestCli, task3.Name)
+	c.Assert(err, check.IsNil)
+	c.Assert(labels, check.IsNil)
+	c.Assert(PutOpenAPITaskTemplate(etcdTestCli, task3, false), check.IsNil)
+	tasks, err = ListOpenAPITaskTemplatesByLabel(etcdTestCli, "env", "prod")
+	c.Assert(err, check.IsNil)
+	c.Assert(tasks, check.HasLen, 1)
+	c.Assert(tasks[0].Name, check.Equals, "test-4")
+
+	_, err = DeleteOpenAPITaskTemplateBatch(etcdTestCli, []string{"test-4"})
+	c.Assert(err, check.IsNil)
+	labels, err = GetOpenAPITaskTemplateLabels(etcdTestCli, "test-4")
+	c.Assert(err, check.IsNil)
+	c.Assert(labels, check.IsNil)
+}
+
+func (t *testForEtcd) TestGetOpenAPITaskTemplatesByNames(c *check.C) {
+	defer clearTestInfoOperation(c)
+	putSourceCfgForOpenAPITaskTest(c)
+
+	task1, err := fixtures.GenNoShardOpenAPITaskForTest()
+	c.Assert(err, check.IsNil)
+	task1.Name = "test-1"
+	task2, err := fixtures.GenShardAndFilterOpenAPITaskForTest()
+	c.Assert(err, check.IsNil)
+	task2.Name = "test-2"
+	task3 := task1
+	task3.Name = "test-3"
+	c.Assert(PutOpenAPITaskTemplateBatch(etcdTestCli, []openapi.Task{task1, task2, task3}, false), check.IsNil)
+
+	tasks, err := GetOpenAPITaskTemplatesByNames(etcdTestCli, nil)
+	c.Assert(err, check.IsNil)
+	c.Assert(tasks, check.HasLen, 0)
+
+	// missing names are omitted, and duplicate names are fetched once.
+	tasks, err = GetOpenAPITaskTemplatesByNames(etcdTestCli, []string{"not-exist", task3.Name, task1.Name, task3.Name})
+	c.Assert(err, check.IsNil)
+	c.Assert(tasks, check.HasLen, 2)
+	c.Assert(*tasks[task1.Name], check.DeepEquals, task1)
+	c.Assert(*tasks[task3.Name], check.DeepEquals, task3)
+
+	tasks, err = GetOpenAPITaskTemplatesByNames(etcdTestCli, []string{"not-exist"})
+	c.Assert(err, check.IsNil)
+	c.Assert(tasks, check.HasLen, 0)
+}
+
+func (t *testForEtcd) TestDeleteOpenAPITaskTemplateBatch(c *check.C) {
+	defer clearTestInfoOperation(c)
+	putSourceCfgForOpenAPITaskTest(c)
+
+	task1, err := fixtures.GenNoShardOpenAPITaskForTest()
+	c.Assert(err, check.IsNil)
+	task1.Name = "test-1"
+	task2, err := fixtures.GenShardAndFilterOpenAPITaskForTest()
+	c.Assert(err, check.IsNil)
+	task2.Name = "test-2"
+	task3 := task1
+	task3.Name = "test-3"
+	c.Assert(PutOpenAPITaskTemplateBatch(etcdTestCli, []openapi.Task{task1, task2, task3}, false), check.IsNil)
+
+	// duplicate names are rejected and nothing is deleted.
+	_, err = DeleteOpenAPITaskTemplateBatch(etcdTestCli, []string{task1.Name, task1.Name})
+	c.Assert(terror.ErrHAInvalidItem.Equal(err), check.IsTrue)
+	count, err := CountOpenAPITaskTemplates(etcdTestCli)
+	c.Assert(err, check.IsNil)
+	c.Assert(count, check.Equals, int64(3))
+
+	// the existing ones are deleted, and the missing ones are reported.
+	notExistNames, err := DeleteOpenAPITaskTemplateBatch(etcdTestCli, []string{"not-exist-1", task1.Name, task2.Name, "not-exist-2"})
+	c.Assert(err, check.IsNil)
+	c.Assert(notExistNames, check.DeepEquals, []string{"not-exist-1", "not-exist-2"})
+	tasks, err := GetAllOpenAPITaskTemplate(etcdTestCli)
+	c.Assert(err, check.IsNil)
+	c.Assert(tasks, check.HasLen, 1)
+	c.Assert(*tasks[0], check.DeepEquals, task3)
+	// the history versions of the deleted ones are deleted too.
+	for name, count := range map[string]int{task1.Name: 0, task2.Name: 0, task3.Name: 1} {
+		versions, err2 := ListOpenAPITaskTemplateVersions(etcdTestCli, name)
+		c.Assert(err2, check.IsNil)
+		c.Assert(versions, check.HasLen, count)
+	}
+
+	notExistNames, err = DeleteOpenAPITaskTemplateBatch(etcdTestCli, []string{task3.Name})
+	c.Assert(err, check.IsNil)
+	c.Assert(notExistNames, check.HasLen, 0)
+	count, err = CountOpenAPITaskTemplates(etcdTestCli)
+	c.Assert(err, check.IsNil)
+	c.Assert(count, check.Equals, int64(0))
+}
+
+func (t *testForEtcd) TestDeleteOpenAPITaskTemplateByPrefix(c *check.C) {
+	defer clearTestInfoOperation(c)
+	putSourceCfgForOpenAPITaskTest(c)
+
+	task, err := fixtures.GenNoShardOpenAPITaskForTest()
+	c.Assert(err, check.IsNil)
+	names := []string{"feature-a-1", "feature-a-2", "feature-ab-1", "feature-b-1", "other"}
+	tasks := make([]openapi.Task, 0, len(names))
+	for _, name := range names {
+		task.Name = name
+		tasks = append(tasks, task)
+	}
+	c.Assert(PutOpenAPITaskTemplateBatch(etcdTestCli, tasks, false), check.IsNil)
+	c.Assert(PutOpenAPITaskTemplateInNamespace(etcdTestCli, "ns", tasks[0], false), check.IsNil)
+	listNames := func() []string {
+		tasksInEtcd, err2 := GetAllOpenAPITaskTemplate(etcdTestCli)
+		c.Assert(err2, check.IsNil)
+		ret := make([]string, 0, len(tasksInEtcd))
+		for _, taskInEtcd := range tasksInEtcd {
+			ret = append(ret, taskInEtcd.Name)
+		}
+		sort.Strings(ret)
+		return ret
+	}
+
+	// an empty prefix is rejected without deleteAll.
+	_, err = DeleteOpenAPITaskTemplateByPrefix(etcdTestCli, "", false)
+	c.Assert(terror.ErrHAInvalidItem.Equal(err), check.IsTrue)
+	c.Assert(listNames(), check.DeepEquals, names)
+
+	// "feature-a-" doesn't match "feature-ab-1".
+	deleted, err := DeleteOpenAPITaskTemplateByPrefix(etcdTestCli, "feature-a-", false)
+	c.Assert(err, check.IsNil)
+	c.Assert(deleted, check.Equals, int64(2))
+	c.Assert(listNames(), check.DeepEquals, []string{"feature-ab-1", "feature-b-1", "other"})
+	// the history versions of the deleted ones are deleted too.
+	for _, name := range names {
+		versions, err2 := ListOpenAPITaskTemplateVersions(etcdTestCli, name)
+		c.Assert(err2, check.IsNil)
+		if strings.HasPrefix(name, "feature-a-") {
+			c.Assert(versions, check.HasLen, 0)
+		} else {
+			c.Assert(versions, check.HasLen, 1)
+		}
+	}
+	deleted, err = DeleteOpenAPITaskTemplateByPrefix(etcdTestCli, "feature-a", false)
+	c.Assert(err, check.IsNil)
+	c.Assert(deleted, check.Equals, int64(1))
+	c.Assert(listNames(), check.DeepEquals, []string{"feature-b-1", "other"})
+	deleted, err = DeleteOpenAPITaskTemplateByPrefix(etcdTestCli, "feature-a", false)
+	c.Assert(err, check.IsNil)
+	c.Assert(deleted, check.Equals, int64(0))
+
+	// deleteAll deletes all templates in the default namespace only.
+	deleted, err = DeleteOpenAPITaskTemplateByPrefix(etcdTestCli, "", true)
+	c.Assert(err, check.IsNil)
+	c.Assert(deleted, check.Equals, int64(2))
+	c.Assert(listNames(), check.HasLen, 0)
+	tasksInNamespace, err := GetAllOpenAPITaskTemplateInNamespace(etcdTestCli, "ns")
+	c.Assert(err, check.IsNil)
+	c.Assert(tasksInNamespace, check.HasLen, 1)
+}
+
+func (t *testForEtcd) TestReplaceAllOpenAPITaskTemplates(c *check.C) {
+	defer clearTestInfoOperation(c)
+	putSourceCfgForOpenAPITaskTest(c)
+
+	task, err := fixtures.GenNoShardOpenAPITaskForTest()
+	c.Assert(err, check.IsNil)
+	genTasks := func(names ...string) []openapi.Task {
+		tasks := make([]openapi.Task, 0, len(names))
+		for _, name := range names {
+			task.Name = name
+			tasks = append(tasks, task)
+		}
+		return tasks
+	}
+	listNames := func() []string {
+		tasksInEtcd, err2 := GetAllOpenAPITaskTemplate(etcdTestCli)
+		c.Assert(err2, check.IsNil)
+		ret := make([]string, 0, len(tasksInEtcd))
+		for _, taskInEtcd := range tasksInEtcd {
+			ret = append(ret, taskInEtcd.Name)
+		}
+		sort.Strings(ret)
+		return ret
+	}
+	oldNames := []string{"old-1", "old-2", "shared"}
+	newNames := []string{"new-1", "shared"}
+	oldTasks, newTasks := genTasks(oldNames...), genTasks(newNames...)
+
+	c.Assert(PutOpenAPITaskTemplateBatch(etcdTestCli, oldTasks, false), check.IsNil)
+	c.Assert(SetOpenAPITaskTemplateLabels(etcdTestCli, "shared", map[string]string{"env": "prod"}), check.IsNil)
+
+	// duplicated names are rejected and nothing is changed.
+	err = ReplaceAllOpenAPITaskTemplates(etcdTestCli, genTasks("new-1", "new-1"))
+	c.Assert(terror.ErrHAInvalidItem.Equal(err), check.IsTrue)
+	c.Assert(listNames(), check.DeepEquals, oldNames)
+
+	// the old templates and their labels are replaced by the new ones.
+	c.Assert(ReplaceAllOpenAPITaskTemplates(etcdTestCli, newTasks), check.IsNil)
+	c.Assert(listNames(), check.DeepEquals, newNames)
+	shared, err := GetOpenAPITaskTemplate(etcdTestCli, "shared")
+	c.Assert(err, check.IsNil)
+	c.Assert(*shared, check.DeepEquals, newTasks[1])
+	labels, err := GetOpenAPITaskTemplateLabels(etcdTestCli, "shared")
+	c.Assert(err, check.IsNil)
+	c.Assert(labels, check.IsNil)
+	// the overwritten template gets a new version.
+	versions, err := ListOpenAPITaskTemplateVersions(etcdTestCli, "shared")
+	c.Assert(err, check.IsNil)
+	c.Assert(versions, check.HasLen, 2)
+	versions, err = ListOpenAPITaskTemplateVersions(etcdTestCli, "new-1")
+	c.Assert(err, check.IsNil)
+	c.Assert(versions, check.HasLen, 1)
+	// the deleted templates lose their history versions.
+	versions, err = ListOpenAPITaskTemplateVersions(etcdTestCli, "old-1")
+	c.Assert(err, check.IsNil)
+	c.Assert(versions, check.HasLen, 0)
+
+	// a concurrent reader sees either the old set or the new set, never a mix of them.
+	ctx, cancel := context.WithCancel(context.Background())
+	readerDone := make(chan struct{})
+	go func() {
+		defer close(readerDone)
+		for ctx.Err() == nil {
+			names := listNames()
+			if !(fmt.Sprint(names) == fmt.Sprint(oldNames) || fmt.Sprint(names) == fmt.Sprint(newNames)) {
+				c.Errorf("read a partial set of templates %v", names)
+				return
+			}
+		}
+	}()
+	for i := 0; i < 10; i++ {
+		c.Assert(ReplaceAllOpenAPITaskTemplates(etcdTestCli, oldTasks), check.IsNil)
+		c.Assert(ReplaceAllOpenAPITaskTemplates(etcdTestCli, newTasks), check.IsNil)
+	}
+	cancel()
+	<-readerDone
+	c.Assert(listNames(), check.DeepEquals, newNames)
+
+	// an empty snapshot deletes all templates in the default namespace.
+	c.Assert(ReplaceAllOpenAPITaskTemplates(etcdTestCli, nil), check.IsNil)
+	c.Assert(listNames(), check.HasLen, 0)
+	versions, err = ListOpenAPITaskTemplateVersions(etcdTestCli, "shared")
+	c.Assert(err, check.IsNil)
+	c.Assert(versions, check.HasLen, 0)
+}
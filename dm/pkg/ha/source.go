@@ -135,8 +135,15 @@ func ClearTestInfoOperation(cli *clientv3.Client) error {
 	clearSubTaskStage := clientv3.OpDelete(common.StageSubTaskKeyAdapter.Path(), clientv3.WithPrefix())
 	clearValidatorStage := clientv3.OpDelete(common.StageValidatorKeyAdapter.Path(), clientv3.WithPrefix())
 	clearLoadTasks := clientv3.OpDelete(common.LoadTaskKeyAdapter.Path(), clientv3.WithPrefix())
+	clearTaskTemplates := clientv3.OpDelete(common.OpenAPITaskTemplateKeyAdapter.Path(), clientv3.WithPrefix())
+	clearTaskTemplateVersions := clientv3.OpDelete(common.OpenAPITaskTemplateVersionKeyAdapter.Path(), clientv3.WithPrefix())
+	clearDeletedTaskTemplates := clientv3.OpDelete(common.OpenAPITaskTemplateDeletedKeyAdapter.Path(), clientv3.WithPrefix())
+	clearNamespaceTaskTemplates := clientv3.OpDelete(common.OpenAPITaskTemplateNamespaceKeyAdapter.Path(), clientv3.WithPrefix())
+	clearNamespaceTaskTemplateVersions := clientv3.OpDelete(common.OpenAPITaskTemplateNamespaceVersionKeyAdapter.Path(), clientv3.WithPrefix())
+	clearTaskTemplateLabels := clientv3.OpDelete(common.OpenAPITaskTemplateLabelsKeyAdapter.Path(), clientv3.WithPrefix())
 	_, _, err := etcdutil.DoTxnWithRepeatable(cli, etcdutil.ThenOpFunc(clearSource, clearSubTask, clearWorkerInfo,
 		clearBound, clearLastBound, clearWorkerKeepAlive, clearRelayStage, clearRelayConfig, clearSubTaskStage,
-		clearValidatorStage, clearLoadTasks))
+		clearValidatorStage, clearLoadTasks, clearTaskTemplates, clearTaskTemplateVersions, clearDeletedTaskTemplates,
+		clearNamespaceTaskTemplates, clearNamespaceTaskTemplateVersions, clearTaskTemplateLabels))
 	return err
 }
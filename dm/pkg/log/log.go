@@ -16,6 +16,7 @@ package log
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 	"sync/atomic"
 
@@ -172,6 +173,26 @@ func ShortError(err error) zap.Field {
 	return zap.String("error", err.Error())
 }
 
+// ErrorFields constructs fields from the named fields attached to the error by
+// `terror.(*Error).WithFields`, so they are emitted as separate keys. Fields are
+// sorted by name. It's usually used together with `ShortError`.
+func ErrorFields(err error) []zap.Field {
+	fields := terror.Fields(err)
+	if len(fields) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	zapFields := make([]zap.Field, 0, len(keys))
+	for _, k := range keys {
+		zapFields = append(zapFields, zap.Any(k, fields[k]))
+	}
+	return zapFields
+}
+
 // L returns the current logger for DM.
 func L() Logger {
 	return appLogger
@@ -27,6 +27,7 @@ import (
 	pclog "github.com/pingcap/log"
 	lightningLog "github.com/pingcap/tidb/pkg/lightning/log"
 	"github.com/pingcap/tidb/pkg/util/logutil"
+	"github.com/pingcap/tiflow/dm/pkg/terror"
 	"github.com/pingcap/tiflow/pkg/version"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
@@ -48,6 +49,16 @@ func TestTestLogger(t *testing.T) {
 	require.Empty(t, buffer.Stripped())
 }
 
+func TestErrorFields(t *testing.T) {
+	logger, buffer := makeTestLogger()
+	require.Nil(t, ErrorFields(nil))
+	require.Nil(t, ErrorFields(errors.New("plain error")))
+
+	err := terror.ErrSyncerUnitGenBAList.WithFields(map[string]interface{}{"task": "t1", "source": "s1"}).Generate()
+	logger.Warn("the message", ErrorFields(err)...)
+	require.Equal(t, `{"$lvl":"WARN","$msg":"the message","source":"s1","task":"t1"}`, buffer.Stripped())
+}
+
 // makeTestLogger creates a Logger instance which produces JSON logs.
 func makeTestLogger() (Logger, *zaptest.Buffer) {
 	buffer := new(zaptest.Buffer)
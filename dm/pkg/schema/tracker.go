@@ -400,6 +400,14 @@ func (tr *Tracker) GetDownStreamTableInfo(tctx *tcontext.Context, tableID string
 	return tr.downstreamTracker.getOrInit(tctx, tableID, originTI)
 }
 
+// FetchDownStreamTableInfo fetches downstream table info by "SHOW CREATE TABLE"
+// without caching it in downstreamTrack.
+func (tr *Tracker) FetchDownStreamTableInfo(tctx *tcontext.Context, tableID string) (*model.TableInfo, error) {
+	tr.downstreamTracker.Lock()
+	defer tr.downstreamTracker.Unlock()
+	return tr.downstreamTracker.getTableInfoByCreateStmt(tctx, tableID)
+}
+
 // RemoveDownstreamSchema just remove schema or table in downstreamTrack.
 func (tr *Tracker) RemoveDownstreamSchema(tctx *tcontext.Context, targetTables []*filter.Table) {
 	if len(targetTables) == 0 {
@@ -211,6 +211,11 @@ func _() {
 	_ = x[codeConfigInvalidLoadAnalyze-20065]
 	_ = x[codeConfigStrictOptimisticShardMode-20066]
 	_ = x[codeConfigSecretKeyPath-20067]
+	_ = x[codeConfigInvalidAppendOnlyTables-20068]
+	_ = x[codeConfigOpenAPITaskConfigStale-20069]
+	_ = x[codeConfigOpenAPITaskConfigInvalid-20070]
+	_ = x[codeConfigOpenAPITaskConfigCorrupt-20071]
+	_ = x[codeConfigInvalidSyncerConfig-20072]
 	_ = x[codeBinlogExtractPosition-22001]
 	_ = x[codeBinlogInvalidFilename-22002]
 	_ = x[codeBinlogParsePosFromStr-22003]
@@ -372,6 +377,9 @@ func _() {
 	_ = x[codeSyncerGetEvent-36069]
 	_ = x[codeSyncerDownstreamTableNotFound-36070]
 	_ = x[codeSyncerReprocessWithSafeModeFail-36071]
+	_ = x[codeSyncerCausalityIndexNotFound-36072]
+	_ = x[codeSyncerConflictFlushTimeout-36073]
+	_ = x[codeSyncerCausalityVerifyFailed-36074]
 	_ = x[codeMasterSQLOpNilRequest-38001]
 	_ = x[codeMasterSQLOpNotSupport-38002]
 	_ = x[codeMasterSQLOpWithoutSharding-38003]
@@ -599,7 +607,7 @@ func _() {
 	_ = x[codeNotSet-50000]
 }
 
-const _ErrCode_name = "DBDriverErrorDBBadConnDBInvalidConnDBUnExpectDBQueryFailedDBExecuteFailedParseMydumperMetaGetFileSizeDropMultipleTablesRenameMultipleTablesAlterMultipleTablesParseSQLUnknownTypeDDLRestoreASTNodeParseGTIDNotSupportedFlavorNotMySQLGTIDNotMariaDBGTIDNotUUIDStringMariaDBDomainIDInvalidServerIDGetSQLModeFromStrVerifySQLOperateArgsStatFileSizeReaderAlreadyRunningReaderAlreadyStartedReaderStateCannotCloseReaderShouldStartSyncEmptyRelayDirReadDirBaseFileNotFoundBinFileCmpCondNotSupportBinlogFileNotValidBinlogFilesNotFoundGetRelayLogStatAddWatchForRelayLogDirWatcherStartWatcherChanClosedWatcherChanRecvErrorRelayLogFileSizeSmallerBinlogFileNotSpecifiedNoRelayLogMatchPosFirstRelayLogNotMatchPosParserParseRelayLogNoSubdirToSwitchNeedSyncAgainSyncClosedSchemaTableNameNotValidGenTableRouterEncryptSecretKeyNotValidEncryptGenCipherEncryptGenIVCiphertextLenNotValidCiphertextContextNotValidInvalidBinlogPosStrEncCipherTextBase64DecodeBinlogWriteBinaryDataBinlogWriteDataToBufferBinlogHeaderLengthNotValidBinlogEventDecodeBinlogEmptyNextBinNameBinlogParseSIDBinlogEmptyGTIDBinlogGTIDSetNotValidBinlogGTIDMySQLNotValidBinlogGTIDMariaDBNotValidBinlogMariaDBServerIDMismatchBinlogOnlyOneGTIDSupportBinlogOnlyOneIntervalInUUIDBinlogIntervalValueNotValidBinlogEmptyQueryBinlogTableMapEvNotValidBinlogExpectFormatDescEvBinlogExpectTableMapEvBinlogExpectRowsEvBinlogUnexpectedEvBinlogParseSingleEvBinlogEventTypeNotValidBinlogEventNoRowsBinlogEventNoColumnsBinlogEventRowLengthNotEqBinlogColumnTypeNotSupportBinlogGoMySQLTypeNotSupportBinlogColumnTypeMisMatchBinlogDummyEvSizeTooSmallBinlogFlavorNotSupportBinlogDMLEmptyDataBinlogLatestGTIDNotInPrevBinlogReadFileByGTIDBinlogWriterNotStateNewBinlogWriterStateCannotCloseBinlogWriterNeedStartBinlogWriterOpenFileBinlogWriterGetFileStatBinlogWriterWriteDataLenBinlogWriterFileNotOpenedBinlogWriterFileSyncBinlogPrevGTIDEvNotValidBinlogDecodeMySQLGTIDSetBinlogNeedMariaDBGTIDSetBinlogParseMariaDBGTIDSetBinlogMariaDBAddGTIDSetTracingEventDataNotValidTracingUploadDataTracingEventTypeNotValidTracingGetTraceCodeTracingDataChecksumTracingGetTSOBackoffArgsNotValidInitLoggerFailGTIDTruncateInvalidRelayLogGivenPosTooBigElectionCampaignFailElectionGetLeaderIDFailBinlogInvalidFilenameWithUUIDSuffixDecodeEtcdKeyFailShardDDLOptimismTrySyncFailConnInvalidTLSConfigConnRegistryTLSConfigUpgradeVersionEtcdFailInvalidV1WorkerMetaPathFailUpdateV1DBSchemaBinlogStatusVarsParseVerifyHandleErrorArgsRewriteSQLNoUUIDDirMatchGTIDNoRelayPosMatchGTIDReaderReachEndOfFileMetadataNoBinlogLocPreviousGTIDNotExistNoMasterStatusBinlogNotLogColumnShardDDLOptimismNeedSkipAndRedirectShardDDLOptimismAddNotFullyDroppedColumnSyncerCancelledDDLIncorrectReturnColumnsNumConfigCheckItemNotSupportConfigTomlTransformConfigYamlTransformConfigTaskNameEmptyConfigEmptySourceIDConfigTooLongSourceIDConfigOnlineSchemeNotSupportConfigInvalidTimezoneConfigParseFlagSetConfigDecryptDBPasswordConfigMetaInvalidConfigMySQLInstNotFoundConfigMySQLInstsAtLeastOneConfigMySQLInstSameSourceIDConfigMydumperCfgConflictConfigLoaderCfgConflictConfigSyncerCfgConflictConfigReadCfgFromFileConfigNeedUniqueTaskNameConfigInvalidTaskModeConfigNeedTargetDBConfigMetadataNotSetConfigRouteRuleNotFoundConfigFilterRuleNotFoundConfigColumnMappingNotFoundConfigBAListNotFoundConfigMydumperCfgNotFoundConfigMydumperPathNotValidConfigLoaderCfgNotFoundConfigSyncerCfgNotFoundConfigSourceIDNotFoundConfigDuplicateCfgItemConfigShardModeNotSupportConfigMoreThanOneConfigEtcdParseConfigMissingForBoundConfigBinlogEventFilterConfigGlobalConfigsUnusedConfigExprFilterManyExprConfigExprFilterNotFoundConfigExprFilterWrongGrammarConfigExprFilterEmptyNameConfigCheckerMaxTooSmallConfigGenBAListConfigGenTableRouterConfigGenColumnMappingConfigInvalidChunkFileSizeConfigOnlineDDLInvalidRegexConfigOnlineDDLMistakeRegexConfigOpenAPITaskConfigExistConfigOpenAPITaskConfigNotExistCollationCompatibleNotSupportConfigInvalidLoadModeConfigInvalidLoadDuplicateResolutionConfigValidationModeContinuousValidatorCfgNotFoundConfigStartTimeTooLateConfigLoaderDirInvalidConfigLoaderS3NotSupportConfigInvalidSafeModeDurationConfigConfictSafeModeDurationAndSafeModeConfigInvalidLoadPhysicalDuplicateResolutionConfigInvalidLoadPhysicalChecksumConfigColumnMappingDeprecatedConfigInvalidLoadAnalyzeConfigStrictOptimisticShardModeConfigSecretKeyPathBinlogExtractPositionBinlogInvalidFilenameBinlogParsePosFromStrCheckpointInvalidTaskModeCheckpointSaveInvalidPosCheckpointInvalidTableFileCheckpointDBNotExistInFileCheckpointTableNotExistInFileCheckpointRestoreCountGreaterTaskCheckSameTableNameTaskCheckFailedOpenDBTaskCheckGenTableRouterTaskCheckGenColumnMappingTaskCheckSyncConfigErrorTaskCheckGenBAListSourceCheckGTIDRelayParseUUIDIndexRelayParseUUIDSuffixRelayUUIDWithSuffixNotFoundRelayGenFakeRotateEventRelayNoValidRelaySubDirRelayUUIDSuffixNotValidRelayUUIDSuffixLessThanPrevRelayLoadMetaDataRelayBinlogNameNotValidRelayNoCurrentUUIDRelayFlushLocalMetaRelayUpdateIndexFileRelayLogDirpathEmptyRelayReaderNotStateNewRelayReaderStateCannotCloseRelayReaderNeedStartRelayTCPReaderStartSyncRelayTCPReaderNilGTIDRelayTCPReaderStartSyncGTIDRelayTCPReaderGetEventRelayWriterNotStateNewRelayWriterStateCannotCloseRelayWriterNeedStartRelayWriterNotOpenedRelayWriterExpectRotateEvRelayWriterRotateEvWithNoWriterRelayWriterStatusNotValidRelayWriterGetFileStatRelayWriterLatestPosGTFileSizeRelayWriterFileOperateRelayCheckBinlogFileHeaderExistRelayCheckFormatDescEventExistRelayCheckFormatDescEventParseEvRelayCheckIsDuplicateEventRelayUpdateGTIDRelayNeedPrevGTIDEvBeforeGTIDEvRelayNeedMaGTIDListEvBeforeGTIDEvRelayMkdirRelaySwitchMasterNeedGTIDRelayThisStrategyIsPurgingRelayOtherStrategyIsPurgingRelayPurgeIsForbiddenRelayNoActiveRelayLogRelayPurgeRequestNotValidRelayTrimUUIDNotFoundRelayRemoveFileFailRelayPurgeArgsNotValidPreviousGTIDsNotValidRotateEventWithDifferentServerIDDumpUnitRuntimeDumpUnitGenTableRouterDumpUnitGenBAListDumpUnitGlobalLockLoadUnitCreateSchemaFileLoadUnitInvalidFileEndingLoadUnitParseQuoteValuesLoadUnitDoColumnMappingLoadUnitReadSchemaFileLoadUnitParseStatementLoadUnitNotCreateTableLoadUnitDispatchSQLFromFileLoadUnitInvalidInsertSQLLoadUnitGenTableRouterLoadUnitGenColumnMappingLoadUnitNoDBFileLoadUnitNoTableFileLoadUnitDumpDirNotFoundLoadUnitDuplicateTableFileLoadUnitGenBAListLoadTaskWorkerNotMatchLoadCheckPointNotMatchLoadLightningRuntimeLoadLightningHasDupLoadLightningChecksumSyncerUnitPanicSyncUnitInvalidTableNameSyncUnitTableNameQuerySyncUnitNotSupportedDMLSyncUnitAddTableInShardingSyncUnitDropSchemaTableInShardingSyncUnitInvalidShardMetaSyncUnitDDLWrongSequenceSyncUnitDDLActiveIndexLargerSyncUnitDupTableGroupSyncUnitShardingGroupNotFoundSyncUnitSafeModeSetCountSyncUnitCausalityConflictSyncUnitDMLStatementFoundSyncerUnitBinlogEventFilterSyncerUnitInvalidReplicaEventSyncerUnitParseStmtSyncerUnitUUIDNotLatestSyncerUnitDDLExecChanCloseOrBusySyncerUnitDDLChanDoneSyncerUnitDDLChanCanceledSyncerUnitDDLOnMultipleTableSyncerUnitInjectDDLOnlySyncerUnitInjectDDLWithoutSchemaSyncerUnitNotSupportedOperateSyncerUnitNilOperatorReqSyncerUnitDMLColumnNotMatchSyncerUnitDMLOldNewValueMismatchSyncerUnitDMLPruneColumnMismatchSyncerUnitGenBinlogEventFilterSyncerUnitGenTableRouterSyncerUnitGenColumnMappingSyncerUnitDoColumnMappingSyncerUnitCacheKeyNotFoundSyncerUnitHeartbeatCheckConfigSyncerUnitHeartbeatRecordExistsSyncerUnitHeartbeatRecordNotFoundSyncerUnitHeartbeatRecordNotValidSyncerUnitOnlineDDLInvalidMetaSyncerUnitOnlineDDLSchemeNotSupportSyncerUnitOnlineDDLOnMultipleTableSyncerUnitGhostApplyEmptyTableSyncerUnitGhostRenameTableNotValidSyncerUnitGhostRenameToGhostTableSyncerUnitGhostRenameGhostTblToOtherSyncerUnitGhostOnlineDDLOnGhostTblSyncerUnitPTApplyEmptyTableSyncerUnitPTRenameTableNotValidSyncerUnitPTRenameToPTTableSyncerUnitPTRenamePTTblToOtherSyncerUnitPTOnlineDDLOnPTTblSyncerUnitRemoteSteamerWithGTIDSyncerUnitRemoteSteamerStartSyncSyncerUnitGetTableFromDBSyncerUnitFirstEndPosNotFoundSyncerUnitResolveCasualityFailSyncerUnitReopenStreamNotSupportSyncerUnitUpdateConfigInShardingSyncerUnitExecWithNoBlockingDDLSyncerUnitGenBAListSyncerUnitHandleDDLFailedSyncerShardDDLConflictSyncerFailpointSyncerEventSyncerOperatorNotExistSyncerEventNotExistSyncerParseDDLSyncerUnsupportedStmtSyncerGetEventSyncerDownstreamTableNotFoundSyncerReprocessWithSafeModeFailMasterSQLOpNilRequestMasterSQLOpNotSupportMasterSQLOpWithoutShardingMasterGRPCCreateConnMasterGRPCSendOnCloseConnMasterGRPCClientCloseMasterGRPCInvalidReqTypeMasterGRPCRequestErrorMasterDeployMapperVerifyMasterConfigParseFlagSetMasterConfigUnknownItemMasterConfigInvalidFlagMasterConfigTomlTransformMasterConfigTimeoutParseMasterConfigUpdateCfgFileMasterShardingDDLDiffMasterStartServiceMasterNoEmitTokenMasterLockNotFoundMasterLockIsResolvingMasterWorkerCliNotFoundMasterWorkerNotWaitLockMasterHandleSQLReqFailMasterOwnerExecDDLMasterPartWorkerExecDDLFailMasterWorkerExistDDLLockMasterGetWorkerCfgExtractorMasterTaskConfigExtractorMasterWorkerArgsExtractorMasterQueryWorkerConfigMasterOperNotFoundMasterOperRespNotSuccessMasterOperRequestTimeoutMasterHandleHTTPApisMasterHostPortNotValidMasterGetHostnameFailMasterGenEmbedEtcdConfigFailMasterStartEmbedEtcdFailMasterParseURLFailMasterJoinEmbedEtcdFailMasterInvalidOperateOpMasterAdvertiseAddrNotValidMasterRequestIsNotForwardToLeaderMasterIsNotAsyncRequestMasterFailToGetExpectResultMasterPessimistNotStartedMasterOptimistNotStartedMasterMasterNameNotExistMasterInvalidOfflineTypeMasterAdvertisePeerURLsNotValidMasterTLSConfigNotValidMasterBoundChangingMasterFailToImportFromV10xMasterInconsistentOptimistDDLsAndInfoMasterOptimisticTableInfobeforeNotExistMasterOptimisticDownstreamMetaNotFoundMasterInvalidClusterIDMasterStartTaskWorkerParseFlagSetWorkerInvalidFlagWorkerDecodeConfigFromFileWorkerUndecodedItemFromFileWorkerNeedSourceIDWorkerTooLongSourceIDWorkerRelayBinlogNameWorkerWriteConfigFileWorkerLogInvalidHandlerWorkerLogPointerInvalidWorkerLogFetchPointerWorkerLogUnmarshalPointerWorkerLogClearPointerWorkerLogTaskKeyNotValidWorkerLogUnmarshalTaskKeyWorkerLogFetchLogIterWorkerLogGetTaskLogWorkerLogUnmarshalBinaryWorkerLogForwardPointerWorkerLogMarshalTaskWorkerLogSaveTaskWorkerLogDeleteKVWorkerLogDeleteKVIterWorkerLogUnmarshalTaskMetaWorkerLogFetchTaskFromMetaWorkerLogVerifyTaskMetaWorkerLogSaveTaskMetaWorkerLogGetTaskMetaWorkerLogDeleteTaskMetaWorkerMetaTomlTransformWorkerMetaOldFileStatWorkerMetaOldReadFileWorkerMetaEncodeTaskWorkerMetaRemoveOldDirWorkerMetaTaskLogNotFoundWorkerMetaHandleTaskOrderWorkerMetaOpenTxnWorkerMetaCommitTxnWorkerRelayStageNotValidWorkerRelayOperNotSupportWorkerOpenKVDBFileWorkerUpgradeCheckKVDirWorkerMarshalVerBinaryWorkerUnmarshalVerBinaryWorkerGetVersionFromKVWorkerSaveVersionToKVWorkerVerAutoDowngradeWorkerStartServiceWorkerAlreadyClosedWorkerNotRunningStageWorkerNotPausedStageWorkerUpdateTaskStageWorkerMigrateStopRelayWorkerSubTaskNotFoundWorkerSubTaskExistsWorkerOperSyncUnitOnlyWorkerRelayUnitStageWorkerNoSyncerRunningWorkerCannotUpdateSourceIDWorkerNoAvailUnitsWorkerDDLLockInfoNotFoundWorkerDDLLockInfoExistsWorkerCacheDDLInfoExistsWorkerExecSkipDDLConflictWorkerExecDDLSyncerOnlyWorkerExecDDLTimeoutWorkerWaitRelayCatchupTimeoutWorkerRelayIsPurgingWorkerHostPortNotValidWorkerNoStartWorkerAlreadyStartedWorkerSourceNotMatchWorkerFailToGetSubtaskConfigFromEtcdWorkerFailToGetSourceConfigFromEtcdWorkerDDLLockOpNotFoundWorkerTLSConfigNotValidWorkerFailConnectMasterWorkerWaitRelayCatchupGTIDWorkerRelayConfigChangingWorkerRouteTableDupMatchWorkerUpdateSubTaskConfigWorkerValidatorNotPausedWorkerServerClosedTracerParseFlagSetTracerConfigTomlTransformTracerConfigInvalidFlagTracerTraceEventNotFoundTracerTraceIDNotProvidedTracerParamNotValidTracerPostMethodOnlyTracerEventAssertionFailTracerEventTypeNotValidTracerStartServiceHAFailTxnOperationHAInvalidItemHAFailWatchEtcdHAFailLeaseOperationHAFailKeepaliveValidatorLoadPersistedDataValidatorPersistDataValidatorGetEventValidatorProcessRowEventValidatorValidateChangeValidatorNotFoundValidatorPanicValidatorTooMuchPendingSchemaTrackerInvalidJSONSchemaTrackerCannotCreateSchemaSchemaTrackerCannotCreateTableSchemaTrackerCannotSerializeSchemaTrackerCannotGetTableSchemaTrackerCannotExecDDLSchemaTrackerCannotFetchDownstreamTableSchemaTrackerCannotParseDownstreamTableSchemaTrackerInvalidCreateTableStmtSchemaTrackerRestoreStmtFailSchemaTrackerCannotDropTableSchemaTrackerInitSchemaTrackerMarshalJSONSchemaTrackerUnMarshalJSONSchemaTrackerUnSchemaNotExistSchemaTrackerCannotSetDownstreamSQLModeSchemaTrackerCannotInitDownstreamParserSchemaTrackerCannotMockDownstreamTableSchemaTrackerCannotFetchDownstreamCreateTableStmtSchemaTrackerIsClosedSchedulerNotStartedSchedulerStartedSchedulerWorkerExistSchedulerWorkerNotExistSchedulerWorkerOnlineSchedulerWorkerInvalidTransSchedulerSourceCfgExistSchedulerSourceCfgNotExistSchedulerSourcesUnboundSchedulerSourceOpTaskExistSchedulerRelayStageInvalidUpdateSchedulerRelayStageSourceNotExistSchedulerMultiTaskSchedulerSubTaskExistSchedulerSubTaskStageInvalidUpdateSchedulerSubTaskOpTaskNotExistSchedulerSubTaskOpSourceNotExistSchedulerTaskNotExistSchedulerRequireRunningTaskInSyncUnitSchedulerRelayWorkersBusySchedulerRelayWorkersBoundSchedulerRelayWorkersWrongRelaySchedulerSourceOpRelayExistSchedulerLatchInUseSchedulerSourceCfgUpdateSchedulerWrongWorkerInputSchedulerCantTransferToRelayWorkerSchedulerStartRelayOnSpecifiedSchedulerStopRelayOnSpecifiedSchedulerStartRelayOnBoundSchedulerStopRelayOnBoundSchedulerPauseTaskForTransferSourceSchedulerWorkerNotFreeSchedulerSubTaskNotExistSchedulerSubTaskCfgUpdateCtlGRPCCreateConnCtlInvalidTLSCfgCtlLoadTLSCfgOpenAPICommonOpenAPITaskSourceNotFoundNotSet"
+const _ErrCode_name = "DBDriverErrorDBBadConnDBInvalidConnDBUnExpectDBQueryFailedDBExecuteFailedParseMydumperMetaGetFileSizeDropMultipleTablesRenameMultipleTablesAlterMultipleTablesParseSQLUnknownTypeDDLRestoreASTNodeParseGTIDNotSupportedFlavorNotMySQLGTIDNotMariaDBGTIDNotUUIDStringMariaDBDomainIDInvalidServerIDGetSQLModeFromStrVerifySQLOperateArgsStatFileSizeReaderAlreadyRunningReaderAlreadyStartedReaderStateCannotCloseReaderShouldStartSyncEmptyRelayDirReadDirBaseFileNotFoundBinFileCmpCondNotSupportBinlogFileNotValidBinlogFilesNotFoundGetRelayLogStatAddWatchForRelayLogDirWatcherStartWatcherChanClosedWatcherChanRecvErrorRelayLogFileSizeSmallerBinlogFileNotSpecifiedNoRelayLogMatchPosFirstRelayLogNotMatchPosParserParseRelayLogNoSubdirToSwitchNeedSyncAgainSyncClosedSchemaTableNameNotValidGenTableRouterEncryptSecretKeyNotValidEncryptGenCipherEncryptGenIVCiphertextLenNotValidCiphertextContextNotValidInvalidBinlogPosStrEncCipherTextBase64DecodeBinlogWriteBinaryDataBinlogWriteDataToBufferBinlogHeaderLengthNotValidBinlogEventDecodeBinlogEmptyNextBinNameBinlogParseSIDBinlogEmptyGTIDBinlogGTIDSetNotValidBinlogGTIDMySQLNotValidBinlogGTIDMariaDBNotValidBinlogMariaDBServerIDMismatchBinlogOnlyOneGTIDSupportBinlogOnlyOneIntervalInUUIDBinlogIntervalValueNotValidBinlogEmptyQueryBinlogTableMapEvNotValidBinlogExpectFormatDescEvBinlogExpectTableMapEvBinlogExpectRowsEvBinlogUnexpectedEvBinlogParseSingleEvBinlogEventTypeNotValidBinlogEventNoRowsBinlogEventNoColumnsBinlogEventRowLengthNotEqBinlogColumnTypeNotSupportBinlogGoMySQLTypeNotSupportBinlogColumnTypeMisMatchBinlogDummyEvSizeTooSmallBinlogFlavorNotSupportBinlogDMLEmptyDataBinlogLatestGTIDNotInPrevBinlogReadFileByGTIDBinlogWriterNotStateNewBinlogWriterStateCannotCloseBinlogWriterNeedStartBinlogWriterOpenFileBinlogWriterGetFileStatBinlogWriterWriteDataLenBinlogWriterFileNotOpenedBinlogWriterFileSyncBinlogPrevGTIDEvNotValidBinlogDecodeMySQLGTIDSetBinlogNeedMariaDBGTIDSetBinlogParseMariaDBGTIDSetBinlogMariaDBAddGTIDSetTracingEventDataNotValidTracingUploadDataTracingEventTypeNotValidTracingGetTraceCodeTracingDataChecksumTracingGetTSOBackoffArgsNotValidInitLoggerFailGTIDTruncateInvalidRelayLogGivenPosTooBigElectionCampaignFailElectionGetLeaderIDFailBinlogInvalidFilenameWithUUIDSuffixDecodeEtcdKeyFailShardDDLOptimismTrySyncFailConnInvalidTLSConfigConnRegistryTLSConfigUpgradeVersionEtcdFailInvalidV1WorkerMetaPathFailUpdateV1DBSchemaBinlogStatusVarsParseVerifyHandleErrorArgsRewriteSQLNoUUIDDirMatchGTIDNoRelayPosMatchGTIDReaderReachEndOfFileMetadataNoBinlogLocPreviousGTIDNotExistNoMasterStatusBinlogNotLogColumnShardDDLOptimismNeedSkipAndRedirectShardDDLOptimismAddNotFullyDroppedColumnSyncerCancelledDDLIncorrectReturnColumnsNumConfigCheckItemNotSupportConfigTomlTransformConfigYamlTransformConfigTaskNameEmptyConfigEmptySourceIDConfigTooLongSourceIDConfigOnlineSchemeNotSupportConfigInvalidTimezoneConfigParseFlagSetConfigDecryptDBPasswordConfigMetaInvalidConfigMySQLInstNotFoundConfigMySQLInstsAtLeastOneConfigMySQLInstSameSourceIDConfigMydumperCfgConflictConfigLoaderCfgConflictConfigSyncerCfgConflictConfigReadCfgFromFileConfigNeedUniqueTaskNameConfigInvalidTaskModeConfigNeedTargetDBConfigMetadataNotSetConfigRouteRuleNotFoundConfigFilterRuleNotFoundConfigColumnMappingNotFoundConfigBAListNotFoundConfigMydumperCfgNotFoundConfigMydumperPathNotValidConfigLoaderCfgNotFoundConfigSyncerCfgNotFoundConfigSourceIDNotFoundConfigDuplicateCfgItemConfigShardModeNotSupportConfigMoreThanOneConfigEtcdParseConfigMissingForBoundConfigBinlogEventFilterConfigGlobalConfigsUnusedConfigExprFilterManyExprConfigExprFilterNotFoundConfigExprFilterWrongGrammarConfigExprFilterEmptyNameConfigCheckerMaxTooSmallConfigGenBAListConfigGenTableRouterConfigGenColumnMappingConfigInvalidChunkFileSizeConfigOnlineDDLInvalidRegexConfigOnlineDDLMistakeRegexConfigOpenAPITaskConfigExistConfigOpenAPITaskConfigNotExistCollationCompatibleNotSupportConfigInvalidLoadModeConfigInvalidLoadDuplicateResolutionConfigValidationModeContinuousValidatorCfgNotFoundConfigStartTimeTooLateConfigLoaderDirInvalidConfigLoaderS3NotSupportConfigInvalidSafeModeDurationConfigConfictSafeModeDurationAndSafeModeConfigInvalidLoadPhysicalDuplicateResolutionConfigInvalidLoadPhysicalChecksumConfigColumnMappingDeprecatedConfigInvalidLoadAnalyzeConfigStrictOptimisticShardModeConfigSecretKeyPathConfigInvalidAppendOnlyTablesConfigOpenAPITaskConfigStaleConfigOpenAPITaskConfigInvalidConfigOpenAPITaskConfigCorruptConfigInvalidSyncerConfigBinlogExtractPositionBinlogInvalidFilenameBinlogParsePosFromStrCheckpointInvalidTaskModeCheckpointSaveInvalidPosCheckpointInvalidTableFileCheckpointDBNotExistInFileCheckpointTableNotExistInFileCheckpointRestoreCountGreaterTaskCheckSameTableNameTaskCheckFailedOpenDBTaskCheckGenTableRouterTaskCheckGenColumnMappingTaskCheckSyncConfigErrorTaskCheckGenBAListSourceCheckGTIDRelayParseUUIDIndexRelayParseUUIDSuffixRelayUUIDWithSuffixNotFoundRelayGenFakeRotateEventRelayNoValidRelaySubDirRelayUUIDSuffixNotValidRelayUUIDSuffixLessThanPrevRelayLoadMetaDataRelayBinlogNameNotValidRelayNoCurrentUUIDRelayFlushLocalMetaRelayUpdateIndexFileRelayLogDirpathEmptyRelayReaderNotStateNewRelayReaderStateCannotCloseRelayReaderNeedStartRelayTCPReaderStartSyncRelayTCPReaderNilGTIDRelayTCPReaderStartSyncGTIDRelayTCPReaderGetEventRelayWriterNotStateNewRelayWriterStateCannotCloseRelayWriterNeedStartRelayWriterNotOpenedRelayWriterExpectRotateEvRelayWriterRotateEvWithNoWriterRelayWriterStatusNotValidRelayWriterGetFileStatRelayWriterLatestPosGTFileSizeRelayWriterFileOperateRelayCheckBinlogFileHeaderExistRelayCheckFormatDescEventExistRelayCheckFormatDescEventParseEvRelayCheckIsDuplicateEventRelayUpdateGTIDRelayNeedPrevGTIDEvBeforeGTIDEvRelayNeedMaGTIDListEvBeforeGTIDEvRelayMkdirRelaySwitchMasterNeedGTIDRelayThisStrategyIsPurgingRelayOtherStrategyIsPurgingRelayPurgeIsForbiddenRelayNoActiveRelayLogRelayPurgeRequestNotValidRelayTrimUUIDNotFoundRelayRemoveFileFailRelayPurgeArgsNotValidPreviousGTIDsNotValidRotateEventWithDifferentServerIDDumpUnitRuntimeDumpUnitGenTableRouterDumpUnitGenBAListDumpUnitGlobalLockLoadUnitCreateSchemaFileLoadUnitInvalidFileEndingLoadUnitParseQuoteValuesLoadUnitDoColumnMappingLoadUnitReadSchemaFileLoadUnitParseStatementLoadUnitNotCreateTableLoadUnitDispatchSQLFromFileLoadUnitInvalidInsertSQLLoadUnitGenTableRouterLoadUnitGenColumnMappingLoadUnitNoDBFileLoadUnitNoTableFileLoadUnitDumpDirNotFoundLoadUnitDuplicateTableFileLoadUnitGenBAListLoadTaskWorkerNotMatchLoadCheckPointNotMatchLoadLightningRuntimeLoadLightningHasDupLoadLightningChecksumSyncerUnitPanicSyncUnitInvalidTableNameSyncUnitTableNameQuerySyncUnitNotSupportedDMLSyncUnitAddTableInShardingSyncUnitDropSchemaTableInShardingSyncUnitInvalidShardMetaSyncUnitDDLWrongSequenceSyncUnitDDLActiveIndexLargerSyncUnitDupTableGroupSyncUnitShardingGroupNotFoundSyncUnitSafeModeSetCountSyncUnitCausalityConflictSyncUnitDMLStatementFoundSyncerUnitBinlogEventFilterSyncerUnitInvalidReplicaEventSyncerUnitParseStmtSyncerUnitUUIDNotLatestSyncerUnitDDLExecChanCloseOrBusySyncerUnitDDLChanDoneSyncerUnitDDLChanCanceledSyncerUnitDDLOnMultipleTableSyncerUnitInjectDDLOnlySyncerUnitInjectDDLWithoutSchemaSyncerUnitNotSupportedOperateSyncerUnitNilOperatorReqSyncerUnitDMLColumnNotMatchSyncerUnitDMLOldNewValueMismatchSyncerUnitDMLPruneColumnMismatchSyncerUnitGenBinlogEventFilterSyncerUnitGenTableRouterSyncerUnitGenColumnMappingSyncerUnitDoColumnMappingSyncerUnitCacheKeyNotFoundSyncerUnitHeartbeatCheckConfigSyncerUnitHeartbeatRecordExistsSyncerUnitHeartbeatRecordNotFoundSyncerUnitHeartbeatRecordNotValidSyncerUnitOnlineDDLInvalidMetaSyncerUnitOnlineDDLSchemeNotSupportSyncerUnitOnlineDDLOnMultipleTableSyncerUnitGhostApplyEmptyTableSyncerUnitGhostRenameTableNotValidSyncerUnitGhostRenameToGhostTableSyncerUnitGhostRenameGhostTblToOtherSyncerUnitGhostOnlineDDLOnGhostTblSyncerUnitPTApplyEmptyTableSyncerUnitPTRenameTableNotValidSyncerUnitPTRenameToPTTableSyncerUnitPTRenamePTTblToOtherSyncerUnitPTOnlineDDLOnPTTblSyncerUnitRemoteSteamerWithGTIDSyncerUnitRemoteSteamerStartSyncSyncerUnitGetTableFromDBSyncerUnitFirstEndPosNotFoundSyncerUnitResolveCasualityFailSyncerUnitReopenStreamNotSupportSyncerUnitUpdateConfigInShardingSyncerUnitExecWithNoBlockingDDLSyncerUnitGenBAListSyncerUnitHandleDDLFailedSyncerShardDDLConflictSyncerFailpointSyncerEventSyncerOperatorNotExistSyncerEventNotExistSyncerParseDDLSyncerUnsupportedStmtSyncerGetEventSyncerDownstreamTableNotFoundSyncerReprocessWithSafeModeFailSyncerCausalityIndexNotFoundSyncerConflictFlushTimeoutSyncerCausalityVerifyFailedMasterSQLOpNilRequestMasterSQLOpNotSupportMasterSQLOpWithoutShardingMasterGRPCCreateConnMasterGRPCSendOnCloseConnMasterGRPCClientCloseMasterGRPCInvalidReqTypeMasterGRPCRequestErrorMasterDeployMapperVerifyMasterConfigParseFlagSetMasterConfigUnknownItemMasterConfigInvalidFlagMasterConfigTomlTransformMasterConfigTimeoutParseMasterConfigUpdateCfgFileMasterShardingDDLDiffMasterStartServiceMasterNoEmitTokenMasterLockNotFoundMasterLockIsResolvingMasterWorkerCliNotFoundMasterWorkerNotWaitLockMasterHandleSQLReqFailMasterOwnerExecDDLMasterPartWorkerExecDDLFailMasterWorkerExistDDLLockMasterGetWorkerCfgExtractorMasterTaskConfigExtractorMasterWorkerArgsExtractorMasterQueryWorkerConfigMasterOperNotFoundMasterOperRespNotSuccessMasterOperRequestTimeoutMasterHandleHTTPApisMasterHostPortNotValidMasterGetHostnameFailMasterGenEmbedEtcdConfigFailMasterStartEmbedEtcdFailMasterParseURLFailMasterJoinEmbedEtcdFailMasterInvalidOperateOpMasterAdvertiseAddrNotValidMasterRequestIsNotForwardToLeaderMasterIsNotAsyncRequestMasterFailToGetExpectResultMasterPessimistNotStartedMasterOptimistNotStartedMasterMasterNameNotExistMasterInvalidOfflineTypeMasterAdvertisePeerURLsNotValidMasterTLSConfigNotValidMasterBoundChangingMasterFailToImportFromV10xMasterInconsistentOptimistDDLsAndInfoMasterOptimisticTableInfobeforeNotExistMasterOptimisticDownstreamMetaNotFoundMasterInvalidClusterIDMasterStartTaskWorkerParseFlagSetWorkerInvalidFlagWorkerDecodeConfigFromFileWorkerUndecodedItemFromFileWorkerNeedSourceIDWorkerTooLongSourceIDWorkerRelayBinlogNameWorkerWriteConfigFileWorkerLogInvalidHandlerWorkerLogPointerInvalidWorkerLogFetchPointerWorkerLogUnmarshalPointerWorkerLogClearPointerWorkerLogTaskKeyNotValidWorkerLogUnmarshalTaskKeyWorkerLogFetchLogIterWorkerLogGetTaskLogWorkerLogUnmarshalBinaryWorkerLogForwardPointerWorkerLogMarshalTaskWorkerLogSaveTaskWorkerLogDeleteKVWorkerLogDeleteKVIterWorkerLogUnmarshalTaskMetaWorkerLogFetchTaskFromMetaWorkerLogVerifyTaskMetaWorkerLogSaveTaskMetaWorkerLogGetTaskMetaWorkerLogDeleteTaskMetaWorkerMetaTomlTransformWorkerMetaOldFileStatWorkerMetaOldReadFileWorkerMetaEncodeTaskWorkerMetaRemoveOldDirWorkerMetaTaskLogNotFoundWorkerMetaHandleTaskOrderWorkerMetaOpenTxnWorkerMetaCommitTxnWorkerRelayStageNotValidWorkerRelayOperNotSupportWorkerOpenKVDBFileWorkerUpgradeCheckKVDirWorkerMarshalVerBinaryWorkerUnmarshalVerBinaryWorkerGetVersionFromKVWorkerSaveVersionToKVWorkerVerAutoDowngradeWorkerStartServiceWorkerAlreadyClosedWorkerNotRunningStageWorkerNotPausedStageWorkerUpdateTaskStageWorkerMigrateStopRelayWorkerSubTaskNotFoundWorkerSubTaskExistsWorkerOperSyncUnitOnlyWorkerRelayUnitStageWorkerNoSyncerRunningWorkerCannotUpdateSourceIDWorkerNoAvailUnitsWorkerDDLLockInfoNotFoundWorkerDDLLockInfoExistsWorkerCacheDDLInfoExistsWorkerExecSkipDDLConflictWorkerExecDDLSyncerOnlyWorkerExecDDLTimeoutWorkerWaitRelayCatchupTimeoutWorkerRelayIsPurgingWorkerHostPortNotValidWorkerNoStartWorkerAlreadyStartedWorkerSourceNotMatchWorkerFailToGetSubtaskConfigFromEtcdWorkerFailToGetSourceConfigFromEtcdWorkerDDLLockOpNotFoundWorkerTLSConfigNotValidWorkerFailConnectMasterWorkerWaitRelayCatchupGTIDWorkerRelayConfigChangingWorkerRouteTableDupMatchWorkerUpdateSubTaskConfigWorkerValidatorNotPausedWorkerServerClosedTracerParseFlagSetTracerConfigTomlTransformTracerConfigInvalidFlagTracerTraceEventNotFoundTracerTraceIDNotProvidedTracerParamNotValidTracerPostMethodOnlyTracerEventAssertionFailTracerEventTypeNotValidTracerStartServiceHAFailTxnOperationHAInvalidItemHAFailWatchEtcdHAFailLeaseOperationHAFailKeepaliveValidatorLoadPersistedDataValidatorPersistDataValidatorGetEventValidatorProcessRowEventValidatorValidateChangeValidatorNotFoundValidatorPanicValidatorTooMuchPendingSchemaTrackerInvalidJSONSchemaTrackerCannotCreateSchemaSchemaTrackerCannotCreateTableSchemaTrackerCannotSerializeSchemaTrackerCannotGetTableSchemaTrackerCannotExecDDLSchemaTrackerCannotFetchDownstreamTableSchemaTrackerCannotParseDownstreamTableSchemaTrackerInvalidCreateTableStmtSchemaTrackerRestoreStmtFailSchemaTrackerCannotDropTableSchemaTrackerInitSchemaTrackerMarshalJSONSchemaTrackerUnMarshalJSONSchemaTrackerUnSchemaNotExistSchemaTrackerCannotSetDownstreamSQLModeSchemaTrackerCannotInitDownstreamParserSchemaTrackerCannotMockDownstreamTableSchemaTrackerCannotFetchDownstreamCreateTableStmtSchemaTrackerIsClosedSchedulerNotStartedSchedulerStartedSchedulerWorkerExistSchedulerWorkerNotExistSchedulerWorkerOnlineSchedulerWorkerInvalidTransSchedulerSourceCfgExistSchedulerSourceCfgNotExistSchedulerSourcesUnboundSchedulerSourceOpTaskExistSchedulerRelayStageInvalidUpdateSchedulerRelayStageSourceNotExistSchedulerMultiTaskSchedulerSubTaskExistSchedulerSubTaskStageInvalidUpdateSchedulerSubTaskOpTaskNotExistSchedulerSubTaskOpSourceNotExistSchedulerTaskNotExistSchedulerRequireRunningTaskInSyncUnitSchedulerRelayWorkersBusySchedulerRelayWorkersBoundSchedulerRelayWorkersWrongRelaySchedulerSourceOpRelayExistSchedulerLatchInUseSchedulerSourceCfgUpdateSchedulerWrongWorkerInputSchedulerCantTransferToRelayWorkerSchedulerStartRelayOnSpecifiedSchedulerStopRelayOnSpecifiedSchedulerStartRelayOnBoundSchedulerStopRelayOnBoundSchedulerPauseTaskForTransferSourceSchedulerWorkerNotFreeSchedulerSubTaskNotExistSchedulerSubTaskCfgUpdateCtlGRPCCreateConnCtlInvalidTLSCfgCtlLoadTLSCfgOpenAPICommonOpenAPITaskSourceNotFoundNotSet"
 
 var _ErrCode_map = map[ErrCode]string{
 	10001: _ErrCode_name[0:13],
@@ -805,392 +813,400 @@ var _ErrCode_map = map[ErrCode]string{
 	20065: _ErrCode_name[4217:4241],
 	20066: _ErrCode_name[4241:4272],
 	20067: _ErrCode_name[4272:4291],
-	22001: _ErrCode_name[4291:4312],
-	22002: _ErrCode_name[4312:4333],
-	22003: _ErrCode_name[4333:4354],
-	24001: _ErrCode_name[4354:4379],
-	24002: _ErrCode_name[4379:4403],
-	24003: _ErrCode_name[4403:4429],
-	24004: _ErrCode_name[4429:4455],
-	24005: _ErrCode_name[4455:4484],
-	24006: _ErrCode_name[4484:4513],
-	26001: _ErrCode_name[4513:4535],
-	26002: _ErrCode_name[4535:4556],
-	26003: _ErrCode_name[4556:4579],
-	26004: _ErrCode_name[4579:4604],
-	26005: _ErrCode_name[4604:4628],
-	26006: _ErrCode_name[4628:4646],
-	26007: _ErrCode_name[4646:4661],
-	28001: _ErrCode_name[4661:4680],
-	28002: _ErrCode_name[4680:4700],
-	28003: _ErrCode_name[4700:4727],
-	28004: _ErrCode_name[4727:4750],
-	28005: _ErrCode_name[4750:4773],
-	30001: _ErrCode_name[4773:4796],
-	30002: _ErrCode_name[4796:4823],
-	30003: _ErrCode_name[4823:4840],
-	30004: _ErrCode_name[4840:4863],
-	30005: _ErrCode_name[4863:4881],
-	30006: _ErrCode_name[4881:4900],
-	30007: _ErrCode_name[4900:4920],
-	30008: _ErrCode_name[4920:4940],
-	30009: _ErrCode_name[4940:4962],
-	30010: _ErrCode_name[4962:4989],
-	30011: _ErrCode_name[4989:5009],
-	30012: _ErrCode_name[5009:5032],
-	30013: _ErrCode_name[5032:5053],
-	30014: _ErrCode_name[5053:5080],
-	30015: _ErrCode_name[5080:5102],
-	30016: _ErrCode_name[5102:5124],
-	30017: _ErrCode_name[5124:5151],
-	30018: _ErrCode_name[5151:5171],
-	30019: _ErrCode_name[5171:5191],
-	30020: _ErrCode_name[5191:5216],
-	30021: _ErrCode_name[5216:5247],
-	30022: _ErrCode_name[5247:5272],
-	30023: _ErrCode_name[5272:5294],
-	30024: _ErrCode_name[5294:5324],
-	30025: _ErrCode_name[5324:5346],
-	30026: _ErrCode_name[5346:5377],
-	30027: _ErrCode_name[5377:5407],
-	30028: _ErrCode_name[5407:5439],
-	30029: _ErrCode_name[5439:5465],
-	30030: _ErrCode_name[5465:5480],
-	30031: _ErrCode_name[5480:5511],
-	30032: _ErrCode_name[5511:5544],
-	30033: _ErrCode_name[5544:5554],
-	30034: _ErrCode_name[5554:5579],
-	30035: _ErrCode_name[5579:5605],
-	30036: _ErrCode_name[5605:5632],
-	30037: _ErrCode_name[5632:5653],
-	30038: _ErrCode_name[5653:5674],
-	30039: _ErrCode_name[5674:5699],
-	30040: _ErrCode_name[5699:5720],
-	30041: _ErrCode_name[5720:5739],
-	30042: _ErrCode_name[5739:5761],
-	30043: _ErrCode_name[5761:5782],
-	30044: _ErrCode_name[5782:5814],
-	32001: _ErrCode_name[5814:5829],
-	32002: _ErrCode_name[5829:5851],
-	32003: _ErrCode_name[5851:5868],
-	32004: _ErrCode_name[5868:5886],
-	34001: _ErrCode_name[5886:5910],
-	34002: _ErrCode_name[5910:5935],
-	34003: _ErrCode_name[5935:5959],
-	34004: _ErrCode_name[5959:5982],
-	34005: _ErrCode_name[5982:6004],
-	34006: _ErrCode_name[6004:6026],
-	34007: _ErrCode_name[6026:6048],
-	34008: _ErrCode_name[6048:6075],
-	34009: _ErrCode_name[6075:6099],
-	34010: _ErrCode_name[6099:6121],
-	34011: _ErrCode_name[6121:6145],
-	34012: _ErrCode_name[6145:6161],
-	34013: _ErrCode_name[6161:6180],
-	34014: _ErrCode_name[6180:6203],
-	34015: _ErrCode_name[6203:6229],
-	34016: _ErrCode_name[6229:6246],
-	34017: _ErrCode_name[6246:6268],
-	34018: _ErrCode_name[6268:6290],
-	34019: _ErrCode_name[6290:6310],
-	34020: _ErrCode_name[6310:6329],
-	34021: _ErrCode_name[6329:6350],
-	36001: _ErrCode_name[6350:6365],
-	36002: _ErrCode_name[6365:6389],
-	36003: _ErrCode_name[6389:6411],
-	36004: _ErrCode_name[6411:6434],
-	36005: _ErrCode_name[6434:6460],
-	36006: _ErrCode_name[6460:6493],
-	36007: _ErrCode_name[6493:6517],
-	36008: _ErrCode_name[6517:6541],
-	36009: _ErrCode_name[6541:6569],
-	36010: _ErrCode_name[6569:6590],
-	36011: _ErrCode_name[6590:6619],
-	36012: _ErrCode_name[6619:6643],
-	36013: _ErrCode_name[6643:6668],
-	36014: _ErrCode_name[6668:6693],
-	36015: _ErrCode_name[6693:6720],
-	36016: _ErrCode_name[6720:6749],
-	36017: _ErrCode_name[6749:6768],
-	36018: _ErrCode_name[6768:6791],
-	36019: _ErrCode_name[6791:6823],
-	36020: _ErrCode_name[6823:6844],
-	36021: _ErrCode_name[6844:6869],
-	36022: _ErrCode_name[6869:6897],
-	36023: _ErrCode_name[6897:6920],
-	36024: _ErrCode_name[6920:6952],
-	36025: _ErrCode_name[6952:6981],
-	36026: _ErrCode_name[6981:7005],
-	36027: _ErrCode_name[7005:7032],
-	36028: _ErrCode_name[7032:7064],
-	36029: _ErrCode_name[7064:7096],
-	36030: _ErrCode_name[7096:7126],
-	36031: _ErrCode_name[7126:7150],
-	36032: _ErrCode_name[7150:7176],
-	36033: _ErrCode_name[7176:7201],
-	36034: _ErrCode_name[7201:7227],
-	36035: _ErrCode_name[7227:7257],
-	36036: _ErrCode_name[7257:7288],
-	36037: _ErrCode_name[7288:7321],
-	36038: _ErrCode_name[7321:7354],
-	36039: _ErrCode_name[7354:7384],
-	36040: _ErrCode_name[7384:7419],
-	36041: _ErrCode_name[7419:7453],
-	36042: _ErrCode_name[7453:7483],
-	36043: _ErrCode_name[7483:7517],
-	36044: _ErrCode_name[7517:7550],
-	36045: _ErrCode_name[7550:7586],
-	36046: _ErrCode_name[7586:7620],
-	36047: _ErrCode_name[7620:7647],
-	36048: _ErrCode_name[7647:7678],
-	36049: _ErrCode_name[7678:7705],
-	36050: _ErrCode_name[7705:7735],
-	36051: _ErrCode_name[7735:7763],
-	36052: _ErrCode_name[7763:7794],
-	36053: _ErrCode_name[7794:7826],
-	36054: _ErrCode_name[7826:7850],
-	36055: _ErrCode_name[7850:7879],
-	36056: _ErrCode_name[7879:7909],
-	36057: _ErrCode_name[7909:7941],
-	36058: _ErrCode_name[7941:7973],
-	36059: _ErrCode_name[7973:8004],
-	36060: _ErrCode_name[8004:8023],
-	36061: _ErrCode_name[8023:8048],
-	36062: _ErrCode_name[8048:8070],
-	36063: _ErrCode_name[8070:8085],
-	36064: _ErrCode_name[8085:8096],
-	36065: _ErrCode_name[8096:8118],
-	36066: _ErrCode_name[8118:8137],
-	36067: _ErrCode_name[8137:8151],
-	36068: _ErrCode_name[8151:8172],
-	36069: _ErrCode_name[8172:8186],
-	36070: _ErrCode_name[8186:8215],
-	36071: _ErrCode_name[8215:8246],
-	38001: _ErrCode_name[8246:8267],
-	38002: _ErrCode_name[8267:8288],
-	38003: _ErrCode_name[8288:8314],
-	38004: _ErrCode_name[8314:8334],
-	38005: _ErrCode_name[8334:8359],
-	38006: _ErrCode_name[8359:8380],
-	38007: _ErrCode_name[8380:8404],
-	38008: _ErrCode_name[8404:8426],
-	38009: _ErrCode_name[8426:8450],
-	38010: _ErrCode_name[8450:8474],
-	38011: _ErrCode_name[8474:8497],
-	38012: _ErrCode_name[8497:8520],
-	38013: _ErrCode_name[8520:8545],
-	38014: _ErrCode_name[8545:8569],
-	38015: _ErrCode_name[8569:8594],
-	38016: _ErrCode_name[8594:8615],
-	38017: _ErrCode_name[8615:8633],
-	38018: _ErrCode_name[8633:8650],
-	38019: _ErrCode_name[8650:8668],
-	38020: _ErrCode_name[8668:8689],
-	38021: _ErrCode_name[8689:8712],
-	38022: _ErrCode_name[8712:8735],
-	38023: _ErrCode_name[8735:8757],
-	38024: _ErrCode_name[8757:8775],
-	38025: _ErrCode_name[8775:8802],
-	38026: _ErrCode_name[8802:8826],
-	38027: _ErrCode_name[8826:8853],
-	38028: _ErrCode_name[8853:8878],
-	38029: _ErrCode_name[8878:8903],
-	38030: _ErrCode_name[8903:8926],
-	38031: _ErrCode_name[8926:8944],
-	38032: _ErrCode_name[8944:8968],
-	38033: _ErrCode_name[8968:8992],
-	38034: _ErrCode_name[8992:9012],
-	38035: _ErrCode_name[9012:9034],
-	38036: _ErrCode_name[9034:9055],
-	38037: _ErrCode_name[9055:9083],
-	38038: _ErrCode_name[9083:9107],
-	38039: _ErrCode_name[9107:9125],
-	38040: _ErrCode_name[9125:9148],
-	38041: _ErrCode_name[9148:9170],
-	38042: _ErrCode_name[9170:9197],
-	38043: _ErrCode_name[9197:9230],
-	38044: _ErrCode_name[9230:9253],
-	38045: _ErrCode_name[9253:9280],
-	38046: _ErrCode_name[9280:9305],
-	38047: _ErrCode_name[9305:9329],
-	38048: _ErrCode_name[9329:9353],
-	38049: _ErrCode_name[9353:9377],
-	38050: _ErrCode_name[9377:9408],
-	38051: _ErrCode_name[9408:9431],
-	38052: _ErrCode_name[9431:9450],
-	38053: _ErrCode_name[9450:9476],
-	38054: _ErrCode_name[9476:9513],
-	38055: _ErrCode_name[9513:9552],
-	38056: _ErrCode_name[9552:9590],
-	38057: _ErrCode_name[9590:9612],
-	38058: _ErrCode_name[9612:9627],
-	40001: _ErrCode_name[9627:9645],
-	40002: _ErrCode_name[9645:9662],
-	40003: _ErrCode_name[9662:9688],
-	40004: _ErrCode_name[9688:9715],
-	40005: _ErrCode_name[9715:9733],
-	40006: _ErrCode_name[9733:9754],
-	40007: _ErrCode_name[9754:9775],
-	40008: _ErrCode_name[9775:9796],
-	40009: _ErrCode_name[9796:9819],
-	40010: _ErrCode_name[9819:9842],
-	40011: _ErrCode_name[9842:9863],
-	40012: _ErrCode_name[9863:9888],
-	40013: _ErrCode_name[9888:9909],
-	40014: _ErrCode_name[9909:9933],
-	40015: _ErrCode_name[9933:9958],
-	40016: _ErrCode_name[9958:9979],
-	40017: _ErrCode_name[9979:9998],
-	40018: _ErrCode_name[9998:10022],
-	40019: _ErrCode_name[10022:10045],
-	40020: _ErrCode_name[10045:10065],
-	40021: _ErrCode_name[10065:10082],
-	40022: _ErrCode_name[10082:10099],
-	40023: _ErrCode_name[10099:10120],
-	40024: _ErrCode_name[10120:10146],
-	40025: _ErrCode_name[10146:10172],
-	40026: _ErrCode_name[10172:10195],
-	40027: _ErrCode_name[10195:10216],
-	40028: _ErrCode_name[10216:10236],
-	40029: _ErrCode_name[10236:10259],
-	40030: _ErrCode_name[10259:10282],
-	40031: _ErrCode_name[10282:10303],
-	40032: _ErrCode_name[10303:10324],
-	40033: _ErrCode_name[10324:10344],
-	40034: _ErrCode_name[10344:10366],
-	40035: _ErrCode_name[10366:10391],
-	40036: _ErrCode_name[10391:10416],
-	40037: _ErrCode_name[10416:10433],
-	40038: _ErrCode_name[10433:10452],
-	40039: _ErrCode_name[10452:10476],
-	40040: _ErrCode_name[10476:10501],
-	40041: _ErrCode_name[10501:10519],
-	40042: _ErrCode_name[10519:10542],
-	40043: _ErrCode_name[10542:10564],
-	40044: _ErrCode_name[10564:10588],
-	40045: _ErrCode_name[10588:10610],
-	40046: _ErrCode_name[10610:10631],
-	40047: _ErrCode_name[10631:10653],
-	40048: _ErrCode_name[10653:10671],
-	40049: _ErrCode_name[10671:10690],
-	40050: _ErrCode_name[10690:10711],
-	40051: _ErrCode_name[10711:10731],
-	40052: _ErrCode_name[10731:10752],
-	40053: _ErrCode_name[10752:10774],
-	40054: _ErrCode_name[10774:10795],
-	40055: _ErrCode_name[10795:10814],
-	40056: _ErrCode_name[10814:10836],
-	40057: _ErrCode_name[10836:10856],
-	40058: _ErrCode_name[10856:10877],
-	40059: _ErrCode_name[10877:10903],
-	40060: _ErrCode_name[10903:10921],
-	40061: _ErrCode_name[10921:10946],
-	40062: _ErrCode_name[10946:10969],
-	40063: _ErrCode_name[10969:10993],
-	40064: _ErrCode_name[10993:11018],
-	40065: _ErrCode_name[11018:11041],
-	40066: _ErrCode_name[11041:11061],
-	40067: _ErrCode_name[11061:11090],
-	40068: _ErrCode_name[11090:11110],
-	40069: _ErrCode_name[11110:11132],
-	40070: _ErrCode_name[11132:11145],
-	40071: _ErrCode_name[11145:11165],
-	40072: _ErrCode_name[11165:11185],
-	40073: _ErrCode_name[11185:11221],
-	40074: _ErrCode_name[11221:11256],
-	40075: _ErrCode_name[11256:11279],
-	40076: _ErrCode_name[11279:11302],
-	40077: _ErrCode_name[11302:11325],
-	40078: _ErrCode_name[11325:11351],
-	40079: _ErrCode_name[11351:11376],
-	40080: _ErrCode_name[11376:11400],
-	40081: _ErrCode_name[11400:11425],
-	40082: _ErrCode_name[11425:11449],
-	40083: _ErrCode_name[11449:11467],
-	42001: _ErrCode_name[11467:11485],
-	42002: _ErrCode_name[11485:11510],
-	42003: _ErrCode_name[11510:11533],
-	42004: _ErrCode_name[11533:11557],
-	42005: _ErrCode_name[11557:11581],
-	42006: _ErrCode_name[11581:11600],
-	42007: _ErrCode_name[11600:11620],
-	42008: _ErrCode_name[11620:11644],
-	42009: _ErrCode_name[11644:11667],
-	42010: _ErrCode_name[11667:11685],
-	42501: _ErrCode_name[11685:11703],
-	42502: _ErrCode_name[11703:11716],
-	42503: _ErrCode_name[11716:11731],
-	42504: _ErrCode_name[11731:11751],
-	42505: _ErrCode_name[11751:11766],
-	43001: _ErrCode_name[11766:11792],
-	43002: _ErrCode_name[11792:11812],
-	43003: _ErrCode_name[11812:11829],
-	43004: _ErrCode_name[11829:11853],
-	43005: _ErrCode_name[11853:11876],
-	43006: _ErrCode_name[11876:11893],
-	43007: _ErrCode_name[11893:11907],
-	43008: _ErrCode_name[11907:11930],
-	44001: _ErrCode_name[11930:11954],
-	44002: _ErrCode_name[11954:11985],
-	44003: _ErrCode_name[11985:12015],
-	44004: _ErrCode_name[12015:12043],
-	44005: _ErrCode_name[12043:12070],
-	44006: _ErrCode_name[12070:12096],
-	44007: _ErrCode_name[12096:12135],
-	44008: _ErrCode_name[12135:12174],
-	44009: _ErrCode_name[12174:12209],
-	44010: _ErrCode_name[12209:12237],
-	44011: _ErrCode_name[12237:12265],
-	44012: _ErrCode_name[12265:12282],
-	44013: _ErrCode_name[12282:12306],
-	44014: _ErrCode_name[12306:12332],
-	44015: _ErrCode_name[12332:12361],
-	44016: _ErrCode_name[12361:12400],
-	44017: _ErrCode_name[12400:12439],
-	44018: _ErrCode_name[12439:12477],
-	44019: _ErrCode_name[12477:12526],
-	44020: _ErrCode_name[12526:12547],
-	46001: _ErrCode_name[12547:12566],
-	46002: _ErrCode_name[12566:12582],
-	46003: _ErrCode_name[12582:12602],
-	46004: _ErrCode_name[12602:12625],
-	46005: _ErrCode_name[12625:12646],
-	46006: _ErrCode_name[12646:12673],
-	46007: _ErrCode_name[12673:12696],
-	46008: _ErrCode_name[12696:12722],
-	46009: _ErrCode_name[12722:12745],
-	46010: _ErrCode_name[12745:12771],
-	46011: _ErrCode_name[12771:12803],
-	46012: _ErrCode_name[12803:12836],
-	46013: _ErrCode_name[12836:12854],
-	46014: _ErrCode_name[12854:12875],
-	46015: _ErrCode_name[12875:12909],
-	46016: _ErrCode_name[12909:12939],
-	46017: _ErrCode_name[12939:12971],
-	46018: _ErrCode_name[12971:12992],
-	46019: _ErrCode_name[12992:13029],
-	46020: _ErrCode_name[13029:13054],
-	46021: _ErrCode_name[13054:13080],
-	46022: _ErrCode_name[13080:13111],
-	46023: _ErrCode_name[13111:13138],
-	46024: _ErrCode_name[13138:13157],
-	46025: _ErrCode_name[13157:13181],
-	46026: _ErrCode_name[13181:13206],
-	46027: _ErrCode_name[13206:13240],
-	46028: _ErrCode_name[13240:13270],
-	46029: _ErrCode_name[13270:13299],
-	46030: _ErrCode_name[13299:13325],
-	46031: _ErrCode_name[13325:13350],
-	46032: _ErrCode_name[13350:13385],
-	46033: _ErrCode_name[13385:13407],
-	46034: _ErrCode_name[13407:13431],
-	46035: _ErrCode_name[13431:13456],
-	48001: _ErrCode_name[13456:13473],
-	48002: _ErrCode_name[13473:13489],
-	48003: _ErrCode_name[13489:13502],
-	49001: _ErrCode_name[13502:13515],
-	49002: _ErrCode_name[13515:13540],
-	50000: _ErrCode_name[13540:13546],
+	20068: _ErrCode_name[4291:4320],
+	20069: _ErrCode_name[4320:4348],
+	20070: _ErrCode_name[4348:4378],
+	20071: _ErrCode_name[4378:4408],
+	20072: _ErrCode_name[4408:4433],
+	22001: _ErrCode_name[4433:4454],
+	22002: _ErrCode_name[4454:4475],
+	22003: _ErrCode_name[4475:4496],
+	24001: _ErrCode_name[4496:4521],
+	24002: _ErrCode_name[4521:4545],
+	24003: _ErrCode_name[4545:4571],
+	24004: _ErrCode_name[4571:4597],
+	24005: _ErrCode_name[4597:4626],
+	24006: _ErrCode_name[4626:4655],
+	26001: _ErrCode_name[4655:4677],
+	26002: _ErrCode_name[4677:4698],
+	26003: _ErrCode_name[4698:4721],
+	26004: _ErrCode_name[4721:4746],
+	26005: _ErrCode_name[4746:4770],
+	26006: _ErrCode_name[4770:4788],
+	26007: _ErrCode_name[4788:4803],
+	28001: _ErrCode_name[4803:4822],
+	28002: _ErrCode_name[4822:4842],
+	28003: _ErrCode_name[4842:4869],
+	28004: _ErrCode_name[4869:4892],
+	28005: _ErrCode_name[4892:4915],
+	30001: _ErrCode_name[4915:4938],
+	30002: _ErrCode_name[4938:4965],
+	30003: _ErrCode_name[4965:4982],
+	30004: _ErrCode_name[4982:5005],
+	30005: _ErrCode_name[5005:5023],
+	30006: _ErrCode_name[5023:5042],
+	30007: _ErrCode_name[5042:5062],
+	30008: _ErrCode_name[5062:5082],
+	30009: _ErrCode_name[5082:5104],
+	30010: _ErrCode_name[5104:5131],
+	30011: _ErrCode_name[5131:5151],
+	30012: _ErrCode_name[5151:5174],
+	30013: _ErrCode_name[5174:5195],
+	30014: _ErrCode_name[5195:5222],
+	30015: _ErrCode_name[5222:5244],
+	30016: _ErrCode_name[5244:5266],
+	30017: _ErrCode_name[5266:5293],
+	30018: _ErrCode_name[5293:5313],
+	30019: _ErrCode_name[5313:5333],
+	30020: _ErrCode_name[5333:5358],
+	30021: _ErrCode_name[5358:5389],
+	30022: _ErrCode_name[5389:5414],
+	30023: _ErrCode_name[5414:5436],
+	30024: _ErrCode_name[5436:5466],
+	30025: _ErrCode_name[5466:5488],
+	30026: _ErrCode_name[5488:5519],
+	30027: _ErrCode_name[5519:5549],
+	30028: _ErrCode_name[5549:5581],
+	30029: _ErrCode_name[5581:5607],
+	30030: _ErrCode_name[5607:5622],
+	30031: _ErrCode_name[5622:5653],
+	30032: _ErrCode_name[5653:5686],
+	30033: _ErrCode_name[5686:5696],
+	30034: _ErrCode_name[5696:5721],
+	30035: _ErrCode_name[5721:5747],
+	30036: _ErrCode_name[5747:5774],
+	30037: _ErrCode_name[5774:5795],
+	30038: _ErrCode_name[5795:5816],
+	30039: _ErrCode_name[5816:5841],
+	30040: _ErrCode_name[5841:5862],
+	30041: _ErrCode_name[5862:5881],
+	30042: _ErrCode_name[5881:5903],
+	30043: _ErrCode_name[5903:5924],
+	30044: _ErrCode_name[5924:5956],
+	32001: _ErrCode_name[5956:5971],
+	32002: _ErrCode_name[5971:5993],
+	32003: _ErrCode_name[5993:6010],
+	32004: _ErrCode_name[6010:6028],
+	34001: _ErrCode_name[6028:6052],
+	34002: _ErrCode_name[6052:6077],
+	34003: _ErrCode_name[6077:6101],
+	34004: _ErrCode_name[6101:6124],
+	34005: _ErrCode_name[6124:6146],
+	34006: _ErrCode_name[6146:6168],
+	34007: _ErrCode_name[6168:6190],
+	34008: _ErrCode_name[6190:6217],
+	34009: _ErrCode_name[6217:6241],
+	34010: _ErrCode_name[6241:6263],
+	34011: _ErrCode_name[6263:6287],
+	34012: _ErrCode_name[6287:6303],
+	34013: _ErrCode_name[6303:6322],
+	34014: _ErrCode_name[6322:6345],
+	34015: _ErrCode_name[6345:6371],
+	34016: _ErrCode_name[6371:6388],
+	34017: _ErrCode_name[6388:6410],
+	34018: _ErrCode_name[6410:6432],
+	34019: _ErrCode_name[6432:6452],
+	34020: _ErrCode_name[6452:6471],
+	34021: _ErrCode_name[6471:6492],
+	36001: _ErrCode_name[6492:6507],
+	36002: _ErrCode_name[6507:6531],
+	36003: _ErrCode_name[6531:6553],
+	36004: _ErrCode_name[6553:6576],
+	36005: _ErrCode_name[6576:6602],
+	36006: _ErrCode_name[6602:6635],
+	36007: _ErrCode_name[6635:6659],
+	36008: _ErrCode_name[6659:6683],
+	36009: _ErrCode_name[6683:6711],
+	36010: _ErrCode_name[6711:6732],
+	36011: _ErrCode_name[6732:6761],
+	36012: _ErrCode_name[6761:6785],
+	36013: _ErrCode_name[6785:6810],
+	36014: _ErrCode_name[6810:6835],
+	36015: _ErrCode_name[6835:6862],
+	36016: _ErrCode_name[6862:6891],
+	36017: _ErrCode_name[6891:6910],
+	36018: _ErrCode_name[6910:6933],
+	36019: _ErrCode_name[6933:6965],
+	36020: _ErrCode_name[6965:6986],
+	36021: _ErrCode_name[6986:7011],
+	36022: _ErrCode_name[7011:7039],
+	36023: _ErrCode_name[7039:7062],
+	36024: _ErrCode_name[7062:7094],
+	36025: _ErrCode_name[7094:7123],
+	36026: _ErrCode_name[7123:7147],
+	36027: _ErrCode_name[7147:7174],
+	36028: _ErrCode_name[7174:7206],
+	36029: _ErrCode_name[7206:7238],
+	36030: _ErrCode_name[7238:7268],
+	36031: _ErrCode_name[7268:7292],
+	36032: _ErrCode_name[7292:7318],
+	36033: _ErrCode_name[7318:7343],
+	36034: _ErrCode_name[7343:7369],
+	36035: _ErrCode_name[7369:7399],
+	36036: _ErrCode_name[7399:7430],
+	36037: _ErrCode_name[7430:7463],
+	36038: _ErrCode_name[7463:7496],
+	36039: _ErrCode_name[7496:7526],
+	36040: _ErrCode_name[7526:7561],
+	36041: _ErrCode_name[7561:7595],
+	36042: _ErrCode_name[7595:7625],
+	36043: _ErrCode_name[7625:7659],
+	36044: _ErrCode_name[7659:7692],
+	36045: _ErrCode_name[7692:7728],
+	36046: _ErrCode_name[7728:7762],
+	36047: _ErrCode_name[7762:7789],
+	36048: _ErrCode_name[7789:7820],
+	36049: _ErrCode_name[7820:7847],
+	36050: _ErrCode_name[7847:7877],
+	36051: _ErrCode_name[7877:7905],
+	36052: _ErrCode_name[7905:7936],
+	36053: _ErrCode_name[7936:7968],
+	36054: _ErrCode_name[7968:7992],
+	36055: _ErrCode_name[7992:8021],
+	36056: _ErrCode_name[8021:8051],
+	36057: _ErrCode_name[8051:8083],
+	36058: _ErrCode_name[8083:8115],
+	36059: _ErrCode_name[8115:8146],
+	36060: _ErrCode_name[8146:8165],
+	36061: _ErrCode_name[8165:8190],
+	36062: _ErrCode_name[8190:8212],
+	36063: _ErrCode_name[8212:8227],
+	36064: _ErrCode_name[8227:8238],
+	36065: _ErrCode_name[8238:8260],
+	36066: _ErrCode_name[8260:8279],
+	36067: _ErrCode_name[8279:8293],
+	36068: _ErrCode_name[8293:8314],
+	36069: _ErrCode_name[8314:8328],
+	36070: _ErrCode_name[8328:8357],
+	36071: _ErrCode_name[8357:8388],
+	36072: _ErrCode_name[8388:8416],
+	36073: _ErrCode_name[8416:8442],
+	36074: _ErrCode_name[8442:8469],
+	38001: _ErrCode_name[8469:8490],
+	38002: _ErrCode_name[8490:8511],
+	38003: _ErrCode_name[8511:8537],
+	38004: _ErrCode_name[8537:8557],
+	38005: _ErrCode_name[8557:8582],
+	38006: _ErrCode_name[8582:8603],
+	38007: _ErrCode_name[8603:8627],
+	38008: _ErrCode_name[8627:8649],
+	38009: _ErrCode_name[8649:8673],
+	38010: _ErrCode_name[8673:8697],
+	38011: _ErrCode_name[8697:8720],
+	38012: _ErrCode_name[8720:8743],
+	38013: _ErrCode_name[8743:8768],
+	38014: _ErrCode_name[8768:8792],
+	38015: _ErrCode_name[8792:8817],
+	38016: _ErrCode_name[8817:8838],
+	38017: _ErrCode_name[8838:8856],
+	38018: _ErrCode_name[8856:8873],
+	38019: _ErrCode_name[8873:8891],
+	38020: _ErrCode_name[8891:8912],
+	38021: _ErrCode_name[8912:8935],
+	38022: _ErrCode_name[8935:8958],
+	38023: _ErrCode_name[8958:8980],
+	38024: _ErrCode_name[8980:8998],
+	38025: _ErrCode_name[8998:9025],
+	38026: _ErrCode_name[9025:9049],
+	38027: _ErrCode_name[9049:9076],
+	38028: _ErrCode_name[9076:9101],
+	38029: _ErrCode_name[9101:9126],
+	38030: _ErrCode_name[9126:9149],
+	38031: _ErrCode_name[9149:9167],
+	38032: _ErrCode_name[9167:9191],
+	38033: _ErrCode_name[9191:9215],
+	38034: _ErrCode_name[9215:9235],
+	38035: _ErrCode_name[9235:9257],
+	38036: _ErrCode_name[9257:9278],
+	38037: _ErrCode_name[9278:9306],
+	38038: _ErrCode_name[9306:9330],
+	38039: _ErrCode_name[9330:9348],
+	38040: _ErrCode_name[9348:9371],
+	38041: _ErrCode_name[9371:9393],
+	38042: _ErrCode_name[9393:9420],
+	38043: _ErrCode_name[9420:9453],
+	38044: _ErrCode_name[9453:9476],
+	38045: _ErrCode_name[9476:9503],
+	38046: _ErrCode_name[9503:9528],
+	38047: _ErrCode_name[9528:9552],
+	38048: _ErrCode_name[9552:9576],
+	38049: _ErrCode_name[9576:9600],
+	38050: _ErrCode_name[9600:9631],
+	38051: _ErrCode_name[9631:9654],
+	38052: _ErrCode_name[9654:9673],
+	38053: _ErrCode_name[9673:9699],
+	38054: _ErrCode_name[9699:9736],
+	38055: _ErrCode_name[9736:9775],
+	38056: _ErrCode_name[9775:9813],
+	38057: _ErrCode_name[9813:9835],
+	38058: _ErrCode_name[9835:9850],
+	40001: _ErrCode_name[9850:9868],
+	40002: _ErrCode_name[9868:9885],
+	40003: _ErrCode_name[9885:9911],
+	40004: _ErrCode_name[9911:9938],
+	40005: _ErrCode_name[9938:9956],
+	40006: _ErrCode_name[9956:9977],
+	40007: _ErrCode_name[9977:9998],
+	40008: _ErrCode_name[9998:10019],
+	40009: _ErrCode_name[10019:10042],
+	40010: _ErrCode_name[10042:10065],
+	40011: _ErrCode_name[10065:10086],
+	40012: _ErrCode_name[10086:10111],
+	40013: _ErrCode_name[10111:10132],
+	40014: _ErrCode_name[10132:10156],
+	40015: _ErrCode_name[10156:10181],
+	40016: _ErrCode_name[10181:10202],
+	40017: _ErrCode_name[10202:10221],
+	40018: _ErrCode_name[10221:10245],
+	40019: _ErrCode_name[10245:10268],
+	40020: _ErrCode_name[10268:10288],
+	40021: _ErrCode_name[10288:10305],
+	40022: _ErrCode_name[10305:10322],
+	40023: _ErrCode_name[10322:10343],
+	40024: _ErrCode_name[10343:10369],
+	40025: _ErrCode_name[10369:10395],
+	40026: _ErrCode_name[10395:10418],
+	40027: _ErrCode_name[10418:10439],
+	40028: _ErrCode_name[10439:10459],
+	40029: _ErrCode_name[10459:10482],
+	40030: _ErrCode_name[10482:10505],
+	40031: _ErrCode_name[10505:10526],
+	40032: _ErrCode_name[10526:10547],
+	40033: _ErrCode_name[10547:10567],
+	40034: _ErrCode_name[10567:10589],
+	40035: _ErrCode_name[10589:10614],
+	40036: _ErrCode_name[10614:10639],
+	40037: _ErrCode_name[10639:10656],
+	40038: _ErrCode_name[10656:10675],
+	40039: _ErrCode_name[10675:10699],
+	40040: _ErrCode_name[10699:10724],
+	40041: _ErrCode_name[10724:10742],
+	40042: _ErrCode_name[10742:10765],
+	40043: _ErrCode_name[10765:10787],
+	40044: _ErrCode_name[10787:10811],
+	40045: _ErrCode_name[10811:10833],
+	40046: _ErrCode_name[10833:10854],
+	40047: _ErrCode_name[10854:10876],
+	40048: _ErrCode_name[10876:10894],
+	40049: _ErrCode_name[10894:10913],
+	40050: _ErrCode_name[10913:10934],
+	40051: _ErrCode_name[10934:10954],
+	40052: _ErrCode_name[10954:10975],
+	40053: _ErrCode_name[10975:10997],
+	40054: _ErrCode_name[10997:11018],
+	40055: _ErrCode_name[11018:11037],
+	40056: _ErrCode_name[11037:11059],
+	40057: _ErrCode_name[11059:11079],
+	40058: _ErrCode_name[11079:11100],
+	40059: _ErrCode_name[11100:11126],
+	40060: _ErrCode_name[11126:11144],
+	40061: _ErrCode_name[11144:11169],
+	40062: _ErrCode_name[11169:11192],
+	40063: _ErrCode_name[11192:11216],
+	40064: _ErrCode_name[11216:11241],
+	40065: _ErrCode_name[11241:11264],
+	40066: _ErrCode_name[11264:11284],
+	40067: _ErrCode_name[11284:11313],
+	40068: _ErrCode_name[11313:11333],
+	40069: _ErrCode_name[11333:11355],
+	40070: _ErrCode_name[11355:11368],
+	40071: _ErrCode_name[11368:11388],
+	40072: _ErrCode_name[11388:11408],
+	40073: _ErrCode_name[11408:11444],
+	40074: _ErrCode_name[11444:11479],
+	40075: _ErrCode_name[11479:11502],
+	40076: _ErrCode_name[11502:11525],
+	40077: _ErrCode_name[11525:11548],
+	40078: _ErrCode_name[11548:11574],
+	40079: _ErrCode_name[11574:11599],
+	40080: _ErrCode_name[11599:11623],
+	40081: _ErrCode_name[11623:11648],
+	40082: _ErrCode_name[11648:11672],
+	40083: _ErrCode_name[11672:11690],
+	42001: _ErrCode_name[11690:11708],
+	42002: _ErrCode_name[11708:11733],
+	42003: _ErrCode_name[11733:11756],
+	42004: _ErrCode_name[11756:11780],
+	42005: _ErrCode_name[11780:11804],
+	42006: _ErrCode_name[11804:11823],
+	42007: _ErrCode_name[11823:11843],
+	42008: _ErrCode_name[11843:11867],
+	42009: _ErrCode_name[11867:11890],
+	42010: _ErrCode_name[11890:11908],
+	42501: _ErrCode_name[11908:11926],
+	42502: _ErrCode_name[11926:11939],
+	42503: _ErrCode_name[11939:11954],
+	42504: _ErrCode_name[11954:11974],
+	42505: _ErrCode_name[11974:11989],
+	43001: _ErrCode_name[11989:12015],
+	43002: _ErrCode_name[12015:12035],
+	43003: _ErrCode_name[12035:12052],
+	43004: _ErrCode_name[12052:12076],
+	43005: _ErrCode_name[12076:12099],
+	43006: _ErrCode_name[12099:12116],
+	43007: _ErrCode_name[12116:12130],
+	43008: _ErrCode_name[12130:12153],
+	44001: _ErrCode_name[12153:12177],
+	44002: _ErrCode_name[12177:12208],
+	44003: _ErrCode_name[12208:12238],
+	44004: _ErrCode_name[12238:12266],
+	44005: _ErrCode_name[12266:12293],
+	44006: _ErrCode_name[12293:12319],
+	44007: _ErrCode_name[12319:12358],
+	44008: _ErrCode_name[12358:12397],
+	44009: _ErrCode_name[12397:12432],
+	44010: _ErrCode_name[12432:12460],
+	44011: _ErrCode_name[12460:12488],
+	44012: _ErrCode_name[12488:12505],
+	44013: _ErrCode_name[12505:12529],
+	44014: _ErrCode_name[12529:12555],
+	44015: _ErrCode_name[12555:12584],
+	44016: _ErrCode_name[12584:12623],
+	44017: _ErrCode_name[12623:12662],
+	44018: _ErrCode_name[12662:12700],
+	44019: _ErrCode_name[12700:12749],
+	44020: _ErrCode_name[12749:12770],
+	46001: _ErrCode_name[12770:12789],
+	46002: _ErrCode_name[12789:12805],
+	46003: _ErrCode_name[12805:12825],
+	46004: _ErrCode_name[12825:12848],
+	46005: _ErrCode_name[12848:12869],
+	46006: _ErrCode_name[12869:12896],
+	46007: _ErrCode_name[12896:12919],
+	46008: _ErrCode_name[12919:12945],
+	46009: _ErrCode_name[12945:12968],
+	46010: _ErrCode_name[12968:12994],
+	46011: _ErrCode_name[12994:13026],
+	46012: _ErrCode_name[13026:13059],
+	46013: _ErrCode_name[13059:13077],
+	46014: _ErrCode_name[13077:13098],
+	46015: _ErrCode_name[13098:13132],
+	46016: _ErrCode_name[13132:13162],
+	46017: _ErrCode_name[13162:13194],
+	46018: _ErrCode_name[13194:13215],
+	46019: _ErrCode_name[13215:13252],
+	46020: _ErrCode_name[13252:13277],
+	46021: _ErrCode_name[13277:13303],
+	46022: _ErrCode_name[13303:13334],
+	46023: _ErrCode_name[13334:13361],
+	46024: _ErrCode_name[13361:13380],
+	46025: _ErrCode_name[13380:13404],
+	46026: _ErrCode_name[13404:13429],
+	46027: _ErrCode_name[13429:13463],
+	46028: _ErrCode_name[13463:13493],
+	46029: _ErrCode_name[13493:13522],
+	46030: _ErrCode_name[13522:13548],
+	46031: _ErrCode_name[13548:13573],
+	46032: _ErrCode_name[13573:13608],
+	46033: _ErrCode_name[13608:13630],
+	46034: _ErrCode_name[13630:13654],
+	46035: _ErrCode_name[13654:13679],
+	48001: _ErrCode_name[13679:13696],
+	48002: _ErrCode_name[13696:13712],
+	48003: _ErrCode_name[13712:13725],
+	49001: _ErrCode_name[13725:13738],
+	49002: _ErrCode_name[13738:13763],
+	50000: _ErrCode_name[13763:13769],
 }
 
 func (i ErrCode) String() string {
@@ -272,6 +272,11 @@ const (
 	codeConfigInvalidLoadAnalyze
 	codeConfigStrictOptimisticShardMode
 	codeConfigSecretKeyPath
+	codeConfigInvalidAppendOnlyTables
+	codeConfigOpenAPITaskConfigStale
+	codeConfigOpenAPITaskConfigInvalid
+	codeConfigOpenAPITaskConfigCorrupt
+	codeConfigInvalidSyncerConfig
 )
 
 // Binlog operation error code list.
@@ -465,6 +470,9 @@ const (
 	codeSyncerGetEvent
 	codeSyncerDownstreamTableNotFound
 	codeSyncerReprocessWithSafeModeFail
+	codeSyncerCausalityIndexNotFound
+	codeSyncerConflictFlushTimeout
+	codeSyncerCausalityVerifyFailed
 )
 
 // DM-master error code.
@@ -985,6 +993,11 @@ var (
 	ErrConfigInvalidLoadAnalyze                 = New(codeConfigInvalidLoadAnalyze, ClassConfig, ScopeInternal, LevelMedium, "invalid load analyze option '%s'", "Please choose a valid value in ['required', 'optional', 'off'] or leave it empty.")
 	ErrConfigStrictOptimisticShardMode          = New(codeConfigStrictOptimisticShardMode, ClassConfig, ScopeInternal, LevelMedium, "cannot enable `strict-optimistic-shard-mode` while `shard-mode` is not `optimistic`", "Please set `shard-mode` to `optimistic` if you want to enable `strict-optimistic-shard-mode`.")
 	ErrConfigSecretKeyPath                      = New(codeConfigSecretKeyPath, ClassConfig, ScopeInternal, LevelHigh, "invalid secret key path or content: %v", "Please check whether the path is valid, and has required permission to read the file, and the key is correct.")
+	ErrConfigInvalidAppendOnlyTables            = New(codeConfigInvalidAppendOnlyTables, ClassConfig, ScopeInternal, LevelMedium, "invalid append-only-tables %v", "Please check the `append-only-tables` config in task configuration file.")
+	ErrOpenAPITaskConfigStale                   = New(codeConfigOpenAPITaskConfigStale, ClassConfig, ScopeInternal, LevelLow, "the openapi task config for '%s' has been modified, expected revision %d, current revision %d", "Please get the latest openapi task config and try again.")
+	ErrOpenAPITaskConfigInvalid                 = New(codeConfigOpenAPITaskConfigInvalid, ClassConfig, ScopeInternal, LevelLow, "the openapi task config for '%s' is invalid: %s", "Please check the openapi task config.")
+	ErrOpenAPITaskConfigCorrupt                 = New(codeConfigOpenAPITaskConfigCorrupt, ClassConfig, ScopeInternal, LevelHigh, "the openapi task config in etcd is corrupted, expected checksum %08x, actual checksum %08x", "Please check the data in etcd and put the openapi task config again.")
+	ErrConfigInvalidSyncerConfig                = New(codeConfigInvalidSyncerConfig, ClassConfig, ScopeInternal, LevelMedium, "invalid %d for `%s` of syncer config, it should not be negative", "Please check the syncer config in task configuration file.")
 
 	// Binlog operation error.
 	ErrBinlogExtractPosition = New(codeBinlogExtractPosition, ClassBinlogOp, ScopeInternal, LevelHigh, "", "")
@@ -1164,6 +1177,9 @@ var (
 	ErrSyncerDownstreamTableNotFound        = New(codeSyncerDownstreamTableNotFound, ClassSyncUnit, ScopeInternal, LevelHigh, "downstream table %s not found", "")
 	ErrSyncerCancelledDDL                   = New(codeSyncerCancelledDDL, ClassSyncUnit, ScopeInternal, LevelHigh, "DDL %s executed in background and met error", "Please manually check the error from TiDB and handle it.")
 	ErrSyncerReprocessWithSafeModeFail      = New(codeSyncerReprocessWithSafeModeFail, ClassSyncUnit, ScopeInternal, LevelMedium, "your `safe-mode-duration` in task.yaml is set to 0s, the task can't be re-processed without safe mode currently", "Please stop and re-start this task. If you want to start task successfully, you need set `safe-mode-duration` greater than `0s`.")
+	ErrSyncerCausalityIndexNotFound         = New(codeSyncerCausalityIndexNotFound, ClassSyncUnit, ScopeDownstream, LevelHigh, "index %s configured in `causality-indexes` is not a unique index of downstream table %s", "Please check the `causality-indexes` config and the downstream table structure.")
+	ErrSyncerConflictFlushTimeout           = New(codeSyncerConflictFlushTimeout, ClassSyncUnit, ScopeDownstream, LevelHigh, "DML workers %v are not drained by the conflict job in %s", "Please check whether the downstream is slow or blocked, or increase `conflict-flush-timeout`.")
+	ErrSyncerCausalityVerifyFailed          = New(codeSyncerCausalityVerifyFailed, ClassSyncUnit, ScopeInternal, LevelHigh, "causality verification finds sampled rows executed out of the order decided by causality", "Please report it as a bug of causality with the logs, and disable `causality-verify-sample-rate` outside staging.")
 
 	// DM-master error.
 	ErrMasterSQLOpNilRequest        = New(codeMasterSQLOpNilRequest, ClassDMMaster, ScopeInternal, LevelMedium, "nil request not valid", "")
@@ -0,0 +1,52 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terror
+
+// retryableCodes are codes of the errors which are transient, the operation failed with them can be retried
+// as a whole (e.g. re-read the latest revision and try again), other errors need human intervention.
+var retryableCodes = map[ErrCode]struct{}{
+	// etcd is temporarily unavailable.
+	codeUpgradeVersionEtcdFail: {},
+	codeHAFailTxnOperation:     {},
+	codeHAFailWatchEtcd:        {},
+	codeHAFailLeaseOperation:   {},
+	codeHAFailKeepalive:        {},
+	// conflicts with concurrent modifications.
+	codeConfigOpenAPITaskConfigStale:     {},
+	codeSyncerUnitUpdateConfigInSharding: {},
+	codeMasterBoundChanging:              {},
+	codeWorkerRelayConfigChanging:        {},
+	codeSchedulerLatchInUse:              {},
+	// relay log is being purged.
+	codeRelayPurgeIsForbidden: {},
+	codeWorkerRelayIsPurging:  {},
+}
+
+// Retryable returns whether the error is transient and the failed operation can be retried.
+func (e *Error) Retryable() bool {
+	_, ok := retryableCodes[e.code]
+	return ok
+}
+
+// IsRetryable returns whether err is transient. It's decided by the outermost *Error in the chain of err,
+// because the outer error knows better about the failed operation. It returns false if there's no *Error.
+func IsRetryable(err error) bool {
+	for i := 0; err != nil && i < maxErrorChainDepth; i++ {
+		if e, ok := err.(*Error); ok {
+			return e.Retryable()
+		}
+		err = unwrapOnce(err)
+	}
+	return false
+}
@@ -16,6 +16,7 @@ package terror
 import (
 	"fmt"
 	"io"
+	"sort"
 	"sync"
 
 	"github.com/pingcap/errors"
@@ -158,6 +159,7 @@ type Error struct {
 	args       []interface{}
 	rawCause   error
 	stack      errors.StackTracer
+	fields     map[string]interface{}
 }
 
 // New creates a new *Error instance.
@@ -283,6 +285,34 @@ func (e *Error) SetMessage(message string) *Error {
 	return &err
 }
 
+// WithFields clones an Error and attaches named fields (e.g. task, source, table) to it, fields with the same
+// name are overwritten. Fields are not a part of the error message, they are kept for structured logging,
+// and are carried to the errors created by `Generate`, `Delegate` and so on.
+func (e *Error) WithFields(fields map[string]interface{}) *Error {
+	err := *e
+	err.args = append([]interface{}{}, e.args...)
+	err.fields = make(map[string]interface{}, len(e.fields)+len(fields))
+	for k, v := range e.fields {
+		err.fields[k] = v
+	}
+	for k, v := range fields {
+		err.fields[k] = v
+	}
+	return &err
+}
+
+// Fields returns a copy of the named fields attached to *Error.
+func (e *Error) Fields() map[string]interface{} {
+	if len(e.fields) == 0 {
+		return nil
+	}
+	fields := make(map[string]interface{}, len(e.fields))
+	for k, v := range e.fields {
+		fields[k] = v
+	}
+	return fields
+}
+
 // New generates a new *Error with the same class and code, and replace message with new message.
 func (e *Error) New(message string) error {
 	return e.stackLevelGeneratef(1, message)
@@ -309,6 +339,7 @@ func (e *Error) stackLevelGeneratef(stackSkipLevel int, format string, args ...i
 		workaround: e.workaround,
 		args:       args,
 		stack:      errors.NewStack(stackSkipLevel),
+		fields:     e.fields,
 	}
 }
 
@@ -320,9 +351,16 @@ func (e *Error) Delegate(err error, args ...interface{}) error {
 	}
 
 	rawCause := err
-	// we only get the root rawCause
-	if tErr, ok := err.(*Error); ok && tErr.rawCause != nil {
-		rawCause = tErr.rawCause
+	fields := e.fields
+	if tErr, ok := err.(*Error); ok {
+		// we only get the root rawCause
+		if tErr.rawCause != nil {
+			rawCause = tErr.rawCause
+		}
+		// keep the fields of the delegated error, fields of e take precedence
+		if len(tErr.fields) > 0 {
+			fields = tErr.WithFields(e.fields).fields
+		}
 	}
 
 	return &Error{
@@ -335,6 +373,7 @@ func (e *Error) Delegate(err error, args ...interface{}) error {
 		args:       args,
 		rawCause:   rawCause,
 		stack:      errors.NewStack(0),
+		fields:     fields,
 	}
 }
 
@@ -415,6 +454,26 @@ func WithClass(err error, class ErrClass) error {
 	return e
 }
 
+// Fields returns the named fields attached to all *Error in the chain of err, for fields with the same name,
+// the outer one takes precedence. It returns nil if there's no field.
+func Fields(err error) map[string]interface{} {
+	var fields map[string]interface{}
+	for i := 0; err != nil && i < maxErrorChainDepth; i++ {
+		if e, ok := err.(*Error); ok {
+			for k, v := range e.fields {
+				if fields == nil {
+					fields = make(map[string]interface{}, len(e.fields))
+				}
+				if _, exist := fields[k]; !exist {
+					fields[k] = v
+				}
+			}
+		}
+		err = unwrapOnce(err)
+	}
+	return fields
+}
+
 // ErrorFromCode queries registered error from error code.
 func ErrorFromCode(code ErrCode) (*Error, bool) {
 	value, ok := codeToErrorMap.Load(code)
@@ -423,3 +482,81 @@ func ErrorFromCode(code ErrCode) (*Error, bool) {
 	}
 	return value.(*Error), true
 }
+
+// maxErrorChainDepth limits the depth of walking an error chain, in case of a cycle.
+const maxErrorChainDepth = 100
+
+// unwrapOnce returns the next error in the chain of err by `Unwrap` or `Cause`, or nil if err wraps nothing.
+func unwrapOnce(err error) error {
+	if u, ok := err.(interface{ Unwrap() error }); ok {
+		if next := u.Unwrap(); next != nil {
+			return next
+		}
+	}
+	if c, ok := err.(interface{ Cause() error }); ok {
+		if next := c.Cause(); next != err {
+			return next
+		}
+	}
+	return nil
+}
+
+// AllCodes returns the codes of all *Error in the chain of err, from the outermost to the innermost.
+// The chain is walked by `Unwrap` and `Cause`, so errors wrapped by fmt.Errorf, pingcap/errors and Delegate are
+// all covered.
+func AllCodes(err error) []ErrCode {
+	var codes []ErrCode
+	for i := 0; err != nil && i < maxErrorChainDepth; i++ {
+		if e, ok := err.(*Error); ok {
+			codes = append(codes, e.code)
+		}
+		err = unwrapOnce(err)
+	}
+	return codes
+}
+
+// RootCode returns the code of the innermost *Error in the chain of err, it returns false if there's no *Error.
+func RootCode(err error) (ErrCode, bool) {
+	codes := AllCodes(err)
+	if len(codes) == 0 {
+		return 0, false
+	}
+	return codes[len(codes)-1], true
+}
+
+// ErrorInfo describes a registered error, it's JSON-serializable so it can be used to generate docs.
+type ErrorInfo struct {
+	Code       ErrCode `json:"code"`
+	Class      string  `json:"class"`
+	Scope      string  `json:"scope"`
+	Level      string  `json:"level"`
+	Message    string  `json:"message"`
+	Workaround string  `json:"workaround"`
+	Retryable  bool    `json:"retryable"`
+}
+
+// Info returns the description of *Error, Message is the message template without arguments.
+func (e *Error) Info() ErrorInfo {
+	return ErrorInfo{
+		Code:       e.code,
+		Class:      e.class.String(),
+		Scope:      e.scope.String(),
+		Level:      e.level.String(),
+		Message:    e.message,
+		Workaround: e.workaround,
+		Retryable:  e.Retryable(),
+	}
+}
+
+// AllErrors returns the descriptions of all registered errors sorted by error code.
+func AllErrors() []ErrorInfo {
+	var infos []ErrorInfo
+	codeToErrorMap.Range(func(_, value interface{}) bool {
+		infos = append(infos, value.(*Error).Info())
+		return true
+	})
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Code < infos[j].Code
+	})
+	return infos
+}
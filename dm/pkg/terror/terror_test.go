@@ -14,6 +14,7 @@
 package terror
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
@@ -209,3 +210,157 @@ func TestTerrorCodeMap(t *testing.T) {
 	_, ok = ErrorFromCode(1000)
 	require.False(t, ok)
 }
+
+func TestErrorChainCodes(t *testing.T) {
+	t.Parallel()
+
+	require.Nil(t, AllCodes(nil))
+	_, ok := RootCode(nil)
+	require.False(t, ok)
+	_, ok = RootCode(errors.New("plain error"))
+	require.False(t, ok)
+
+	configErr := ErrConfigInvalidSafeModeDuration.Generate("1x", errors.New("invalid duration"))
+	require.Equal(t, []ErrCode{codeConfigInvalidSafeModeDuration}, AllCodes(configErr))
+
+	// Delegate keeps the config error as raw cause.
+	startErr := ErrSyncerUnitGenBAList.Delegate(configErr)
+	require.Equal(t, []ErrCode{codeSyncerUnitGenBAList, codeConfigInvalidSafeModeDuration}, AllCodes(startErr))
+	// Equal only compares the top-level code.
+	require.False(t, ErrConfigInvalidSafeModeDuration.Equal(startErr))
+
+	// wrapped by pingcap/errors, fmt.Errorf and Delegate again.
+	wrapped := ErrWorkerStartService.Delegate(fmt.Errorf("start task: %w", perrors.Annotate(startErr, "start syncer")))
+	require.Equal(t, []ErrCode{codeWorkerStartService, codeSyncerUnitGenBAList, codeConfigInvalidSafeModeDuration}, AllCodes(wrapped))
+	code, ok := RootCode(wrapped)
+	require.True(t, ok)
+	require.Equal(t, codeConfigInvalidSafeModeDuration, code)
+
+	// a non-terror root cause.
+	code, ok = RootCode(fmt.Errorf("outer: %w", ErrSyncerUnitGenBAList.Delegate(errors.New("raw cause"))))
+	require.True(t, ok)
+	require.Equal(t, codeSyncerUnitGenBAList, code)
+}
+
+func TestErrorWithFields(t *testing.T) {
+	t.Parallel()
+
+	require.Nil(t, ErrSyncerUnitGenBAList.Fields())
+	require.Nil(t, Fields(nil))
+	require.Nil(t, Fields(errors.New("plain error")))
+
+	base := ErrConfigInvalidSafeModeDuration.WithFields(map[string]interface{}{"task": "t1", "source": "s1"})
+	// the registered error is not changed.
+	require.Nil(t, ErrConfigInvalidSafeModeDuration.Fields())
+	require.True(t, ErrConfigInvalidSafeModeDuration.Equal(base))
+
+	// fields are carried by Generate but not a part of the message.
+	err := base.Generate("1x", "invalid duration")
+	require.Equal(t, ErrConfigInvalidSafeModeDuration.Generate("1x", "invalid duration").Error(), err.Error())
+	require.Equal(t, map[string]interface{}{"task": "t1", "source": "s1"}, Fields(err))
+
+	// Fields returns a copy.
+	fields := base.Fields()
+	fields["task"] = "t2"
+	require.Equal(t, "t1", base.Fields()["task"])
+
+	// WithFields merges and overwrites fields.
+	e2 := base.WithFields(map[string]interface{}{"task": "t2", "table": "`db`.`tbl`"})
+	require.Equal(t, map[string]interface{}{"task": "t2", "source": "s1", "table": "`db`.`tbl`"}, e2.Fields())
+	require.Equal(t, "t1", base.Fields()["task"])
+
+	// Delegate keeps fields of both errors, the outer one takes precedence.
+	outer := ErrSyncerUnitGenBAList.WithFields(map[string]interface{}{"task": "t3"}).Delegate(err)
+	require.Equal(t, map[string]interface{}{"task": "t3", "source": "s1"}, outer.(*Error).Fields())
+
+	// fields in the chain are collected.
+	wrapped := ErrWorkerStartService.Delegate(fmt.Errorf("start task: %w", ErrSyncerUnitGenBAList.Delegate(errors.New("raw"))))
+	require.Nil(t, Fields(wrapped))
+	wrapped = fmt.Errorf("start task: %w", perrors.Annotate(outer, "start syncer"))
+	require.Equal(t, map[string]interface{}{"task": "t3", "source": "s1"}, Fields(wrapped))
+}
+
+func TestAllErrors(t *testing.T) {
+	t.Parallel()
+
+	infos := AllErrors()
+	require.Greater(t, len(infos), 1)
+	for i := 1; i < len(infos); i++ {
+		require.Less(t, infos[i-1].Code, infos[i].Code)
+	}
+
+	expected := ErrorInfo{
+		Code:       codeConfigOpenAPITaskConfigExist,
+		Class:      "config",
+		Scope:      "internal",
+		Level:      "low",
+		Message:    "the openapi task config for '%s' already exist",
+		Workaround: "If you want to override it, please use the overwrite flag.",
+	}
+	require.Equal(t, expected, ErrOpenAPITaskConfigExist.Info())
+	require.Contains(t, infos, expected)
+
+	data, err := json.Marshal(expected)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"code":20050,"class":"config","scope":"internal","level":"low","message":"the openapi task config for '%s' already exist","workaround":"If you want to override it, please use the overwrite flag.","retryable":false}`, string(data))
+}
+
+func TestIsRetryable(t *testing.T) {
+	t.Parallel()
+
+	require.False(t, IsRetryable(nil))
+	require.False(t, IsRetryable(errors.New("plain error")))
+
+	cases := []struct {
+		err       *Error
+		retryable bool
+	}{
+		{ErrDecodeEtcdKeyFail, false},
+		{ErrUpgradeVersionEtcdFail, true},
+		{ErrConfigEtcdParse, false},
+		{ErrConfigMissingForBound, false},
+		{ErrOpenAPITaskConfigExist, false},
+		{ErrOpenAPITaskConfigNotExist, false},
+		{ErrOpenAPITaskConfigStale, true},
+		{ErrOpenAPITaskConfigInvalid, false},
+		{ErrMasterGenEmbedEtcdConfigFail, false},
+		{ErrMasterStartEmbedEtcdFail, false},
+		{ErrMasterJoinEmbedEtcdFail, false},
+		{ErrWorkerFailToGetSubtaskConfigFromEtcd, false},
+		{ErrWorkerFailToGetSourceConfigFromEtcd, false},
+		{ErrHAFailTxnOperation, true},
+		{ErrHAInvalidItem, false},
+		{ErrHAFailWatchEtcd, true},
+		{ErrHAFailLeaseOperation, true},
+		{ErrHAFailKeepalive, true},
+		{ErrOpenAPICommonError, false},
+		{ErrOpenAPITaskSourceNotFound, false},
+		{ErrSyncerUnitUpdateConfigInSharding, true},
+		{ErrMasterBoundChanging, true},
+		{ErrWorkerRelayConfigChanging, true},
+		{ErrSchedulerLatchInUse, true},
+		{ErrRelayPurgeIsForbidden, true},
+		{ErrWorkerRelayIsPurging, true},
+	}
+	expected := make(map[ErrCode]bool, len(cases))
+	for _, cs := range cases {
+		expected[cs.err.Code()] = cs.retryable
+		require.Equal(t, cs.retryable, cs.err.Retryable(), cs.err.Code())
+		require.Equal(t, cs.retryable, IsRetryable(cs.err.Generate()), cs.err.Code())
+	}
+
+	// all other defined errors are not retryable.
+	for _, info := range AllErrors() {
+		require.Equal(t, expected[info.Code], info.Retryable, info.Code)
+		e, ok := ErrorFromCode(info.Code)
+		require.True(t, ok)
+		require.Equal(t, expected[info.Code], IsRetryable(e), info.Code)
+	}
+
+	// the outermost *Error decides.
+	err := ErrHAFailTxnOperation.Delegate(errors.New("etcdserver: request timed out"))
+	require.True(t, IsRetryable(err))
+	require.True(t, IsRetryable(fmt.Errorf("put task: %w", perrors.Annotate(err, "put"))))
+	require.False(t, IsRetryable(ErrOpenAPITaskConfigInvalid.Delegate(err)))
+	require.True(t, IsRetryable(ErrOpenAPITaskConfigStale.Delegate(ErrOpenAPITaskConfigInvalid.Generate())))
+}
@@ -14,12 +14,24 @@
 package syncer
 
 import (
+	"context"
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"hash/fnv"
 	"math"
+	"strconv"
+	"sync"
 	"time"
 
+	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/pkg/sessionctx"
+	tfilter "github.com/pingcap/tidb/pkg/util/table-filter"
 	"github.com/pingcap/tiflow/dm/pkg/log"
 	"github.com/pingcap/tiflow/dm/syncer/metrics"
+	"github.com/pingcap/tiflow/pkg/sqlmodel"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/atomic"
 	"go.uber.org/zap"
 )
 
@@ -51,6 +63,113 @@ type causality struct {
 	logger      log.Logger
 	sessCtx     sessionctx.Context
 	workerCount int
+	// maxKeys is the max number of keys kept in relation, 0 means unlimited.
+	maxKeys int
+	// maxGroups is the max number of groups kept in relation, the oldest groups are merged when exceeded,
+	// 0 means unlimited.
+	maxGroups int
+	// hashKey is true if the selected relation should be hashed before used as the queue key of DML workers.
+	hashKey bool
+	// hashedKeys is true if the causality keys are replaced by their fixed-size hashes to reduce the memory of
+	// relation. all keys are hashed, so the same keys still have the same hashes, a hash collision only
+	// makes distinct keys conflict or join the same relation, which serializes more DMLs but never reorders them.
+	hashedKeys bool
+	// appendOnlyTables matches tables whose DMLs skip conflict detection, nil means no such table.
+	appendOnlyTables tfilter.Filter
+	// dumpCh receives requests of dumping relation, the snapshot is sent back by the request channel.
+	dumpCh chan chan []causalityRelationGroupDump
+	// clearCh receives requests of clearing relation by a conflict job, the wait group of the conflict job is sent
+	// back by the request channel, it's nil if the conflict job is skipped.
+	clearCh chan chan *sync.WaitGroup
+	// flushCh receives flush and asyncFlush jobs ahead of the buffered jobs in inCh, it's nil if they are sent to
+	// inCh. see receiveFlush for the ordering guarantee.
+	flushCh chan *job
+	// received is the number of jobs received from inCh.
+	received int64
+	// drained is true if no DML job is sent after the last flush or conflict job, which means all DMLs
+	// before will be executed before the next DML, so there's no need to send another conflict job.
+	drained bool
+
+	// partialFlush is true if a conflict only drains the DML workers of the conflicting relations and then merges
+	// them, instead of draining all DML workers and clearing relation, see partialFlushWorkers. it's experimental.
+	partialFlush bool
+
+	// dryRun is true if conflicts are only recorded without sending conflict jobs, it's unsafe and only for analysis.
+	dryRun bool
+
+	// conflictWindowSize is the max number of conflicting DML jobs held before sending one conflict job for all
+	// of them, 0 means a conflict job is sent on every conflict.
+	conflictWindowSize int
+	// conflictWindowInterval is the max time the first held job waits before the conflict job is sent.
+	conflictWindowInterval time.Duration
+	// heldJobs are the DML jobs held back by the conflict window, they are handled again in order after the
+	// conflict job is sent.
+	heldJobs []*job
+	// heldKeys are the causality keys of heldJobs, later DML jobs with any of them are held too to keep them ordered.
+	heldKeys map[string]struct{}
+	// heldTimer fires when the conflict window expires, it's nil if no job is held.
+	heldTimer *time.Timer
+
+	// atomicTxn is true if the DML jobs of an upstream transaction are buffered until the transaction ends, then
+	// their conflicts are detected together and they are dispatched to the same DML worker.
+	atomicTxn bool
+	// txnJobs are the buffered DML jobs of the current transaction.
+	txnJobs []*job
+
+	// idleInterval is the time without any incoming job after which the relation is cleared if DML workers are
+	// drained, 0 means the relation is never cleared for idleness.
+	idleInterval time.Duration
+	// idleTicker checks whether causality is idle, it's nil if idleInterval is 0.
+	idleTicker *time.Ticker
+	// lastJobTime is the time when the last job is received from inCh.
+	lastJobTime time.Time
+
+	// selfCheckInterval is the interval to check the invariants of relation, 0 means the self-check is disabled.
+	selfCheckInterval time.Duration
+	// selfCheckTicker triggers the self-check, it's nil if selfCheckInterval is 0.
+	selfCheckTicker *time.Ticker
+
+	// maxGroupAge is the max age of the oldest group which has keys, all DML workers are flushed to clear the
+	// relation when exceeded, 0 means unlimited. it's a safety net in case flush jobs are stalled and groups are
+	// never removed by gc.
+	maxGroupAge time.Duration
+	// groupAgeTicker checks the age of groups, it's nil if maxGroupAge is 0.
+	groupAgeTicker *time.Ticker
+
+	// stats receives the statistics published every causalityStatsInterval, they are not published if it's nil.
+	stats *atomic.Pointer[causalityStats]
+	// statsTicker triggers publishing stats, it's nil if stats is nil.
+	statsTicker *time.Ticker
+	// statsConflicts and statsDispatched are the number of conflicts and the number of DMLs dispatched to each DML
+	// worker since statsTime, when the stats are published last time.
+	statsConflicts  int64
+	statsDispatched []int64
+	statsTime       time.Time
+	// totalConflicts is the number of conflicts since causality starts, it's never reset by clearing relation or
+	// publishing stats.
+	totalConflicts int64
+
+	// logSampleRate is N if only 1 in N debug logs for every DML and conflict are written, 0 or 1 means all.
+	logSampleRate int64
+	// logSampleCount is the number of debug logs which are sampled by logSampleRate.
+	logSampleCount int64
+
+	// blockWarnInterval is the time causality is blocked on outCh before a warning is logged, the warning is
+	// repeated every interval until the job is sent. 0 means no warning.
+	blockWarnInterval time.Duration
+
+	// conflictResolver decides whether a conflict flushes DML workers, nil means always.
+	conflictResolver CausalityConflictResolver
+
+	// verifier samples DMLs to verify their execution order, it's nil if disabled.
+	verifier *causalityVerifier
+	// decisions records the latest operations on relation for replay debugging, it's nil if disabled.
+	decisions *causalityDecisionLog
+	// decisionDumpCh receives requests of dumping decisions, the snapshot is sent back by the request channel.
+	decisionDumpCh chan chan []causalityDecision
+	// relationSnapshotCh receives the relation after each gc job to be persisted, it's nil if disabled. it's
+	// buffered by 1, see sendRelationSnapshot.
+	relationSnapshotCh chan *causalityRelationSnapshot
 
 	// for MetricsProxies
 	task          string
@@ -58,63 +177,1135 @@ type causality struct {
 	metricProxies *metrics.Proxies
 }
 
-// causalityWrap creates and runs a causality instance.
-func causalityWrap(inCh chan *job, syncer *Syncer) chan *job {
-	causality := &causality{
-		relation:      newCausalityRelation(),
-		task:          syncer.cfg.Name,
-		source:        syncer.cfg.SourceID,
-		metricProxies: syncer.metricsProxies,
-		logger:        syncer.tctx.Logger.WithFields(zap.String("component", "causality")),
+// defaultConflictWindowInterval is used when the conflict window is enabled without an interval.
+const defaultConflictWindowInterval = 10 * time.Millisecond
+
+// causalityStatsInterval is the interval to publish causalityStats.
+const causalityStatsInterval = 10 * time.Second
+
+// newCausality creates a causality instance with the minimal dependencies and default options, so it can be
+// driven without a Syncer. the bloom filter of relation is sized by the capacity of outCh. the instance is not
+// running, the caller should call run and then close.
+func newCausality(
+	workerCount int,
+	sessCtx sessionctx.Context,
+	metricProxies *metrics.Proxies,
+	inCh, outCh chan *job,
+) *causality {
+	return &causality{
+		relation:      newCausalityRelationWithFilter(cap(outCh)),
+		metricProxies: metricProxies,
+		logger:        log.L().WithFields(zap.String("component", "causality")),
 		inCh:          inCh,
-		outCh:         make(chan *job, syncer.cfg.QueueSize),
-		sessCtx:       syncer.sessCtx,
-		workerCount:   syncer.cfg.WorkerCount,
+		outCh:         outCh,
+		sessCtx:       sessCtx,
+		workerCount:   workerCount,
+		// no DML is sent yet
+		drained: true,
+	}
+}
+
+// causalityWrap creates and runs a causality instance.
+// when ctx is done or inCh is closed, the causality instance handles all remaining jobs in inCh until it's closed and
+// sends a final conflict job if needed, then closes the returned channel. no job is dropped, since the producer may
+// wait for a flush job to be executed.
+// the relation starts empty, or with the relation restored by loadCausalityRelation if persist-causality-relation is
+// enabled, which is persisted after the gc jobs of flushed checkpoints, see sendRelationSnapshot.
+// if experimental-causality-shards is more than 1, jobs are handled by sharded causality, see shardedCausalityWrap.
+func causalityWrap(ctx context.Context, inCh chan *job, syncer *Syncer) chan *job {
+	outChSize := syncer.cfg.CausalityQueueSize
+	if outChSize <= 0 {
+		outChSize = syncer.cfg.QueueSize
+	}
+	logger := syncer.tctx.Logger.WithFields(zap.String("component", "causality"))
+	if syncer.cfg.UnsafeCausalityDryRun {
+		logger.Warn("UNSAFE causality dry-run is enabled, conflicts are only recorded to metrics and logs " +
+			"without being resolved, data inconsistency may happen! it should only be used for analysis")
+	}
+	if syncer.cfg.ExperimentalPartialConflictFlush {
+		logger.Warn("EXPERIMENTAL partial conflict flush is enabled, a conflict only drains the DML workers " +
+			"of the conflicting relations, conflict-window-size is ignored")
+	}
+	if syncer.cfg.CausalityLogSampleRate > 1 {
+		logger.Info("debug logs of causality are sampled", zap.Int("sample rate", syncer.cfg.CausalityLogSampleRate))
+	}
+	syncer.causalityVerifier = nil
+	if syncer.cfg.CausalityVerifySampleRate > 0 {
+		logger.Warn("causality verification is enabled, it's only for staging",
+			zap.Int("sample rate", syncer.cfg.CausalityVerifySampleRate))
+		syncer.causalityVerifier = newCausalityVerifier(syncer.cfg.CausalityVerifySampleRate,
+			syncer.tctx.Logger.WithFields(zap.String("component", "causality verifier")),
+			syncer.metricsProxies.Metrics.CausalityVerifyWarningCounter)
+	}
+	// the stats of the previous run are outdated.
+	syncer.causalityStats.Store(nil)
+	if syncer.cfg.ExperimentalCausalityShards > 1 {
+		return shardedCausalityWrap(ctx, inCh, syncer, outChSize)
+	}
+
+	causality := newSyncerCausality(syncer, inCh, make(chan *job, outChSize))
+	// compactor merges DMLs across transactions, so there's no transaction boundary after it.
+	causality.atomicTxn = syncer.cfg.AtomicTxnCausality && !syncer.cfg.Compact
+	causality.dumpCh = syncer.causalityDumpCh
+	causality.clearCh = syncer.causalityClearCh
+	causality.flushCh = syncer.causalityFlushCh
+	doneCh := syncer.causalityDoneCh
+	if relations := syncer.causalityRestoredRelation; relations != nil {
+		// it's only restored once, the relation of a later run is loaded again.
+		syncer.causalityRestoredRelation = nil
+		causality.relation.restore(relations)
+		causality.updateRelationMetrics()
+		logger.Info("restore the persisted causality relation", zap.Int("keys", causality.relation.len()))
+	}
+	causality.relationSnapshotCh = syncer.causalityRelationSnapshotCh
+	snapshotCh := syncer.causalityRelationSnapshotCh
+	syncer.causalityRelation.Store(causality.relation)
+	causality.stats = &syncer.causalityStats
+	// the stats are published by the run goroutine as a whole, so the published variable is always consistent.
+	causalityExpvar.Set(syncer.causalityExpvarKey(), expvar.Func(func() interface{} {
+		return syncer.causalityStats.Load()
+	}))
+	causality.decisionDumpCh = syncer.causalityDecisionDumpCh
+	causality.blockWarnInterval = time.Duration(syncer.cfg.CausalityBlockWarnInterval) * time.Millisecond
+	if syncer.cfg.CausalityDecisionLog > 0 || syncer.causalityDecisionTap != nil {
+		causality.decisions = newCausalityDecisionLog(syncer.cfg.CausalityDecisionLog)
+		causality.decisions.tap = syncer.causalityDecisionTap
+		causality.decisions.tapDropped = syncer.metricsProxies.Metrics.CausalityTapDroppedCounter
 	}
 
 	go func() {
-		causality.run()
+		causality.run(ctx)
 		causality.close()
+		if doneCh != nil {
+			close(doneCh)
+		}
+		if snapshotCh != nil {
+			close(snapshotCh)
+		}
 	}()
 
 	return causality.outCh
 }
 
+// newSyncerCausality creates a causality instance with the options of syncer which apply to both a single causality
+// and the shards of sharded causality. the instance is not running.
+func newSyncerCausality(syncer *Syncer, inCh, outCh chan *job) *causality {
+	causality := newCausality(syncer.cfg.WorkerCount, syncer.sessCtx, syncer.metricsProxies, inCh, outCh)
+	causality.task = syncer.cfg.Name
+	causality.source = syncer.cfg.SourceID
+	causality.logger = syncer.tctx.Logger.WithFields(zap.String("component", "causality"))
+	causality.maxKeys = syncer.cfg.MaxCausalityKeys
+	causality.maxGroups = syncer.cfg.MaxCausalityGroups
+	if syncer.cfg.CausalityRelationCapacity > 0 {
+		causality.relation = newCausalityRelationWithCapacity(cap(outCh), syncer.cfg.CausalityRelationCapacity)
+	}
+	causality.hashKey = syncer.cfg.HashCausalityKey
+	causality.hashedKeys = syncer.cfg.HashedCausalityKeys
+	causality.idleInterval = time.Duration(syncer.cfg.CausalityIdleInterval) * time.Millisecond
+	causality.selfCheckInterval = time.Duration(syncer.cfg.CausalitySelfCheckInterval) * time.Millisecond
+	causality.maxGroupAge = time.Duration(syncer.cfg.MaxCausalityGroupAge) * time.Millisecond
+	causality.verifier = syncer.causalityVerifier
+	causality.conflictResolver = syncer.causalityConflictResolver
+	causality.dryRun = syncer.cfg.UnsafeCausalityDryRun
+	causality.partialFlush = syncer.cfg.ExperimentalPartialConflictFlush
+	if syncer.cfg.CausalityLogSampleRate > 1 {
+		causality.logSampleRate = int64(syncer.cfg.CausalityLogSampleRate)
+	}
+	if syncer.cfg.ConflictWindowSize > 0 && !causality.partialFlush {
+		causality.conflictWindowSize = syncer.cfg.ConflictWindowSize
+		causality.conflictWindowInterval = time.Duration(syncer.cfg.ConflictWindowInterval) * time.Millisecond
+		if causality.conflictWindowInterval <= 0 {
+			causality.conflictWindowInterval = defaultConflictWindowInterval
+		}
+		causality.heldKeys = make(map[string]struct{})
+	}
+	if len(syncer.cfg.AppendOnlyTables) > 0 {
+		// the patterns are already checked in SubTaskConfig.Adjust
+		f, err := tfilter.Parse(syncer.cfg.AppendOnlyTables)
+		if err != nil {
+			causality.logger.Warn("invalid append-only-tables, conflict detection is enabled for all tables", zap.Strings("append-only-tables", syncer.cfg.AppendOnlyTables), zap.Error(err))
+		} else {
+			if !syncer.cfg.CaseSensitive {
+				f = tfilter.CaseInsensitive(f)
+			}
+			causality.appendOnlyTables = f
+		}
+	}
+	return causality
+}
+
 // run receives dml jobs and send causality jobs by adding causality key.
 // When meet conflict, sends a conflict job.
-func (c *causality) run() {
-	for j := range c.inCh {
-		c.metricProxies.QueueSizeGauge.WithLabelValues(c.task, "causality_input", c.source).Set(float64(len(c.inCh)))
-
-		startTime := time.Now()
-
-		switch j.tp {
-		case flush, asyncFlush:
-			c.relation.rotate(j.flushSeq)
-		case gc:
-			// gc is only used on inner-causality logic
-			c.relation.gc(j.flushSeq)
+func (c *causality) run(ctx context.Context) {
+	if c.idleInterval > 0 {
+		c.idleTicker = time.NewTicker(c.idleInterval)
+		defer c.idleTicker.Stop()
+	}
+	if c.selfCheckInterval > 0 {
+		c.selfCheckTicker = time.NewTicker(c.selfCheckInterval)
+		defer c.selfCheckTicker.Stop()
+	}
+	if c.maxGroupAge > 0 {
+		// check twice in maxGroupAge, so a group is reclaimed no later than 1.5 * maxGroupAge.
+		c.groupAgeTicker = time.NewTicker(c.maxGroupAge / 2)
+		defer c.groupAgeTicker.Stop()
+	}
+	if c.stats != nil {
+		c.statsTicker = time.NewTicker(causalityStatsInterval)
+		defer c.statsTicker.Stop()
+		c.resetStats(time.Now())
+	}
+	c.lastJobTime = time.Now()
+
+	for {
+		// flush jobs are preferred to the buffered jobs in inCh.
+		if f := c.tryReceiveFlush(); f != nil {
+			c.receiveFlush(f)
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			// the jobs are still handled, a dropped flush job blocks its producer forever. DML workers fail the DMLs
+			// since they are cancelled by the same ctx, so no checkpoint is flushed past them.
+			c.logger.Info("context is done, causality will exit after all remaining jobs are handled")
+			c.drain()
+			return
+		case respCh := <-c.dumpCh:
+			// relation is only accessed by this goroutine, so we dump it here.
+			respCh <- c.relation.dump()
+		case respCh := <-c.decisionDumpCh:
+			respCh <- c.decisions.snapshot()
+		case respCh := <-c.clearCh:
+			c.forceClear(respCh)
+		case <-c.heldTimerC():
+			c.flushWorkers()
+		case <-c.idleTickerC():
+			c.clearIfIdle()
+		case <-c.selfCheckTickerC():
+			c.selfCheck()
+		case <-c.groupAgeTickerC():
+			c.reclaimAgedGroups()
+		case now := <-c.statsTickerC():
+			c.publishStats(now)
+		case f := <-c.flushCh:
+			c.receiveFlush(f)
+		case j, ok := <-c.inCh:
+			if !ok {
+				c.finish()
+				return
+			}
+			c.receiveInputJob(j)
+		}
+	}
+}
+
+// drain handles all remaining jobs in inCh until it's closed, then finishes the causality.
+// the producer must close inCh after cancelling the context.
+func (c *causality) drain() {
+	for {
+		select {
+		case <-c.heldTimerC():
+			c.flushWorkers()
+		case f := <-c.flushCh:
+			c.receiveFlush(f)
+		case j, ok := <-c.inCh:
+			if !ok {
+				c.finish()
+				return
+			}
+			c.receiveInputJob(j)
+		}
+	}
+}
+
+// finish sends a final conflict job if some DMLs are sent after the last flush or conflict job,
+// so DML workers will execute all DMLs before outCh is closed.
+// the ordering guarantee is: all jobs received from inCh are sent to outCh in order, followed by the
+// final conflict job (if any), and then outCh is closed.
+func (c *causality) finish() {
+	// the producer sends all flush jobs before closing inCh.
+	for f := c.tryReceiveFlush(); f != nil; f = c.tryReceiveFlush() {
+		c.receiveFlush(f)
+	}
+	c.endTxn()
+	c.releaseHeldJobs()
+	if c.drained {
+		return
+	}
+	c.logger.Info("send the final conflict job before causality exits", zap.Int("relation size", c.relation.len()))
+	c.flushWorkers()
+	c.updateRelationMetrics()
+}
+
+// tryReceiveFlush returns a flush job from flushCh without blocking, or nil if there's none.
+func (c *causality) tryReceiveFlush() *job {
+	select {
+	case f := <-c.flushCh:
+		return f
+	default:
+		return nil
+	}
+}
+
+// receiveFlush handles a flush job received from flushCh.
+// flushCh only changes how soon a flush job is received, never the order in which jobs are handled:
+//   - the producer sends all jobs in order, a flush job records the number of jobs sent to inCh before it as
+//     inputSeq, so those jobs are already in inCh or received when the flush job is received. gc jobs are sent to
+//     inCh concurrently by another goroutine, a job is counted together with its sending under a lock, so the first
+//     inputSeq jobs in inCh always include the jobs sent before the flush job.
+//   - the flush job is handled after the jobs received from inCh reach inputSeq, so the relation is rotated after
+//     all DMLs before the flush job are added, and the flush job is sent to DML workers after them.
+//   - the jobs sent to inCh after the flush job are only received after it's handled, see receiveInputJob.
+//
+// so the flush job is received without waiting behind the buffered jobs in inCh, and the producer doesn't block
+// on a full inCh to send it, but it still waits for the jobs before it to be handled.
+func (c *causality) receiveFlush(f *job) {
+	for c.received < f.inputSeq {
+		j, ok := <-c.inCh
+		if !ok {
+			// unreachable, the producer never closes inCh before sending the jobs.
+			c.logger.Warn("inCh is closed before the jobs sent before the flush job are received",
+				zap.Int64("received", c.received), zap.Int64("input seq", f.inputSeq))
+			break
+		}
+		c.acceptInputJob(j)
+	}
+	c.receiveJob(f)
+}
+
+// receiveInputJob handles a job received from inCh. the flush jobs sent before the job are already in flushCh,
+// they are handled before it.
+func (c *causality) receiveInputJob(j *job) {
+	for f := c.tryReceiveFlush(); f != nil; f = c.tryReceiveFlush() {
+		if f.inputSeq > c.received {
+			// the flush job is sent after j.
+			c.acceptInputJob(j)
+			c.receiveFlush(f)
+			return
+		}
+		c.receiveJob(f)
+	}
+	c.acceptInputJob(j)
+}
+
+// acceptInputJob counts a job received from inCh and handles it.
+func (c *causality) acceptInputJob(j *job) {
+	c.received++
+	c.lastJobTime = time.Now()
+	c.metricProxies.Metrics.CausalityInputDequeueCounter.Inc()
+	c.receiveJob(j)
+}
+
+// receiveJob handles a job received from inCh or flushCh.
+// when atomicTxn is enabled, DML jobs are buffered until the transaction ends, which is marked by a xid job.
+// any other job also ends the buffered transaction before it's handled, to keep all jobs in order.
+func (c *causality) receiveJob(j *job) {
+	if !c.atomicTxn {
+		c.handleJob(j)
+		return
+	}
+	switch j.tp {
+	case dml:
+		if dropNilDMLJob(c.logger, c.metricProxies.Metrics, j) {
+			return
+		}
+		c.txnJobs = append(c.txnJobs, j)
+	case xid:
+		c.endTxn()
+	default:
+		c.endTxn()
+		c.handleJob(j)
+	}
+}
+
+// endTxn handles the buffered DML jobs of the current transaction.
+func (c *causality) endTxn() {
+	jobs := c.txnJobs
+	c.txnJobs = nil
+	switch len(jobs) {
+	case 0:
+	case 1:
+		c.handleJob(jobs[0])
+	default:
+		c.handleTxn(jobs)
+	}
+}
+
+// handleTxn is like handleJob but for all DML jobs of a transaction. the union of their causality keys is
+// detected and added to relation once, so all jobs get the same queue key and are sent in order.
+// the transaction is never held by the conflict window, the held jobs are released before it instead.
+func (c *causality) handleTxn(jobs []*job) {
+	c.metricProxies.QueueSizeGauge.WithLabelValues(c.task, "causality_input", c.source).Set(float64(len(c.inCh)))
+
+	startTime := time.Now()
+
+	var (
+		keys          []string
+		appendOnlyKey string
+		seen          = make(map[string]struct{})
+	)
+	for _, j := range jobs {
+		jobKeys := c.causalityKeys(j)
+		c.verifier.sample(j, jobKeys)
+		// append-only tables never conflict, their keys are only used to dispatch.
+		if c.isAppendOnly(j) {
+			if appendOnlyKey == "" && len(jobKeys) > 0 {
+				appendOnlyKey = jobKeys[0]
+			}
 			continue
-		default:
-			keys := j.dml.CausalityKeys()
+		}
+		for _, key := range jobKeys {
+			if _, ok := seen[key]; !ok {
+				seen[key] = struct{}{}
+				keys = append(keys, key)
+			}
+		}
+	}
+
+	var queueKey string
+	if len(keys) == 0 {
+		if appendOnlyKey != "" {
+			queueKey = c.queueKey(appendOnlyKey)
+			c.decisions.record(causalityDecision{
+				Type: causalityDecisionDispatch, Keys: []string{appendOnlyKey}, AppendOnly: true, QueueKey: queueKey,
+			})
+		}
+	} else {
+		c.releaseHeldJobs()
+
+		// too many keys in relation, flush all workers to release them
+		if c.maxKeys > 0 && c.relation.len() >= c.maxKeys {
+			if c.sampleDebugLog() {
+				c.logger.Debug("causality relation exceeds max keys, will generate a conflict job to flush all sqls",
+					zap.Int("max keys", c.maxKeys))
+			}
+			c.metricProxies.Metrics.CausalityForcedFlushCounter.Inc()
+			c.flushWorkers()
+		}
+
+		merged := false
+		if c.dryRun {
+			c.recordConflict(jobs[0], keys)
+		} else if conflict, ok := c.findConflict(keys); ok {
+			c.decisions.record(causalityDecision{Type: causalityDecisionDetect, Keys: keys, Conflict: true})
+			if !c.partialFlush && c.sampleDebugLog() {
+				c.logger.Debug("meet causality key of transaction, will generate a conflict job to flush all sqls",
+					zap.Int("dml count", len(jobs)), zap.Strings("keys", keys))
+			}
+			c.logConflict(keys, conflict)
+			c.countConflict(jobs[0])
+			if c.partialFlush {
+				queueKey = c.queueKey(c.partialFlushWorkers(keys))
+				merged = true
+			} else {
+				c.flushWorkers()
+			}
+		} else {
+			c.decisions.record(causalityDecision{Type: causalityDecisionDetect, Keys: keys})
+		}
+		if merged {
+			c.decisions.record(causalityDecision{Type: causalityDecisionMerge, Keys: keys, QueueKey: queueKey})
+		} else {
+			queueKey = c.queueKey(c.add(keys))
+			c.decisions.record(causalityDecision{Type: causalityDecisionDispatch, Keys: keys, QueueKey: queueKey})
+		}
+		if c.sampleDebugLog() {
+			c.logger.Debug("key for keys of transaction", zap.String("key", queueKey), zap.Strings("keys", keys))
+		}
+	}
+	c.metricProxies.Metrics.ConflictDetectDurationHistogram.Observe(time.Since(startTime).Seconds())
+	c.updateRelationMetrics()
 
-			// detectConflict before add
-			if c.detectConflict(keys) {
+	for _, j := range jobs {
+		j.dmlQueueKey = queueKey
+		c.verifier.track(j)
+		c.sendJob(j)
+	}
+}
+
+// handleJob detects conflict for the job and sends it to outCh.
+func (c *causality) handleJob(j *job) {
+	c.metricProxies.QueueSizeGauge.WithLabelValues(c.task, "causality_input", c.source).Set(float64(len(c.inCh)))
+
+	startTime := time.Now()
+
+	switch j.tp {
+	case flush, asyncFlush:
+		// the held DMLs are before the flush job, so they must be sent first.
+		c.releaseHeldJobs()
+		c.relation.rotate(j.flushSeq)
+		c.decisions.record(causalityDecision{Type: causalityDecisionRotate, FlushSeq: j.flushSeq})
+		keysBefore := c.relation.approxLen()
+		if merged := c.relation.mergeOldestGroups(c.maxGroups); merged > 0 {
+			c.metricProxies.Metrics.CausalityGroupMergeCounter.Add(float64(merged))
+			// the keys overridden by the newer group are reclaimed.
+			c.metricProxies.Metrics.CausalityGCReclaimedKeysCounter.Add(float64(keysBefore - c.relation.approxLen()))
+		}
+	case gc:
+		// gc is only used on inner-causality logic
+		// the sizes are read in O(1), so the reclaimed volume costs nothing more than gc itself.
+		keysBefore, groupsBefore := c.relation.approxLen(), len(c.relation.groups)
+		c.relation.gc(j.flushSeq)
+		c.decisions.record(causalityDecision{Type: causalityDecisionGC, FlushSeq: j.flushSeq})
+		c.metricProxies.Metrics.CausalityGCReclaimedKeysCounter.Add(float64(keysBefore - c.relation.approxLen()))
+		c.metricProxies.Metrics.CausalityGCReclaimedGroupsGauge.Set(float64(groupsBefore - len(c.relation.groups)))
+		c.metricProxies.Metrics.GCDetectDurationHistogram.Observe(time.Since(startTime).Seconds())
+		c.updateRelationMetrics()
+		c.sendRelationSnapshot(j)
+		return
+	case conflict:
+		// a conflict job is only received from the router of sharded causality, DML workers are drained by it after
+		// every shard sends it, so the relation can be cleared. the held DMLs are before it, so they must be sent first.
+		c.releaseHeldJobs()
+		c.relation.clear()
+		c.decisions.record(causalityDecision{Type: causalityDecisionClear})
+		c.updateRelationMetrics()
+		c.sendJob(j)
+		return
+	default:
+		if dropNilDMLJob(c.logger, c.metricProxies.Metrics, j) {
+			return
+		}
+		keys := c.causalityKeys(j)
+		c.verifier.sample(j, keys)
+
+		// append-only tables never conflict, dispatch them by key directly.
+		if c.isAppendOnly(j) {
+			if len(keys) > 0 {
+				j.dmlQueueKey = c.queueKey(keys[0])
+			}
+			c.decisions.record(causalityDecision{Type: causalityDecisionDispatch, Keys: keys, AppendOnly: true, QueueKey: j.dmlQueueKey})
+			break
+		}
+
+		// the job may depend on the held jobs, so it must be held to be sent after them.
+		if c.dependOnHeldJobs(keys) {
+			c.holdJob(j, keys)
+			return
+		}
+
+		// too many keys in relation, flush all workers to release them
+		if c.maxKeys > 0 && c.relation.len() >= c.maxKeys {
+			if c.sampleDebugLog() {
+				c.logger.Debug("causality relation exceeds max keys, will generate a conflict job to flush all sqls",
+					zap.Int("max keys", c.maxKeys))
+			}
+			c.metricProxies.Metrics.CausalityForcedFlushCounter.Inc()
+			c.flushWorkers()
+		}
+
+		if c.dryRun {
+			c.recordConflict(j, keys)
+			j.dmlQueueKey = c.queueKey(c.add(keys))
+			c.decisions.record(causalityDecision{Type: causalityDecisionDispatch, Keys: keys, QueueKey: j.dmlQueueKey})
+			break
+		}
+
+		// detectConflict before add
+		conflict, ok := c.findConflict(keys)
+		c.decisions.record(causalityDecision{Type: causalityDecisionDetect, Keys: keys, Conflict: ok})
+		if ok && c.suppressConflictFlush(j, keys, conflict) {
+			ok = false
+		}
+		if ok {
+			if !c.partialFlush && c.sampleDebugLog() {
 				c.logger.Debug("meet causality key, will generate a conflict job to flush all sqls", zap.Strings("keys", keys))
-				c.outCh <- newConflictJob(c.workerCount)
-				c.relation.clear()
 			}
-			j.dmlQueueKey = c.add(keys)
+			c.logConflict(keys, conflict)
+			c.countConflict(j)
+			if c.partialFlush {
+				j.dmlQueueKey = c.queueKey(c.partialFlushWorkers(keys))
+				c.decisions.record(causalityDecision{Type: causalityDecisionMerge, Keys: keys, QueueKey: j.dmlQueueKey})
+				break
+			}
+			if c.conflictWindowSize > 0 {
+				if len(c.heldJobs) > 0 {
+					// the conflict job for the held jobs will also work for this one.
+					c.metricProxies.Metrics.CausalitySavedConflictCounter.Inc()
+				}
+				c.holdJob(j, keys)
+				return
+			}
+			c.flushWorkers()
+		}
+		j.dmlQueueKey = c.queueKey(c.add(keys))
+		c.decisions.record(causalityDecision{Type: causalityDecisionDispatch, Keys: keys, QueueKey: j.dmlQueueKey})
+		if c.sampleDebugLog() {
 			c.logger.Debug("key for keys", zap.String("key", j.dmlQueueKey), zap.Strings("keys", keys))
 		}
-		c.metricProxies.Metrics.ConflictDetectDurationHistogram.Observe(time.Since(startTime).Seconds())
+	}
+	c.detectDurationHistogram(j.tp).Observe(time.Since(startTime).Seconds())
+	c.updateRelationMetrics()
+
+	c.verifier.track(j)
+	c.sendJob(j)
+}
+
+// dropNilDMLJob returns true if the DML job has no row change, which is a bug of the producer. such a job can't be
+// dispatched or executed, so it's dropped with an error log and the invalid job metric instead of panicking.
+func dropNilDMLJob(logger log.Logger, m *metrics.Metrics, j *job) bool {
+	if j.dml != nil {
+		return false
+	}
+	logger.Error("causality receives a DML job without row change, drop it", zap.Stringer("job", j))
+	m.CausalityInvalidJobCounter.Inc()
+	return true
+}
+
+// detectDurationHistogram returns the histogram of conflict detect time for the job type, flush jobs are
+// observed apart from DMLs since they rotate and merge the relation instead of detecting conflict.
+func (c *causality) detectDurationHistogram(tp opType) prometheus.Observer {
+	switch tp {
+	case flush:
+		return c.metricProxies.Metrics.FlushDetectDurationHistogram
+	case asyncFlush:
+		return c.metricProxies.Metrics.AsyncFlushDetectDurationHistogram
+	case gc:
+		return c.metricProxies.Metrics.GCDetectDurationHistogram
+	default:
+		return c.metricProxies.Metrics.ConflictDetectDurationHistogram
+	}
+}
+
+// flushWorkers sends a conflict job to wait all DMLs in DML workers are executed and clears the relation,
+// then the held jobs are handled again in order, some of them may be held again if they conflict with others.
+// the conflict job is skipped if workers are already drained by the last flush or conflict job.
+func (c *causality) flushWorkers() {
+	c.flushWorkersWithJob()
+}
+
+// flushWorkersWithJob is flushWorkers which also returns the conflict job, it's nil if the conflict job is skipped.
+func (c *causality) flushWorkersWithJob() *job {
+	heldJobs := c.heldJobs
+	c.resetHeldJobs()
+
+	var conflictJob *job
+	if c.drained {
+		c.logger.Debug("DML workers are already drained, skip the conflict job")
+		c.metricProxies.Metrics.CausalitySkippedConflictCounter.Inc()
+	} else {
+		conflictJob = newConflictJob(c.workerCount)
+		c.sendJob(conflictJob)
+	}
+	c.relation.clear()
+	c.decisions.record(causalityDecision{Type: causalityDecisionClear})
+
+	for _, j := range heldJobs {
+		c.handleJob(j)
+	}
+	return conflictJob
+}
+
+// partialFlushWorkers resolves the conflict of keys without clearing relation. it sends a partial conflict job to
+// the DML workers of the relations of keys, except the worker of the relation which keys will join, then merges
+// all relations of keys into that one and returns it.
+// it's correct because all DMLs of a relation are dispatched to the same DML worker and executed in order, and DML
+// workers don't dispatch the DMLs after a conflict job until it's executed. so the DMLs of the other relations are
+// all executed before the merged relation has any new DML, and the DMLs of the selected relation are executed before
+// the new DMLs in the same worker. the relations on the selected worker don't need draining for the same reason.
+func (c *causality) partialFlushWorkers(keys []string) string {
+	selected, _ := selectRelation(c.relation, keys)
+	selectedWorker := dmlQueueBucket(c.queueKey(selected), c.workerCount)
+	seen := map[int]struct{}{selectedWorker: {}}
+	var workers []int
+	for _, key := range keys {
+		relation, ok := c.relation.get(key)
+		if !ok {
+			continue
+		}
+		worker := dmlQueueBucket(c.queueKey(relation), c.workerCount)
+		if _, ok := seen[worker]; !ok {
+			seen[worker] = struct{}{}
+			workers = append(workers, worker)
+		}
+	}
+	if c.sampleDebugLog() {
+		c.logger.Debug("meet causality key, will generate a partial conflict job to flush sqls of conflicting relations",
+			zap.Int("selected worker", selectedWorker), zap.Ints("flushed workers", workers))
+	}
+	c.metricProxies.Metrics.CausalityPartialConflictCounter.Inc()
+	// all conflicting relations are on the selected worker, the DMLs are executed in order without waiting.
+	if len(workers) > 0 {
+		c.sendJob(newPartialConflictJob(workers))
+	}
+	return c.merge(keys)
+}
+
+// forceClear clears relation by a conflict job on demand, it's always safe since a conflict job only waits for
+// the DMLs dispatched before. the wait group of the conflict job is sent back by respCh, it's nil if DML workers are
+// already drained.
+func (c *causality) forceClear(respCh chan *sync.WaitGroup) {
+	c.logger.Warn("force to clear causality relation on demand, will generate a conflict job to flush all sqls",
+		zap.Int("relation keys", c.relation.len()), zap.Int("held jobs", len(c.heldJobs)), zap.Bool("drained", c.drained))
+	conflictJob := c.flushWorkersWithJob()
+	var wg *sync.WaitGroup
+	if conflictJob != nil {
+		wg = conflictJob.flushWg
+	}
+	respCh <- wg
+}
+
+// logConflict logs the two conflicting keys, their relations and the DML workers they are dispatched to, so we can verify
+// whether the conflict job is needed. if both relations are dispatched to the same worker, the DMLs are executed
+// sequentially anyway, and a conflict job only waits for the other workers.
+func (c *causality) logConflict(keys []string, conflict causalityConflict) {
+	if !c.sampleDebugLog() {
+		return
+	}
+	fields := []zap.Field{
+		zap.Strings("keys", keys),
+		zap.String("existed key", conflict.existedKey),
+		zap.String("existed relation", conflict.existedRelation),
+		zap.String("conflicted key", conflict.conflictedKey),
+		zap.String("conflicted relation", conflict.conflictedRelation),
+	}
+	if c.workerCount > 0 {
+		existedWorker := dmlQueueBucket(c.queueKey(conflict.existedRelation), c.workerCount)
+		conflictedWorker := dmlQueueBucket(c.queueKey(conflict.conflictedRelation), c.workerCount)
+		fields = append(fields,
+			zap.Int("existed worker", existedWorker),
+			zap.Int("conflicted worker", conflictedWorker),
+			zap.Bool("same worker", existedWorker == conflictedWorker))
+	}
+	c.logger.Debug("conflicting relations of causality keys", fields...)
+}
+
+// sampleDebugLog returns whether a debug log for a DML or a conflict should be written, only 1 in logSampleRate
+// of them are written. it's cheap enough for every DML since it's only a level check and a counter, the fields of
+// the log should be built after it.
+func (c *causality) sampleDebugLog() bool {
+	if !c.logger.Core().Enabled(zap.DebugLevel) {
+		return false
+	}
+	if c.logSampleRate <= 1 {
+		return true
+	}
+	c.logSampleCount++
+	return c.logSampleCount%c.logSampleRate == 1
+}
+
+// recordConflict records the conflict of the DML job to metrics and logs in dry-run mode, the relation is kept as is.
+func (c *causality) recordConflict(j *job, keys []string) {
+	sourceTable := j.dml.GetSourceTable()
+	c.metricProxies.CausalityDryRunDMLTotal.WithLabelValues(c.task, c.source, sourceTable.Schema, sourceTable.Table).Inc()
+	conflict := c.detectConflict(keys)
+	c.decisions.record(causalityDecision{Type: causalityDecisionDetect, Keys: keys, Conflict: conflict})
+	if conflict {
+		c.logger.Info("[dry-run] meet causality key, conflict job is not generated",
+			zap.String("schema", sourceTable.Schema), zap.String("table", sourceTable.Table), zap.Strings("keys", keys))
+		c.countConflict(j)
+	}
+}
+
+// countConflict counts a conflict of the DML job. statsConflicts is reset every stats interval, while
+// totalConflicts keeps counting until causality stops. the lifetime count of the task is CausalityConflictTotal,
+// which is never reset.
+func (c *causality) countConflict(j *job) {
+	sourceTable := j.dml.GetSourceTable()
+	c.metricProxies.CausalityConflictTotal.WithLabelValues(c.task, c.source, sourceTable.Schema, sourceTable.Table).Inc()
+	c.statsConflicts++
+	c.totalConflicts++
+}
+
+// holdJob holds the DML job in the conflict window, the conflict job is sent when the window is full.
+func (c *causality) holdJob(j *job, keys []string) {
+	c.heldJobs = append(c.heldJobs, j)
+	for _, key := range keys {
+		c.heldKeys[key] = struct{}{}
+	}
+	if c.heldTimer == nil {
+		c.heldTimer = time.NewTimer(c.conflictWindowInterval)
+	}
+	if len(c.heldJobs) >= c.conflictWindowSize {
+		c.flushWorkers()
+	}
+}
+
+// dependOnHeldJobs returns whether the keys have any key of the held jobs.
+func (c *causality) dependOnHeldJobs(keys []string) bool {
+	if len(c.heldJobs) == 0 {
+		return false
+	}
+	for _, key := range keys {
+		if _, ok := c.heldKeys[key]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// releaseHeldJobs sends all held jobs.
+func (c *causality) releaseHeldJobs() {
+	// the released jobs may be held again, so loop until no job is held.
+	for len(c.heldJobs) > 0 {
+		c.flushWorkers()
+	}
+}
+
+func (c *causality) resetHeldJobs() {
+	if c.heldTimer != nil {
+		c.heldTimer.Stop()
+		c.heldTimer = nil
+	}
+	c.heldJobs = nil
+	for key := range c.heldKeys {
+		delete(c.heldKeys, key)
+	}
+}
+
+// heldTimerC returns the channel of heldTimer, or nil if no job is held.
+func (c *causality) heldTimerC() <-chan time.Time {
+	if c.heldTimer == nil {
+		return nil
+	}
+	return c.heldTimer.C
+}
+
+// idleTickerC returns the channel of idleTicker, or nil if clearing on idle is disabled.
+func (c *causality) idleTickerC() <-chan time.Time {
+	if c.idleTicker == nil {
+		return nil
+	}
+	return c.idleTicker.C
+}
+
+// clearIfIdle clears the relation if no job is received in idleInterval and DML workers are drained.
+// when drained, all DMLs in relation are sent before the last flush or conflict job, and DML workers wait for it
+// to finish before executing any later DML, so no later DML depends on these keys. asyncFlush jobs don't wait,
+// so they don't drain DML workers.
+func (c *causality) clearIfIdle() {
+	if !c.drained || len(c.heldJobs) > 0 || time.Since(c.lastJobTime) < c.idleInterval {
+		return
+	}
+	size := c.relation.len()
+	if size == 0 {
+		return
+	}
+	c.relation.clearKeys()
+	c.decisions.record(causalityDecision{Type: causalityDecisionIdleClear})
+	c.updateRelationMetrics()
+	c.metricProxies.Metrics.CausalityIdleClearCounter.Inc()
+	c.logger.Info("causality is idle, clear the relation", zap.Int("relation size", size),
+		zap.Duration("idle interval", c.idleInterval))
+}
+
+// groupAgeTickerC returns the channel of groupAgeTicker, or nil if maxGroupAge is 0.
+func (c *causality) groupAgeTickerC() <-chan time.Time {
+	if c.groupAgeTicker == nil {
+		return nil
+	}
+	return c.groupAgeTicker.C
+}
+
+// reclaimAgedGroups flushes all DML workers to clear the relation if its oldest group which has keys is older than
+// maxGroupAge.
+func (c *causality) reclaimAgedGroups() {
+	createdAt, ok := c.relation.oldestKeyTime()
+	if !ok {
+		return
+	}
+	age := time.Since(createdAt)
+	if age < c.maxGroupAge {
+		return
+	}
+	c.logger.Info("causality relation has groups older than max group age, will generate a conflict job to flush all sqls",
+		zap.Duration("max group age", c.maxGroupAge), zap.Duration("oldest group age", age),
+		zap.Int("relation size", c.relation.len()))
+	c.metricProxies.Metrics.CausalityGroupAgeFlushCounter.Inc()
+	c.flushWorkers()
+	c.updateRelationMetrics()
+}
+
+// selfCheckTickerC returns the channel of selfCheckTicker, or nil if the self-check is disabled.
+func (c *causality) selfCheckTickerC() <-chan time.Time {
+	if c.selfCheckTicker == nil {
+		return nil
+	}
+	return c.selfCheckTicker.C
+}
+
+// maxSelfCheckViolationLogs is the max number of violations logged by one self-check, the others are only counted.
+const maxSelfCheckViolationLogs = 10
+
+// selfCheck checks the invariants of relation, violations are logged and counted in metrics but never fail the
+// task, since they are only expected by bugs of causality.
+func (c *causality) selfCheck() {
+	violations := c.relation.checkInvariants()
+	if len(violations) == 0 {
+		return
+	}
+	total := len(violations)
+	c.metricProxies.Metrics.CausalityViolationCounter.Add(float64(total))
+	if total > maxSelfCheckViolationLogs {
+		violations = violations[:maxSelfCheckViolationLogs]
+	}
+	c.logger.Error("causality relation violates its invariants, it's a bug of causality",
+		zap.Int("violations", total), zap.Strings("samples", violations))
+}
+
+// sendJob sends a job to outCh. the job is never dropped, DML workers keep receiving jobs until outCh is closed.
+func (c *causality) sendJob(j *job) {
+	sendCausalityOutput(c.outCh, j, c.blockWarnInterval, c.logger, c.metricProxies.Metrics)
+	c.metricProxies.Metrics.CausalityOutputEnqueueCounter.Inc()
+	switch j.tp {
+	case flush, conflict:
+		// a partial conflict job doesn't drain all DML workers.
+		if j.conflictWorkers == nil {
+			c.drained = true
+		}
+	case dml:
+		c.drained = false
+		if c.statsDispatched != nil {
+			c.statsDispatched[dmlQueueBucket(j.dmlQueueKey, c.workerCount)]++
+		}
+	}
+}
+
+// sendCausalityOutput sends a job to outCh. if outCh is full, which means DML workers fall behind, the time blocked
+// is observed in metrics, and a warning is logged every warnInterval while blocked if it's positive.
+func sendCausalityOutput(
+	outCh chan<- *job,
+	j *job,
+	warnInterval time.Duration,
+	logger log.Logger,
+	m *metrics.Metrics,
+) {
+	select {
+	case outCh <- j:
+		return
+	default:
+	}
+
+	start := time.Now()
+	defer func() {
+		m.CausalityOutBlockedHistogram.Observe(time.Since(start).Seconds())
+	}()
+	var warnC <-chan time.Time
+	if warnInterval > 0 {
+		ticker := time.NewTicker(warnInterval)
+		defer ticker.Stop()
+		warnC = ticker.C
+	}
+	for {
+		select {
+		case outCh <- j:
+			return
+		case <-warnC:
+			logger.Warn("causality is blocked on sending a job to DML workers, DML workers fall behind",
+				zap.Duration("blocked", time.Since(start)),
+				zap.Int("queued jobs", len(outCh)),
+				zap.Stringer("job", j))
+		}
+	}
+}
+
+// causalityStats is the conflict statistics of causality, it's published periodically by causality so others can
+// read it without waiting for causality.
+type causalityStats struct {
+	// ConflictsPerSecond is the number of conflicts per second in the last stats interval, the conflicts in
+	// dry-run mode are also counted.
+	ConflictsPerSecond float64
+	// RelationSize is the number of keys in relation when the stats are published.
+	RelationSize int64
+	// WorkerSkew is the max number of DMLs dispatched to a DML worker divided by the average in the last stats
+	// interval, 1 means DMLs are dispatched evenly. it's 0 if no DML is dispatched.
+	WorkerSkew float64
+	// TotalConflicts is the number of conflicts since causality starts.
+	TotalConflicts int64
+	// RelationGroups is the number of groups in relation when the stats are published.
+	RelationGroups int
+}
+
+// causalityExpvar publishes the causalityStats of every running causality keyed by causalityExpvarKey, so they can be
+// read from the `/debug/vars` endpoint where metrics are not scraped.
+var causalityExpvar = expvar.NewMap("dm_syncer_causality")
+
+// causalityExpvarKey returns the key of the causality of syncer in causalityExpvar.
+func (s *Syncer) causalityExpvarKey() string {
+	return s.cfg.Name + "/" + s.cfg.SourceID
+}
+
+// statsTickerC returns the channel of statsTicker, or nil if stats are not published.
+func (c *causality) statsTickerC() <-chan time.Time {
+	if c.statsTicker == nil {
+		return nil
+	}
+	return c.statsTicker.C
+}
+
+// resetStats starts a new stats interval at now.
+func (c *causality) resetStats(now time.Time) {
+	c.statsConflicts = 0
+	if c.workerCount > 0 {
+		c.statsDispatched = make([]int64, c.workerCount)
+	}
+	c.statsTime = now
+}
+
+// publishStats publishes the stats since the last time they are published, and starts a new stats interval.
+func (c *causality) publishStats(now time.Time) {
+	stats := &causalityStats{
+		RelationSize:   int64(c.relation.len()),
+		TotalConflicts: c.totalConflicts,
+		RelationGroups: len(c.relation.groups),
+	}
+	if elapsed := now.Sub(c.statsTime).Seconds(); elapsed > 0 {
+		stats.ConflictsPerSecond = float64(c.statsConflicts) / elapsed
+	}
+	var total, maxDispatched int64
+	for _, n := range c.statsDispatched {
+		total += n
+		if n > maxDispatched {
+			maxDispatched = n
+		}
+	}
+	if total > 0 {
+		stats.WorkerSkew = float64(maxDispatched) * float64(len(c.statsDispatched)) / float64(total)
+	}
+	c.stats.Store(stats)
+	c.resetStats(now)
+}
+
+// CausalityRelationSize returns the approximate number of keys in causality relation without waiting for
+// causality, so it can be called frequently, e.g. by a metrics scraper. it returns 0 if causality is not running.
+func (s *Syncer) CausalityRelationSize() int64 {
+	relation := s.causalityRelation.Load()
+	if relation == nil {
+		return 0
+	}
+	return relation.approxLen()
+}
+
+// ClearCausalityRelation forces causality to send a conflict job and clear its relation, then waits until all DML
+// workers execute the conflict job or ctx is done. it's a recovery lever for a stuck causality without restarting
+// the task, and it's always safe since a conflict job only waits for the DMLs dispatched before.
+func (s *Syncer) ClearCausalityRelation(ctx context.Context) error {
+	s.tctx.L().Warn("force to clear causality relation on demand")
+	respCh := make(chan *sync.WaitGroup, 1)
+	select {
+	case s.causalityClearCh <- respCh:
+	case <-ctx.Done():
+		return errors.Trace(ctx.Err())
+	}
+	var wg *sync.WaitGroup
+	select {
+	case wg = <-respCh:
+	case <-ctx.Done():
+		return errors.Trace(ctx.Err())
+	}
+	if wg != nil {
+		done := make(chan struct{})
+		go func() {
+			wg.Wait()
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return errors.Trace(ctx.Err())
+		}
+	}
+	s.tctx.L().Warn("causality relation is cleared on demand", zap.Bool("conflict job skipped", wg == nil))
+	return nil
+}
+
+// DumpCausalityRelation returns a JSON snapshot of the causality relation, it's used for debugging.
+// it waits until causality is running or ctx is done. it's not supported by sharded causality.
+func (s *Syncer) DumpCausalityRelation(ctx context.Context) ([]byte, error) {
+	if s.cfg.ExperimentalCausalityShards > 1 {
+		return nil, errors.New("dumping causality relation is not supported when experimental-causality-shards is enabled")
+	}
+	respCh := make(chan []causalityRelationGroupDump, 1)
+	select {
+	case s.causalityDumpCh <- respCh:
+	case <-ctx.Done():
+		return nil, errors.Trace(ctx.Err())
+	}
+	select {
+	case groups := <-respCh:
+		data, err := json.Marshal(groups)
+		return data, errors.Trace(err)
+	case <-ctx.Done():
+		return nil, errors.Trace(ctx.Err())
+	}
+}
+
+// causalityKeys returns the causality keys of the DML job, they are hashed if hashedKeys is true.
+// the keys already computed by the router of sharded causality are reused.
+func (c *causality) causalityKeys(j *job) []string {
+	if j.causalityKeys != nil {
+		return j.causalityKeys
+	}
+	return rowChangeCausalityKeys(j.dml, j.safeMode, c.hashedKeys)
+}
+
+// CausalityKeyOptions are the options of a task which affect the causality keys of a DML.
+type CausalityKeyOptions struct {
+	// Indexes are the lower case names of the indexes in `causality-indexes` for the target table of the DML,
+	// the primary key is named sqlmodel.PrimaryIndexName. nil means all unique indexes are used.
+	Indexes map[string]struct{}
+	// SafeMode is whether the DML is executed in safe mode.
+	SafeMode bool
+	// HashedKeys is `hashed-causality-keys`.
+	HashedKeys bool
+}
+
+// CausalityKeys returns the causality keys of the row change, which are the same as the keys used by causality of a
+// syncer with the options, so it can be used to analyze the conflicts of DMLs without a syncer. the row change should
+// be created with the table info of both upstream and downstream like the syncer does, and its causality indexes are
+// replaced by opts.Indexes.
+func CausalityKeys(change *sqlmodel.RowChange, opts CausalityKeyOptions) []string {
+	change.SetCausalityIndexes(opts.Indexes)
+	return rowChangeCausalityKeys(change, opts.SafeMode, opts.HashedKeys)
+}
+
+// rowChangeCausalityKeys returns the causality keys of the row change. in safe mode the DML is executed as REPLACE or
+// DELETE + REPLACE, which touches all unique keys of the row, so the keys are generated from all unique indexes
+// even if `causality-indexes` restricts them.
+func rowChangeCausalityKeys(change *sqlmodel.RowChange, safeMode, hashedKeys bool) []string {
+	if safeMode {
+		change.SetCausalityIndexes(nil)
+	}
+	keys := change.CausalityKeys()
+	if hashedKeys {
+		for i, key := range keys {
+			keys[i] = strconv.FormatUint(mixHash(key), 16)
+		}
+	}
+	return keys
+}
 
-		c.outCh <- j
+// isAppendOnly returns whether the job belongs to an append-only table.
+func (c *causality) isAppendOnly(j *job) bool {
+	if c.appendOnlyTables == nil {
+		return false
 	}
+	table := j.dml.GetSourceTable()
+	return c.appendOnlyTables.MatchTable(table.Schema, table.Table)
+}
+
+// updateRelationMetrics reports the current stats of the causality relation.
+func (c *causality) updateRelationMetrics() {
+	reportRelationMetrics(c.metricProxies.Metrics, c.relation)
 }
 
-// close closes outer channel.
+// reportRelationMetrics reports the current stats of relation to m.
+func reportRelationMetrics(m *metrics.Metrics, relation *causalityRelation) {
+	stats := relation.Stats()
+	m.CausalityRelationSizeGauge.Set(float64(stats.Keys))
+	m.CausalityRelationGroupsGauge.Set(float64(stats.Groups))
+	m.CausalityRelationNewestKeysGauge.Set(float64(stats.NewestGroupKeys))
+	m.CausalityRelationOldestSeqGauge.Set(float64(stats.OldestFlushJobSeq))
+	m.CausalityRelationBytesGauge.Set(float64(stats.EstimatedBytes))
+}
+
+// close closes outer channel. it reports the final stats of relation and clears the queue size of input and output
+// channel, so the metrics of a stopped causality are not stale. DML workers still update the queue size of output
+// channel if they dequeue the remaining jobs.
 func (c *causality) close() {
+	c.updateRelationMetrics()
+	c.metricProxies.QueueSizeGauge.WithLabelValues(c.task, "causality_input", c.source).Set(0)
+	c.metricProxies.QueueSizeGauge.WithLabelValues(c.task, "dml_worker_input", c.source).Set(0)
 	close(c.outCh)
 }
 
@@ -124,71 +1315,271 @@ func (c *causality) add(keys []string) string {
 		return ""
 	}
 
-	// find causal key
+	selectedRelation, nonExistKeys := selectRelation(c.relation, keys)
+	// join those non-exist keys into the selected relation
+	for _, key := range nonExistKeys {
+		c.relation.union(key, selectedRelation)
+	}
+
+	return selectedRelation
+}
+
+// merge is like add, but it also merges the relations of the existing keys into the selected relation. the DMLs of
+// the merged relations must be all executed or dispatched to the same DML worker, see partialFlushWorkers.
+func (c *causality) merge(keys []string) string {
+	if len(keys) == 0 {
+		return ""
+	}
+
+	selectedRelation, _ := selectRelation(c.relation, keys)
+	for _, key := range keys {
+		c.relation.union(key, selectedRelation)
+	}
+	return selectedRelation
+}
+
+// selectRelation returns the relation which keys would join by add, and the keys not in relation yet.
+// the relation of the last existing key is selected, or the first key if none exists. it never mutates relation.
+func selectRelation(relation *causalityRelation, keys []string) (string, []string) {
+	if len(keys) == 0 {
+		return "", nil
+	}
 	selectedRelation := keys[0]
 	var nonExistKeys []string
 	for _, key := range keys {
-		if val, ok := c.relation.get(key); ok {
-			selectedRelation = val
+		if root, _, ok := relation.find(key); ok {
+			selectedRelation = root
 		} else {
 			nonExistKeys = append(nonExistKeys, key)
 		}
 	}
-	// set causal relations for those non-exist keys
-	for _, key := range nonExistKeys {
-		c.relation.set(key, selectedRelation)
+	return selectedRelation, nonExistKeys
+}
+
+// routeKeys returns the queue key and the index of the DML worker which a DML with the causality keys would be
+// dispatched to by the current relation, without adding the keys or mutating anything, so it can be used to
+// inspect the routing. the keys are the raw causality keys of the DML, they are hashed first if hashedKeys is
+// enabled. conflicts are not detected, and the worker index is -1 if there's no DML worker.
+func (c *causality) routeKeys(keys []string) (string, int) {
+	if c.hashedKeys {
+		hashed := make([]string, 0, len(keys))
+		for _, key := range keys {
+			hashed = append(hashed, strconv.FormatUint(mixHash(key), 16))
+		}
+		keys = hashed
 	}
+	relation, _ := selectRelation(c.relation, keys)
+	queueKey := c.queueKey(relation)
+	if c.workerCount <= 0 {
+		return queueKey, -1
+	}
+	return queueKey, dmlQueueBucket(queueKey, c.workerCount)
+}
 
-	return selectedRelation
+// queueKey returns the key used by DML workers to choose the worker for the relation.
+// all keys of a relation share the same root, so they are still dispatched to the same worker.
+func (c *causality) queueKey(relation string) string {
+	if !c.hashKey || relation == "" {
+		return relation
+	}
+	return strconv.FormatUint(mixHash(relation), 16)
+}
+
+// mixHash hashes the key by FNV-1a and mixes the bits by the finalizer of MurmurHash3, so keys
+// sharing a long prefix are spread evenly.
+func mixHash(key string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(key))
+	sum := h.Sum64()
+	sum ^= sum >> 33
+	sum *= 0xff51afd7ed558ccd
+	sum ^= sum >> 33
+	sum *= 0xc4ceb3fe1a85ec53
+	sum ^= sum >> 33
+	return sum
 }
 
 // detectConflict detects whether there is a conflict.
 func (c *causality) detectConflict(keys []string) bool {
+	_, ok := c.findConflict(keys)
+	return ok
+}
+
+// causalityConflict is the first pair of keys which belong to different relations.
+type causalityConflict struct {
+	existedKey         string
+	existedRelation    string
+	conflictedKey      string
+	conflictedRelation string
+}
+
+// findConflict returns the first two keys which belong to different relations, and whether they conflict.
+func (c *causality) findConflict(keys []string) (causalityConflict, bool) {
 	if len(keys) == 0 {
-		return false
+		return causalityConflict{}, false
+	}
+	// fast path, none of the keys has been seen before.
+	if !c.relation.mayContainAny(keys) {
+		return causalityConflict{}, false
 	}
 
-	var existedRelation string
+	var existedKey, existedRelation string
 	for _, key := range keys {
 		if val, ok := c.relation.get(key); ok {
 			if existedRelation != "" && val != existedRelation {
-				return true
+				return causalityConflict{
+					existedKey:         existedKey,
+					existedRelation:    existedRelation,
+					conflictedKey:      key,
+					conflictedRelation: val,
+				}, true
 			}
-			existedRelation = val
+			existedKey, existedRelation = key, val
 		}
 	}
 
-	return false
+	return causalityConflict{}, false
 }
 
 // dmlJobKeyRelationGroup stores a group of dml job key relations as data, and a flush job seq representing last flush job before adding any job keys.
+// data maps a key to its parent key, a key whose parent is itself (or is not stored anymore) is the root of a relation.
 type dmlJobKeyRelationGroup struct {
 	data            map[string]string
 	prevFlushJobSeq int64
+	// keyBytes is the estimated memory used by data.
+	keyBytes int64
+	// filter is nil when bloom filter is disabled.
+	filter *keyFilter
+	// createdAt is the time when the group is rotated, a merged group keeps the earlier one.
+	createdAt time.Time
+}
+
+// mayContain returns false if key is definitely not in the group.
+func (g *dmlJobKeyRelationGroup) mayContain(key string) bool {
+	return g.filter == nil || g.filter.mayContain(key)
 }
 
-// causalityRelation stores causality keys by group, where each group created on each flush and it helps to remove stale causality keys.
+// causalityRelation stores causality keys as a disjoint set (union-find) by group, where each group created on each flush and
+// it helps to remove stale causality keys.
+// as groups are removed by gc, the parent of a key may be removed before the key itself, in this case the removed parent is
+// still treated as the root of the relation, which keeps the relation of the remaining keys unchanged.
 type causalityRelation struct {
 	groups []*dmlJobKeyRelationGroup
+	// expected number of keys in one group to size the bloom filter, 0 means bloom filter is disabled.
+	filterKeys int
+	// capacity of the data map of a new group, 0 means the map is taken from relationGroupDataPool.
+	groupCapacity int
+	// approxKeys is the number of keys in all groups like len, it's updated when keys are added or removed and
+	// can be read by other goroutines, see approxLen.
+	approxKeys atomic.Int64
+	// partialGC is true if some groups are removed by gc since the relation is cleared, so the parent of a key may
+	// be removed, see checkInvariants.
+	partialGC bool
 }
 
 func newCausalityRelation() *causalityRelation {
-	m := &causalityRelation{}
+	return newCausalityRelationWithFilter(0)
+}
+
+// newCausalityRelationWithFilter creates a causalityRelation whose groups use a bloom filter sized for
+// expectedKeys keys, so that lookups of keys which are never seen can skip the group quickly.
+func newCausalityRelationWithFilter(expectedKeys int) *causalityRelation {
+	return newCausalityRelationWithCapacity(expectedKeys, 0)
+}
+
+// newCausalityRelationWithCapacity creates a causalityRelation like newCausalityRelationWithFilter, and the data
+// map of each group is pre-sized for groupCapacity keys.
+func newCausalityRelationWithCapacity(expectedKeys, groupCapacity int) *causalityRelation {
+	m := &causalityRelation{filterKeys: expectedKeys, groupCapacity: groupCapacity}
 	m.rotate(-1)
 	return m
 }
 
-func (m *causalityRelation) get(key string) (string, bool) {
+// find returns the root of key and the index of group which stores key, the path is not compressed.
+func (m *causalityRelation) find(key string) (string, int, bool) {
+	root, idx, ok := m.parent(key)
+	if !ok {
+		return "", -1, false
+	}
+	for root != key {
+		next, _, ok := m.parent(root)
+		if !ok || next == root {
+			break
+		}
+		root = next
+	}
+	return root, idx, true
+}
+
+// parent returns the parent of key and the index of group which stores it.
+func (m *causalityRelation) parent(key string) (string, int, bool) {
 	for i := len(m.groups) - 1; i >= 0; i-- {
+		if !m.groups[i].mayContain(key) {
+			continue
+		}
 		if v, ok := m.groups[i].data[key]; ok {
-			return v, true
+			return v, i, true
 		}
 	}
-	return "", false
+	return "", -1, false
+}
+
+// mayContainAny returns false if none of the keys is in the relation.
+func (m *causalityRelation) mayContainAny(keys []string) bool {
+	for _, g := range m.groups {
+		for _, key := range keys {
+			if g.mayContain(key) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// get returns the root of the relation which key belongs to.
+func (m *causalityRelation) get(key string) (string, bool) {
+	root, idx, ok := m.find(key)
+	if !ok {
+		return "", false
+	}
+	// compress the path only inside the same group, so that gc semantics of other groups are not changed.
+	if m.groups[idx].data[key] != root {
+		m.groups[idx].data[key] = root
+	}
+	return root, true
 }
 
+// set sets the relation of key to val in the newest group. val is always a root read from relation or the key which
+// creates a new relation, so all values of a relation already share the backing bytes of its root and don't need to
+// be interned, see TestCausalityRelationValuesShareRoot.
 func (m *causalityRelation) set(key string, val string) {
-	m.groups[len(m.groups)-1].data[key] = val
+	g := m.groups[len(m.groups)-1]
+	if _, ok := g.data[key]; !ok {
+		g.keyBytes += int64(len(key)+len(val)) + relationEntryOverhead
+		m.approxKeys.Inc()
+	}
+	g.data[key] = val
+	if g.filter != nil {
+		g.filter.add(key)
+	}
+}
+
+// union joins the relation of key into the relation of target.
+// NOTE: when both key and target already exist and belong to different relations, the caller must make sure that
+// jobs of these two relations are all executed before merging, because they may be dispatched to different workers.
+func (m *causalityRelation) union(key, target string) {
+	targetRoot, ok := m.get(target)
+	if !ok {
+		targetRoot = target
+	}
+	keyRoot, ok := m.get(key)
+	if !ok {
+		m.set(key, targetRoot)
+		return
+	}
+	if keyRoot != targetRoot {
+		m.set(keyRoot, targetRoot)
+	}
 }
 
 func (m *causalityRelation) len() int {
@@ -199,33 +1590,356 @@ func (m *causalityRelation) len() int {
 	return cnt
 }
 
+// approxLen returns the number of keys in relation, it's the only method safe to be called concurrently with
+// the goroutine which modifies the relation. the value is approximate, it may lag behind or run ahead of len
+// while keys are being added or removed, but it equals len when the relation isn't modified.
+func (m *causalityRelation) approxLen() int64 {
+	return m.approxKeys.Load()
+}
+
+// relationEntryOverhead is the estimated memory of a map entry in dmlJobKeyRelationGroup besides the content of
+// key and value, including two string headers and the amortized cost of map buckets.
+const relationEntryOverhead = 48
+
+// RelationStats is the statistics of causalityRelation.
+type RelationStats struct {
+	// Groups is the number of groups.
+	Groups int
+	// Keys is the total number of keys in all groups.
+	Keys int
+	// NewestGroupKeys is the number of keys in the newest group.
+	NewestGroupKeys int
+	// OldestFlushJobSeq is the prevFlushJobSeq of the oldest group.
+	OldestFlushJobSeq int64
+	// EstimatedBytes is the estimated memory used by keys and bloom filters of all groups.
+	EstimatedBytes int64
+}
+
+// Stats returns the statistics of the relation, it doesn't allocate and only costs O(groups).
+func (m *causalityRelation) Stats() RelationStats {
+	stats := RelationStats{Groups: len(m.groups)}
+	for _, g := range m.groups {
+		stats.Keys += len(g.data)
+		stats.EstimatedBytes += g.keyBytes
+		if g.filter != nil {
+			stats.EstimatedBytes += int64(len(g.filter.bits) * 8)
+		}
+	}
+	if len(m.groups) > 0 {
+		stats.NewestGroupKeys = len(m.groups[len(m.groups)-1].data)
+		stats.OldestFlushJobSeq = m.groups[0].prevFlushJobSeq
+	}
+	return stats
+}
+
+// causalityRelationGroupDump is the snapshot of a dmlJobKeyRelationGroup, it's used for debugging.
+type causalityRelationGroupDump struct {
+	PrevFlushJobSeq int64             `json:"prev-flush-job-seq"`
+	Relations       map[string]string `json:"relations"`
+}
+
+// dump returns a snapshot of all groups, from the oldest to the newest.
+// the relation value of each key is its root in the relation.
+func (m *causalityRelation) dump() []causalityRelationGroupDump {
+	ret := make([]causalityRelationGroupDump, 0, len(m.groups))
+	for _, g := range m.groups {
+		relations := make(map[string]string, len(g.data))
+		for key := range g.data {
+			relations[key], _ = m.get(key)
+		}
+		ret = append(ret, causalityRelationGroupDump{
+			PrevFlushJobSeq: g.prevFlushJobSeq,
+			Relations:       relations,
+		})
+	}
+	return ret
+}
+
+// snapshot returns the keys of all groups by the roots of their relations, a root is not repeated in its keys.
+// the groups are not kept, the relations are restored to one group by restore.
+func (m *causalityRelation) snapshot() map[string][]string {
+	ret := make(map[string][]string)
+	// a key may be stored in several groups.
+	seen := make(map[string]struct{})
+	for _, g := range m.groups {
+		for key := range g.data {
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			root, _ := m.get(key)
+			keys, ok := ret[root]
+			if !ok {
+				keys = []string{}
+			}
+			if key != root {
+				keys = append(keys, key)
+			}
+			ret[root] = keys
+		}
+	}
+	return ret
+}
+
+// restore adds the relations of a snapshot to the newest group, it's only called on an empty relation, so the
+// restored keys are removed by the gc job of the first flush job after it.
+func (m *causalityRelation) restore(relations map[string][]string) {
+	for root, keys := range relations {
+		m.set(root, root)
+		for _, key := range keys {
+			m.set(key, root)
+		}
+	}
+}
+
+// relationGroupDataPool pools the data maps of dmlJobKeyRelationGroup to reduce allocations when groups are
+// rotated and garbage collected frequently. maps are cleared before they are put back, so keys never leak
+// between groups or tasks.
+var relationGroupDataPool = sync.Pool{
+	New: func() interface{} {
+		return make(map[string]string)
+	},
+}
+
+// recycle clears the data of the group and puts it back to the pool, the group must not be used afterwards.
+func (g *dmlJobKeyRelationGroup) recycle() {
+	clear(g.data)
+	relationGroupDataPool.Put(g.data)
+	g.data = nil
+}
+
 func (m *causalityRelation) rotate(flushJobSeq int64) {
-	m.groups = append(m.groups, &dmlJobKeyRelationGroup{
-		data:            make(map[string]string),
-		prevFlushJobSeq: flushJobSeq,
-	})
+	g := &dmlJobKeyRelationGroup{prevFlushJobSeq: flushJobSeq, createdAt: time.Now()}
+	// the capacity of a pooled map is unknown, so a pre-sized map is always made.
+	if m.groupCapacity > 0 {
+		g.data = make(map[string]string, m.groupCapacity)
+	} else {
+		g.data = relationGroupDataPool.Get().(map[string]string)
+	}
+	if m.filterKeys > 0 {
+		g.filter = newKeyFilter(m.filterKeys)
+	}
+	m.groups = append(m.groups, g)
+}
+
+// mergeOldestGroups merges the oldest two groups repeatedly until at most maxGroups groups are kept, and returns
+// the number of merges. at least two groups are kept, and 0 maxGroups means unlimited.
+// a key in the newer group overrides the same key in the older one, just like parent looks up the newer group first.
+// the merged group keeps the smaller prevFlushJobSeq, so it's removed by gc no earlier than any of the two groups,
+// which may keep the keys of the older group longer but never drops keys too early. bloom filters are merged by OR,
+// which only increases false positives.
+func (m *causalityRelation) mergeOldestGroups(maxGroups int) int {
+	if maxGroups <= 0 {
+		return 0
+	}
+	maxGroups = max(maxGroups, 2)
+	merged := 0
+	for len(m.groups) > maxGroups {
+		older, newer := m.groups[0], m.groups[1]
+		target := older
+		if len(older.data) >= len(newer.data) {
+			for key, val := range newer.data {
+				if oldVal, ok := older.data[key]; ok {
+					older.keyBytes -= int64(len(key)+len(oldVal)) + relationEntryOverhead
+					m.approxKeys.Dec()
+				}
+				older.data[key] = val
+			}
+			older.keyBytes += newer.keyBytes
+			older.prevFlushJobSeq = min(older.prevFlushJobSeq, newer.prevFlushJobSeq)
+			if newer.createdAt.Before(older.createdAt) {
+				older.createdAt = newer.createdAt
+			}
+			older.filter.merge(newer.filter)
+			newer.recycle()
+		} else {
+			// copy the smaller group into the bigger one.
+			for key, val := range older.data {
+				if _, ok := newer.data[key]; !ok {
+					newer.data[key] = val
+					newer.keyBytes += int64(len(key)+len(val)) + relationEntryOverhead
+				} else {
+					m.approxKeys.Dec()
+				}
+			}
+			newer.prevFlushJobSeq = min(older.prevFlushJobSeq, newer.prevFlushJobSeq)
+			if older.createdAt.Before(newer.createdAt) {
+				newer.createdAt = older.createdAt
+			}
+			newer.filter.merge(older.filter)
+			older.recycle()
+			target = newer
+		}
+		m.groups[0] = target
+		copy(m.groups[1:], m.groups[2:])
+		m.groups[len(m.groups)-1] = nil
+		m.groups = m.groups[:len(m.groups)-1]
+		merged++
+	}
+	return merged
+}
+
+// oldestKeyTime returns the createdAt of the oldest group which has keys, it's false if relation has no key.
+func (m *causalityRelation) oldestKeyTime() (time.Time, bool) {
+	for _, g := range m.groups {
+		if len(g.data) > 0 {
+			return g.createdAt, true
+		}
+	}
+	return time.Time{}, false
 }
 
 func (m *causalityRelation) clear() {
 	m.gc(math.MaxInt64)
 }
 
-// remove group of keys where its group's prevFlushJobSeq is smaller than or equal with the given flushJobSeq.
+// clearKeys removes all keys like clear, but keeps the prevFlushJobSeq of the newest group, so the seqs of groups
+// are still in order when later flush jobs rotate the relation, and the gc jobs of sent flush jobs work as before.
+func (m *causalityRelation) clearKeys() {
+	seq := m.groups[len(m.groups)-1].prevFlushJobSeq
+	m.clear()
+	m.groups[0].prevFlushJobSeq = seq
+}
+
+// gc removes the groups whose keys are all added before the flush job of flushJobSeq is sent, that is, the groups
+// followed by a group whose prevFlushJobSeq is smaller than or equal with the given flushJobSeq. the newest group
+// is always kept. prevFlushJobSeq is expected to be non-decreasing across groups, but all groups are checked in
+// case it's not, so a group is never retained or removed because of the order of other groups.
 func (m *causalityRelation) gc(flushJobSeq int64) {
 	if flushJobSeq == math.MaxInt64 {
+		m.recycleGroups(len(m.groups))
 		m.groups = m.groups[:0]
+		m.partialGC = false
 		m.rotate(-1)
 		return
 	}
 
-	idx := 0
-	for i, d := range m.groups {
-		if d.prevFlushJobSeq <= flushJobSeq {
-			idx = i
-		} else {
-			break
+	n := len(m.groups)
+	kept := 0
+	nonMonotonic := false
+	prevSeq := int64(math.MinInt64)
+	for i, g := range m.groups {
+		if g.prevFlushJobSeq < prevSeq {
+			nonMonotonic = true
+		}
+		prevSeq = g.prevFlushJobSeq
+		// the group is rotated out by the flush job of the next group's prevFlushJobSeq.
+		if i < n-1 && m.groups[i+1].prevFlushJobSeq <= flushJobSeq {
+			m.approxKeys.Sub(int64(len(g.data)))
+			g.recycle()
+			continue
+		}
+		m.groups[kept] = g
+		kept++
+	}
+	for i := kept; i < n; i++ {
+		m.groups[i] = nil
+	}
+	m.groups = m.groups[:kept]
+	if kept < n {
+		m.partialGC = true
+	}
+	if nonMonotonic {
+		log.L().Warn("flush job seqs of causality relation groups are not in order", zap.Int64("flush job seq", flushJobSeq))
+	}
+}
+
+// checkInvariants returns the violations of the invariants of relation, it costs O(keys * groups) and is only used
+// by the self-check of causality.
+//   - there's at least one group, and no group is recycled. groups without keys are valid, such as the group rotated
+//     by a flush job just after another one.
+//   - prevFlushJobSeq is non-decreasing from the oldest group to the newest one, merged groups keep the smaller seq.
+//   - every key maps to a value which is itself a key in some group, unless partialGC is true, since gc may remove
+//     the parent of a key which is then treated as a root.
+func (m *causalityRelation) checkInvariants() []string {
+	if len(m.groups) == 0 {
+		return []string{"relation has no group"}
+	}
+	var violations []string
+	for i, g := range m.groups {
+		if g.data == nil {
+			violations = append(violations, fmt.Sprintf("group %d is recycled", i))
+			continue
 		}
+		if i > 0 && g.prevFlushJobSeq < m.groups[i-1].prevFlushJobSeq {
+			violations = append(violations, fmt.Sprintf("prevFlushJobSeq %d of group %d is less than %d of group %d",
+				g.prevFlushJobSeq, i, m.groups[i-1].prevFlushJobSeq, i-1))
+		}
+	}
+	if m.partialGC {
+		return violations
+	}
+	for i, g := range m.groups {
+		for key, val := range g.data {
+			if _, _, ok := m.parent(val); !ok {
+				violations = append(violations, fmt.Sprintf("key %q of group %d maps to %q which is not a key", key, i, val))
+			}
+		}
+	}
+	return violations
+}
+
+// recycleGroups recycles the first n groups and removes their references from the underlying array.
+func (m *causalityRelation) recycleGroups(n int) {
+	for i := 0; i < n; i++ {
+		m.approxKeys.Sub(int64(len(m.groups[i].data)))
+		m.groups[i].recycle()
+		m.groups[i] = nil
+	}
+}
+
+const (
+	keyFilterBitsPerKey = 10
+	keyFilterHashCount  = 7
+)
+
+// keyFilter is a simple bloom filter of causality keys.
+type keyFilter struct {
+	bits []uint64
+	size uint64
+}
+
+func newKeyFilter(expectedKeys int) *keyFilter {
+	size := uint64(expectedKeys * keyFilterBitsPerKey)
+	return &keyFilter{
+		bits: make([]uint64, (size+63)/64),
+		size: size,
+	}
+}
+
+// hash returns two hash values of key, they are combined to simulate k hash functions.
+func (f *keyFilter) hash(key string) (uint64, uint64) {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(key))
+	sum := h.Sum64()
+	return sum & math.MaxUint32, sum>>32 | 1
+}
+
+func (f *keyFilter) add(key string) {
+	h1, h2 := f.hash(key)
+	for i := uint64(0); i < keyFilterHashCount; i++ {
+		pos := (h1 + i*h2) % f.size
+		f.bits[pos/64] |= 1 << (pos % 64)
+	}
+}
+
+// merge adds all keys of other to f, both filters must have the same size. it's a no-op if any of them is nil.
+func (f *keyFilter) merge(other *keyFilter) {
+	if f == nil || other == nil {
+		return
+	}
+	for i := range f.bits {
+		f.bits[i] |= other.bits[i]
 	}
+}
 
-	m.groups = m.groups[idx:]
+func (f *keyFilter) mayContain(key string) bool {
+	h1, h2 := f.hash(key)
+	for i := uint64(0); i < keyFilterHashCount; i++ {
+		pos := (h1 + i*h2) % f.size
+		if f.bits[pos/64]&(1<<(pos%64)) == 0 {
+			return false
+		}
+	}
+	return true
 }
@@ -0,0 +1,193 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pingcap/errors"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// types of causalityDecision, each one is an operation on causality relation.
+const (
+	// the keys are checked for conflict, Conflict is the result.
+	causalityDecisionDetect = "detect"
+	// the keys are added to relation and dispatched by QueueKey, or only dispatched if AppendOnly.
+	causalityDecisionDispatch = "dispatch"
+	// the keys are added to relation with merging their relations by a partial conflict job, and dispatched by QueueKey.
+	causalityDecisionMerge = "merge"
+	// the relation is cleared after a conflict job.
+	causalityDecisionClear = "clear"
+	// the relation is rotated by the flush job of FlushSeq.
+	causalityDecisionRotate = "rotate"
+	// the groups before the flush job of FlushSeq are removed.
+	causalityDecisionGC = "gc"
+	// the relation is cleared because causality is idle.
+	causalityDecisionIdleClear = "idle-clear"
+)
+
+// causalityDecision records an operation of causality on its relation, replaying all decisions in order on an
+// empty relation re-derives the conflicts and queue keys, see replayCausalityDecisions.
+type causalityDecision struct {
+	Seq  uint64 `json:"seq"`
+	Type string `json:"type"`
+	// Keys are the causality keys, they are already hashed if hashed-causality-keys is enabled.
+	Keys       []string `json:"keys,omitempty"`
+	Conflict   bool     `json:"conflict,omitempty"`
+	AppendOnly bool     `json:"append-only,omitempty"`
+	QueueKey   string   `json:"queue-key,omitempty"`
+	FlushSeq   int64    `json:"flush-seq,omitempty"`
+}
+
+// causalityDecisionLog keeps the latest decisions of causality in a ring buffer. a nil log records nothing, so
+// causality can always call record. it's only accessed by the goroutine of causality.
+type causalityDecisionLog struct {
+	records []causalityDecision
+	// next is the position of the next record when the buffer is full.
+	next int
+	seq  uint64
+
+	// tap receives a copy of each decision for external observers, it's nil if no one observes. it's best-effort,
+	// a decision is dropped instead of blocking causality if tap is full, so an observer may miss decisions under
+	// load and should check the continuity of Seq. the Keys are shared with the ring buffer and must not be modified.
+	tap chan<- causalityDecision
+	// tapDropped counts the decisions dropped because tap is full.
+	tapDropped prometheus.Counter
+}
+
+// newCausalityDecisionLog creates a log which keeps the latest size decisions, size can be 0 if decisions are only
+// sent to tap.
+func newCausalityDecisionLog(size int) *causalityDecisionLog {
+	return &causalityDecisionLog{records: make([]causalityDecision, 0, size)}
+}
+
+func (l *causalityDecisionLog) record(d causalityDecision) {
+	if l == nil {
+		return
+	}
+	d.Seq = l.seq
+	l.seq++
+	if l.tap != nil {
+		select {
+		case l.tap <- d:
+		default:
+			l.tapDropped.Inc()
+		}
+	}
+	if cap(l.records) == 0 {
+		return
+	}
+	if len(l.records) < cap(l.records) {
+		l.records = append(l.records, d)
+		return
+	}
+	l.records[l.next] = d
+	l.next = (l.next + 1) % len(l.records)
+}
+
+// snapshot returns the kept decisions from the oldest to the newest.
+func (l *causalityDecisionLog) snapshot() []causalityDecision {
+	if l == nil {
+		return nil
+	}
+	ret := make([]causalityDecision, 0, len(l.records))
+	ret = append(ret, l.records[l.next:]...)
+	ret = append(ret, l.records[:l.next]...)
+	return ret
+}
+
+// replayCausalityDecisions replays the decisions on an empty relation with the options hashKey and maxGroups of
+// the causality which records them, and returns an error at the first decision whose conflict or queue key is not
+// re-derived. if the oldest decisions are dropped by the ring buffer, the replay starts from the first clear
+// decision, since the relation is empty then, and the decisions after it must be continuous with it.
+func replayCausalityDecisions(decisions []causalityDecision, hashKey bool, maxGroups int) error {
+	if len(decisions) > 0 && decisions[0].Seq != 0 {
+		start := -1
+		for i, d := range decisions {
+			if d.Type == causalityDecisionClear {
+				start = i
+				break
+			}
+		}
+		if start < 0 {
+			return errors.Errorf("decisions start from seq %d without a clear decision to replay from", decisions[0].Seq)
+		}
+		decisions = decisions[start:]
+	}
+
+	c := &causality{relation: newCausalityRelation(), hashKey: hashKey, maxGroups: maxGroups}
+	for i, d := range decisions {
+		if i > 0 && d.Seq != decisions[i-1].Seq+1 {
+			return errors.Errorf("decision seq %d is not continuous with seq %d", d.Seq, decisions[i-1].Seq)
+		}
+		switch d.Type {
+		case causalityDecisionDetect:
+			if conflict := c.detectConflict(d.Keys); conflict != d.Conflict {
+				return errors.Errorf("decision seq %d: keys %v conflict %v, replayed %v", d.Seq, d.Keys, d.Conflict, conflict)
+			}
+		case causalityDecisionDispatch:
+			var queueKey string
+			if d.AppendOnly {
+				if len(d.Keys) > 0 {
+					queueKey = c.queueKey(d.Keys[0])
+				}
+			} else {
+				queueKey = c.queueKey(c.add(d.Keys))
+			}
+			if queueKey != d.QueueKey {
+				return errors.Errorf("decision seq %d: keys %v queue key %q, replayed %q", d.Seq, d.Keys, d.QueueKey, queueKey)
+			}
+		case causalityDecisionMerge:
+			if queueKey := c.queueKey(c.merge(d.Keys)); queueKey != d.QueueKey {
+				return errors.Errorf("decision seq %d: keys %v queue key %q, replayed %q", d.Seq, d.Keys, d.QueueKey, queueKey)
+			}
+		case causalityDecisionClear:
+			c.relation.clear()
+		case causalityDecisionRotate:
+			c.relation.rotate(d.FlushSeq)
+			c.relation.mergeOldestGroups(c.maxGroups)
+		case causalityDecisionGC:
+			c.relation.gc(d.FlushSeq)
+		case causalityDecisionIdleClear:
+			c.relation.clearKeys()
+		default:
+			return errors.Errorf("decision seq %d: unknown type %s", d.Seq, d.Type)
+		}
+	}
+	return nil
+}
+
+// DumpCausalityDecisions returns the latest decisions of causality as JSON, they are only recorded when
+// causality-decision-log is set. it waits until causality is running or ctx is done. it's not supported by sharded
+// causality.
+func (s *Syncer) DumpCausalityDecisions(ctx context.Context) ([]byte, error) {
+	if s.cfg.ExperimentalCausalityShards > 1 {
+		return nil, errors.New("dumping causality decisions is not supported when experimental-causality-shards is enabled")
+	}
+	respCh := make(chan []causalityDecision, 1)
+	select {
+	case s.causalityDecisionDumpCh <- respCh:
+	case <-ctx.Done():
+		return nil, errors.Trace(ctx.Err())
+	}
+	select {
+	case decisions := <-respCh:
+		data, err := json.Marshal(decisions)
+		return data, errors.Trace(err)
+	case <-ctx.Done():
+		return nil, errors.Trace(ctx.Err())
+	}
+}
@@ -0,0 +1,212 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/go-mysql-org/go-mysql/mysql"
+	cdcmodel "github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/dm/config"
+	"github.com/pingcap/tiflow/dm/pkg/binlog"
+	tcontext "github.com/pingcap/tiflow/dm/pkg/context"
+	"github.com/pingcap/tiflow/dm/pkg/log"
+	"github.com/pingcap/tiflow/dm/pkg/utils"
+	"github.com/pingcap/tiflow/dm/syncer/metrics"
+	"github.com/pingcap/tiflow/pkg/sqlmodel"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCausalityDecisionLog(t *testing.T) {
+	t.Parallel()
+
+	var nilLog *causalityDecisionLog
+	nilLog.record(causalityDecision{Type: causalityDecisionClear})
+	require.Nil(t, nilLog.snapshot())
+
+	l := newCausalityDecisionLog(3)
+	require.Empty(t, l.snapshot())
+	for i := 0; i < 5; i++ {
+		l.record(causalityDecision{Type: causalityDecisionGC, FlushSeq: int64(i)})
+		if i == 1 {
+			require.Equal(t, []causalityDecision{
+				{Seq: 0, Type: causalityDecisionGC},
+				{Seq: 1, Type: causalityDecisionGC, FlushSeq: 1},
+			}, l.snapshot())
+		}
+	}
+	require.Equal(t, []causalityDecision{
+		{Seq: 2, Type: causalityDecisionGC, FlushSeq: 2},
+		{Seq: 3, Type: causalityDecisionGC, FlushSeq: 3},
+		{Seq: 4, Type: causalityDecisionGC, FlushSeq: 4},
+	}, l.snapshot())
+}
+
+func TestCausalityDecisionTap(t *testing.T) {
+	t.Parallel()
+
+	ti := mockTableInfo(t, "create table tb(a int primary key, b int unique);")
+	table := &cdcmodel.TableName{Schema: "test", Table: "t1"}
+	location := binlog.MustZeroLocation(mysql.MySQLFlavor)
+	ec := &eventContext{startLocation: location, endLocation: location, lastLocation: location}
+	jobCh := make(chan *job, 10)
+	tap := make(chan causalityDecision, 2)
+	syncer := &Syncer{
+		cfg: &config.SubTaskConfig{
+			SyncerConfig: config.SyncerConfig{
+				QueueSize: 1024,
+			},
+			Name:     "decision-tap-task",
+			SourceID: "source",
+		},
+		tctx:                    tcontext.Background().WithLogger(log.L()),
+		sessCtx:                 utils.NewSessionCtx(map[string]string{"time_zone": "UTC"}),
+		causalityDecisionDumpCh: make(chan chan []causalityDecision),
+		causalityDecisionTap:    tap,
+	}
+	syncer.metricsProxies = metrics.DefaultMetricsProxies.CacheForOneTask("decision-tap-task", "worker", "source")
+	causalityCh := causalityWrap(context.Background(), jobCh, syncer)
+	defer close(jobCh)
+
+	// the decisions of the first DML fill the tap, the ones of the second DML are dropped without blocking.
+	jobCh <- newDMLJob(sqlmodel.NewRowChange(table, nil, nil, []interface{}{1, 2}, ti, nil, nil), ec)
+	jobCh <- newDMLJob(sqlmodel.NewRowChange(table, nil, nil, []interface{}{3, 4}, ti, nil, nil), ec)
+	require.Equal(t, dml, (<-causalityCh).tp)
+	require.Equal(t, dml, (<-causalityCh).tp)
+	detect := <-tap
+	require.Equal(t, uint64(0), detect.Seq)
+	require.Equal(t, causalityDecisionDetect, detect.Type)
+	require.False(t, detect.Conflict)
+	dispatch := <-tap
+	require.Equal(t, uint64(1), dispatch.Seq)
+	require.Equal(t, causalityDecisionDispatch, dispatch.Type)
+	require.Equal(t, detect.Keys, dispatch.Keys)
+	require.NotEmpty(t, dispatch.QueueKey)
+	m := &dto.Metric{}
+	require.NoError(t, syncer.metricsProxies.Metrics.CausalityTapDroppedCounter.Write(m))
+	require.Equal(t, float64(2), m.GetCounter().GetValue())
+
+	// decisions are not kept without causality-decision-log, later ones are sent to the tap again.
+	data, err := syncer.DumpCausalityDecisions(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "[]", string(data))
+	jobCh <- newFlushJob(0, 1)
+	require.Equal(t, flush, (<-causalityCh).tp)
+	rotate := <-tap
+	require.Equal(t, causalityDecision{Seq: 4, Type: causalityDecisionRotate, FlushSeq: 1}, rotate)
+}
+
+func TestReplayCausalityDecisions(t *testing.T) {
+	t.Parallel()
+
+	ti := mockTableInfo(t, "create table tb(a int primary key, b int unique);")
+	table := &cdcmodel.TableName{Schema: "test", Table: "t1"}
+	location := binlog.MustZeroLocation(mysql.MySQLFlavor)
+	ec := &eventContext{startLocation: location, endLocation: location, lastLocation: location}
+	newDML := func(pre, post []interface{}) *job {
+		return newDMLJob(sqlmodel.NewRowChange(table, nil, pre, post, ti, nil, nil), ec)
+	}
+	runCausality := func(logSize int) (chan *job, []causalityDecision) {
+		jobCh := make(chan *job, 20)
+		syncer := &Syncer{
+			cfg: &config.SubTaskConfig{
+				SyncerConfig: config.SyncerConfig{
+					QueueSize:            1024,
+					HashCausalityKey:     true,
+					MaxCausalityGroups:   2,
+					CausalityDecisionLog: logSize,
+				},
+				Name:     "task",
+				SourceID: "source",
+			},
+			tctx:                    tcontext.Background().WithLogger(log.L()),
+			sessCtx:                 utils.NewSessionCtx(map[string]string{"time_zone": "UTC"}),
+			causalityDecisionDumpCh: make(chan chan []causalityDecision),
+		}
+		syncer.metricsProxies = metrics.DefaultMetricsProxies.CacheForOneTask("task", "worker", "source")
+		causalityCh := causalityWrap(context.Background(), jobCh, syncer)
+
+		jobs := []*job{
+			newDML(nil, []interface{}{1, 1}),
+			newDML(nil, []interface{}{2, 2}),
+			newFlushJob(0, 1),
+			newDML([]interface{}{1, 1}, []interface{}{1, 3}),
+			newFlushJob(0, 2),
+			// conflicts with both (1, 3) and (2, 2).
+			newDML([]interface{}{1, 3}, []interface{}{1, 2}),
+			newGCJob(1),
+			newDML(nil, []interface{}{4, 4}),
+			newFlushJob(0, 3),
+			newFlushJob(0, 4),
+			newDML([]interface{}{4, 4}, []interface{}{4, 5}),
+			newGCJob(3),
+		}
+		for _, j := range jobs {
+			jobCh <- j
+		}
+		// gc jobs are not sent, and no conflict job is sent before (1, 2) since DML workers are drained by the flush
+		// job before it.
+		require.Eventually(t, func() bool {
+			return len(causalityCh) == len(jobs)-2 && len(jobCh) == 0
+		}, 3*time.Second, 10*time.Millisecond)
+
+		data, err := syncer.DumpCausalityDecisions(context.Background())
+		require.NoError(t, err)
+		var decisions []causalityDecision
+		require.NoError(t, json.Unmarshal(data, &decisions))
+		return jobCh, decisions
+	}
+
+	jobCh, decisions := runCausality(100)
+	defer close(jobCh)
+	require.Equal(t, uint64(0), decisions[0].Seq)
+	conflicts, queueKeys := 0, 0
+	for _, d := range decisions {
+		if d.Conflict {
+			conflicts++
+		}
+		if d.QueueKey != "" {
+			queueKeys++
+		}
+	}
+	require.Equal(t, 1, conflicts)
+	require.Equal(t, 6, queueKeys)
+	require.NoError(t, replayCausalityDecisions(decisions, true, 2))
+
+	// the replay fails if any decision is not re-derived.
+	require.Error(t, replayCausalityDecisions(decisions, false, 2))
+	for i := range decisions {
+		if decisions[i].Conflict {
+			decisions[i].Conflict = false
+			break
+		}
+	}
+	require.Error(t, replayCausalityDecisions(decisions, true, 2))
+
+	// the oldest decisions are dropped, the replay starts from the clear decision of the conflict.
+	jobCh2, decisions := runCausality(10)
+	defer close(jobCh2)
+	require.Len(t, decisions, 10)
+	require.NotEqual(t, uint64(0), decisions[0].Seq)
+	require.NoError(t, replayCausalityDecisions(decisions, true, 2))
+	for len(decisions) > 0 && decisions[0].Type != causalityDecisionClear {
+		decisions = decisions[1:]
+	}
+	require.Error(t, replayCausalityDecisions(decisions[1:], true, 2))
+	require.Error(t, replayCausalityDecisions(append(decisions[:1:1], decisions[2:]...), true, 2))
+}
@@ -0,0 +1,210 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-mysql-org/go-mysql/mysql"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/util/dbutil"
+	"github.com/pingcap/tiflow/dm/config"
+	"github.com/pingcap/tiflow/dm/pkg/binlog"
+	"github.com/pingcap/tiflow/dm/pkg/conn"
+	tcontext "github.com/pingcap/tiflow/dm/pkg/context"
+	"github.com/pingcap/tiflow/dm/pkg/cputil"
+	"github.com/pingcap/tiflow/dm/pkg/gtid"
+	"github.com/pingcap/tiflow/dm/pkg/log"
+	"github.com/pingcap/tiflow/dm/pkg/terror"
+	"go.uber.org/zap"
+)
+
+// maxPersistedCausalityRelationKeys is the max number of keys of a persisted causality relation, a larger relation
+// is not persisted since the row may exceed the entry size limit of downstream.
+const maxPersistedCausalityRelationKeys = 50000
+
+// causalityRelationSnapshot is the causality relation after the gc job of a flushed checkpoint. the keys of DMLs
+// before the checkpoint are removed by the gc job, so it only keeps the keys of DMLs after the checkpoint, which
+// are replayed from the checkpoint after the syncer restarts.
+type causalityRelationSnapshot struct {
+	// location is the flushed global checkpoint.
+	location binlog.Location
+	// flushJobSeq is the seq of the last flushed job, the seqs of a restarted syncer start over, so it's only
+	// recorded for debugging.
+	flushJobSeq int64
+	// relations maps the root of each relation to its other keys, see causalityRelation.snapshot.
+	relations map[string][]string
+}
+
+// sendRelationSnapshot sends the relation after the gc job of a flushed checkpoint to be persisted. a snapshot
+// which is not received yet is replaced since it's outdated, and the channel is only sent by causality, so it
+// never blocks.
+func (c *causality) sendRelationSnapshot(j *job) {
+	if c.relationSnapshotCh == nil {
+		return
+	}
+	if keys := c.relation.len(); keys > maxPersistedCausalityRelationKeys {
+		c.logger.Warn("causality relation has too many keys, skip persisting it",
+			zap.Int("keys", keys), zap.Int("max keys", maxPersistedCausalityRelationKeys))
+		return
+	}
+	snapshot := &causalityRelationSnapshot{
+		location:    j.currentLocation,
+		flushJobSeq: j.flushSeq,
+		relations:   c.relation.snapshot(),
+	}
+	select {
+	case <-c.relationSnapshotCh:
+	default:
+	}
+	c.relationSnapshotCh <- snapshot
+}
+
+// causalityRelationStore persists the causality relation snapshot of a source into the meta schema of downstream.
+type causalityRelationStore struct {
+	db        *conn.BaseDB
+	tableName string
+	id        string
+	cfg       *config.SubTaskConfig
+}
+
+func newCausalityRelationStore(cfg *config.SubTaskConfig, db *conn.BaseDB) *causalityRelationStore {
+	return &causalityRelationStore{
+		db:        db,
+		tableName: dbutil.TableName(cfg.MetaSchema, cputil.SyncerCausalityRelation(cfg.Name)),
+		id:        cfg.SourceID,
+		cfg:       cfg,
+	}
+}
+
+// init creates the schema and table of the store if they don't exist.
+func (st *causalityRelationStore) init(tctx *tcontext.Context) error {
+	query := fmt.Sprintf("CREATE SCHEMA IF NOT EXISTS %s", dbutil.ColumnName(st.cfg.MetaSchema))
+	if _, err := st.db.ExecContext(tctx, query); err != nil {
+		return terror.WithScope(err, terror.ScopeDownstream)
+	}
+	query = `CREATE TABLE IF NOT EXISTS ` + st.tableName + ` (
+		id VARCHAR(32) NOT NULL,
+		binlog_name VARCHAR(128),
+		binlog_pos INT UNSIGNED,
+		binlog_gtid TEXT,
+		flush_job_seq BIGINT NOT NULL,
+		relation JSON NOT NULL,
+		create_time timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		update_time timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+		PRIMARY KEY (id)
+	)`
+	if _, err := st.db.ExecContext(tctx, query); err != nil {
+		return terror.WithScope(err, terror.ScopeDownstream)
+	}
+	tctx.L().Info("create causality relation table", zap.String("table", st.tableName))
+	return nil
+}
+
+// save persists the snapshot, it replaces the snapshot persisted before.
+func (st *causalityRelationStore) save(tctx *tcontext.Context, snapshot *causalityRelationSnapshot) error {
+	relation, err := json.Marshal(snapshot.relations)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	query := `INSERT INTO ` + st.tableName + `
+		(id, binlog_name, binlog_pos, binlog_gtid, flush_job_seq, relation) VALUES (?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			binlog_name = VALUES(binlog_name),
+			binlog_pos = VALUES(binlog_pos),
+			binlog_gtid = VALUES(binlog_gtid),
+			flush_job_seq = VALUES(flush_job_seq),
+			relation = VALUES(relation)`
+	_, err = st.db.ExecContext(tctx, query, st.id, snapshot.location.Position.Name, snapshot.location.Position.Pos,
+		snapshot.location.GTIDSetStr(), snapshot.flushJobSeq, string(relation))
+	return terror.WithScope(err, terror.ScopeDownstream)
+}
+
+// load returns the persisted snapshot, it's nil if there's no snapshot.
+func (st *causalityRelationStore) load(tctx *tcontext.Context) (*causalityRelationSnapshot, error) {
+	query := `SELECT binlog_name, binlog_pos, binlog_gtid, flush_job_seq, relation FROM ` + st.tableName + ` WHERE id = ?`
+	rows, err := st.db.QueryContext(tctx, query, st.id)
+	if err != nil {
+		return nil, terror.WithScope(err, terror.ScopeDownstream)
+	}
+	defer rows.Close()
+
+	// at most one row
+	if !rows.Next() {
+		return nil, terror.DBErrorAdapt(rows.Err(), terror.ScopeDownstream, terror.ErrDBDriverError)
+	}
+	var (
+		binlogName, binlogGTIDStr string
+		binlogPos                 uint32
+		relation                  []byte
+		snapshot                  = &causalityRelationSnapshot{}
+	)
+	if err = rows.Scan(&binlogName, &binlogPos, &binlogGTIDStr, &snapshot.flushJobSeq, &relation); err != nil {
+		return nil, terror.DBErrorAdapt(err, terror.ScopeDownstream, terror.ErrDBDriverError)
+	}
+	gset, err := gtid.ParserGTID(st.cfg.Flavor, binlogGTIDStr)
+	if err != nil {
+		return nil, err
+	}
+	snapshot.location = binlog.NewLocation(mysql.Position{Name: binlogName, Pos: binlogPos}, gset)
+	if err = json.Unmarshal(relation, &snapshot.relations); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return snapshot, terror.DBErrorAdapt(rows.Err(), terror.ScopeDownstream, terror.ErrDBDriverError)
+}
+
+// loadCausalityRelation loads the persisted causality relation to be restored by causalityWrap. the relation is
+// discarded if it's not persisted at the global checkpoint, because the binlog is not replayed from the location
+// where its DMLs start, or the persisting is failed after the checkpoint is flushed. it's only an optimization, so
+// the relation is also discarded if it can't be loaded.
+func (s *Syncer) loadCausalityRelation(tctx *tcontext.Context) {
+	s.causalityRestoredRelation = nil
+	if s.causalityRelationStore == nil {
+		return
+	}
+	snapshot, err := s.causalityRelationStore.load(tctx)
+	if err != nil {
+		s.tctx.L().Warn("fail to load the persisted causality relation, causality starts with an empty relation", zap.Error(err))
+		return
+	}
+	if snapshot == nil {
+		return
+	}
+	globalPoint := s.checkpoint.GlobalPoint()
+	if binlog.CompareLocation(snapshot.location, globalPoint, s.cfg.EnableGTID) != 0 {
+		s.tctx.L().Info("discard the persisted causality relation which is not at the global checkpoint",
+			zap.Stringer("location", snapshot.location), zap.Stringer("checkpoint", globalPoint))
+		return
+	}
+	s.tctx.L().Info("load the persisted causality relation", zap.Stringer("location", snapshot.location),
+		zap.Int64("flush job seq", snapshot.flushJobSeq), zap.Int("relations", len(snapshot.relations)))
+	s.causalityRestoredRelation = snapshot.relations
+}
+
+// persistCausalityRelation persists the snapshots received from snapshotCh until it's closed by causality. a failed
+// snapshot is skipped, the next one will be persisted after the next checkpoint flush.
+func (s *Syncer) persistCausalityRelation(snapshotCh chan *causalityRelationSnapshot) {
+	defer s.runWg.Done()
+	for snapshot := range snapshotCh {
+		// use a new context apart from syncer, the snapshot of the last checkpoint flush is persisted after it's stopped.
+		tctx, cancel := s.tctx.WithContext(context.Background()).WithTimeout(maxDMLConnectionDuration)
+		err := s.causalityRelationStore.save(tctx, snapshot)
+		cancel()
+		if err != nil {
+			s.tctx.L().Warn("fail to persist causality relation", zap.Stringer("location", snapshot.location), log.ShortError(err))
+		}
+	}
+}
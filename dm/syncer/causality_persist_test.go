@@ -0,0 +1,198 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"context"
+	"database/sql/driver"
+	"math"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-mysql-org/go-mysql/mysql"
+	cdcmodel "github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/dm/config"
+	"github.com/pingcap/tiflow/dm/pkg/binlog"
+	"github.com/pingcap/tiflow/dm/pkg/conn"
+	tcontext "github.com/pingcap/tiflow/dm/pkg/context"
+	"github.com/pingcap/tiflow/dm/pkg/log"
+	"github.com/pingcap/tiflow/dm/pkg/utils"
+	"github.com/pingcap/tiflow/dm/syncer/metrics"
+	"github.com/pingcap/tiflow/pkg/sqlmodel"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCausalityRelationSnapshot(t *testing.T) {
+	t.Parallel()
+
+	rm := newCausalityRelation()
+	rm.union("a", "a")
+	rm.union("b", "a")
+	rm.rotate(1)
+	rm.union("c", "c")
+	rm.union("a", "d")
+	snapshot := rm.snapshot()
+	require.Len(t, snapshot, 2)
+	require.ElementsMatch(t, []string{"a", "b"}, snapshot["d"])
+	require.Equal(t, []string{}, snapshot["c"])
+
+	restored := newCausalityRelation()
+	restored.restore(snapshot)
+	require.Len(t, restored.groups, 1)
+	for _, key := range []string{"a", "b", "d"} {
+		root, ok := restored.get(key)
+		require.True(t, ok)
+		require.Equal(t, "d", root)
+	}
+	root, ok := restored.get("c")
+	require.True(t, ok)
+	require.Equal(t, "c", root)
+	require.Empty(t, restored.checkInvariants())
+
+	// the restored keys are removed by the gc job of the first flush job.
+	restored.rotate(1)
+	restored.union("e", "e")
+	restored.gc(1)
+	require.Equal(t, 1, restored.len())
+	_, ok = restored.get("a")
+	require.False(t, ok)
+}
+
+func TestCausalitySendRelationSnapshot(t *testing.T) {
+	t.Parallel()
+
+	ti := mockTableInfo(t, "create table tb(a int primary key, b int unique);")
+	table := &cdcmodel.TableName{Schema: "test", Table: "t1"}
+	location := binlog.MustZeroLocation(mysql.MySQLFlavor)
+	ec := &eventContext{startLocation: location, endLocation: location, lastLocation: location}
+	jobCh := make(chan *job, 10)
+	syncer := &Syncer{
+		cfg: &config.SubTaskConfig{
+			SyncerConfig: config.SyncerConfig{
+				QueueSize: 1024,
+			},
+			Name:     "task",
+			SourceID: "source",
+		},
+		tctx:    tcontext.Background().WithLogger(log.L()),
+		sessCtx: utils.NewSessionCtx(map[string]string{"time_zone": "UTC"}),
+	}
+	syncer.metricsProxies = metrics.DefaultMetricsProxies.CacheForOneTask("task", "worker", "source")
+	snapshotCh := make(chan *causalityRelationSnapshot, 1)
+	syncer.causalityRelationSnapshotCh = snapshotCh
+	syncer.causalityRestoredRelation = map[string][]string{"restored": {"restored-key"}}
+	causalityCh := causalityWrap(context.Background(), jobCh, syncer)
+	require.Nil(t, syncer.causalityRestoredRelation)
+	require.Equal(t, int64(2), syncer.causalityRelation.Load().approxLen())
+
+	jobCh <- newDMLJob(sqlmodel.NewRowChange(table, nil, nil, []interface{}{1, 2}, ti, nil, nil), ec)
+	jobCh <- newFlushJob(1, 1)
+	jobCh <- newDMLJob(sqlmodel.NewRowChange(table, nil, nil, []interface{}{3, 4}, ti, nil, nil), ec)
+	flushed := location
+	flushed.Position.Pos = 100
+	gcJob := newGCJob(1)
+	gcJob.currentLocation = flushed
+	jobCh <- gcJob
+	for i := 0; i < 3; i++ {
+		<-causalityCh
+	}
+
+	// the keys before the flush job, including the restored ones, are removed by the gc job.
+	snapshot := <-snapshotCh
+	require.Equal(t, flushed, snapshot.location)
+	require.Equal(t, int64(1), snapshot.flushJobSeq)
+	require.Len(t, snapshot.relations, 1)
+	for root, keys := range snapshot.relations {
+		require.Len(t, append(keys, root), 2)
+	}
+
+	// the snapshot which is not received yet is replaced.
+	jobCh <- newGCJob(math.MaxInt64)
+	jobCh <- newGCJob(math.MaxInt64)
+	close(jobCh)
+	for range causalityCh {
+	}
+	snapshot, ok := <-snapshotCh
+	require.True(t, ok)
+	require.Empty(t, snapshot.relations)
+	_, ok = <-snapshotCh
+	require.False(t, ok)
+}
+
+func TestCausalityRelationStore(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	cfg := &config.SubTaskConfig{Name: "task", SourceID: "source", MetaSchema: "dm_meta", Flavor: mysql.MySQLFlavor}
+	store := newCausalityRelationStore(cfg, conn.NewBaseDBForTest(db))
+	tctx := tcontext.Background()
+
+	mock.ExpectExec(regexp.QuoteMeta("CREATE SCHEMA IF NOT EXISTS `dm_meta`")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE IF NOT EXISTS `dm_meta`.`task_syncer_causality_relation`")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	require.NoError(t, store.init(tctx))
+
+	location := binlog.MustZeroLocation(mysql.MySQLFlavor)
+	location.Position = mysql.Position{Name: "mysql-bin.000001", Pos: 100}
+	snapshot := &causalityRelationSnapshot{
+		location:    location,
+		flushJobSeq: 3,
+		relations:   map[string][]string{"a": {"b"}},
+	}
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `dm_meta`.`task_syncer_causality_relation`")).
+		WithArgs("source", "mysql-bin.000001", uint32(100), "", int64(3), `{"a":["b"]}`).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	require.NoError(t, store.save(tctx, snapshot))
+
+	columns := []string{"binlog_name", "binlog_pos", "binlog_gtid", "flush_job_seq", "relation"}
+	mock.ExpectQuery("SELECT .* FROM `dm_meta`.`task_syncer_causality_relation` WHERE id = ?").WithArgs("source").
+		WillReturnRows(sqlmock.NewRows(columns).AddRow("mysql-bin.000001", 100, "", 3, `{"a":["b"]}`))
+	loaded, err := store.load(tctx)
+	require.NoError(t, err)
+	require.Equal(t, 0, binlog.CompareLocation(location, loaded.location, false))
+	require.Equal(t, snapshot.flushJobSeq, loaded.flushJobSeq)
+	require.Equal(t, snapshot.relations, loaded.relations)
+
+	// the relation is only restored at the global checkpoint.
+	syncer := NewSyncer(cfg, nil, nil)
+	syncer.causalityRelationStore = store
+	syncer.checkpoint.SaveGlobalPointForcibly(location)
+	mock.ExpectQuery("SELECT .* FROM `dm_meta`.`task_syncer_causality_relation`").
+		WillReturnRows(sqlmock.NewRows(columns).AddRow("mysql-bin.000001", 100, "", 3, `{"a":["b"]}`))
+	syncer.loadCausalityRelation(tctx)
+	require.Equal(t, snapshot.relations, syncer.causalityRestoredRelation)
+
+	location.Position.Pos = 200
+	syncer.checkpoint.SaveGlobalPointForcibly(location)
+	mock.ExpectQuery("SELECT .* FROM `dm_meta`.`task_syncer_causality_relation`").
+		WillReturnRows(sqlmock.NewRows(columns).AddRow("mysql-bin.000001", 100, "", 3, `{"a":["b"]}`))
+	syncer.loadCausalityRelation(tctx)
+	require.Nil(t, syncer.causalityRestoredRelation)
+
+	// no snapshot is persisted.
+	mock.ExpectQuery("SELECT .* FROM `dm_meta`.`task_syncer_causality_relation`").
+		WillReturnRows(sqlmock.NewRows(columns))
+	loaded, err = store.load(tctx)
+	require.NoError(t, err)
+	require.Nil(t, loaded)
+
+	// a snapshot which can't be loaded is discarded.
+	syncer.causalityRestoredRelation = snapshot.relations
+	mock.ExpectQuery("SELECT .* FROM `dm_meta`.`task_syncer_causality_relation`").WillReturnError(driver.ErrBadConn)
+	syncer.loadCausalityRelation(tctx)
+	require.Nil(t, syncer.causalityRestoredRelation)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,92 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"github.com/pingcap/errors"
+	cdcmodel "github.com/pingcap/tiflow/cdc/model"
+	"go.uber.org/zap"
+)
+
+// CausalityConflict is a conflict found by causality, the causality keys of a DML belong to different relations,
+// so the DML may depend on DMLs which are dispatched to different DML workers and may still be executing.
+type CausalityConflict struct {
+	// SourceTable and TargetTable are the upstream and downstream tables of the DML.
+	SourceTable *cdcmodel.TableName
+	TargetTable *cdcmodel.TableName
+	// Keys are all causality keys of the DML.
+	Keys []string
+	// ExistedKey and ConflictedKey are the first two keys in Keys which belong to different relations.
+	ExistedKey    string
+	ConflictedKey string
+}
+
+// CausalityConflictResolver decides whether all DML workers are flushed for a conflict before the DML is
+// dispatched, it returns false to suppress the flush. it's only called by the causality goroutine for a conflict
+// of a single DML, so it must be fast. the conflict must not be modified or retained.
+//
+// WARNING: suppressing a flush breaks the ordering guarantee of causality. the DML is dispatched as if there were
+// no conflict, so it may be executed before or concurrently with the DMLs it depends on, and rows of the same
+// PK/UK may be written in a different order than upstream. it's only safe if the downstream tolerates the
+// reordering for the DML, e.g. it deduplicates idempotent writes of the table, otherwise the downstream data
+// becomes inconsistent, or DMLs fail with duplicate key errors. conflicts of append-only tables are never detected.
+type CausalityConflictResolver func(conflict *CausalityConflict) bool
+
+// FlushOnCausalityConflict is the default CausalityConflictResolver, it never suppresses the flush.
+func FlushOnCausalityConflict(*CausalityConflict) bool {
+	return true
+}
+
+// SetCausalityConflictResolver sets the resolver of causality conflicts, nil means FlushOnCausalityConflict. it
+// must be called before the syncer runs, see CausalityConflictResolver for the correctness caveats.
+// a resolver is rejected if atomic-txn-causality or experimental-causality-shards is enabled, the conflicts of
+// transactions and of DMLs across shards always flush, so some conflicts would not call it.
+func (s *Syncer) SetCausalityConflictResolver(resolver CausalityConflictResolver) error {
+	if resolver != nil {
+		if s.cfg.AtomicTxnCausality && !s.cfg.Compact {
+			return errors.New("causality conflict resolver is not supported when atomic-txn-causality is enabled")
+		}
+		if s.cfg.ExperimentalCausalityShards > 1 {
+			return errors.New("causality conflict resolver is not supported when experimental-causality-shards is enabled")
+		}
+	}
+	s.causalityConflictResolver = resolver
+	return nil
+}
+
+// suppressConflictFlush returns whether the flush for the conflict of the DML job is suppressed by the resolver.
+// the suppressed conflict is still counted.
+func (c *causality) suppressConflictFlush(j *job, keys []string, conflict causalityConflict) bool {
+	if c.conflictResolver == nil {
+		return false
+	}
+	flush := c.conflictResolver(&CausalityConflict{
+		SourceTable:   j.dml.GetSourceTable(),
+		TargetTable:   j.dml.GetTargetTable(),
+		Keys:          keys,
+		ExistedKey:    conflict.existedKey,
+		ConflictedKey: conflict.conflictedKey,
+	})
+	if flush {
+		return false
+	}
+	c.logConflict(keys, conflict)
+	c.countConflict(j)
+	c.metricProxies.Metrics.CausalitySuppressedFlushCounter.Inc()
+	if c.sampleDebugLog() {
+		c.logger.Debug("causality conflict flush is suppressed by the conflict resolver",
+			zap.Stringer("table", j.dml.GetTargetTable()), zap.Strings("keys", keys))
+	}
+	return true
+}
@@ -0,0 +1,113 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-mysql-org/go-mysql/mysql"
+	cdcmodel "github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/dm/config"
+	"github.com/pingcap/tiflow/dm/pkg/binlog"
+	tcontext "github.com/pingcap/tiflow/dm/pkg/context"
+	"github.com/pingcap/tiflow/dm/pkg/log"
+	"github.com/pingcap/tiflow/dm/pkg/utils"
+	"github.com/pingcap/tiflow/dm/syncer/metrics"
+	"github.com/pingcap/tiflow/pkg/sqlmodel"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCausalityConflictResolver(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, FlushOnCausalityConflict(&CausalityConflict{}))
+
+	ti := mockTableInfo(t, "create table tb(a int primary key, b int unique);")
+	location := binlog.MustZeroLocation(mysql.MySQLFlavor)
+	ec := &eventContext{startLocation: location, endLocation: location, lastLocation: location}
+	source := &cdcmodel.TableName{Schema: "test", Table: "tb"}
+	idempotent := &cdcmodel.TableName{Schema: "test", Table: "idempotent"}
+	strict := &cdcmodel.TableName{Schema: "test", Table: "strict"}
+	jobCh := make(chan *job, 10)
+	syncer := &Syncer{
+		cfg: &config.SubTaskConfig{
+			SyncerConfig: config.SyncerConfig{
+				QueueSize: 1024,
+			},
+			Name:     "task-resolver",
+			SourceID: "source",
+		},
+		tctx:    tcontext.Background().WithLogger(log.L()),
+		sessCtx: utils.NewSessionCtx(map[string]string{"time_zone": "UTC"}),
+	}
+	syncer.metricsProxies = metrics.DefaultMetricsProxies.CacheForOneTask("task-resolver", "worker", "source")
+	var conflicts []CausalityConflict
+	require.NoError(t, syncer.SetCausalityConflictResolver(func(conflict *CausalityConflict) bool {
+		conflicts = append(conflicts, *conflict)
+		return conflict.TargetTable.Table != idempotent.Table
+	}))
+	causalityCh := causalityWrap(context.Background(), jobCh, syncer)
+	defer close(jobCh)
+
+	// the UPDATE writes the row of the first INSERT, the conflict flush is only suppressed for the idempotent table.
+	for _, target := range []*cdcmodel.TableName{idempotent, strict} {
+		jobCh <- newDMLJob(sqlmodel.NewRowChange(source, target, nil, []interface{}{1, 2}, ti, nil, nil), ec)
+		jobCh <- newDMLJob(sqlmodel.NewRowChange(source, target, nil, []interface{}{3, 4}, ti, nil, nil), ec)
+		jobCh <- newDMLJob(sqlmodel.NewRowChange(source, target, []interface{}{3, 4}, []interface{}{1, 5}, ti, nil, nil), ec)
+	}
+	results := []opType{dml, dml, dml, dml, dml, conflict, dml}
+	require.Eventually(t, func() bool {
+		return len(causalityCh) == len(results)
+	}, 3*time.Second, 100*time.Millisecond)
+	for _, op := range results {
+		require.Equal(t, op, (<-causalityCh).tp)
+	}
+
+	require.Len(t, conflicts, 2)
+	require.Equal(t, source, conflicts[0].SourceTable)
+	require.Equal(t, idempotent, conflicts[0].TargetTable)
+	require.Len(t, conflicts[0].Keys, 4)
+	require.NotEqual(t, conflicts[0].ExistedKey, conflicts[0].ConflictedKey)
+	require.Equal(t, strict, conflicts[1].TargetTable)
+	m := &dto.Metric{}
+	require.NoError(t, syncer.metricsProxies.Metrics.CausalitySuppressedFlushCounter.Write(m))
+	require.Equal(t, float64(1), m.GetCounter().GetValue())
+}
+
+func TestCausalityConflictResolverNotSupported(t *testing.T) {
+	t.Parallel()
+
+	syncer := &Syncer{cfg: &config.SubTaskConfig{}}
+	resolver := func(*CausalityConflict) bool { return false }
+
+	// conflicts of transactions don't call the resolver.
+	syncer.cfg.AtomicTxnCausality = true
+	require.ErrorContains(t, syncer.SetCausalityConflictResolver(resolver), "atomic-txn-causality")
+	require.Nil(t, syncer.causalityConflictResolver)
+	// compactor disables atomic-txn-causality.
+	syncer.cfg.Compact = true
+	require.NoError(t, syncer.SetCausalityConflictResolver(resolver))
+	require.NotNil(t, syncer.causalityConflictResolver)
+
+	// conflicts across shards don't call the resolver.
+	syncer.cfg.ExperimentalCausalityShards = 2
+	require.ErrorContains(t, syncer.SetCausalityConflictResolver(resolver), "experimental-causality-shards")
+	require.NotNil(t, syncer.causalityConflictResolver)
+	// resetting to the default is always fine.
+	require.NoError(t, syncer.SetCausalityConflictResolver(nil))
+	require.Nil(t, syncer.causalityConflictResolver)
+}
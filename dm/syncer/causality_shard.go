@@ -0,0 +1,365 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	tfilter "github.com/pingcap/tidb/pkg/util/table-filter"
+	"github.com/pingcap/tiflow/dm/pkg/log"
+	"github.com/pingcap/tiflow/dm/syncer/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// shardedCausality runs several causality shards in parallel, so the conflicts of independent keys are detected
+// concurrently when a single causality is the bottleneck. it consists of three parts:
+//   - the router receives all jobs in order. a DML job is sent to one shard by its causality keys, flush, asyncFlush
+//     and gc jobs are broadcast to all shards.
+//   - the shards are causality instances, each of them keeps the relation of the DMLs it receives and sends conflict
+//     jobs for the conflicts among them, just like a single causality.
+//   - the forwarders send the jobs sent by shards to outCh, one forwarder per shard. a broadcast job is sent to outCh
+//     once after every shard sends it, see forward.
+//
+// the router keeps owners, which maps the keys of the DMLs which may still be executing to the shard the DMLs are
+// sent to. a DML is sent to the shard which owns its keys, or to the shard of its first key by hash if none of its
+// keys is owned, and then the shard owns all its keys. if the keys of a DML are owned by different shards, the DML
+// depends on DMLs of several shards which no shard can detect, so the router broadcasts a conflict job to drain all
+// DML workers and clears owners before sending the DML. owners is rotated and gc-ed by the same flush and gc jobs as
+// the relation of shards.
+//
+// it's correct for the following reasons:
+//   - the DMLs sharing a key are sent to the same shard while the earlier ones may be executing, since a key is only
+//     removed from owners when its DMLs are executed, by gc after a flush or by the conflict job of the router. so
+//     the shard detects their dependency in its relation as a single causality does.
+//   - the forwarder of a shard keeps the order of its jobs, so a conflict job of the shard still waits for all its
+//     DMLs sent before. the DMLs of different shards share no keys of executing DMLs, they can be in any order.
+//   - a broadcast job is sent after the jobs before it in every shard, and before the jobs after it. so a flush job
+//     still waits for all DMLs before it, and the conflict job of the router drains all DMLs before the DML whose keys
+//     are owned by different shards.
+//
+// the router computes causality keys for shards, so it's still sequential, but the shards maintain their relations
+// and detect conflicts in parallel. atomic-txn-causality and prioritize-causality-flush are ignored, the relation and
+// decisions of shards can't be dumped, and the causality stats aren't published. the relation metrics report owners.
+type shardedCausality struct {
+	inCh   chan *job
+	outCh  chan *job
+	shards []*causality
+	// owners maps a key to the label of the shard which owns it, see ownerLabels.
+	owners *causalityRelation
+	// ownerLabels are the values of owners for each shard.
+	ownerLabels []string
+	// barrier gathers the forwarders of all shards at each broadcast job.
+	barrier *causalityBarrier
+	// clearCh receives requests of clearing relation, the router handles them by its conflict job.
+	clearCh chan chan *sync.WaitGroup
+
+	workerCount      int
+	maxKeys          int
+	maxGroups        int
+	hashedKeys       bool
+	appendOnlyTables tfilter.Filter
+	// blockWarnInterval is causality.blockWarnInterval of the forwarders.
+	blockWarnInterval time.Duration
+
+	logger        log.Logger
+	metricProxies *metrics.Proxies
+}
+
+// shardedCausalityWrap creates and runs sharded causality, it's causalityWrap with experimental-causality-shards.
+// the input and output channels of every shard have the same size as the returned channel.
+func shardedCausalityWrap(ctx context.Context, inCh chan *job, syncer *Syncer, outChSize int) chan *job {
+	shardCount := syncer.cfg.ExperimentalCausalityShards
+	s := &shardedCausality{
+		inCh:          inCh,
+		outCh:         make(chan *job, outChSize),
+		owners:        newCausalityRelationWithCapacity(outChSize, syncer.cfg.CausalityRelationCapacity),
+		ownerLabels:   make([]string, shardCount),
+		barrier:       newCausalityBarrier(shardCount),
+		clearCh:       syncer.causalityClearCh,
+		workerCount:   syncer.cfg.WorkerCount,
+		maxKeys:       syncer.cfg.MaxCausalityKeys,
+		maxGroups:     syncer.cfg.MaxCausalityGroups,
+		hashedKeys:    syncer.cfg.HashedCausalityKeys,
+		logger:        syncer.tctx.Logger.WithFields(zap.String("component", "causality router")),
+		metricProxies: syncer.metricsProxies,
+	}
+	s.blockWarnInterval = time.Duration(syncer.cfg.CausalityBlockWarnInterval) * time.Millisecond
+	shardMetrics := shardMetricProxies(syncer.metricsProxies)
+	for i := 0; i < shardCount; i++ {
+		shard := newSyncerCausality(syncer, make(chan *job, outChSize), make(chan *job, outChSize))
+		shard.metricProxies = shardMetrics
+		shard.logger = shard.logger.WithFields(zap.Int("shard", i))
+		s.shards = append(s.shards, shard)
+		s.ownerLabels[i] = strconv.Itoa(i)
+	}
+	s.appendOnlyTables = s.shards[0].appendOnlyTables
+	syncer.causalityRelation.Store(s.owners)
+	s.logger.Warn("EXPERIMENTAL sharded causality is enabled, atomic-txn-causality, prioritize-causality-flush "+
+		"and causality-decision-log are ignored", zap.Int("shards", shardCount))
+
+	var wg sync.WaitGroup
+	for _, shard := range s.shards {
+		wg.Add(1)
+		go func() {
+			shard.run(ctx)
+			shard.close()
+		}()
+		go func() {
+			defer wg.Done()
+			s.forward(shard.outCh)
+		}()
+	}
+	go s.run()
+	go func() {
+		wg.Wait()
+		s.close()
+	}()
+
+	return s.outCh
+}
+
+// shardMetricProxies returns the metrics of shards. the jobs into and out of causality are counted by the router and
+// the forwarders, and the relation metrics report owners of the router, so these metrics of shards are discarded.
+func shardMetricProxies(proxies *metrics.Proxies) *metrics.Proxies {
+	ret := *proxies
+	m := *proxies.Metrics
+	m.CausalityInputDequeueCounter = prometheus.NewCounter(prometheus.CounterOpts{Name: "discarded"})
+	m.CausalityOutputEnqueueCounter = prometheus.NewCounter(prometheus.CounterOpts{Name: "discarded"})
+	m.CausalityOutBlockedHistogram = prometheus.NewHistogram(prometheus.HistogramOpts{Name: "discarded"})
+	m.CausalityRelationSizeGauge = prometheus.NewGauge(prometheus.GaugeOpts{Name: "discarded"})
+	m.CausalityRelationGroupsGauge = prometheus.NewGauge(prometheus.GaugeOpts{Name: "discarded"})
+	m.CausalityRelationNewestKeysGauge = prometheus.NewGauge(prometheus.GaugeOpts{Name: "discarded"})
+	m.CausalityRelationOldestSeqGauge = prometheus.NewGauge(prometheus.GaugeOpts{Name: "discarded"})
+	m.CausalityRelationBytesGauge = prometheus.NewGauge(prometheus.GaugeOpts{Name: "discarded"})
+	ret.Metrics = &m
+	return &ret
+}
+
+// run receives jobs and sends them to shards until inCh is closed, then closes the input channels of shards, so they
+// handle the remaining jobs and exit. it doesn't exit when ctx is done, the shards still handle all jobs like causality.
+func (s *shardedCausality) run() {
+	defer func() {
+		for _, shard := range s.shards {
+			close(shard.inCh)
+		}
+	}()
+
+	for {
+		select {
+		case respCh := <-s.clearCh:
+			s.logger.Warn("force to clear causality relation on demand, will generate a conflict job to flush all sqls",
+				zap.Int64("owned keys", s.owners.approxLen()))
+			respCh <- s.flushAllShards().flushWg
+		case j, ok := <-s.inCh:
+			if !ok {
+				return
+			}
+			s.metricProxies.Metrics.CausalityInputDequeueCounter.Inc()
+			s.handleJob(j)
+		}
+	}
+}
+
+// handleJob sends the job to the shards.
+func (s *shardedCausality) handleJob(j *job) {
+	switch j.tp {
+	case flush, asyncFlush:
+		s.owners.rotate(j.flushSeq)
+		s.owners.mergeOldestGroups(s.maxGroups)
+		j.broadcast = true
+		s.broadcast(j)
+		return
+	case gc:
+		// shards never send gc jobs, so they are not gathered by forwarders.
+		s.owners.gc(j.flushSeq)
+		reportRelationMetrics(s.metricProxies.Metrics, s.owners)
+		s.broadcast(j)
+		return
+	case xid:
+		// atomic-txn-causality is ignored, so there's no transaction to end.
+		return
+	}
+
+	if dropNilDMLJob(s.logger, s.metricProxies.Metrics, j) {
+		return
+	}
+	keys := rowChangeCausalityKeys(j.dml, j.safeMode, s.hashedKeys)
+	j.causalityKeys = keys
+	// append-only tables never conflict, their keys are only used to dispatch.
+	if s.isAppendOnly(j) {
+		s.sendToShard(s.shardByHash(keys), j)
+		return
+	}
+
+	shard, flushAll := s.ownerOf(keys)
+	if flushAll {
+		s.logger.Debug("causality keys are owned by different shards, will generate a conflict job to flush all sqls",
+			zap.Strings("keys", keys))
+		s.metricProxies.Metrics.CausalityCrossShardFlushCounter.Inc()
+	} else if s.maxKeys > 0 && s.owners.approxLen() >= int64(s.maxKeys*len(s.shards)) {
+		// owners keeps the keys of all shards, each of them keeps at most maxKeys.
+		s.logger.Debug("causality router exceeds max keys of all shards, will generate a conflict job to flush all sqls",
+			zap.Int("max keys", s.maxKeys))
+		s.metricProxies.Metrics.CausalityForcedFlushCounter.Inc()
+		flushAll = true
+	}
+	if flushAll {
+		s.flushAllShards()
+		// no key is owned after owners is cleared.
+		shard = s.shardByHash(keys)
+	}
+	for _, key := range keys {
+		s.own(key, shard)
+	}
+	reportRelationMetrics(s.metricProxies.Metrics, s.owners)
+	s.sendToShard(shard, j)
+}
+
+// isAppendOnly returns whether the job belongs to an append-only table.
+func (s *shardedCausality) isAppendOnly(j *job) bool {
+	if s.appendOnlyTables == nil {
+		return false
+	}
+	table := j.dml.GetSourceTable()
+	return s.appendOnlyTables.MatchTable(table.Schema, table.Table)
+}
+
+// ownerOf returns the shard which owns any of keys, or the shard of the first key by hash if none is owned. it also
+// returns whether keys are owned by different shards.
+func (s *shardedCausality) ownerOf(keys []string) (int, bool) {
+	owner := -1
+	for _, key := range keys {
+		label, _, ok := s.owners.parent(key)
+		if !ok {
+			continue
+		}
+		shard, _ := strconv.Atoi(label)
+		if owner < 0 {
+			owner = shard
+		} else if shard != owner {
+			return owner, true
+		}
+	}
+	if owner < 0 {
+		owner = s.shardByHash(keys)
+	}
+	return owner, false
+}
+
+// shardByHash returns the shard of the first key by hash, so a key is always sent to the same shard when it's not
+// owned, e.g. the primary key of the rows of a table.
+func (s *shardedCausality) shardByHash(keys []string) int {
+	if len(keys) == 0 {
+		return 0
+	}
+	return int(mixHash(keys[0]) % uint64(len(s.shards)))
+}
+
+// own records that key is owned by the shard in the newest group of owners, so it's kept as long as the key in the
+// relation of the shard.
+func (s *shardedCausality) own(key string, shard int) {
+	label, idx, ok := s.owners.parent(key)
+	if ok && idx == len(s.owners.groups)-1 && label == s.ownerLabels[shard] {
+		return
+	}
+	s.owners.set(key, s.ownerLabels[shard])
+}
+
+// flushAllShards broadcasts a conflict job to all shards, every shard clears its relation and sends it, so the conflict
+// job drains all DML workers after the DMLs sent to shards before. owners is cleared too since the later DMLs are
+// dispatched after all DMLs before are executed.
+func (s *shardedCausality) flushAllShards() *job {
+	conflictJob := newConflictJob(s.workerCount)
+	conflictJob.broadcast = true
+	s.broadcast(conflictJob)
+	s.owners.clear()
+	reportRelationMetrics(s.metricProxies.Metrics, s.owners)
+	return conflictJob
+}
+
+// broadcast sends the job to all shards in order.
+func (s *shardedCausality) broadcast(j *job) {
+	for i := range s.shards {
+		s.sendToShard(i, j)
+	}
+}
+
+// sendToShard sends the job to the input channel of the shard.
+func (s *shardedCausality) sendToShard(shard int, j *job) {
+	s.shards[shard].inCh <- j
+}
+
+// forward sends the jobs sent by a shard to outCh in order until the shard exits. a broadcast job is sent by the
+// forwarder which reaches it last, and the others wait until it's sent. so it's sent after the jobs before it in all
+// shards and before the jobs after it.
+func (s *shardedCausality) forward(shardCh chan *job) {
+	for j := range shardCh {
+		if !j.broadcast {
+			s.sendJob(j)
+			continue
+		}
+		last, done := s.barrier.arrive()
+		if !last {
+			<-done
+			continue
+		}
+		s.sendJob(j)
+		close(done)
+	}
+}
+
+// sendJob sends a job to outCh.
+func (s *shardedCausality) sendJob(j *job) {
+	sendCausalityOutput(s.outCh, j, s.blockWarnInterval, s.logger, s.metricProxies.Metrics)
+	s.metricProxies.Metrics.CausalityOutputEnqueueCounter.Inc()
+}
+
+// close closes outCh after all forwarders exit.
+func (s *shardedCausality) close() {
+	reportRelationMetrics(s.metricProxies.Metrics, s.owners)
+	close(s.outCh)
+}
+
+// causalityBarrier gathers the forwarders of all shards at each broadcast job. all shards send broadcast jobs in the
+// same order as the router broadcasts them, so the n-th arrival of every forwarder is at the same job.
+type causalityBarrier struct {
+	mu      sync.Mutex
+	shards  int
+	arrived int
+	done    chan struct{}
+}
+
+func newCausalityBarrier(shards int) *causalityBarrier {
+	return &causalityBarrier{shards: shards, done: make(chan struct{})}
+}
+
+// arrive returns true if the caller is the last forwarder which reaches the current broadcast job, then it should
+// send the job and close the returned channel to release the others. otherwise it should wait until the channel is
+// closed.
+func (b *causalityBarrier) arrive() (bool, chan struct{}) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	done := b.done
+	b.arrived++
+	if b.arrived < b.shards {
+		return false, done
+	}
+	b.arrived = 0
+	b.done = make(chan struct{})
+	return true, done
+}
@@ -0,0 +1,169 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-mysql-org/go-mysql/mysql"
+	cdcmodel "github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/dm/config"
+	"github.com/pingcap/tiflow/dm/pkg/binlog"
+	tcontext "github.com/pingcap/tiflow/dm/pkg/context"
+	"github.com/pingcap/tiflow/dm/pkg/log"
+	"github.com/pingcap/tiflow/dm/pkg/utils"
+	"github.com/pingcap/tiflow/dm/syncer/metrics"
+	"github.com/pingcap/tiflow/pkg/sqlmodel"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShardedCausality(t *testing.T) {
+	t.Parallel()
+
+	ti := mockTableInfo(t, "create table tb(a int primary key, b int unique);")
+	table := &cdcmodel.TableName{Schema: "test", Table: "tb"}
+	location := binlog.MustZeroLocation(mysql.MySQLFlavor)
+	ec := &eventContext{startLocation: location, endLocation: location, lastLocation: location}
+	newDML := func(pre, post []interface{}) *job {
+		return newDMLJob(sqlmodel.NewRowChange(table, nil, pre, post, ti, nil, nil), ec)
+	}
+	const shardCount = 2
+	// an inserted row is sent to the shard of its first causality key by hash.
+	shardOf := func(i int) int {
+		keys := rowChangeCausalityKeys(newDML(nil, []interface{}{i, i}).dml, false, false)
+		return int(mixHash(keys[0]) % shardCount)
+	}
+
+	jobCh := make(chan *job, 20)
+	syncer := &Syncer{
+		cfg: &config.SubTaskConfig{
+			SyncerConfig: config.SyncerConfig{
+				QueueSize:                   1024,
+				ExperimentalCausalityShards: shardCount,
+			},
+			Name:     "task-sharded",
+			SourceID: "source",
+		},
+		tctx:             tcontext.Background().WithLogger(log.L()),
+		sessCtx:          utils.NewSessionCtx(map[string]string{"time_zone": "UTC"}),
+		causalityClearCh: make(chan chan *sync.WaitGroup),
+	}
+	syncer.metricsProxies = metrics.DefaultMetricsProxies.CacheForOneTask("task-sharded", "worker", "source")
+	causalityCh := causalityWrap(context.Background(), jobCh, syncer)
+	receive := func(n int) []*job {
+		var jobs []*job
+		for len(jobs) < n {
+			select {
+			case j := <-causalityCh:
+				jobs = append(jobs, j)
+			case <-time.After(3 * time.Second):
+				require.FailNow(t, "timeout to receive jobs", "received %d of %d jobs", len(jobs), n)
+			}
+		}
+		return jobs
+	}
+	indexOf := func(jobs []*job, target *job) int {
+		for i, j := range jobs {
+			if j == target {
+				return i
+			}
+		}
+		require.FailNow(t, "job is not sent", "job %s", target)
+		return -1
+	}
+
+	// row 1 and row 2 are sent to different shards, row 3 to the same shard as row 1.
+	row1, row2, row3 := 1, 0, 0
+	for i := 2; row2 == 0 || row3 == 0; i++ {
+		switch {
+		case row2 == 0 && shardOf(i) != shardOf(row1):
+			row2 = i
+		case row3 == 0 && shardOf(i) == shardOf(row1):
+			row3 = i
+		}
+	}
+	inserts := []*job{newDML(nil, []interface{}{row1, row1}), newDML(nil, []interface{}{row2, row2})}
+	flushJob := newFlushJob(0, 1)
+	for _, j := range append(inserts, flushJob) {
+		jobCh <- j
+	}
+	// the flush job is sent once after the DMLs of all shards.
+	jobs := receive(3)
+	require.Equal(t, 2, indexOf(jobs, flushJob))
+	for _, j := range inserts {
+		require.Less(t, indexOf(jobs, j), 2)
+	}
+
+	// the update of row 3 conflicts with row 1 in the same shard, the shard sends its own conflict job.
+	insert3 := newDML(nil, []interface{}{row3, row3})
+	update1 := newDML([]interface{}{row3, row3}, []interface{}{row3, row1})
+	jobCh <- insert3
+	jobCh <- update1
+	jobs = receive(3)
+	require.Equal(t, insert3, jobs[0])
+	require.Equal(t, conflict, jobs[1].tp)
+	require.False(t, jobs[1].broadcast)
+	require.Equal(t, update1, jobs[2])
+
+	// the update depends on DMLs of both shards, the router sends a conflict job to drain all DML workers.
+	update2 := newDML([]interface{}{row1, row1}, []interface{}{row1, row2})
+	jobCh <- update2
+	jobs = receive(2)
+	require.Equal(t, conflict, jobs[0].tp)
+	require.True(t, jobs[0].broadcast)
+	require.Equal(t, update2, jobs[1])
+	m := &dto.Metric{}
+	require.NoError(t, syncer.metricsProxies.Metrics.CausalityCrossShardFlushCounter.Write(m))
+	require.Equal(t, float64(1), m.GetCounter().GetValue())
+	// all keys of update2 are owned by one shard now, so an update of row 2 is sent without a conflict job.
+	update3 := newDML([]interface{}{row2, row2}, []interface{}{row2, row3})
+	jobCh <- update3
+	require.Equal(t, []*job{update3}, receive(1))
+
+	// xid jobs are skipped since atomic-txn-causality is ignored, they are not counted as invalid jobs.
+	update4 := newDML([]interface{}{row2, row3}, []interface{}{row2, row2})
+	jobCh <- newXIDJob(location, location, location)
+	jobCh <- update4
+	require.Equal(t, []*job{update4}, receive(1))
+	m = &dto.Metric{}
+	require.NoError(t, syncer.metricsProxies.Metrics.CausalityInvalidJobCounter.Write(m))
+	require.Zero(t, m.GetCounter().GetValue())
+
+	// clearing relation on demand is also done by a conflict job of the router.
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- syncer.ClearCausalityRelation(context.Background())
+	}()
+	jobs = receive(1)
+	require.Equal(t, conflict, jobs[0].tp)
+	require.True(t, jobs[0].broadcast)
+	require.NoError(t, <-errCh)
+	_, err := syncer.DumpCausalityRelation(context.Background())
+	require.Error(t, err)
+
+	// the output is closed after all shards exit.
+	close(jobCh)
+	require.Eventually(t, func() bool {
+		select {
+		case _, ok := <-causalityCh:
+			return !ok
+		default:
+			return false
+		}
+	}, 3*time.Second, 10*time.Millisecond)
+}
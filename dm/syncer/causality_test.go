@@ -14,12 +14,26 @@
 package syncer
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"expvar"
+	"flag"
+	"fmt"
 	"math"
+	"math/rand"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
 	"time"
+	"unsafe"
 
 	"github.com/go-mysql-org/go-mysql/mysql"
 	"github.com/pingcap/check"
+	"github.com/pingcap/failpoint"
 	cdcmodel "github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/dm/config"
 	"github.com/pingcap/tiflow/dm/pkg/binlog"
@@ -28,7 +42,12 @@ import (
 	"github.com/pingcap/tiflow/dm/pkg/utils"
 	"github.com/pingcap/tiflow/dm/syncer/metrics"
 	"github.com/pingcap/tiflow/pkg/sqlmodel"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
 )
 
 func (s *testSyncerSuite) TestDetectConflict(c *check.C) {
@@ -60,6 +79,14 @@ func (s *testSyncerSuite) TestDetectConflict(c *check.C) {
 	assertRelationsEq(excepted)
 	conflictData := []string{"test_4", "test_3"}
 	c.Assert(ca.detectConflict(conflictData), check.IsTrue)
+	conflict, ok := ca.findConflict(conflictData)
+	c.Assert(ok, check.IsTrue)
+	c.Assert(conflict, check.Equals, causalityConflict{
+		existedKey:         "test_4",
+		existedRelation:    "test_4",
+		conflictedKey:      "test_3",
+		conflictedRelation: "test_1",
+	})
 	ca.relation.clear()
 	c.Assert(ca.relation.len(), check.Equals, 0)
 }
@@ -84,7 +111,7 @@ func TestCausality(t *testing.T) {
 		metricsProxies: &metrics.Proxies{},
 	}
 	syncer.metricsProxies = metrics.DefaultMetricsProxies.CacheForOneTask("task", "worker", "source")
-	causalityCh := causalityWrap(jobCh, syncer)
+	causalityCh := causalityWrap(context.Background(), jobCh, syncer)
 	testCases := []struct {
 		preVals  []interface{}
 		postVals []interface{}
@@ -127,6 +154,169 @@ func TestCausality(t *testing.T) {
 	}
 }
 
+func TestCausalityCrossTableKeys(t *testing.T) {
+	t.Parallel()
+
+	ti := mockTableInfo(t, "create table tb(a int primary key, b int unique);")
+	location := binlog.MustZeroLocation(mysql.MySQLFlavor)
+	ec := &eventContext{startLocation: location, endLocation: location, lastLocation: location}
+	t1 := &cdcmodel.TableName{Schema: "test", Table: "t1"}
+	t2 := &cdcmodel.TableName{Schema: "test", Table: "t2"}
+
+	for _, hashedKeys := range []bool{false, true} {
+		jobCh := make(chan *job, 10)
+		syncer := &Syncer{
+			cfg: &config.SubTaskConfig{
+				SyncerConfig: config.SyncerConfig{
+					QueueSize:           1024,
+					HashedCausalityKeys: hashedKeys,
+				},
+				Name:     "task",
+				SourceID: "source",
+			},
+			tctx:    tcontext.Background().WithLogger(log.L()),
+			sessCtx: utils.NewSessionCtx(map[string]string{"time_zone": "UTC"}),
+		}
+		syncer.metricsProxies = metrics.DefaultMetricsProxies.CacheForOneTask("task", "worker", "source")
+		causalityCh := causalityWrap(context.Background(), jobCh, syncer)
+
+		// the UPDATE of t2 has the same UK values as both rows of t1, but the keys of different tables never
+		// collide, so no conflict job is generated.
+		jobCh <- newDMLJob(sqlmodel.NewRowChange(t1, nil, nil, []interface{}{1, 2}, ti, nil, nil), ec)
+		jobCh <- newDMLJob(sqlmodel.NewRowChange(t1, nil, nil, []interface{}{3, 4}, ti, nil, nil), ec)
+		jobCh <- newDMLJob(sqlmodel.NewRowChange(t2, nil, []interface{}{1, 2}, []interface{}{3, 4}, ti, nil, nil), ec)
+		// the same UPDATE of t1 conflicts.
+		jobCh <- newDMLJob(sqlmodel.NewRowChange(t1, nil, []interface{}{1, 2}, []interface{}{3, 4}, ti, nil, nil), ec)
+		results := []opType{dml, dml, dml, conflict, dml}
+
+		require.Eventually(t, func() bool {
+			return len(causalityCh) == len(results)
+		}, 3*time.Second, 100*time.Millisecond)
+		for _, op := range results {
+			j := <-causalityCh
+			require.Equal(t, op, j.tp, "hashed keys %v", hashedKeys)
+		}
+		close(jobCh)
+	}
+}
+
+func TestCausalityMergedShardKeys(t *testing.T) {
+	t.Parallel()
+
+	ti := mockTableInfo(t, "create table tb(a int primary key, b int unique);")
+	location := binlog.MustZeroLocation(mysql.MySQLFlavor)
+	ec := &eventContext{startLocation: location, endLocation: location, lastLocation: location}
+	shard1 := &cdcmodel.TableName{Schema: "shard1", Table: "tb"}
+	shard2 := &cdcmodel.TableName{Schema: "shard2", Table: "tb"}
+	target := &cdcmodel.TableName{Schema: "test", Table: "tb"}
+
+	for _, hashedKeys := range []bool{false, true} {
+		jobCh := make(chan *job, 10)
+		syncer := &Syncer{
+			cfg: &config.SubTaskConfig{
+				SyncerConfig: config.SyncerConfig{
+					QueueSize:           1024,
+					HashedCausalityKeys: hashedKeys,
+				},
+				Name:     "task",
+				SourceID: "source",
+			},
+			tctx:    tcontext.Background().WithLogger(log.L()),
+			sessCtx: utils.NewSessionCtx(map[string]string{"time_zone": "UTC"}),
+		}
+		syncer.metricsProxies = metrics.DefaultMetricsProxies.CacheForOneTask("task", "worker", "source")
+		causalityCh := causalityWrap(context.Background(), jobCh, syncer)
+
+		// both shards are merged into the same downstream table, the UPDATE of shard2 writes the downstream row
+		// of the INSERT of shard1, so it conflicts.
+		jobCh <- newDMLJob(sqlmodel.NewRowChange(shard1, target, nil, []interface{}{1, 2}, ti, nil, nil), ec)
+		jobCh <- newDMLJob(sqlmodel.NewRowChange(shard2, target, nil, []interface{}{3, 4}, ti, nil, nil), ec)
+		jobCh <- newDMLJob(sqlmodel.NewRowChange(shard2, target, []interface{}{3, 4}, []interface{}{1, 5}, ti, nil, nil), ec)
+		// the UPDATE of shard1 writes another downstream row, it doesn't conflict.
+		jobCh <- newDMLJob(sqlmodel.NewRowChange(shard1, target, []interface{}{6, 7}, []interface{}{8, 9}, ti, nil, nil), ec)
+		results := []opType{dml, dml, conflict, dml, dml}
+
+		require.Eventually(t, func() bool {
+			return len(causalityCh) == len(results)
+		}, 3*time.Second, 100*time.Millisecond)
+		for _, op := range results {
+			j := <-causalityCh
+			require.Equal(t, op, j.tp, "hashed keys %v", hashedKeys)
+		}
+		close(jobCh)
+	}
+}
+
+func TestCausalityNoUniqueKey(t *testing.T) {
+	t.Parallel()
+
+	ti := mockTableInfo(t, "create table tb(a int, b int);")
+
+	jobCh := make(chan *job, 10)
+	syncer := &Syncer{
+		cfg: &config.SubTaskConfig{
+			SyncerConfig: config.SyncerConfig{
+				QueueSize: 1024,
+			},
+			Name:     "task",
+			SourceID: "source",
+		},
+		tctx:           tcontext.Background().WithLogger(log.L()),
+		sessCtx:        utils.NewSessionCtx(map[string]string{"time_zone": "UTC"}),
+		metricsProxies: &metrics.Proxies{},
+	}
+	syncer.metricsProxies = metrics.DefaultMetricsProxies.CacheForOneTask("task", "worker", "source")
+	causalityCh := causalityWrap(context.Background(), jobCh, syncer)
+	testCases := []struct {
+		preVals  []interface{}
+		postVals []interface{}
+	}{
+		{
+			postVals: []interface{}{1, 2},
+		},
+		// duplicate row
+		{
+			postVals: []interface{}{1, 2},
+		},
+		{
+			postVals: []interface{}{3, 4},
+		},
+		// deletes one of the duplicate rows, must be after both INSERTs
+		{
+			preVals: []interface{}{1, 2},
+		},
+		// the UPDATE touches both relations
+		{
+			preVals:  []interface{}{3, 4},
+			postVals: []interface{}{1, 2},
+		},
+	}
+	results := []opType{dml, dml, dml, dml, conflict, dml}
+	table := &cdcmodel.TableName{Schema: "test", Table: "t1"}
+	location := binlog.MustZeroLocation(mysql.MySQLFlavor)
+	ec := &eventContext{startLocation: location, endLocation: location, lastLocation: location}
+
+	for _, tc := range testCases {
+		change := sqlmodel.NewRowChange(table, nil, tc.preVals, tc.postVals, ti, nil, nil)
+		jobCh <- newDMLJob(change, ec)
+	}
+
+	require.Eventually(t, func() bool {
+		return len(causalityCh) == len(results)
+	}, 3*time.Second, 100*time.Millisecond)
+
+	jobs := make([]*job, 0, len(results))
+	for _, op := range results {
+		job := <-causalityCh
+		require.Equal(t, op, job.tp)
+		jobs = append(jobs, job)
+	}
+	// the duplicate rows and the DELETE are dispatched to the same worker in order.
+	require.Equal(t, jobs[0].dmlQueueKey, jobs[1].dmlQueueKey)
+	require.Equal(t, jobs[0].dmlQueueKey, jobs[3].dmlQueueKey)
+	require.NotEqual(t, jobs[0].dmlQueueKey, jobs[2].dmlQueueKey)
+}
+
 func (s *testSyncerSuite) TestCasualityRelation(c *check.C) {
 	rm := newCausalityRelation()
 	c.Assert(rm.len(), check.Equals, 0)
@@ -204,3 +394,2342 @@ func (s *testSyncerSuite) TestCasualityRelation(c *check.C) {
 	rm.clear()
 	c.Assert(rm.len(), check.Equals, 0)
 }
+
+func TestCausalityMaxKeys(t *testing.T) {
+	t.Parallel()
+
+	schemaStr := "create table tb(a int primary key, b int unique);"
+	ti := mockTableInfo(t, schemaStr)
+
+	jobCh := make(chan *job, 10)
+	syncer := &Syncer{
+		cfg: &config.SubTaskConfig{
+			SyncerConfig: config.SyncerConfig{
+				QueueSize:        1024,
+				MaxCausalityKeys: 2,
+			},
+			Name:     "task",
+			SourceID: "source",
+		},
+		tctx:    tcontext.Background().WithLogger(log.L()),
+		sessCtx: utils.NewSessionCtx(map[string]string{"time_zone": "UTC"}),
+	}
+	syncer.metricsProxies = metrics.DefaultMetricsProxies.CacheForOneTask("task", "worker", "source")
+	causalityCh := causalityWrap(context.Background(), jobCh, syncer)
+	testCases := [][]interface{}{{1, 2}, {3, 4}, {5, 6}}
+	// every row change brings two new keys, so relation is full after each job.
+	results := []opType{dml, conflict, dml, conflict, dml}
+	table := &cdcmodel.TableName{Schema: "test", Table: "t1"}
+	location := binlog.MustZeroLocation(mysql.MySQLFlavor)
+	ec := &eventContext{startLocation: location, endLocation: location, lastLocation: location}
+
+	for _, postVals := range testCases {
+		change := sqlmodel.NewRowChange(table, nil, nil, postVals, ti, nil, nil)
+		jobCh <- newDMLJob(change, ec)
+	}
+
+	require.Eventually(t, func() bool {
+		return len(causalityCh) == len(results)
+	}, 3*time.Second, 100*time.Millisecond)
+
+	for _, op := range results {
+		job := <-causalityCh
+		require.Equal(t, op, job.tp)
+	}
+}
+
+func TestCausalitySkipConflictAfterFlush(t *testing.T) {
+	t.Parallel()
+
+	schemaStr := "create table tb(a int primary key, b int unique);"
+	ti := mockTableInfo(t, schemaStr)
+
+	jobCh := make(chan *job, 10)
+	syncer := &Syncer{
+		cfg: &config.SubTaskConfig{
+			SyncerConfig: config.SyncerConfig{
+				QueueSize: 1024,
+			},
+			Name:     "task",
+			SourceID: "source",
+		},
+		tctx:    tcontext.Background().WithLogger(log.L()),
+		sessCtx: utils.NewSessionCtx(map[string]string{"time_zone": "UTC"}),
+	}
+	syncer.metricsProxies = metrics.DefaultMetricsProxies.CacheForOneTask("task", "worker", "source")
+	causalityCh := causalityWrap(context.Background(), jobCh, syncer)
+	table := &cdcmodel.TableName{Schema: "test", Table: "t1"}
+	location := binlog.MustZeroLocation(mysql.MySQLFlavor)
+	ec := &eventContext{startLocation: location, endLocation: location, lastLocation: location}
+
+	jobCh <- newDMLJob(sqlmodel.NewRowChange(table, nil, nil, []interface{}{1, 2}, ti, nil, nil), ec)
+	jobCh <- newDMLJob(sqlmodel.NewRowChange(table, nil, nil, []interface{}{2, 3}, ti, nil, nil), ec)
+	jobCh <- newFlushJob(syncer.cfg.WorkerCount, 1)
+	// conflicts with both rows above, but DML workers are already drained by the flush job.
+	jobCh <- newDMLJob(sqlmodel.NewRowChange(table, nil, nil, []interface{}{1, 3}, ti, nil, nil), ec)
+	jobCh <- newDMLJob(sqlmodel.NewRowChange(table, nil, nil, []interface{}{4, 5}, ti, nil, nil), ec)
+	// a DML is sent after the flush job, so a conflict job is needed.
+	jobCh <- newDMLJob(sqlmodel.NewRowChange(table, nil, nil, []interface{}{1, 5}, ti, nil, nil), ec)
+	results := []opType{dml, dml, flush, dml, dml, conflict, dml}
+
+	require.Eventually(t, func() bool {
+		return len(causalityCh) == len(results)
+	}, 3*time.Second, 100*time.Millisecond)
+
+	for _, op := range results {
+		job := <-causalityCh
+		require.Equal(t, op, job.tp)
+	}
+}
+
+func TestCausalityRelationUnion(t *testing.T) {
+	t.Parallel()
+
+	rm := newCausalityRelation()
+	rm.union("a", "a")
+	rm.union("b", "a")
+	rm.rotate(1)
+	rm.union("c", "b")
+	rm.union("x", "x")
+	rm.union("y", "x")
+
+	for _, key := range []string{"a", "b", "c"} {
+		root, ok := rm.get(key)
+		require.True(t, ok)
+		require.Equal(t, "a", root)
+	}
+
+	// merge two existed relations
+	rm.union("y", "c")
+	for _, key := range []string{"a", "b", "c", "x", "y"} {
+		root, ok := rm.get(key)
+		require.True(t, ok)
+		require.Equal(t, "a", root)
+	}
+
+	// the root is removed by gc, but the remaining keys still share the same relation
+	rm.gc(1)
+	require.Equal(t, 3, rm.len())
+	for _, key := range []string{"c", "x", "y"} {
+		root, ok := rm.get(key)
+		require.True(t, ok)
+		require.Equal(t, "a", root)
+	}
+	_, ok := rm.get("b")
+	require.False(t, ok)
+}
+
+func TestCausalityHashKey(t *testing.T) {
+	t.Parallel()
+
+	ca := &causality{
+		relation: newCausalityRelation(),
+		hashKey:  true,
+	}
+	key1 := ca.queueKey(ca.add([]string{"a", "b"}))
+	key2 := ca.queueKey(ca.add([]string{"b", "c"}))
+	require.Equal(t, key1, key2)
+	require.NotEqual(t, "a", key1)
+	require.Equal(t, "", ca.queueKey(ca.add(nil)))
+
+	// keys sharing a long prefix should be spread to all workers
+	workerCount := 16
+	buckets := make([]int, workerCount)
+	for i := 0; i < 1600; i++ {
+		key := ca.queueKey("`test`.`tb`.`id`.1000000000000000" + strconv.Itoa(i))
+		buckets[int(utils.GenHashKey(key))%workerCount]++
+	}
+	for _, cnt := range buckets {
+		require.Greater(t, cnt, 50)
+	}
+
+	ca.hashKey = false
+	require.Equal(t, "a", ca.queueKey(ca.add([]string{"a"})))
+}
+
+func TestCausalityRelationFilter(t *testing.T) {
+	t.Parallel()
+
+	rm := newCausalityRelationWithFilter(16)
+	for i := 0; i < 100; i++ {
+		rm.set(strconv.Itoa(i), strconv.Itoa(i))
+		if i%10 == 0 {
+			rm.rotate(int64(i))
+		}
+	}
+	for i := 0; i < 100; i++ {
+		require.True(t, rm.mayContainAny([]string{strconv.Itoa(i)}))
+		val, ok := rm.get(strconv.Itoa(i))
+		require.True(t, ok)
+		require.Equal(t, strconv.Itoa(i), val)
+	}
+	_, ok := rm.get("not-exist")
+	require.False(t, ok)
+
+	rm.clear()
+	require.False(t, rm.mayContainAny([]string{"1", "2"}))
+}
+
+func TestCausalityRelationCapacity(t *testing.T) {
+	t.Parallel()
+
+	rm := newCausalityRelationWithCapacity(16, 64)
+	require.Equal(t, 64, rm.groupCapacity)
+	for i := 0; i < 100; i++ {
+		rm.set(strconv.Itoa(i), strconv.Itoa(i))
+		if i%10 == 0 {
+			rm.rotate(int64(i))
+		}
+	}
+	require.Equal(t, 100, rm.len())
+	rm.gc(50)
+	_, ok := rm.get("10")
+	require.False(t, ok)
+	val, ok := rm.get("99")
+	require.True(t, ok)
+	require.Equal(t, "99", val)
+	rm.clear()
+	require.Zero(t, rm.len())
+	require.Len(t, rm.groups, 1)
+
+	// the relation of causality is pre-sized by causality-relation-capacity.
+	syncer := &Syncer{
+		cfg: &config.SubTaskConfig{
+			SyncerConfig: config.SyncerConfig{QueueSize: 1024, CausalityRelationCapacity: 128},
+			Name:         "task-capacity",
+			SourceID:     "source",
+		},
+		tctx:    tcontext.Background().WithLogger(log.L()),
+		sessCtx: utils.NewSessionCtx(map[string]string{"time_zone": "UTC"}),
+	}
+	syncer.metricsProxies = metrics.DefaultMetricsProxies.CacheForOneTask("task-capacity", "worker", "source")
+	c := newSyncerCausality(syncer, nil, make(chan *job, 8))
+	require.Equal(t, 128, c.relation.groupCapacity)
+	require.Equal(t, 8, c.relation.filterKeys)
+	syncer.cfg.CausalityRelationCapacity = 0
+	require.Zero(t, newSyncerCausality(syncer, nil, make(chan *job, 8)).relation.groupCapacity)
+}
+
+func TestCausalityRelationGCOutOfOrder(t *testing.T) {
+	t.Parallel()
+
+	// rotate groups by shuffled flush seqs, group i has key i.
+	newRelation := func(seqs []int64) *causalityRelation {
+		rm := newCausalityRelation()
+		rm.set("0", "0")
+		for i, seq := range seqs {
+			rm.rotate(seq)
+			rm.set(strconv.Itoa(i+1), strconv.Itoa(i+1))
+		}
+		return rm
+	}
+	checkKeys := func(rm *causalityRelation, kept, removed []string) {
+		for _, key := range kept {
+			_, ok := rm.get(key)
+			require.True(t, ok, key)
+		}
+		for _, key := range removed {
+			_, ok := rm.get(key)
+			require.False(t, ok, key)
+		}
+	}
+
+	rm := newRelation([]int64{3, 1, 4, 2, 5})
+	// groups of key 1 and 3 are rotated out by flush job 1 and 2.
+	rm.gc(2)
+	checkKeys(rm, []string{"0", "2", "4", "5"}, []string{"1", "3"})
+	rm.gc(4)
+	checkKeys(rm, []string{"4", "5"}, []string{"0", "1", "2", "3"})
+	// the newest group is always kept.
+	rm.gc(10)
+	checkKeys(rm, []string{"5"}, []string{"0", "1", "2", "3", "4"})
+	require.Len(t, rm.groups, 1)
+
+	for round := 0; round < 10; round++ {
+		seqs := make([]int64, 10)
+		for i, v := range rand.Perm(len(seqs)) {
+			seqs[i] = int64(v)
+		}
+		gcSeq := int64(rand.Intn(len(seqs)))
+		var kept, removed []string
+		for i := 0; i <= len(seqs); i++ {
+			if i < len(seqs) && seqs[i] <= gcSeq {
+				removed = append(removed, strconv.Itoa(i))
+			} else {
+				kept = append(kept, strconv.Itoa(i))
+			}
+		}
+		rm = newRelation(seqs)
+		rm.gc(gcSeq)
+		checkKeys(rm, kept, removed)
+		require.Len(t, rm.groups, len(kept))
+	}
+}
+
+// TestCausalityRelationStats isn't parallel since testing.AllocsPerRun panics in parallel tests.
+func TestCausalityRelationStats(t *testing.T) {
+	rm := newCausalityRelation()
+	require.Equal(t, RelationStats{Groups: 1, OldestFlushJobSeq: -1}, rm.Stats())
+
+	rm.set("a", "a")
+	rm.set("b", "a")
+	// overwriting a key doesn't change the estimated memory.
+	rm.set("b", "b")
+	rm.rotate(1)
+	rm.set("cc", "a")
+	require.Equal(t, RelationStats{
+		Groups:            2,
+		Keys:              3,
+		NewestGroupKeys:   1,
+		OldestFlushJobSeq: -1,
+		EstimatedBytes:    2*(2+relationEntryOverhead) + 3 + relationEntryOverhead,
+	}, rm.Stats())
+
+	rm.rotate(2)
+	rm.gc(1)
+	require.Equal(t, RelationStats{
+		Groups:            2,
+		Keys:              1,
+		OldestFlushJobSeq: 1,
+		EstimatedBytes:    3 + relationEntryOverhead,
+	}, rm.Stats())
+
+	// bloom filters are counted.
+	rm = newCausalityRelationWithFilter(16)
+	require.Equal(t, int64(len(rm.groups[0].filter.bits)*8), rm.Stats().EstimatedBytes)
+	require.Zero(t, testing.AllocsPerRun(10, func() { rm.Stats() }))
+}
+
+func TestCausalityRelationApproxLen(t *testing.T) {
+	t.Parallel()
+
+	rm := newCausalityRelationWithFilter(16)
+	check := func() {
+		require.Equal(t, int64(rm.len()), rm.approxLen())
+	}
+	check()
+	rm.set("a", "a")
+	rm.set("b", "a")
+	rm.set("b", "b")
+	check()
+	rm.rotate(1)
+	rm.union("b", "c")
+	rm.union("d", "a")
+	check()
+	rm.rotate(2)
+	rm.set("a", "e")
+	rm.rotate(3)
+	require.Equal(t, 2, rm.mergeOldestGroups(2))
+	check()
+	rm.gc(3)
+	check()
+	rm.clear()
+	check()
+	require.Zero(t, rm.approxLen())
+
+	// the counter can be read while the relation is modified.
+	done := make(chan struct{})
+	var minLen int64
+	go func() {
+		defer close(done)
+		for i := 0; i < 1000; i++ {
+			minLen = min(minLen, rm.approxLen())
+		}
+	}()
+	for i := 0; i < 1000; i++ {
+		rm.set(strconv.Itoa(i), strconv.Itoa(i))
+		if i%100 == 0 {
+			rm.rotate(int64(i))
+			rm.gc(int64(i))
+		}
+	}
+	<-done
+	require.Zero(t, minLen)
+	check()
+
+	syncer := &Syncer{}
+	require.Zero(t, syncer.CausalityRelationSize())
+	syncer.causalityRelation.Store(rm)
+	require.Equal(t, int64(rm.len()), syncer.CausalityRelationSize())
+}
+
+func TestCausalityRelationMergeGroups(t *testing.T) {
+	t.Parallel()
+
+	rm := newCausalityRelationWithFilter(16)
+	rm.set("a", "a")
+	rm.set("b", "a")
+	rm.rotate(1)
+	// overrides b in the older group.
+	rm.set("b", "c")
+	rm.set("c", "c")
+	rm.rotate(2)
+	rm.set("d", "a")
+	rm.rotate(3)
+	rm.set("e", "e")
+	roots := map[string]string{"a": "a", "b": "a", "c": "a", "d": "a", "e": "e"}
+	// a is the root of c after b is unioned with a in the newest group.
+	rm.union("b", "a")
+	bytes := rm.Stats().EstimatedBytes
+
+	require.Zero(t, rm.mergeOldestGroups(0))
+	require.Zero(t, rm.mergeOldestGroups(4))
+	require.Equal(t, 2, rm.mergeOldestGroups(2))
+	require.Len(t, rm.groups, 2)
+	require.Equal(t, int64(-1), rm.groups[0].prevFlushJobSeq)
+	require.Equal(t, int64(3), rm.groups[1].prevFlushJobSeq)
+	require.Equal(t, map[string]string{"a": "a", "b": "c", "c": "c", "d": "a"}, rm.groups[0].data)
+	for key, root := range roots {
+		require.True(t, rm.mayContainAny([]string{key}), key)
+		got, ok := rm.get(key)
+		require.True(t, ok, key)
+		require.Equal(t, root, got, key)
+	}
+	// the overridden b and the bloom filters of the merged groups are not counted.
+	filterBytes := int64(len(rm.groups[0].filter.bits) * 8)
+	require.Equal(t, bytes-(1+1+relationEntryOverhead)-2*filterBytes, rm.Stats().EstimatedBytes)
+
+	// the smaller older group is merged into the newer one, at least two groups are kept.
+	rm = newCausalityRelation()
+	rm.set("a", "a")
+	rm.rotate(2)
+	rm.set("a", "b")
+	rm.set("b", "b")
+	rm.set("c", "b")
+	rm.rotate(1)
+	rm.rotate(3)
+	require.Equal(t, 2, rm.mergeOldestGroups(1))
+	require.Len(t, rm.groups, 2)
+	require.Equal(t, int64(-1), rm.groups[0].prevFlushJobSeq)
+	require.Equal(t, map[string]string{"a": "b", "b": "b", "c": "b"}, rm.groups[0].data)
+	require.Equal(t, int64(3*(2+relationEntryOverhead)), rm.Stats().EstimatedBytes)
+
+	// the merged group is removed only after all flush jobs of the merged groups.
+	rm.gc(2)
+	require.Len(t, rm.groups, 2)
+	rm.gc(3)
+	require.Len(t, rm.groups, 1)
+	_, ok := rm.get("a")
+	require.False(t, ok)
+}
+
+func TestCausalityRelationRecycle(t *testing.T) {
+	t.Parallel()
+
+	rm := newCausalityRelation()
+	rm.set("1", "1")
+	rm.rotate(1)
+	rm.set("2", "2")
+	recycled := rm.groups[0]
+	rm.gc(1)
+	require.Nil(t, recycled.data)
+	require.Len(t, rm.groups, 1)
+
+	// groups rotated after recycling never see the stale keys
+	for i := 0; i < 10; i++ {
+		rm.rotate(int64(i + 2))
+	}
+	rm.clear()
+	for i := 0; i < 10; i++ {
+		rm.rotate(int64(i + 2))
+		require.Empty(t, rm.groups[len(rm.groups)-1].data)
+	}
+	_, ok := rm.get("1")
+	require.False(t, ok)
+	_, ok = rm.get("2")
+	require.False(t, ok)
+}
+
+func benchmarkDetectConflict(b *testing.B, relation *causalityRelation) {
+	c := &causality{relation: relation}
+	// prepare several groups of existing keys
+	for i := 0; i < 8; i++ {
+		for j := 0; j < 1024; j++ {
+			key := strconv.Itoa(i*1024 + j)
+			c.add([]string{key})
+		}
+		c.relation.rotate(int64(i))
+	}
+	keys := make([][]string, 1024)
+	for i := range keys {
+		keys[i] = []string{"new." + strconv.Itoa(i), "new.uk." + strconv.Itoa(i)}
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.detectConflict(keys[i%len(keys)])
+	}
+}
+
+func BenchmarkDetectConflictWithoutFilter(b *testing.B) {
+	benchmarkDetectConflict(b, newCausalityRelation())
+}
+
+func BenchmarkDetectConflictWithFilter(b *testing.B) {
+	benchmarkDetectConflict(b, newCausalityRelationWithFilter(1024))
+}
+
+// BenchmarkCausalityRelationFanIn reports the heap memory of relation per key when many keys join a few relations,
+// the keys of every DML are new strings like the keys generated from row changes.
+func BenchmarkCausalityRelationFanIn(b *testing.B) {
+	const relations = 16
+	runtime.GC()
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	keys := make([][]string, b.N)
+	for i := range keys {
+		keys[i] = []string{"shared." + strconv.Itoa(i%relations), "own." + strconv.Itoa(i)}
+	}
+
+	c := &causality{relation: newCausalityRelation()}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i, k := range keys {
+		c.add(k)
+		if i%1024 == 1023 {
+			c.relation.rotate(int64(i))
+		}
+	}
+	b.StopTimer()
+	// the keys are only referenced by relation now.
+	keys = nil
+	runtime.GC()
+	runtime.ReadMemStats(&after)
+	b.ReportMetric((float64(after.HeapAlloc)-float64(before.HeapAlloc))/float64(c.relation.len()), "heap-bytes/key")
+	runtime.KeepAlive(c)
+}
+
+func BenchmarkCausalityRelationRotate(b *testing.B) {
+	relation := newCausalityRelation()
+	keys := make([]string, 64)
+	for i := range keys {
+		keys[i] = strconv.Itoa(i)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, key := range keys {
+			relation.set(key, key)
+		}
+		// every flush rotates a new group and the group before last flush is garbage collected.
+		relation.rotate(int64(i))
+		relation.gc(int64(i - 1))
+	}
+}
+
+// benchmarkCausality feeds the DML jobs generated by genValues to a running causality, and reports the number
+// of conflict jobs per DML.
+func benchmarkCausality(b *testing.B, genValues func(i int) (preValues, postValues []interface{})) {
+	ti := mockTableInfo(b, "create table tb(a int primary key, b int unique);")
+	table := &cdcmodel.TableName{Schema: "test", Table: "t1"}
+	location := binlog.MustZeroLocation(mysql.MySQLFlavor)
+	ec := &eventContext{startLocation: location, endLocation: location, lastLocation: location}
+	jobs := make([]*job, b.N)
+	for i := range jobs {
+		preValues, postValues := genValues(i)
+		jobs[i] = newDMLJob(sqlmodel.NewRowChange(table, nil, preValues, postValues, ti, nil, nil), ec)
+	}
+
+	inCh := make(chan *job, 1024)
+	c := newCausality(
+		16,
+		utils.NewSessionCtx(map[string]string{"time_zone": "UTC"}),
+		metrics.DefaultMetricsProxies.CacheForOneTask("task", "worker", "source"),
+		inCh,
+		make(chan *job, 1024),
+	)
+	go func() {
+		c.run(context.Background())
+		c.close()
+	}()
+	conflicts := 0
+	done := make(chan struct{})
+	go func() {
+		for j := range c.outCh {
+			if j.tp == conflict {
+				conflicts++
+			}
+		}
+		close(done)
+	}()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for _, j := range jobs {
+		inCh <- j
+	}
+	close(inCh)
+	<-done
+	b.StopTimer()
+	b.ReportMetric(float64(conflicts)/float64(b.N), "conflicts/op")
+}
+
+func BenchmarkCausalityInsertOnly(b *testing.B) {
+	benchmarkCausality(b, func(i int) ([]interface{}, []interface{}) {
+		return nil, []interface{}{i, i}
+	})
+}
+
+func BenchmarkCausalityUpdateHeavy(b *testing.B) {
+	// every row is updated repeatedly, and the unique key changes in each update.
+	const rows = 1024
+	benchmarkCausality(b, func(i int) ([]interface{}, []interface{}) {
+		row, version := i%rows, i/rows
+		return []interface{}{row, row + version*rows}, []interface{}{row, row + (version+1)*rows}
+	})
+}
+
+func BenchmarkCausalityHotKey(b *testing.B) {
+	// a few hot rows are updated with unique keys taken from each other.
+	const rows = 16
+	benchmarkCausality(b, func(i int) ([]interface{}, []interface{}) {
+		return []interface{}{i % rows, i % rows}, []interface{}{i % rows, (i / rows) % rows}
+	})
+}
+
+// BenchmarkCausalityWideIndexKeys reports the estimated memory of relation per DML on a table with a wide
+// composite primary key, with raw and hashed causality keys.
+func BenchmarkCausalityWideIndexKeys(b *testing.B) {
+	ti := mockTableInfo(b, "create table tb(a varchar(64), b varchar(64), c varchar(64), d int, primary key(a, b, c, d));")
+	table := &cdcmodel.TableName{Schema: "test", Table: "t1"}
+	location := binlog.MustZeroLocation(mysql.MySQLFlavor)
+	ec := &eventContext{startLocation: location, endLocation: location, lastLocation: location}
+	prefix := strings.Repeat("x", 48)
+
+	for _, hashed := range []bool{false, true} {
+		name := "raw"
+		if hashed {
+			name = "hashed"
+		}
+		b.Run(name, func(b *testing.B) {
+			jobs := make([]*job, b.N)
+			for i := range jobs {
+				values := []interface{}{prefix + strconv.Itoa(i), prefix + "b", prefix + "c", i}
+				jobs[i] = newDMLJob(sqlmodel.NewRowChange(table, nil, nil, values, ti, nil, nil), ec)
+			}
+			c := &causality{relation: newCausalityRelation(), hashedKeys: hashed}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for _, j := range jobs {
+				c.add(c.causalityKeys(j))
+			}
+			b.StopTimer()
+			b.ReportMetric(float64(c.relation.Stats().EstimatedBytes)/float64(b.N), "relation-bytes/op")
+		})
+	}
+}
+
+func TestCausalityHashedKeys(t *testing.T) {
+	t.Parallel()
+
+	ti := mockTableInfo(t, "create table tb(a int primary key, b int unique);")
+	table := &cdcmodel.TableName{Schema: "test", Table: "t1"}
+	location := binlog.MustZeroLocation(mysql.MySQLFlavor)
+	ec := &eventContext{startLocation: location, endLocation: location, lastLocation: location}
+	newDML := func(pre, post []interface{}) *job {
+		return newDMLJob(sqlmodel.NewRowChange(table, nil, pre, post, ti, nil, nil), ec)
+	}
+
+	raw := &causality{relation: newCausalityRelation()}
+	hashed := &causality{relation: newCausalityRelation(), hashedKeys: true}
+	rawKeys := raw.causalityKeys(newDML(nil, []interface{}{1, 2}))
+	hashedKeys := hashed.causalityKeys(newDML(nil, []interface{}{1, 2}))
+	require.ElementsMatch(t, []string{"1.a.test.t1", "2.b.test.t1"}, rawKeys)
+	require.Len(t, hashedKeys, len(rawKeys))
+	for i := range rawKeys {
+		require.Equal(t, strconv.FormatUint(mixHash(rawKeys[i]), 16), hashedKeys[i])
+	}
+
+	// hashed keys detect the same conflicts as raw keys.
+	for _, c := range []*causality{raw, hashed} {
+		c.add(c.causalityKeys(newDML(nil, []interface{}{1, 2})))
+		c.add(c.causalityKeys(newDML(nil, []interface{}{3, 4})))
+		require.False(t, c.detectConflict(c.causalityKeys(newDML([]interface{}{1, 2}, []interface{}{1, 5}))))
+		require.True(t, c.detectConflict(c.causalityKeys(newDML([]interface{}{1, 2}, []interface{}{1, 4}))))
+	}
+
+	// hashed keys have a fixed size, so they save memory for long keys.
+	longTI := mockTableInfo(t, "create table tb(a varchar(64) primary key);")
+	raw = &causality{relation: newCausalityRelation()}
+	hashed = &causality{relation: newCausalityRelation(), hashedKeys: true}
+	for _, c := range []*causality{raw, hashed} {
+		for i := 0; i < 10; i++ {
+			value := strings.Repeat(strconv.Itoa(i), 32)
+			j := newDMLJob(sqlmodel.NewRowChange(table, nil, nil, []interface{}{value}, longTI, nil, nil), ec)
+			c.add(c.causalityKeys(j))
+		}
+	}
+	require.Less(t, hashed.relation.Stats().EstimatedBytes, raw.relation.Stats().EstimatedBytes)
+}
+
+func TestCausalityRouteKeys(t *testing.T) {
+	t.Parallel()
+
+	c := &causality{relation: newCausalityRelation(), workerCount: 4}
+	c.relation.union("a", "a")
+	c.relation.union("b", "a")
+	c.relation.rotate(1)
+	c.relation.union("c", "c")
+	c.relation.union("d", "c")
+	// the path of d is d -> c -> a now, get compresses it but routeKeys doesn't.
+	c.relation.union("c", "a")
+	c.relation.union("x", "x")
+	snapshot := func() []map[string]string {
+		ret := make([]map[string]string, 0, len(c.relation.groups))
+		for _, g := range c.relation.groups {
+			data := make(map[string]string, len(g.data))
+			for k, v := range g.data {
+				data[k] = v
+			}
+			ret = append(ret, data)
+		}
+		return ret
+	}
+	before := snapshot()
+
+	cases := []struct {
+		keys     []string
+		queueKey string
+	}{
+		{nil, ""},
+		{[]string{"f"}, "f"},
+		{[]string{"d"}, "a"},
+		{[]string{"f", "d"}, "a"},
+		{[]string{"d", "f", "x"}, "x"},
+	}
+	for _, cs := range cases {
+		queueKey, worker := c.routeKeys(cs.keys)
+		require.Equal(t, cs.queueKey, queueKey, cs.keys)
+		require.Equal(t, dmlQueueBucket(cs.queueKey, c.workerCount), worker, cs.keys)
+	}
+	require.Equal(t, before, snapshot())
+
+	// the routing is the same as add.
+	queueKey, _ := c.routeKeys([]string{"e", "b"})
+	require.Equal(t, c.add([]string{"e", "b"}), queueKey)
+
+	// hashed queue keys and hashed causality keys.
+	c.hashKey = true
+	queueKey, _ = c.routeKeys([]string{"d"})
+	require.Equal(t, strconv.FormatUint(mixHash("a"), 16), queueKey)
+	c.hashKey = false
+	c.hashedKeys = true
+	queueKey, _ = c.routeKeys([]string{"y"})
+	require.Equal(t, strconv.FormatUint(mixHash("y"), 16), queueKey)
+
+	// no DML worker.
+	c.workerCount = 0
+	queueKey, worker := c.routeKeys([]string{"f"})
+	require.Equal(t, strconv.FormatUint(mixHash("f"), 16), queueKey)
+	require.Equal(t, -1, worker)
+}
+
+func TestCausalitySafeModeKeys(t *testing.T) {
+	t.Parallel()
+
+	ti := mockTableInfo(t, "create table tb(a int primary key, b int unique, c int unique);")
+	table := &cdcmodel.TableName{Schema: "test", Table: "t1"}
+	location := binlog.MustZeroLocation(mysql.MySQLFlavor)
+	newDML := func(safeMode bool, vals ...interface{}) *job {
+		ec := &eventContext{startLocation: location, endLocation: location, lastLocation: location, safeMode: safeMode}
+		rowChange := sqlmodel.NewRowChange(table, nil, nil, vals, ti, nil, nil)
+		// only the primary key is used as causality key.
+		rowChange.SetCausalityIndexes(map[string]struct{}{sqlmodel.PrimaryIndexName: {}})
+		return newDMLJob(rowChange, ec)
+	}
+	runCausality := func() (chan *job, chan *job) {
+		syncer := &Syncer{
+			cfg: &config.SubTaskConfig{
+				SyncerConfig: config.SyncerConfig{
+					QueueSize: 1024,
+				},
+				Name:     "task",
+				SourceID: "source",
+			},
+			tctx:    tcontext.Background().WithLogger(log.L()),
+			sessCtx: utils.NewSessionCtx(map[string]string{"time_zone": "UTC"}),
+		}
+		syncer.metricsProxies = metrics.DefaultMetricsProxies.CacheForOneTask("task", "worker", "source")
+		jobCh := make(chan *job, 10)
+		return jobCh, causalityWrap(context.Background(), jobCh, syncer)
+	}
+	checkResults := func(causalityCh chan *job, results []opType) {
+		require.Eventually(t, func() bool {
+			return len(causalityCh) == len(results)
+		}, 3*time.Second, 10*time.Millisecond)
+		for _, op := range results {
+			require.Equal(t, op, (<-causalityCh).tp)
+		}
+	}
+
+	// without safe mode, the unique keys b and c are not used.
+	jobCh, causalityCh := runCausality()
+	jobCh <- newDML(false, 1, 2, 3)
+	jobCh <- newDML(false, 4, 5, 6)
+	jobCh <- newDML(false, 7, 2, 6)
+	checkResults(causalityCh, []opType{dml, dml, dml})
+
+	// in safe mode, the REPLACE of (7, 2, 6) may delete both rows above, so it conflicts with them.
+	jobCh, causalityCh = runCausality()
+	jobCh <- newDML(true, 1, 2, 3)
+	jobCh <- newDML(true, 4, 5, 6)
+	jobCh <- newDML(true, 7, 2, 6)
+	checkResults(causalityCh, []opType{dml, dml, conflict, dml})
+}
+
+func TestCausalityAtomicTxn(t *testing.T) {
+	t.Parallel()
+
+	ti := mockTableInfo(t, "create table tb(a int primary key, b int unique);")
+	table := &cdcmodel.TableName{Schema: "test", Table: "t1"}
+	location := binlog.MustZeroLocation(mysql.MySQLFlavor)
+	ec := &eventContext{startLocation: location, endLocation: location, lastLocation: location}
+	newDML := func(pre, post []interface{}) *job {
+		return newDMLJob(sqlmodel.NewRowChange(table, nil, pre, post, ti, nil, nil), ec)
+	}
+	syncer := &Syncer{
+		cfg: &config.SubTaskConfig{
+			SyncerConfig: config.SyncerConfig{
+				QueueSize:          1024,
+				AtomicTxnCausality: true,
+			},
+			Name:     "task",
+			SourceID: "source",
+		},
+		tctx:    tcontext.Background().WithLogger(log.L()),
+		sessCtx: utils.NewSessionCtx(map[string]string{"time_zone": "UTC"}),
+	}
+	syncer.metricsProxies = metrics.DefaultMetricsProxies.CacheForOneTask("task", "worker", "source")
+	jobCh := make(chan *job, 10)
+	causalityCh := causalityWrap(context.Background(), jobCh, syncer)
+	checkResults := func(results []opType) []*job {
+		require.Eventually(t, func() bool {
+			return len(causalityCh) == len(results)
+		}, 3*time.Second, 10*time.Millisecond)
+		jobs := make([]*job, 0, len(results))
+		for _, op := range results {
+			j := <-causalityCh
+			require.Equal(t, op, j.tp)
+			jobs = append(jobs, j)
+		}
+		return jobs
+	}
+	xidJob := newXIDJob(location, location, location)
+
+	// DMLs are buffered until the transaction ends.
+	jobCh <- newDML(nil, []interface{}{1, 1})
+	jobCh <- newDML(nil, []interface{}{2, 2})
+	require.Never(t, func() bool {
+		return len(causalityCh) > 0
+	}, 100*time.Millisecond, 10*time.Millisecond)
+	jobCh <- xidJob
+	jobs := checkResults([]opType{dml, dml})
+	require.NotEmpty(t, jobs[0].dmlQueueKey)
+	require.Equal(t, jobs[0].dmlQueueKey, jobs[1].dmlQueueKey)
+	firstKey := jobs[0].dmlQueueKey
+
+	// a transaction with disjoint keys doesn't conflict.
+	jobCh <- newDML(nil, []interface{}{3, 3})
+	jobCh <- newDML(nil, []interface{}{4, 4})
+	jobCh <- xidJob
+	jobs = checkResults([]opType{dml, dml})
+	require.NotEqual(t, firstKey, jobs[0].dmlQueueKey)
+	require.Equal(t, jobs[0].dmlQueueKey, jobs[1].dmlQueueKey)
+
+	// each DML only overlaps one transaction above, but the whole transaction overlaps both of them.
+	jobCh <- newDML([]interface{}{1, 1}, []interface{}{1, 5})
+	jobCh <- newDML([]interface{}{3, 3}, []interface{}{3, 6})
+	jobCh <- xidJob
+	jobs = checkResults([]opType{conflict, dml, dml})
+	require.NotEmpty(t, jobs[1].dmlQueueKey)
+	require.Equal(t, jobs[1].dmlQueueKey, jobs[2].dmlQueueKey)
+
+	// a transaction overlapping only the last one joins its relation.
+	jobCh <- newDML([]interface{}{1, 5}, []interface{}{1, 7})
+	jobCh <- newDML(nil, []interface{}{8, 8})
+	jobCh <- xidJob
+	txnJobs := checkResults([]opType{dml, dml})
+	require.Equal(t, jobs[1].dmlQueueKey, txnJobs[0].dmlQueueKey)
+	require.Equal(t, jobs[1].dmlQueueKey, txnJobs[1].dmlQueueKey)
+
+	// other jobs end the buffered transaction before they are sent.
+	jobCh <- newDML(nil, []interface{}{9, 9})
+	jobCh <- newFlushJob(0, 1)
+	checkResults([]opType{dml, flush})
+
+	// the unfinished transaction is sent before causality exits.
+	jobCh <- newDML(nil, []interface{}{10, 10})
+	close(jobCh)
+	checkResults([]opType{dml, conflict})
+}
+
+func TestCausalityAppendOnlyTables(t *testing.T) {
+	t.Parallel()
+
+	schemaStr := "create table tb(a int primary key, b int unique);"
+	ti := mockTableInfo(t, schemaStr)
+
+	jobCh := make(chan *job, 10)
+	syncer := &Syncer{
+		cfg: &config.SubTaskConfig{
+			SyncerConfig: config.SyncerConfig{
+				QueueSize:        1024,
+				AppendOnlyTables: []string{"test.t1"},
+			},
+			Name:     "task",
+			SourceID: "source",
+		},
+		tctx:    tcontext.Background().WithLogger(log.L()),
+		sessCtx: utils.NewSessionCtx(map[string]string{"time_zone": "UTC"}),
+	}
+	syncer.metricsProxies = metrics.DefaultMetricsProxies.CacheForOneTask("task", "worker", "source")
+	causalityCh := causalityWrap(context.Background(), jobCh, syncer)
+	// (1, 2) and (2, 3) will conflict with (1, 3) if t1 is not an append-only table.
+	testCases := [][]interface{}{{1, 2}, {2, 3}, {1, 3}}
+	location := binlog.MustZeroLocation(mysql.MySQLFlavor)
+	ec := &eventContext{startLocation: location, endLocation: location, lastLocation: location}
+
+	for _, postVals := range testCases {
+		change := sqlmodel.NewRowChange(&cdcmodel.TableName{Schema: "test", Table: "t1"}, nil, nil, postVals, ti, nil, nil)
+		jobCh <- newDMLJob(change, ec)
+	}
+	// t2 is not an append-only table, so the last one generates a conflict job.
+	for _, postVals := range testCases {
+		change := sqlmodel.NewRowChange(&cdcmodel.TableName{Schema: "test", Table: "t2"}, nil, nil, postVals, ti, nil, nil)
+		jobCh <- newDMLJob(change, ec)
+	}
+	results := []opType{dml, dml, dml, dml, dml, conflict, dml}
+
+	require.Eventually(t, func() bool {
+		return len(causalityCh) == len(results)
+	}, 3*time.Second, 100*time.Millisecond)
+
+	for _, op := range results {
+		job := <-causalityCh
+		require.Equal(t, op, job.tp)
+	}
+}
+
+func TestCausalityCancel(t *testing.T) {
+	t.Parallel()
+
+	for _, shards := range []int{0, 2} {
+		jobCh := make(chan *job, 10)
+		syncer := &Syncer{
+			cfg: &config.SubTaskConfig{
+				SyncerConfig: config.SyncerConfig{
+					ExperimentalCausalityShards: shards,
+				},
+				Name:     "task",
+				SourceID: "source",
+			},
+			tctx: tcontext.Background().WithLogger(log.L()),
+		}
+		syncer.metricsProxies = metrics.DefaultMetricsProxies.CacheForOneTask("task", "worker", "source")
+		ctx, cancel := context.WithCancel(context.Background())
+		// outCh has no buffer, so the flush job is in-flight when cancel.
+		causalityCh := causalityWrap(ctx, jobCh, syncer)
+		jobCh <- newFlushJob(1, 1)
+		cancel()
+
+		// the in-flight flush job and the ones sent after cancel are still sent, since the producer waits for them.
+		jobCh <- newFlushJob(1, 2)
+		for _, seq := range []int64{1, 2} {
+			select {
+			case j := <-causalityCh:
+				require.Equal(t, flush, j.tp)
+				require.Equal(t, seq, j.flushSeq)
+			case <-time.After(3 * time.Second):
+				require.FailNow(t, "timeout to receive the flush job", "shards %d, flush seq %d", shards, seq)
+			}
+		}
+
+		// outCh is closed after jobCh is closed.
+		close(jobCh)
+		require.Eventually(t, func() bool {
+			select {
+			case _, ok := <-causalityCh:
+				return !ok
+			default:
+				return false
+			}
+		}, 3*time.Second, 100*time.Millisecond)
+	}
+}
+
+func TestCausalityStop(t *testing.T) {
+	t.Parallel()
+
+	schemaStr := "create table tb(a int primary key, b int unique);"
+	ti := mockTableInfo(t, schemaStr)
+
+	jobCh := make(chan *job, 10)
+	syncer := &Syncer{
+		cfg: &config.SubTaskConfig{
+			SyncerConfig: config.SyncerConfig{
+				QueueSize: 1024,
+			},
+			Name:     "task",
+			SourceID: "source",
+		},
+		tctx:    tcontext.Background().WithLogger(log.L()),
+		sessCtx: utils.NewSessionCtx(map[string]string{"time_zone": "UTC"}),
+	}
+	syncer.metricsProxies = metrics.DefaultMetricsProxies.CacheForOneTask("task", "worker", "source")
+	table := &cdcmodel.TableName{Schema: "test", Table: "t1"}
+	location := binlog.MustZeroLocation(mysql.MySQLFlavor)
+	ec := &eventContext{startLocation: location, endLocation: location, lastLocation: location}
+
+	// all jobs are buffered before causality starts
+	jobCh <- newDMLJob(sqlmodel.NewRowChange(table, nil, nil, []interface{}{1, 2}, ti, nil, nil), ec)
+	jobCh <- newDMLJob(sqlmodel.NewRowChange(table, nil, nil, []interface{}{3, 4}, ti, nil, nil), ec)
+	close(jobCh)
+	causalityCh := causalityWrap(context.Background(), jobCh, syncer)
+
+	// the final conflict job is sent before outCh is closed
+	results := []opType{dml, dml, conflict}
+	for _, op := range results {
+		job := <-causalityCh
+		require.Equal(t, op, job.tp)
+	}
+	_, ok := <-causalityCh
+	require.False(t, ok)
+
+	// no final conflict job if DML workers are already drained
+	jobCh = make(chan *job, 10)
+	jobCh <- newDMLJob(sqlmodel.NewRowChange(table, nil, nil, []interface{}{1, 2}, ti, nil, nil), ec)
+	jobCh <- newFlushJob(syncer.cfg.WorkerCount, 1)
+	close(jobCh)
+	causalityCh = causalityWrap(context.Background(), jobCh, syncer)
+	results = []opType{dml, flush}
+	for _, op := range results {
+		job := <-causalityCh
+		require.Equal(t, op, job.tp)
+	}
+	_, ok = <-causalityCh
+	require.False(t, ok)
+}
+
+func TestDumpCausalityRelation(t *testing.T) {
+	t.Parallel()
+
+	jobCh := make(chan *job, 10)
+	syncer := &Syncer{
+		cfg: &config.SubTaskConfig{
+			SyncerConfig: config.SyncerConfig{
+				QueueSize: 1024,
+			},
+			Name:     "task",
+			SourceID: "source",
+		},
+		tctx:            tcontext.Background().WithLogger(log.L()),
+		causalityDumpCh: make(chan chan []causalityRelationGroupDump),
+	}
+	syncer.metricsProxies = metrics.DefaultMetricsProxies.CacheForOneTask("task", "worker", "source")
+
+	// causality is not running
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	_, err := syncer.DumpCausalityRelation(ctx)
+	cancel()
+	require.Error(t, err)
+
+	causalityCh := causalityWrap(context.Background(), jobCh, syncer)
+	defer close(jobCh)
+	jobCh <- newFlushJob(1, 1)
+	<-causalityCh
+
+	data, err := syncer.DumpCausalityRelation(context.Background())
+	require.NoError(t, err)
+	require.JSONEq(t, `[{"prev-flush-job-seq":-1,"relations":{}},{"prev-flush-job-seq":1,"relations":{}}]`, string(data))
+
+	rm := newCausalityRelation()
+	rm.union("a", "a")
+	rm.rotate(1)
+	rm.union("b", "a")
+	require.Equal(t, []causalityRelationGroupDump{
+		{PrevFlushJobSeq: -1, Relations: map[string]string{"a": "a"}},
+		{PrevFlushJobSeq: 1, Relations: map[string]string{"b": "a"}},
+	}, rm.dump())
+}
+
+func TestCausalityIdleClear(t *testing.T) {
+	t.Parallel()
+
+	ti := mockTableInfo(t, "create table tb(a int primary key, b int unique);")
+	table := &cdcmodel.TableName{Schema: "test", Table: "t1"}
+	location := binlog.MustZeroLocation(mysql.MySQLFlavor)
+	ec := &eventContext{startLocation: location, endLocation: location, lastLocation: location}
+	jobCh := make(chan *job, 10)
+	syncer := &Syncer{
+		cfg: &config.SubTaskConfig{
+			SyncerConfig: config.SyncerConfig{
+				QueueSize:             1024,
+				CausalityIdleInterval: 20,
+			},
+			Name:     "task",
+			SourceID: "source",
+		},
+		tctx:            tcontext.Background().WithLogger(log.L()),
+		sessCtx:         utils.NewSessionCtx(map[string]string{"time_zone": "UTC"}),
+		causalityDumpCh: make(chan chan []causalityRelationGroupDump),
+	}
+	syncer.metricsProxies = metrics.DefaultMetricsProxies.CacheForOneTask("task", "worker", "source")
+	causalityCh := causalityWrap(context.Background(), jobCh, syncer)
+	defer close(jobCh)
+	dumpRelation := func() string {
+		data, err := syncer.DumpCausalityRelation(context.Background())
+		require.NoError(t, err)
+		return string(data)
+	}
+	countKeys := func() int {
+		var groups []causalityRelationGroupDump
+		require.NoError(t, json.Unmarshal([]byte(dumpRelation()), &groups))
+		keyCount := 0
+		for _, group := range groups {
+			keyCount += len(group.Relations)
+		}
+		return keyCount
+	}
+
+	// DML workers are not drained, the relation is kept.
+	jobCh <- newDMLJob(sqlmodel.NewRowChange(table, nil, nil, []interface{}{1, 2}, ti, nil, nil), ec)
+	require.Equal(t, dml, (<-causalityCh).tp)
+	time.Sleep(100 * time.Millisecond)
+	require.Equal(t, 2, countKeys())
+
+	// the flush job drains DML workers, then the relation is cleared but the flush job seq is kept.
+	jobCh <- newFlushJob(0, 1)
+	require.Equal(t, flush, (<-causalityCh).tp)
+	require.Eventually(t, func() bool {
+		return dumpRelation() == `[{"prev-flush-job-seq":1,"relations":{}}]`
+	}, 3*time.Second, 10*time.Millisecond)
+
+	// the gc job of the flush job still works, and later DMLs don't conflict with the cleared keys.
+	jobCh <- newGCJob(1)
+	jobCh <- newDMLJob(sqlmodel.NewRowChange(table, nil, nil, []interface{}{1, 3}, ti, nil, nil), ec)
+	require.Equal(t, dml, (<-causalityCh).tp)
+	require.Len(t, causalityCh, 0)
+
+	rm := newCausalityRelation()
+	rm.union("a", "a")
+	rm.rotate(1)
+	rm.union("b", "a")
+	rm.rotate(2)
+	rm.clearKeys()
+	require.Equal(t, []causalityRelationGroupDump{{PrevFlushJobSeq: 2, Relations: map[string]string{}}}, rm.dump())
+	rm.rotate(3)
+	rm.gc(2)
+	require.Equal(t, 2, rm.Stats().Groups)
+	rm.gc(3)
+	require.Equal(t, 1, rm.Stats().Groups)
+	require.Equal(t, int64(3), rm.Stats().OldestFlushJobSeq)
+}
+
+func TestCausalityGCReclaimMetrics(t *testing.T) {
+	t.Parallel()
+
+	ti := mockTableInfo(t, "create table tb(a int primary key, b int unique);")
+	table := &cdcmodel.TableName{Schema: "test", Table: "t1"}
+	location := binlog.MustZeroLocation(mysql.MySQLFlavor)
+	ec := &eventContext{startLocation: location, endLocation: location, lastLocation: location}
+	jobCh := make(chan *job, 10)
+	syncer := &Syncer{
+		cfg: &config.SubTaskConfig{
+			SyncerConfig: config.SyncerConfig{
+				QueueSize: 1024,
+			},
+			Name:     "gc-reclaim-task",
+			SourceID: "source",
+		},
+		tctx:    tcontext.Background().WithLogger(log.L()),
+		sessCtx: utils.NewSessionCtx(map[string]string{"time_zone": "UTC"}),
+	}
+	syncer.metricsProxies = metrics.DefaultMetricsProxies.CacheForOneTask("gc-reclaim-task", "worker", "source")
+	causalityCh := causalityWrap(context.Background(), jobCh, syncer)
+	defer close(jobCh)
+	reclaimedKeys := func() float64 {
+		m := &dto.Metric{}
+		require.NoError(t, syncer.metricsProxies.Metrics.CausalityGCReclaimedKeysCounter.Write(m))
+		return m.GetCounter().GetValue()
+	}
+	reclaimedGroups := func() float64 {
+		m := &dto.Metric{}
+		require.NoError(t, syncer.metricsProxies.Metrics.CausalityGCReclaimedGroupsGauge.Write(m))
+		return m.GetGauge().GetValue()
+	}
+
+	jobCh <- newDMLJob(sqlmodel.NewRowChange(table, nil, nil, []interface{}{1, 1}, ti, nil, nil), ec)
+	jobCh <- newFlushJob(0, 1)
+	jobCh <- newDMLJob(sqlmodel.NewRowChange(table, nil, nil, []interface{}{2, 2}, ti, nil, nil), ec)
+	jobCh <- newFlushJob(0, 2)
+	// removes the group of (1, 1).
+	jobCh <- newGCJob(1)
+	require.Eventually(t, func() bool {
+		return reclaimedKeys() == 2
+	}, 3*time.Second, 10*time.Millisecond)
+	require.Equal(t, float64(1), reclaimedGroups())
+
+	// nothing to reclaim.
+	jobCh <- newGCJob(1)
+	require.Eventually(t, func() bool {
+		return reclaimedGroups() == 0
+	}, 3*time.Second, 10*time.Millisecond)
+	require.Equal(t, float64(2), reclaimedKeys())
+
+	// removes the group of (2, 2).
+	jobCh <- newGCJob(2)
+	require.Eventually(t, func() bool {
+		return reclaimedKeys() == 4
+	}, 3*time.Second, 10*time.Millisecond)
+	require.Equal(t, float64(1), reclaimedGroups())
+	require.Len(t, causalityCh, 4)
+}
+
+func TestCausalityMaxGroupAge(t *testing.T) {
+	t.Parallel()
+
+	ti := mockTableInfo(t, "create table tb(a int primary key, b int unique);")
+	table := &cdcmodel.TableName{Schema: "test", Table: "t1"}
+	location := binlog.MustZeroLocation(mysql.MySQLFlavor)
+	ec := &eventContext{startLocation: location, endLocation: location, lastLocation: location}
+	jobCh := make(chan *job, 10)
+	syncer := &Syncer{
+		cfg: &config.SubTaskConfig{
+			SyncerConfig: config.SyncerConfig{
+				QueueSize:            1024,
+				MaxCausalityGroupAge: 50,
+			},
+			Name:     "group-age-task",
+			SourceID: "source",
+		},
+		tctx:            tcontext.Background().WithLogger(log.L()),
+		sessCtx:         utils.NewSessionCtx(map[string]string{"time_zone": "UTC"}),
+		causalityDumpCh: make(chan chan []causalityRelationGroupDump),
+	}
+	syncer.metricsProxies = metrics.DefaultMetricsProxies.CacheForOneTask("group-age-task", "worker", "source")
+	causalityCh := causalityWrap(context.Background(), jobCh, syncer)
+	defer close(jobCh)
+	ageFlushes := func() float64 {
+		m := &dto.Metric{}
+		require.NoError(t, syncer.metricsProxies.Metrics.CausalityGroupAgeFlushCounter.Write(m))
+		return m.GetCounter().GetValue()
+	}
+
+	// no flush job is received, the group of the DML is reclaimed by a conflict job when it's too old.
+	jobCh <- newDMLJob(sqlmodel.NewRowChange(table, nil, nil, []interface{}{1, 2}, ti, nil, nil), ec)
+	require.Equal(t, dml, (<-causalityCh).tp)
+	select {
+	case j := <-causalityCh:
+		require.Equal(t, conflict, j.tp)
+	case <-time.After(3 * time.Second):
+		require.FailNow(t, "timeout to receive the conflict job")
+	}
+	require.Eventually(t, func() bool {
+		data, err := syncer.DumpCausalityRelation(context.Background())
+		require.NoError(t, err)
+		return string(data) == `[{"prev-flush-job-seq":-1,"relations":{}}]`
+	}, 3*time.Second, 10*time.Millisecond)
+	require.Equal(t, float64(1), ageFlushes())
+
+	// the relation has no key, nothing is reclaimed.
+	time.Sleep(150 * time.Millisecond)
+	require.Len(t, causalityCh, 0)
+	require.Equal(t, float64(1), ageFlushes())
+
+	rm := newCausalityRelation()
+	rm.union("a", "a")
+	createdAt, ok := rm.oldestKeyTime()
+	require.True(t, ok)
+	rm.rotate(1)
+	rm.union("b", "b")
+	rm.rotate(2)
+	// the merged group keeps the earlier createdAt.
+	require.Equal(t, 1, rm.mergeOldestGroups(2))
+	oldest, ok := rm.oldestKeyTime()
+	require.True(t, ok)
+	require.Equal(t, createdAt, oldest)
+	rm.clear()
+	_, ok = rm.oldestKeyTime()
+	require.False(t, ok)
+}
+
+func TestCausalityConflictWindow(t *testing.T) {
+	t.Parallel()
+
+	schemaStr := "create table tb(a int primary key, b int unique);"
+	ti := mockTableInfo(t, schemaStr)
+	table := &cdcmodel.TableName{Schema: "test", Table: "t1"}
+	location := binlog.MustZeroLocation(mysql.MySQLFlavor)
+	ec := &eventContext{startLocation: location, endLocation: location, lastLocation: location}
+	newDML := func(vals ...interface{}) *job {
+		return newDMLJob(sqlmodel.NewRowChange(table, nil, nil, vals, ti, nil, nil), ec)
+	}
+	runCausality := func(windowSize, windowInterval int) (chan *job, chan *job) {
+		syncer := &Syncer{
+			cfg: &config.SubTaskConfig{
+				SyncerConfig: config.SyncerConfig{
+					QueueSize:              1024,
+					ConflictWindowSize:     windowSize,
+					ConflictWindowInterval: windowInterval,
+				},
+				Name:     "task",
+				SourceID: "source",
+			},
+			tctx:    tcontext.Background().WithLogger(log.L()),
+			sessCtx: utils.NewSessionCtx(map[string]string{"time_zone": "UTC"}),
+		}
+		syncer.metricsProxies = metrics.DefaultMetricsProxies.CacheForOneTask("task", "worker", "source")
+		jobCh := make(chan *job, 10)
+		return jobCh, causalityWrap(context.Background(), jobCh, syncer)
+	}
+	checkResults := func(causalityCh chan *job, results []opType, dmlVals [][]interface{}) {
+		require.Eventually(t, func() bool {
+			return len(causalityCh) == len(results)
+		}, 3*time.Second, 10*time.Millisecond)
+		for _, op := range results {
+			j := <-causalityCh
+			require.Equal(t, op, j.tp)
+			if op == dml {
+				require.Equal(t, dmlVals[0], j.dml.RowValues())
+				dmlVals = dmlVals[1:]
+			}
+		}
+	}
+
+	// the conflict job is sent when the window is full.
+	jobCh, causalityCh := runCausality(3, 10000)
+	jobCh <- newDML(1, 2)
+	jobCh <- newDML(2, 3)
+	// conflicts with both rows above, held.
+	jobCh <- newDML(1, 3)
+	// doesn't conflict, sent directly.
+	jobCh <- newDML(4, 5)
+	// conflicts with (2, 3) and (4, 5), held and shares the conflict job with (1, 3).
+	jobCh <- newDML(2, 5)
+	// depends on the held (1, 3), held to keep the order, and the window is full.
+	jobCh <- newDML(1, 6)
+	checkResults(causalityCh, []opType{dml, dml, dml, conflict, dml, dml, dml},
+		[][]interface{}{{1, 2}, {2, 3}, {4, 5}, {1, 3}, {2, 5}, {1, 6}})
+
+	// the conflict job is sent when the window expires.
+	jobCh, causalityCh = runCausality(10, 10)
+	jobCh <- newDML(1, 2)
+	jobCh <- newDML(2, 3)
+	jobCh <- newDML(1, 3)
+	checkResults(causalityCh, []opType{dml, dml, conflict, dml},
+		[][]interface{}{{1, 2}, {2, 3}, {1, 3}})
+
+	// held jobs are sent before the flush job, and held again if they conflict with each other.
+	jobCh, causalityCh = runCausality(10, 10000)
+	jobCh <- newDML(1, 2)
+	jobCh <- newDML(2, 3)
+	jobCh <- newDML(1, 3)
+	jobCh <- newDML(4, 5)
+	jobCh <- newDML(2, 5)
+	jobCh <- newDML(2, 3)
+	jobCh <- newFlushJob(0, 1)
+	checkResults(causalityCh, []opType{dml, dml, dml, conflict, dml, dml, conflict, dml, flush},
+		[][]interface{}{{1, 2}, {2, 3}, {4, 5}, {1, 3}, {2, 5}, {2, 3}})
+
+	// held jobs are sent before causality exits.
+	jobCh, causalityCh = runCausality(10, 10000)
+	jobCh <- newDML(1, 2)
+	jobCh <- newDML(2, 3)
+	jobCh <- newDML(1, 3)
+	close(jobCh)
+	checkResults(causalityCh, []opType{dml, dml, conflict, dml, conflict},
+		[][]interface{}{{1, 2}, {2, 3}, {1, 3}})
+	_, ok := <-causalityCh
+	require.False(t, ok)
+}
+
+func TestCausalityDryRun(t *testing.T) {
+	t.Parallel()
+
+	schemaStr := "create table tb(a int primary key, b int unique);"
+	ti := mockTableInfo(t, schemaStr)
+
+	jobCh := make(chan *job, 10)
+	syncer := &Syncer{
+		cfg: &config.SubTaskConfig{
+			SyncerConfig: config.SyncerConfig{
+				QueueSize:             1024,
+				UnsafeCausalityDryRun: true,
+			},
+			Name:     "task",
+			SourceID: "source",
+		},
+		tctx:            tcontext.Background().WithLogger(log.L()),
+		sessCtx:         utils.NewSessionCtx(map[string]string{"time_zone": "UTC"}),
+		causalityDumpCh: make(chan chan []causalityRelationGroupDump),
+	}
+	syncer.metricsProxies = metrics.DefaultMetricsProxies.CacheForOneTask("task", "worker", "source")
+	causalityCh := causalityWrap(context.Background(), jobCh, syncer)
+	table := &cdcmodel.TableName{Schema: "test", Table: "t1"}
+	location := binlog.MustZeroLocation(mysql.MySQLFlavor)
+	ec := &eventContext{startLocation: location, endLocation: location, lastLocation: location}
+
+	jobCh <- newDMLJob(sqlmodel.NewRowChange(table, nil, nil, []interface{}{1, 2}, ti, nil, nil), ec)
+	jobCh <- newDMLJob(sqlmodel.NewRowChange(table, nil, nil, []interface{}{2, 3}, ti, nil, nil), ec)
+	// conflicts with both rows above, but no conflict job is generated.
+	jobCh <- newDMLJob(sqlmodel.NewRowChange(table, nil, nil, []interface{}{1, 3}, ti, nil, nil), ec)
+	results := []opType{dml, dml, dml}
+
+	require.Eventually(t, func() bool {
+		return len(causalityCh) == len(results)
+	}, 3*time.Second, 100*time.Millisecond)
+
+	for _, op := range results {
+		job := <-causalityCh
+		require.Equal(t, op, job.tp)
+	}
+
+	// the relation isn't cleared, all keys of the first two rows are still in it.
+	data, err := syncer.DumpCausalityRelation(context.Background())
+	require.NoError(t, err)
+	var groups []causalityRelationGroupDump
+	require.NoError(t, json.Unmarshal(data, &groups))
+	keyCount := 0
+	for _, group := range groups {
+		keyCount += len(group.Relations)
+	}
+	require.Equal(t, 4, keyCount)
+}
+
+func TestCausalityQueueSize(t *testing.T) {
+	t.Parallel()
+
+	newSyncer := func(causalityQueueSize int) *Syncer {
+		syncer := &Syncer{
+			cfg: &config.SubTaskConfig{
+				SyncerConfig: config.SyncerConfig{
+					QueueSize:          1024,
+					CausalityQueueSize: causalityQueueSize,
+				},
+				Name:     "task",
+				SourceID: "source",
+			},
+			tctx:    tcontext.Background().WithLogger(log.L()),
+			sessCtx: utils.NewSessionCtx(map[string]string{"time_zone": "UTC"}),
+		}
+		syncer.metricsProxies = metrics.DefaultMetricsProxies.CacheForOneTask("task", "worker", "source")
+		return syncer
+	}
+
+	for _, cs := range []struct {
+		causalityQueueSize int
+		expected           int
+	}{
+		{0, 1024},
+		{16, 16},
+	} {
+		jobCh := make(chan *job)
+		causalityCh := causalityWrap(context.Background(), jobCh, newSyncer(cs.causalityQueueSize))
+		require.Equal(t, cs.expected, cap(causalityCh))
+		close(jobCh)
+		for range causalityCh {
+		}
+	}
+}
+
+func TestCausalityPrioritizeFlush(t *testing.T) {
+	t.Parallel()
+
+	ti := mockTableInfo(t, "create table tb(a int primary key, b int unique);")
+	table := &cdcmodel.TableName{Schema: "test", Table: "t1"}
+	location := binlog.MustZeroLocation(mysql.MySQLFlavor)
+	ec := &eventContext{startLocation: location, endLocation: location, lastLocation: location}
+	newDML := func(a int) *job {
+		return newDMLJob(sqlmodel.NewRowChange(table, nil, nil, []interface{}{a, a}, ti, nil, nil), ec)
+	}
+	syncer := &Syncer{
+		cfg: &config.SubTaskConfig{
+			SyncerConfig: config.SyncerConfig{
+				QueueSize:                1024,
+				PrioritizeCausalityFlush: true,
+			},
+			Name:     "task",
+			SourceID: "source",
+		},
+		tctx:    tcontext.Background().WithLogger(log.L()),
+		sessCtx: utils.NewSessionCtx(map[string]string{"time_zone": "UTC"}),
+	}
+	syncer.metricsProxies = metrics.DefaultMetricsProxies.CacheForOneTask("task", "worker", "source")
+	syncer.newJobChans()
+	require.NotNil(t, syncer.causalityFlushCh)
+	// the flush job is sent without waiting for space of the full dmlJobCh.
+	syncer.dmlJobCh = make(chan *job, 2)
+	jobs := []*job{newDML(1), newDML(2), newFlushJob(0, 1)}
+	for _, j := range jobs {
+		syncer.sendDMLJob(j)
+	}
+	require.Equal(t, int64(2), jobs[2].inputSeq)
+	require.Len(t, syncer.causalityFlushCh, 1)
+
+	causalityCh := causalityWrap(context.Background(), syncer.dmlJobCh, syncer)
+	more := []*job{newDML(3), newAsyncFlushJob(0, 2), newFlushJob(0, 3), newDML(4), newDML(5), newFlushJob(0, 4)}
+	for _, j := range more {
+		syncer.sendDMLJob(j)
+	}
+	jobs = append(jobs, more...)
+	close(syncer.dmlJobCh)
+	// all jobs are sent to DML workers in order, no final conflict job is needed after the last flush job.
+	for _, j := range jobs {
+		require.Same(t, j, <-causalityCh)
+	}
+	_, ok := <-causalityCh
+	require.False(t, ok)
+
+	// flush jobs are released instead of blocking the producer after causality exits.
+	<-syncer.causalityDoneCh
+	syncer.cfg.WorkerCount = 2
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i <= causalityFlushChanSize; i++ {
+			syncer.addJob(newFlushJob(2, int64(5+i)))
+		}
+		asyncFlushJob := newAsyncFlushJob(2, 100)
+		syncer.addJob(asyncFlushJob)
+		asyncFlushJob.flushWg.Wait()
+		syncer.jobWg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(3 * time.Second):
+		require.FailNow(t, "flush jobs are blocked after causality exits")
+	}
+
+	// flush jobs go through compactor.
+	syncer.cfg.Compact = true
+	syncer.newJobChans()
+	require.Nil(t, syncer.causalityFlushCh)
+}
+
+func TestCausalityPrioritizeFlushWithConcurrentGC(t *testing.T) {
+	// widen the gap between sending a gc job and counting it.
+	require.NoError(t, failpoint.Enable("github.com/pingcap/tiflow/dm/syncer/SlowDownCountingGCJob", `return()`))
+	//nolint:errcheck
+	defer failpoint.Disable("github.com/pingcap/tiflow/dm/syncer/SlowDownCountingGCJob")
+
+	ti := mockTableInfo(t, "create table tb(a int primary key, b int unique);")
+	table := &cdcmodel.TableName{Schema: "test", Table: "t1"}
+	location := binlog.MustZeroLocation(mysql.MySQLFlavor)
+	ec := &eventContext{startLocation: location, endLocation: location, lastLocation: location}
+	syncer := &Syncer{
+		cfg: &config.SubTaskConfig{
+			SyncerConfig: config.SyncerConfig{
+				QueueSize:                16,
+				PrioritizeCausalityFlush: true,
+			},
+			Name:     "task",
+			SourceID: "source",
+		},
+		tctx:    tcontext.Background().WithLogger(log.L()),
+		sessCtx: utils.NewSessionCtx(map[string]string{"time_zone": "UTC"}),
+	}
+	syncer.metricsProxies = metrics.DefaultMetricsProxies.CacheForOneTask("task", "worker", "source")
+	syncer.newJobChans()
+	causalityCh := causalityWrap(context.Background(), syncer.dmlJobCh, syncer)
+
+	const dmlCount = 200
+	go func() {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		// gc jobs are sent by checkpointFlushWorker concurrently with the binlog loop.
+		go func() {
+			defer wg.Done()
+			for i := 0; i < dmlCount; i++ {
+				syncer.sendDMLJob(newGCJob(int64(i)))
+			}
+		}()
+		for i := 1; i <= dmlCount; i++ {
+			syncer.sendDMLJob(newDMLJob(sqlmodel.NewRowChange(table, nil, nil, []interface{}{i, i}, ti, nil, nil), ec))
+			if i%10 == 0 {
+				// flushSeq records the number of DMLs sent before the flush job.
+				syncer.sendDMLJob(newFlushJob(0, int64(i)))
+			}
+		}
+		wg.Wait()
+		close(syncer.dmlJobCh)
+	}()
+
+	dmls, flushes := 0, 0
+	for j := range causalityCh {
+		switch j.tp {
+		case dml:
+			dmls++
+		case flush:
+			flushes++
+			require.Equal(t, int(j.flushSeq), dmls)
+		}
+	}
+	require.Equal(t, dmlCount, dmls)
+	require.Equal(t, dmlCount/10, flushes)
+}
+
+func TestCausalityKeys(t *testing.T) {
+	t.Parallel()
+
+	ti := mockTableInfo(t, "create table tb(a int primary key, b int, c int, unique key b(b), unique key c(c));")
+	table := &cdcmodel.TableName{Schema: "test", Table: "t1"}
+	location := binlog.MustZeroLocation(mysql.MySQLFlavor)
+	rows := [][2][]interface{}{
+		{nil, {1, 2, 3}},
+		{{1, 2, 3}, {1, 5, 3}},
+		{{4, 5, 6}, nil},
+	}
+	newRowChange := func(i int, indexes map[string]struct{}) *sqlmodel.RowChange {
+		rowChange := sqlmodel.NewRowChange(table, nil, rows[i][0], rows[i][1], ti, nil, nil)
+		rowChange.SetCausalityIndexes(indexes)
+		return rowChange
+	}
+	// syncerKeys returns the keys of rows consumed by causality of a syncer.
+	syncerKeys := func(opts CausalityKeyOptions) [][]string {
+		jobCh := make(chan *job, 10)
+		syncer := &Syncer{
+			cfg: &config.SubTaskConfig{
+				SyncerConfig: config.SyncerConfig{
+					QueueSize:            1024,
+					HashedCausalityKeys:  opts.HashedKeys,
+					CausalityDecisionLog: 100,
+				},
+				Name:     "task",
+				SourceID: "source",
+			},
+			tctx:                    tcontext.Background().WithLogger(log.L()),
+			sessCtx:                 utils.NewSessionCtx(map[string]string{"time_zone": "UTC"}),
+			causalityDecisionDumpCh: make(chan chan []causalityDecision),
+		}
+		syncer.metricsProxies = metrics.DefaultMetricsProxies.CacheForOneTask("task", "worker", "source")
+		causalityCh := causalityWrap(context.Background(), jobCh, syncer)
+		defer close(jobCh)
+		ec := &eventContext{startLocation: location, endLocation: location, lastLocation: location, safeMode: opts.SafeMode}
+		for i := range rows {
+			jobCh <- newDMLJob(newRowChange(i, opts.Indexes), ec)
+		}
+		require.Eventually(t, func() bool {
+			return len(causalityCh) >= len(rows)
+		}, 3*time.Second, 10*time.Millisecond)
+
+		data, err := syncer.DumpCausalityDecisions(context.Background())
+		require.NoError(t, err)
+		var decisions []causalityDecision
+		require.NoError(t, json.Unmarshal(data, &decisions))
+		ret := make([][]string, 0, len(rows))
+		for _, d := range decisions {
+			if d.Type == causalityDecisionDispatch {
+				ret = append(ret, d.Keys)
+			}
+		}
+		return ret
+	}
+
+	primaryAndC := map[string]struct{}{sqlmodel.PrimaryIndexName: {}, "c": {}}
+	cases := []struct {
+		opts     CausalityKeyOptions
+		expected [][]string
+	}{
+		{
+			CausalityKeyOptions{},
+			[][]string{
+				{"1.a.test.t1", "2.b.test.t1", "3.c.test.t1"},
+				{"1.a.test.t1", "2.b.test.t1", "3.c.test.t1", "1.a.test.t1", "5.b.test.t1", "3.c.test.t1"},
+				{"4.a.test.t1", "5.b.test.t1", "6.c.test.t1"},
+			},
+		},
+		{
+			CausalityKeyOptions{Indexes: map[string]struct{}{sqlmodel.PrimaryIndexName: {}}},
+			[][]string{{"1.a.test.t1"}, {"1.a.test.t1", "1.a.test.t1"}, {"4.a.test.t1"}},
+		},
+		{
+			CausalityKeyOptions{Indexes: map[string]struct{}{"b": {}}},
+			[][]string{{"2.b.test.t1"}, {"2.b.test.t1", "5.b.test.t1"}, {"5.b.test.t1"}},
+		},
+		{
+			CausalityKeyOptions{Indexes: primaryAndC},
+			[][]string{
+				{"1.a.test.t1", "3.c.test.t1"},
+				{"1.a.test.t1", "3.c.test.t1", "1.a.test.t1", "3.c.test.t1"},
+				{"4.a.test.t1", "6.c.test.t1"},
+			},
+		},
+		{
+			// safe mode ignores the indexes.
+			CausalityKeyOptions{Indexes: primaryAndC, SafeMode: true},
+			[][]string{
+				{"1.a.test.t1", "2.b.test.t1", "3.c.test.t1"},
+				{"1.a.test.t1", "2.b.test.t1", "3.c.test.t1", "1.a.test.t1", "5.b.test.t1", "3.c.test.t1"},
+				{"4.a.test.t1", "5.b.test.t1", "6.c.test.t1"},
+			},
+		},
+		{
+			CausalityKeyOptions{Indexes: primaryAndC, HashedKeys: true},
+			[][]string{
+				{"1.a.test.t1", "3.c.test.t1"},
+				{"1.a.test.t1", "3.c.test.t1", "1.a.test.t1", "3.c.test.t1"},
+				{"4.a.test.t1", "6.c.test.t1"},
+			},
+		},
+	}
+	for _, cs := range cases {
+		inSyncer := syncerKeys(cs.opts)
+		require.Len(t, inSyncer, len(rows), cs.opts)
+		for i := range rows {
+			// the indexes of the row change are replaced by the options.
+			keys := CausalityKeys(newRowChange(i, map[string]struct{}{"b": {}}), cs.opts)
+			require.Equal(t, inSyncer[i], keys, cs.opts)
+
+			expected := cs.expected[i]
+			if cs.opts.HashedKeys {
+				hashed := make([]string, 0, len(expected))
+				for _, key := range expected {
+					hashed = append(hashed, strconv.FormatUint(mixHash(key), 16))
+				}
+				expected = hashed
+			}
+			require.ElementsMatch(t, expected, keys, cs.opts)
+		}
+	}
+}
+
+func TestCausalityCloseMetrics(t *testing.T) {
+	t.Parallel()
+
+	ti := mockTableInfo(t, "create table tb(a int primary key, b int unique);")
+	table := &cdcmodel.TableName{Schema: "test", Table: "t1"}
+	location := binlog.MustZeroLocation(mysql.MySQLFlavor)
+	ec := &eventContext{startLocation: location, endLocation: location, lastLocation: location}
+	jobCh := make(chan *job, 10)
+	syncer := &Syncer{
+		cfg: &config.SubTaskConfig{
+			SyncerConfig: config.SyncerConfig{
+				QueueSize: 1024,
+			},
+			Name:     "task-close-metrics",
+			SourceID: "source",
+		},
+		tctx:    tcontext.Background().WithLogger(log.L()),
+		sessCtx: utils.NewSessionCtx(map[string]string{"time_zone": "UTC"}),
+	}
+	syncer.metricsProxies = metrics.DefaultMetricsProxies.CacheForOneTask("task-close-metrics", "worker", "source")
+	ctx, cancel := context.WithCancel(context.Background())
+	causalityCh := causalityWrap(ctx, jobCh, syncer)
+	gaugeValue := func(g prometheus.Gauge) float64 {
+		m := &dto.Metric{}
+		require.NoError(t, g.Write(m))
+		return m.GetGauge().GetValue()
+	}
+	queueSizeGauge := func(queue string) prometheus.Gauge {
+		return syncer.metricsProxies.QueueSizeGauge.WithLabelValues("task-close-metrics", queue, "source")
+	}
+
+	jobCh <- newDMLJob(sqlmodel.NewRowChange(table, nil, nil, []interface{}{1, 2}, ti, nil, nil), ec)
+	require.Equal(t, dml, (<-causalityCh).tp)
+	// stale values before causality stops.
+	syncer.metricsProxies.Metrics.CausalityRelationSizeGauge.Set(100)
+	queueSizeGauge("causality_input").Set(5)
+	queueSizeGauge("dml_worker_input").Set(5)
+
+	cancel()
+	close(jobCh)
+	// the final conflict job clears the relation.
+	for range causalityCh {
+	}
+	require.Equal(t, float64(0), gaugeValue(syncer.metricsProxies.Metrics.CausalityRelationSizeGauge))
+	require.Equal(t, float64(0), gaugeValue(queueSizeGauge("causality_input")))
+	require.Equal(t, float64(0), gaugeValue(queueSizeGauge("dml_worker_input")))
+}
+
+func TestCausalityDetectDurationByJobType(t *testing.T) {
+	t.Parallel()
+
+	ti := mockTableInfo(t, "create table tb(a int primary key, b int unique);")
+	table := &cdcmodel.TableName{Schema: "test", Table: "t1"}
+	location := binlog.MustZeroLocation(mysql.MySQLFlavor)
+	ec := &eventContext{startLocation: location, endLocation: location, lastLocation: location}
+	jobCh := make(chan *job, 10)
+	syncer := &Syncer{
+		cfg: &config.SubTaskConfig{
+			SyncerConfig: config.SyncerConfig{
+				QueueSize: 1024,
+			},
+			Name:     "task-detect-duration",
+			SourceID: "source",
+		},
+		tctx:    tcontext.Background().WithLogger(log.L()),
+		sessCtx: utils.NewSessionCtx(map[string]string{"time_zone": "UTC"}),
+	}
+	syncer.metricsProxies = metrics.DefaultMetricsProxies.CacheForOneTask("task-detect-duration", "worker", "source")
+	causalityCh := causalityWrap(context.Background(), jobCh, syncer)
+	defer close(jobCh)
+	sampleCount := func(o prometheus.Observer) uint64 {
+		m := &dto.Metric{}
+		require.NoError(t, o.(prometheus.Histogram).Write(m))
+		return m.GetHistogram().GetSampleCount()
+	}
+
+	jobs := []*job{
+		newDMLJob(sqlmodel.NewRowChange(table, nil, nil, []interface{}{1, 2}, ti, nil, nil), ec),
+		newDMLJob(sqlmodel.NewRowChange(table, nil, nil, []interface{}{2, 3}, ti, nil, nil), ec),
+		newFlushJob(0, 1),
+		newAsyncFlushJob(0, 2),
+		newGCJob(1),
+	}
+	for _, j := range jobs {
+		jobCh <- j
+	}
+	// gc jobs are not sent.
+	for range jobs[:len(jobs)-1] {
+		<-causalityCh
+	}
+	m := syncer.metricsProxies.Metrics
+	require.Eventually(t, func() bool {
+		return sampleCount(m.GCDetectDurationHistogram) == 1
+	}, 3*time.Second, 10*time.Millisecond)
+	require.Equal(t, uint64(2), sampleCount(m.ConflictDetectDurationHistogram))
+	require.Equal(t, uint64(1), sampleCount(m.FlushDetectDurationHistogram))
+	require.Equal(t, uint64(1), sampleCount(m.AsyncFlushDetectDurationHistogram))
+}
+
+func TestCausalityRelationValuesShareRoot(t *testing.T) {
+	t.Parallel()
+
+	c := &causality{relation: newCausalityRelation()}
+	for i := 0; i < 1000; i++ {
+		// the shared keys of every DML are new strings.
+		c.add([]string{"shared." + strconv.Itoa(i%4), "own." + strconv.Itoa(i)})
+		if i%100 == 99 {
+			c.relation.rotate(int64(i))
+		}
+	}
+	// all values of the 4 relations share 4 backing arrays in all groups.
+	backings := make(map[*byte]struct{})
+	for _, g := range c.relation.groups {
+		for _, val := range g.data {
+			backings[unsafe.StringData(val)] = struct{}{}
+		}
+	}
+	require.Len(t, c.relation.groups, 11)
+	require.Len(t, backings, 4)
+}
+
+func TestCausalityRelationCheckInvariants(t *testing.T) {
+	t.Parallel()
+
+	c := &causality{relation: newCausalityRelationWithFilter(16)}
+	c.add([]string{"a", "b"})
+	c.relation.rotate(1)
+	// c joins the relation of a in the older group.
+	c.add([]string{"b", "c"})
+	c.relation.rotate(2)
+	// the group without keys is valid.
+	c.relation.rotate(3)
+	c.add([]string{"d"})
+	require.Empty(t, c.relation.checkInvariants())
+
+	// c maps to the removed a after gc, which is still a root.
+	c.relation.gc(1)
+	require.Len(t, c.relation.groups, 3)
+	require.True(t, c.relation.partialGC)
+	require.Empty(t, c.relation.checkInvariants())
+	require.Equal(t, 1, c.relation.mergeOldestGroups(2))
+	require.Empty(t, c.relation.checkInvariants())
+
+	c.relation.clear()
+	require.False(t, c.relation.partialGC)
+	c.relation.set("x", "y")
+	c.relation.rotate(5)
+	c.relation.rotate(4)
+	c.relation.groups[1].recycle()
+	violations := c.relation.checkInvariants()
+	require.Len(t, violations, 3)
+	require.Equal(t, "group 1 is recycled", violations[0])
+	require.Equal(t, "prevFlushJobSeq 4 of group 2 is less than 5 of group 1", violations[1])
+	require.Equal(t, `key "x" of group 0 maps to "y" which is not a key`, violations[2])
+
+	c.relation.groups = nil
+	require.Equal(t, []string{"relation has no group"}, c.relation.checkInvariants())
+}
+
+func TestCausalitySelfCheck(t *testing.T) {
+	t.Parallel()
+
+	c := &causality{
+		relation:      newCausalityRelation(),
+		logger:        log.L(),
+		metricProxies: metrics.DefaultMetricsProxies.CacheForOneTask("task-self-check", "worker", "source"),
+	}
+	counterValue := func() float64 {
+		m := &dto.Metric{}
+		require.NoError(t, c.metricProxies.Metrics.CausalityViolationCounter.Write(m))
+		return m.GetCounter().GetValue()
+	}
+
+	c.add([]string{"a", "b"})
+	c.selfCheck()
+	require.Zero(t, counterValue())
+
+	// all violations are counted though only some of them are logged.
+	for i := 0; i < maxSelfCheckViolationLogs+2; i++ {
+		c.relation.set(strconv.Itoa(i), "x")
+	}
+	c.selfCheck()
+	require.Equal(t, float64(maxSelfCheckViolationLogs+2), counterValue())
+}
+
+func TestClearCausalityRelation(t *testing.T) {
+	t.Parallel()
+
+	ti := mockTableInfo(t, "create table tb(a int primary key, b int unique);")
+	jobCh := make(chan *job, 10)
+	defer close(jobCh)
+	syncer := &Syncer{
+		cfg: &config.SubTaskConfig{
+			SyncerConfig: config.SyncerConfig{
+				QueueSize:   1024,
+				WorkerCount: 2,
+			},
+			Name:     "task",
+			SourceID: "source",
+		},
+		tctx:             tcontext.Background().WithLogger(log.L()),
+		sessCtx:          utils.NewSessionCtx(map[string]string{"time_zone": "UTC"}),
+		causalityClearCh: make(chan chan *sync.WaitGroup),
+	}
+	syncer.metricsProxies = metrics.DefaultMetricsProxies.CacheForOneTask("task", "worker", "source")
+	causalityCh := causalityWrap(context.Background(), jobCh, syncer)
+
+	table := &cdcmodel.TableName{Schema: "test", Table: "t1"}
+	location := binlog.MustZeroLocation(mysql.MySQLFlavor)
+	ec := &eventContext{startLocation: location, endLocation: location, lastLocation: location}
+	jobCh <- newDMLJob(sqlmodel.NewRowChange(table, nil, nil, []interface{}{1, 2}, ti, nil, nil), ec)
+	require.Equal(t, dml, (<-causalityCh).tp)
+	require.Equal(t, int64(2), syncer.CausalityRelationSize())
+
+	// it returns after the conflict job is executed by all DML workers.
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- syncer.ClearCausalityRelation(context.Background())
+	}()
+	conflictJob := <-causalityCh
+	require.Equal(t, conflict, conflictJob.tp)
+	require.Eventually(t, func() bool {
+		return syncer.CausalityRelationSize() == 0
+	}, 3*time.Second, 10*time.Millisecond)
+	conflictJob.flushWg.Done()
+	select {
+	case err := <-errCh:
+		require.FailNow(t, "returned before the conflict job is executed", "err: %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+	conflictJob.flushWg.Done()
+	require.NoError(t, <-errCh)
+
+	// DML workers are already drained, so no conflict job is sent.
+	require.NoError(t, syncer.ClearCausalityRelation(context.Background()))
+	require.Len(t, causalityCh, 0)
+}
+
+func TestCausalityPublishStats(t *testing.T) {
+	t.Parallel()
+
+	var stats atomic.Pointer[causalityStats]
+	c := newCausality(2, nil, metrics.DefaultMetricsProxies.CacheForOneTask("task-stats", "worker", "source"), nil, make(chan *job, 10))
+	c.stats = &stats
+	start := time.Now()
+	c.resetStats(start)
+
+	// 3 DMLs are dispatched to the first worker, and 1 DML to the second one.
+	keys := make([][]string, 2)
+	for i := 0; len(keys[0]) < 3 || len(keys[1]) < 1; i++ {
+		key := strconv.Itoa(i)
+		bucket := dmlQueueBucket(key, 2)
+		keys[bucket] = append(keys[bucket], key)
+	}
+	for _, key := range append(keys[0][:3], keys[1][0]) {
+		c.sendJob(&job{tp: dml, dmlQueueKey: key})
+	}
+	c.sendJob(newFlushJob(2, 1))
+	c.add([]string{"a", "b"})
+	c.statsConflicts = 5
+
+	c.publishStats(start.Add(10 * time.Second))
+	require.Equal(t, &causalityStats{ConflictsPerSecond: 0.5, RelationSize: 2, WorkerSkew: 1.5, RelationGroups: 1}, stats.Load())
+
+	// the conflicts and the dispatched DMLs are counted from the last publish.
+	c.publishStats(start.Add(20 * time.Second))
+	require.Equal(t, &causalityStats{RelationSize: 2, RelationGroups: 1}, stats.Load())
+	c.relation.rotate(1)
+	c.publishStats(start.Add(30 * time.Second))
+	require.Equal(t, 2, stats.Load().RelationGroups)
+}
+
+func TestCausalityExpvar(t *testing.T) {
+	t.Parallel()
+
+	jobCh := make(chan *job, 10)
+	syncer := &Syncer{
+		cfg: &config.SubTaskConfig{
+			SyncerConfig: config.SyncerConfig{QueueSize: 1024},
+			Name:         "task-expvar",
+			SourceID:     "source",
+		},
+		tctx:    tcontext.Background().WithLogger(log.L()),
+		sessCtx: utils.NewSessionCtx(map[string]string{"time_zone": "UTC"}),
+	}
+	syncer.metricsProxies = metrics.DefaultMetricsProxies.CacheForOneTask("task-expvar", "worker", "source")
+	causalityWrap(context.Background(), jobCh, syncer)
+	defer close(jobCh)
+
+	// the published variable reads the latest stats until the syncer is closed.
+	v := causalityExpvar.Get("task-expvar/source")
+	require.NotNil(t, v)
+	require.Equal(t, "null", v.String())
+	syncer.causalityStats.Store(&causalityStats{RelationSize: 3, TotalConflicts: 2, RelationGroups: 1})
+	require.JSONEq(t, `{"ConflictsPerSecond":0,"RelationSize":3,"WorkerSkew":0,"TotalConflicts":2,"RelationGroups":1}`, v.String())
+	require.Contains(t, expvar.Get("dm_syncer_causality").String(), `"task-expvar/source"`)
+	causalityExpvar.Delete(syncer.causalityExpvarKey())
+	require.Nil(t, causalityExpvar.Get("task-expvar/source"))
+}
+
+func TestCausalitySampleDebugLog(t *testing.T) {
+	t.Parallel()
+
+	c := newCausality(2, nil, metrics.DefaultMetricsProxies.CacheForOneTask("task-sample-log", "worker", "source"), nil, make(chan *job, 10))
+	conflict := causalityConflict{existedKey: "a", existedRelation: "a", conflictedKey: "b", conflictedRelation: "b"}
+
+	// nothing is sampled if debug level is disabled.
+	obs, logs := observer.New(zap.InfoLevel)
+	c.logger = log.Logger{Logger: zap.New(obs)}
+	c.logSampleRate = 3
+	for i := 0; i < 10; i++ {
+		require.False(t, c.sampleDebugLog())
+	}
+	require.Zero(t, c.logSampleCount)
+
+	// 1 in 3 logs are written, starting from the first one.
+	obs, logs = observer.New(zap.DebugLevel)
+	c.logger = log.Logger{Logger: zap.New(obs)}
+	for i := 0; i < 10; i++ {
+		c.logConflict([]string{"a", "b"}, conflict)
+	}
+	require.Equal(t, 4, logs.Len())
+
+	// all logs are written if the sample rate is 1.
+	c.logSampleRate = 1
+	for i := 0; i < 10; i++ {
+		c.logConflict([]string{"a", "b"}, conflict)
+	}
+	require.Equal(t, 14, logs.Len())
+}
+
+func TestCausalityPartialConflictFlush(t *testing.T) {
+	t.Parallel()
+
+	ti := mockTableInfo(t, "create table tb(a int primary key, b int unique);")
+	table := &cdcmodel.TableName{Schema: "test", Table: "tb"}
+	location := binlog.MustZeroLocation(mysql.MySQLFlavor)
+	ec := &eventContext{startLocation: location, endLocation: location, lastLocation: location}
+	newDML := func(pre, post []interface{}) *job {
+		return newDMLJob(sqlmodel.NewRowChange(table, nil, pre, post, ti, nil, nil), ec)
+	}
+	const workerCount = 8
+	outCh := make(chan *job, 10)
+	c := newCausality(workerCount, nil, metrics.DefaultMetricsProxies.CacheForOneTask("task-partial-flush", "worker", "source"), nil, outCh)
+	c.partialFlush = true
+	c.decisions = newCausalityDecisionLog(100)
+	handle := func(j *job) []*job {
+		c.handleJob(j)
+		var ret []*job
+		for len(outCh) > 0 {
+			ret = append(ret, <-outCh)
+		}
+		return ret
+	}
+	// the relation of an inserted row is its first causality key.
+	workerOf := func(i int) int {
+		return dmlQueueBucket(c.causalityKeys(newDML(nil, []interface{}{i, i}))[0], workerCount)
+	}
+
+	// row 1 and row 2 are dispatched to different workers, row 3 to the same worker as row 1.
+	row1, row2, row3 := 1, 0, 0
+	for i := 2; row2 == 0 || row3 == 0; i++ {
+		switch {
+		case row2 == 0 && workerOf(i) != workerOf(row1):
+			row2 = i
+		case row3 == 0 && workerOf(i) == workerOf(row1):
+			row3 = i
+		}
+	}
+	for _, i := range []int{row1, row2, row3} {
+		require.Len(t, handle(newDML(nil, []interface{}{i, i})), 1)
+	}
+
+	// the update conflicts with the relations of row 1 and row 2, only the worker of row 2 is flushed.
+	update := newDML([]interface{}{row1, row1}, []interface{}{row1, row2})
+	jobs := handle(update)
+	require.Len(t, jobs, 2)
+	require.Equal(t, conflict, jobs[0].tp)
+	require.Equal(t, []int{workerOf(row2)}, jobs[0].conflictWorkers)
+	require.Equal(t, update, jobs[1])
+	require.Equal(t, workerOf(row1), dmlQueueBucket(update.dmlQueueKey, workerCount))
+	// the relation is not cleared, the relations are merged.
+	require.False(t, c.drained)
+	keys := c.causalityKeys(update)
+	for _, key := range keys {
+		root, ok := c.relation.get(key)
+		require.True(t, ok)
+		require.Equal(t, update.dmlQueueKey, root)
+	}
+
+	// the conflicting relations are both on the same worker, no conflict job is needed.
+	update = newDML([]interface{}{row3, row3}, []interface{}{row3, row1})
+	jobs = handle(update)
+	require.Equal(t, []*job{update}, jobs)
+	require.Equal(t, workerOf(row1), dmlQueueBucket(update.dmlQueueKey, workerCount))
+
+	// the decisions can be replayed with the merges.
+	require.NoError(t, replayCausalityDecisions(c.decisions.snapshot(), false, 0))
+}
+
+func TestCausalityLifetimeConflicts(t *testing.T) {
+	t.Parallel()
+
+	ti := mockTableInfo(t, "create table tb(a int primary key, b int unique);")
+	table := &cdcmodel.TableName{Schema: "test", Table: "tb"}
+	location := binlog.MustZeroLocation(mysql.MySQLFlavor)
+	ec := &eventContext{startLocation: location, endLocation: location, lastLocation: location}
+	newDML := func(pre, post []interface{}) *job {
+		return newDMLJob(sqlmodel.NewRowChange(table, nil, pre, post, ti, nil, nil), ec)
+	}
+	var stats atomic.Pointer[causalityStats]
+	outCh := make(chan *job, 20)
+	c := newCausality(2, nil, metrics.DefaultMetricsProxies.CacheForOneTask("task-lifetime-conflicts", "worker", "source"), nil, outCh)
+	c.stats = &stats
+	start := time.Now()
+	c.resetStats(start)
+	counterValue := func() float64 {
+		m := &dto.Metric{}
+		require.NoError(t, c.metricProxies.CausalityConflictTotal.WithLabelValues(c.task, c.source, "test", "tb").Write(m))
+		return m.GetCounter().GetValue()
+	}
+
+	// every update conflicts with the two inserted rows, and the relation is cleared by the conflict job.
+	for i := 0; i < 3; i++ {
+		c.handleJob(newDML(nil, []interface{}{1, 1}))
+		c.handleJob(newDML(nil, []interface{}{2, 2}))
+		c.handleJob(newDML([]interface{}{1, 1}, []interface{}{1, 2}))
+		require.Equal(t, int64(i+1), c.totalConflicts)
+		require.Equal(t, float64(i+1), counterValue())
+		c.relation.clear()
+		for len(outCh) > 0 {
+			<-outCh
+		}
+	}
+
+	// the conflicts per second are reset by publishing stats, but the total conflicts are not.
+	c.publishStats(start.Add(time.Second))
+	require.Equal(t, int64(3), stats.Load().TotalConflicts)
+	require.Equal(t, float64(3), stats.Load().ConflictsPerSecond)
+	c.publishStats(start.Add(2 * time.Second))
+	require.Equal(t, int64(3), stats.Load().TotalConflicts)
+	require.Zero(t, stats.Load().ConflictsPerSecond)
+}
+
+func TestCausalityBlockedOnOutput(t *testing.T) {
+	t.Parallel()
+
+	outCh := make(chan *job, 1)
+	c := newCausality(2, nil, metrics.DefaultMetricsProxies.CacheForOneTask("task-blocked", "worker", "source"), nil, outCh)
+	obs, logs := observer.New(zap.WarnLevel)
+	c.logger = log.Logger{Logger: zap.New(obs)}
+	blocked := func() *dto.Histogram {
+		m := &dto.Metric{}
+		require.NoError(t, c.metricProxies.Metrics.CausalityOutBlockedHistogram.(prometheus.Histogram).Write(m))
+		return m.GetHistogram()
+	}
+	const blockedMsg = "causality is blocked on sending a job to DML workers, DML workers fall behind"
+
+	// the send isn't blocked if outCh has room.
+	c.sendJob(newFlushJob(2, 1))
+	require.Zero(t, blocked().GetSampleCount())
+
+	// the blocked send is observed, and warned periodically if enabled.
+	c.blockWarnInterval = 10 * time.Millisecond
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		<-outCh
+	}()
+	c.sendJob(newFlushJob(2, 2))
+	require.Equal(t, uint64(1), blocked().GetSampleCount())
+	require.GreaterOrEqual(t, blocked().GetSampleSum(), 0.05)
+	require.GreaterOrEqual(t, logs.FilterMessage(blockedMsg).Len(), 2)
+	require.Equal(t, int64(2), (<-outCh).flushSeq)
+
+	// it blocks silently by default.
+	c.blockWarnInterval = 0
+	c.sendJob(newFlushJob(2, 3))
+	warned := logs.FilterMessage(blockedMsg).Len()
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		<-outCh
+	}()
+	c.sendJob(newFlushJob(2, 4))
+	require.Equal(t, uint64(2), blocked().GetSampleCount())
+	require.Equal(t, warned, logs.FilterMessage(blockedMsg).Len())
+	require.Equal(t, int64(4), (<-outCh).flushSeq)
+}
+
+func TestCausalityNilDMLJob(t *testing.T) {
+	t.Parallel()
+
+	outCh := make(chan *job, 10)
+	c := newCausality(2, nil, metrics.DefaultMetricsProxies.CacheForOneTask("task-nil-dml", "worker", "source"), nil, outCh)
+	obs, logs := observer.New(zap.ErrorLevel)
+	c.logger = log.Logger{Logger: zap.New(obs)}
+	invalidJobs := func() float64 {
+		m := &dto.Metric{}
+		require.NoError(t, c.metricProxies.Metrics.CausalityInvalidJobCounter.Write(m))
+		return m.GetCounter().GetValue()
+	}
+
+	// the job is dropped without panicking, and the later jobs are still handled.
+	c.handleJob(&job{tp: dml})
+	c.handleJob(newFlushJob(2, 1))
+	require.Len(t, outCh, 1)
+	require.Equal(t, flush, (<-outCh).tp)
+	require.Equal(t, float64(1), invalidJobs())
+	require.Equal(t, 1, logs.FilterMessage("causality receives a DML job without row change, drop it").Len())
+
+	// the job is not buffered in the transaction either.
+	c.atomicTxn = true
+	c.receiveJob(&job{tp: dml})
+	require.Empty(t, c.txnJobs)
+	c.endTxn()
+	require.Empty(t, outCh)
+	require.Equal(t, float64(2), invalidJobs())
+}
+
+var updateCausalityGolden = flag.Bool("update-causality-golden", false, "update the golden files of causality replay")
+
+// causalityReplayFixture is a recorded sequence of jobs on one table, the DML jobs are row changes of the values
+// in the order of columns.
+type causalityReplayFixture struct {
+	Schema      string `json:"schema"`
+	Table       string `json:"table"`
+	CreateTable string `json:"create-table"`
+	WorkerCount int    `json:"worker-count"`
+	Jobs        []struct {
+		// Type is one of insert, update, delete, flush and gc.
+		Type     string        `json:"type"`
+		Pre      []interface{} `json:"pre"`
+		Post     []interface{} `json:"post"`
+		FlushSeq int64         `json:"flush-seq"`
+	} `json:"jobs"`
+}
+
+// causalityReplayGolden is the output of replaying a fixture. Jobs are the jobs sent by causality in order, a DML or
+// flush job is referred by its index in the fixture, and a DML job has its queue key and DML worker.
+type causalityReplayGolden struct {
+	Jobs      []string            `json:"jobs"`
+	Decisions []causalityDecision `json:"decisions"`
+}
+
+// causalityReplayRow converts the JSON numbers of a row to int64, which is the type of integer values decoded
+// from binlog.
+func causalityReplayRow(values []interface{}) []interface{} {
+	if values == nil {
+		return nil
+	}
+	row := make([]interface{}, len(values))
+	for i, v := range values {
+		row[i] = v
+		if n, ok := v.(json.Number); ok {
+			if i64, err := n.Int64(); err == nil {
+				row[i] = i64
+			}
+		}
+	}
+	return row
+}
+
+func replayCausalityFixture(t *testing.T, name string) causalityReplayGolden {
+	t.Helper()
+
+	data, err := os.ReadFile("testdata/" + name + ".json")
+	require.NoError(t, err)
+	var fixture causalityReplayFixture
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	require.NoError(t, decoder.Decode(&fixture))
+
+	ti := mockTableInfo(t, fixture.CreateTable)
+	table := &cdcmodel.TableName{Schema: fixture.Schema, Table: fixture.Table}
+	location := binlog.MustZeroLocation(mysql.MySQLFlavor)
+	ec := &eventContext{startLocation: location, endLocation: location, lastLocation: location}
+	outCh := make(chan *job, 2*len(fixture.Jobs))
+	c := newCausality(fixture.WorkerCount, nil, metrics.DefaultMetricsProxies.CacheForOneTask("task-"+name, "worker", "source"), nil, outCh)
+	// every DML job records at most 3 decisions.
+	c.decisions = newCausalityDecisionLog(3 * len(fixture.Jobs))
+
+	indexes := make(map[*job]int, len(fixture.Jobs))
+	for i, fj := range fixture.Jobs {
+		var j *job
+		switch fj.Type {
+		case "insert", "update", "delete":
+			j = newDMLJob(sqlmodel.NewRowChange(table, nil, causalityReplayRow(fj.Pre), causalityReplayRow(fj.Post), ti, nil, nil), ec)
+		case "flush":
+			j = newFlushJob(fixture.WorkerCount, fj.FlushSeq)
+		case "gc":
+			j = newGCJob(fj.FlushSeq)
+		default:
+			require.FailNow(t, "unknown job type", "job %d of %s: %s", i, name, fj.Type)
+		}
+		indexes[j] = i
+		c.handleJob(j)
+	}
+	close(outCh)
+
+	ret := causalityReplayGolden{Decisions: c.decisions.snapshot()}
+	for j := range outCh {
+		switch j.tp {
+		case dml:
+			ret.Jobs = append(ret.Jobs, fmt.Sprintf("dml #%d queue-key=%s worker=%d",
+				indexes[j], j.dmlQueueKey, dmlQueueBucket(j.dmlQueueKey, fixture.WorkerCount)))
+		case flush:
+			ret.Jobs = append(ret.Jobs, fmt.Sprintf("flush #%d", indexes[j]))
+		default:
+			ret.Jobs = append(ret.Jobs, j.tp.String())
+		}
+	}
+	return ret
+}
+
+// TestCausalityReplay replays the recorded fixtures in testdata and compares the conflict jobs, worker assignments
+// and decisions with the golden files, run it with -update-causality-golden to regenerate them after an intended
+// change of causality.
+func TestCausalityReplay(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range []string{"causality_readme", "causality_orders"} {
+		actual := replayCausalityFixture(t, name)
+		goldenFile := "testdata/" + name + ".golden.json"
+		if *updateCausalityGolden {
+			data, err := json.MarshalIndent(actual, "", "  ")
+			require.NoError(t, err)
+			require.NoError(t, os.WriteFile(goldenFile, append(data, '\n'), 0o644))
+			continue
+		}
+
+		data, err := os.ReadFile(goldenFile)
+		require.NoError(t, err)
+		var expected causalityReplayGolden
+		require.NoError(t, json.Unmarshal(data, &expected))
+		require.Equal(t, expected, actual, "fixture %s", name)
+		// the decisions can be re-derived from an empty relation.
+		require.NoError(t, replayCausalityDecisions(actual.Decisions, false, 0))
+	}
+}
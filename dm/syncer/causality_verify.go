@@ -0,0 +1,119 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"sync"
+
+	"github.com/pingcap/tiflow/dm/pkg/log"
+	"github.com/pingcap/tiflow/dm/pkg/terror"
+	"github.com/pingcap/tiflow/dm/pkg/utils"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+)
+
+// maxCausalityVerifyKeys is the max number of sampled keys whose last execution is remembered by causalityVerifier,
+// they are all forgotten when exceeded, so only the orders across the reset are not verified.
+const maxCausalityVerifyKeys = 1 << 16
+
+// causalityVerifyRecord is the last execution of a sampled causality key.
+type causalityVerifyRecord struct {
+	seq    int64
+	worker int
+}
+
+// causalityVerifier cross-checks the order decided by causality against the actual execution of DML workers.
+// causality samples 1 in sampleRate causality keys by hash, so all DMLs of a sampled key are verified, and assigns
+// an increasing seq to the DMLs with sampled keys when they are sent. DML workers report the DMLs after they are
+// executed, a DML with a sampled key must be executed after all DMLs of the key with smaller seqs, otherwise
+// dependent rows are executed out of order. a nil verifier verifies nothing.
+type causalityVerifier struct {
+	sampleRate uint32
+	logger     log.Logger
+	warnings   prometheus.Counter
+	// seq is shared by all shards of sharded causality, the DMLs of a key are sent in the order of their seqs
+	// since a key is owned by one shard at a time.
+	seq atomic.Int64
+
+	mu sync.Mutex
+	// executed maps a sampled key to its last execution.
+	executed map[string]causalityVerifyRecord
+}
+
+func newCausalityVerifier(sampleRate int, logger log.Logger, warnings prometheus.Counter) *causalityVerifier {
+	return &causalityVerifier{
+		sampleRate: uint32(sampleRate),
+		logger:     logger,
+		warnings:   warnings,
+		executed:   make(map[string]causalityVerifyRecord),
+	}
+}
+
+// sample sets the sampled keys of the DML job, it's called by causality before the job is dispatched.
+func (v *causalityVerifier) sample(j *job, keys []string) {
+	if v == nil {
+		return
+	}
+	j.verifyKeys = nil
+	for _, key := range keys {
+		if utils.GenHashKey(key)%v.sampleRate == 0 {
+			j.verifyKeys = append(j.verifyKeys, key)
+		}
+	}
+}
+
+// track assigns the next seq to the DML job with sampled keys, it's called by causality just before the job is
+// sent to DML workers.
+func (v *causalityVerifier) track(j *job) {
+	if v == nil || len(j.verifyKeys) == 0 {
+		return
+	}
+	j.verifySeq = v.seq.Inc()
+}
+
+// verify checks the DML jobs executed by the DML worker of queueID in order.
+func (v *causalityVerifier) verify(queueID int, jobs []*job) {
+	if v == nil {
+		return
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	for _, j := range jobs {
+		if len(j.verifyKeys) == 0 {
+			continue
+		}
+		if len(v.executed)+len(j.verifyKeys) > maxCausalityVerifyKeys {
+			v.logger.Debug("too many sampled keys in causality verifier, forget their executions",
+				zap.Int("keys", len(v.executed)))
+			v.executed = make(map[string]causalityVerifyRecord)
+		}
+		outOfOrder := false
+		for _, key := range j.verifyKeys {
+			last, ok := v.executed[key]
+			if ok && last.seq > j.verifySeq {
+				outOfOrder = true
+				v.logger.Warn("DML is executed after a later DML which depends on it",
+					zap.String("key", key), zap.Int64("seq", j.verifySeq), zap.String("worker", queueBucketName(queueID)),
+					zap.Int64("executed seq", last.seq), zap.String("executed worker", queueBucketName(last.worker)),
+					zap.Stringer("job", j), log.ShortError(terror.ErrSyncerCausalityVerifyFailed))
+				continue
+			}
+			v.executed[key] = causalityVerifyRecord{seq: j.verifySeq, worker: queueID}
+		}
+		if outOfOrder {
+			v.warnings.Inc()
+		}
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-mysql-org/go-mysql/mysql"
+	cdcmodel "github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/dm/config"
+	"github.com/pingcap/tiflow/dm/pkg/binlog"
+	tcontext "github.com/pingcap/tiflow/dm/pkg/context"
+	"github.com/pingcap/tiflow/dm/pkg/log"
+	"github.com/pingcap/tiflow/dm/pkg/utils"
+	"github.com/pingcap/tiflow/dm/syncer/metrics"
+	"github.com/pingcap/tiflow/pkg/sqlmodel"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCausalityVerifier(t *testing.T) {
+	t.Parallel()
+
+	var nilVerifier *causalityVerifier
+	j := &job{tp: dml}
+	nilVerifier.sample(j, []string{"a"})
+	nilVerifier.track(j)
+	nilVerifier.verify(0, []*job{j})
+	require.Nil(t, j.verifyKeys)
+	require.Zero(t, j.verifySeq)
+
+	proxies := metrics.DefaultMetricsProxies.CacheForOneTask("verifier-task", "worker", "source")
+	warnings := func() float64 {
+		m := &dto.Metric{}
+		require.NoError(t, proxies.Metrics.CausalityVerifyWarningCounter.Write(m))
+		return m.GetCounter().GetValue()
+	}
+	v := newCausalityVerifier(1, log.L(), proxies.Metrics.CausalityVerifyWarningCounter)
+	newJob := func(keys ...string) *job {
+		j := &job{tp: dml}
+		v.sample(j, keys)
+		v.track(j)
+		return j
+	}
+	j1, j2, j3 := newJob("a", "b"), newJob("b"), newJob("a")
+	require.Equal(t, []string{"a", "b"}, j1.verifyKeys)
+	require.Equal(t, int64(1), j1.verifySeq)
+	require.Equal(t, int64(3), j3.verifySeq)
+
+	// j1 is executed before j2 and j3 which depend on it.
+	v.verify(0, []*job{j1})
+	v.verify(1, []*job{j2})
+	v.verify(0, []*job{j3})
+	require.Zero(t, warnings())
+
+	// j5 is executed before j4 which it depends on.
+	j4, j5 := newJob("b"), newJob("b", "c")
+	v.verify(1, []*job{j5})
+	v.verify(0, []*job{j4})
+	require.Equal(t, float64(1), warnings())
+
+	// only the sampled keys are verified.
+	v = newCausalityVerifier(2, log.L(), proxies.Metrics.CausalityVerifyWarningCounter)
+	keys := []string{"k0", "k1", "k2", "k3", "k4", "k5", "k6", "k7"}
+	var sampled []string
+	for _, key := range keys {
+		if utils.GenHashKey(key)%2 == 0 {
+			sampled = append(sampled, key)
+		}
+	}
+	require.NotEmpty(t, sampled)
+	require.Less(t, len(sampled), len(keys))
+	require.Equal(t, sampled, newJob(keys...).verifyKeys)
+	j = newJob()
+	require.Empty(t, j.verifyKeys)
+	require.Zero(t, j.verifySeq)
+}
+
+func TestCausalityVerifySampleRate(t *testing.T) {
+	t.Parallel()
+
+	ti := mockTableInfo(t, "create table tb(a int primary key, b int unique);")
+	table := &cdcmodel.TableName{Schema: "test", Table: "t1"}
+	location := binlog.MustZeroLocation(mysql.MySQLFlavor)
+	ec := &eventContext{startLocation: location, endLocation: location, lastLocation: location}
+	jobCh := make(chan *job, 10)
+	syncer := &Syncer{
+		cfg: &config.SubTaskConfig{
+			SyncerConfig: config.SyncerConfig{
+				QueueSize:                 1024,
+				CausalityVerifySampleRate: 1,
+			},
+			Name:     "verify-task",
+			SourceID: "source",
+		},
+		tctx:    tcontext.Background().WithLogger(log.L()),
+		sessCtx: utils.NewSessionCtx(map[string]string{"time_zone": "UTC"}),
+	}
+	syncer.metricsProxies = metrics.DefaultMetricsProxies.CacheForOneTask("verify-task", "worker", "source")
+	causalityCh := causalityWrap(context.Background(), jobCh, syncer)
+	defer close(jobCh)
+	require.NotNil(t, syncer.causalityVerifier)
+
+	// all keys are sampled, and DMLs are tracked in the order they are sent.
+	insert := newDMLJob(sqlmodel.NewRowChange(table, nil, nil, []interface{}{1, 2}, ti, nil, nil), ec)
+	update := newDMLJob(sqlmodel.NewRowChange(table, nil, []interface{}{1, 2}, []interface{}{1, 3}, ti, nil, nil), ec)
+	jobCh <- insert
+	jobCh <- newFlushJob(0, 1)
+	jobCh <- update
+	require.Equal(t, insert, <-causalityCh)
+	// the conflict job is skipped since DML workers are drained by the flush job.
+	require.Equal(t, flush, (<-causalityCh).tp)
+	require.Equal(t, update, <-causalityCh)
+	require.Len(t, insert.verifyKeys, 2)
+	require.Len(t, update.verifyKeys, 4)
+	require.Equal(t, int64(1), insert.verifySeq)
+	require.Equal(t, int64(2), update.verifySeq)
+
+	// the update is executed before the insert which it depends on.
+	syncer.causalityVerifier.verify(1, []*job{update})
+	syncer.causalityVerifier.verify(0, []*job{insert})
+	m := &dto.Metric{}
+	require.NoError(t, syncer.metricsProxies.Metrics.CausalityVerifyWarningCounter.Write(m))
+	require.Equal(t, float64(1), m.GetCounter().GetValue())
+}
@@ -79,12 +79,12 @@ func (c *compactor) run() {
 
 			if j.tp == flush || j.tp == asyncFlush {
 				c.flushBuffer()
-				c.outCh <- j
+				c.sendJob(j)
 				continue
 			}
 
 			if j.tp == gc {
-				c.outCh <- j
+				c.sendJob(j)
 				continue
 			}
 
@@ -135,6 +135,12 @@ func (c *compactor) close() {
 	close(c.outCh)
 }
 
+// sendJob sends a job to outCh, which is the input of causality.
+func (c *compactor) sendJob(j *job) {
+	c.outCh <- j
+	c.metricProxies.Metrics.CausalityInputEnqueueCounter.Inc()
+}
+
 // flushBuffer flush buffer and reset compactor.
 func (c *compactor) flushBuffer() {
 	for _, j := range c.buffer {
@@ -142,7 +148,7 @@ func (c *compactor) flushBuffer() {
 			// set safemode for all jobs by first job in buffer.
 			// or safemode for insert(delete + insert = insert with safemode)
 			j.safeMode = c.safeMode || j.safeMode
-			c.outCh <- j
+			c.sendJob(j)
 		}
 	}
 	c.keyMap = make(map[string]map[string]int)
@@ -865,7 +865,7 @@ func (v *DataValidator) processRowsEvent(header *replication.EventHeader, ev *re
 
 	failpoint.Inject("ValidatorPanic", func() {})
 
-	if err := checkLogColumns(ev.SkippedColumns); err != nil {
+	if err := checkLogColumns(ev.SkippedColumns, nil); err != nil {
 		return terror.Annotate(err, sourceTable.String())
 	}
 
@@ -143,6 +143,7 @@ func (s *Syncer) genAndFilterInsertDMLs(tctx *tcontext.Context, param *genDMLPar
 	if err != nil {
 		return nil, err
 	}
+	causalityIndexes := liveCausalityIndexes(s.causalityIndexes[tableID], ti, downstreamTableInfo.WhereHandle)
 
 	if extendData != nil {
 		originalDataSeq = extendData
@@ -176,6 +177,7 @@ RowLoop:
 			s.sessCtx,
 		)
 		rowChange.SetWhereHandle(downstreamTableInfo.WhereHandle)
+		rowChange.SetCausalityIndexes(causalityIndexes)
 		dmls = append(dmls, rowChange)
 	}
 
@@ -202,6 +204,7 @@ func (s *Syncer) genAndFilterUpdateDMLs(
 	if err != nil {
 		return nil, err
 	}
+	causalityIndexes := liveCausalityIndexes(s.causalityIndexes[tableID], ti, downstreamTableInfo.WhereHandle)
 
 	if extendData != nil {
 		originalData = extendData
@@ -253,6 +256,7 @@ RowLoop:
 			s.sessCtx,
 		)
 		rowChange.SetWhereHandle(downstreamTableInfo.WhereHandle)
+		rowChange.SetCausalityIndexes(causalityIndexes)
 		dmls = append(dmls, rowChange)
 	}
 
@@ -274,6 +278,7 @@ func (s *Syncer) genAndFilterDeleteDMLs(tctx *tcontext.Context, param *genDMLPar
 	if err != nil {
 		return nil, err
 	}
+	causalityIndexes := liveCausalityIndexes(s.causalityIndexes[tableID], ti, downstreamTableInfo.WhereHandle)
 
 	if extendData != nil {
 		dataSeq = extendData
@@ -307,12 +312,70 @@ RowLoop:
 			s.sessCtx,
 		)
 		rowChange.SetWhereHandle(downstreamTableInfo.WhereHandle)
+		rowChange.SetCausalityIndexes(causalityIndexes)
 		dmls = append(dmls, rowChange)
 	}
 
 	return dmls, nil
 }
 
+// liveCausalityIndexes returns the names of the downstream unique indexes which generate causality keys, like
+// SetCausalityIndexes of RowChange. only the indexes in configured are used, nil means all of them, and an index is
+// excluded if the tracked source table has no public unique index of the same name. so an index dropped upstream,
+// e.g. during online DDL, stops generating causality keys even if downstream hasn't dropped it yet, which is safe
+// since DML workers are drained before the DDL is executed downstream. configured is returned as is if no index is
+// excluded, or all of them are excluded so that the DMLs still have causality keys.
+func liveCausalityIndexes(
+	configured map[string]struct{},
+	sourceTI *model.TableInfo,
+	whereHandle *sqlmodel.WhereHandle,
+) map[string]struct{} {
+	var live map[string]struct{}
+	excluded := false
+	for _, idx := range whereHandle.UniqueIdxs {
+		name := causalityIndexName(idx)
+		if configured != nil {
+			if _, ok := configured[name]; !ok {
+				continue
+			}
+		}
+		if !hasLiveUniqueIndex(sourceTI, name) {
+			excluded = true
+			continue
+		}
+		if live == nil {
+			live = make(map[string]struct{}, len(whereHandle.UniqueIdxs))
+		}
+		live[name] = struct{}{}
+	}
+	if !excluded || live == nil {
+		return configured
+	}
+	return live
+}
+
+// causalityIndexName returns the name of idx used by SetCausalityIndexes of RowChange.
+func causalityIndexName(idx *model.IndexInfo) string {
+	if idx.Primary {
+		// the PK of PKIsHandle table has no name.
+		return sqlmodel.PrimaryIndexName
+	}
+	return idx.Name.L
+}
+
+// hasLiveUniqueIndex returns whether ti has a public unique index whose causalityIndexName is name.
+func hasLiveUniqueIndex(ti *model.TableInfo, name string) bool {
+	if name == sqlmodel.PrimaryIndexName && ti.PKIsHandle && ti.GetPkColInfo() != nil {
+		return true
+	}
+	for _, idx := range ti.Indices {
+		if idx.Unique && idx.State == model.StatePublic && causalityIndexName(idx) == name {
+			return true
+		}
+	}
+	return false
+}
+
 func castUnsigned(data interface{}, ft *types.FieldType) interface{} {
 	if !mysql.HasUnsignedFlag(ft.GetFlag()) {
 		return data
@@ -343,17 +406,56 @@ func castUnsigned(data interface{}, ft *types.FieldType) interface{} {
 }
 
 // checkLogColumns returns error when not all rows in skipped is empty, which means the binlog doesn't contain all
-// columns.
+// columns, e.g. binlog_row_image is MINIMAL. if a skipped column belongs to a PK/UK of ti, the causality keys of
+// the row can't be computed and dependent DMLs may be executed concurrently, so the error names the column. ti can
+// be nil if the table info is not known yet.
 // TODO: don't return error when all skipped columns is non-PK.
-func checkLogColumns(skipped [][]int) error {
+func checkLogColumns(skipped [][]int, ti *model.TableInfo) error {
+	complete := true
 	for _, row := range skipped {
-		if len(row) > 0 {
-			return terror.ErrBinlogNotLogColumn
+		if len(row) == 0 {
+			continue
+		}
+		complete = false
+		if ti == nil {
+			break
+		}
+		for _, offset := range row {
+			if idx := uniqueIndexOfColumn(ti, offset); idx != "" {
+				return terror.ErrBinlogNotLogColumn.Generatef(
+					"upstream didn't log column `%s` of unique key `%s` in binlog, so causality of the row can't be computed",
+					ti.Columns[offset].Name.O, idx)
+			}
 		}
 	}
+	if !complete {
+		return terror.ErrBinlogNotLogColumn.Generate()
+	}
 	return nil
 }
 
+// uniqueIndexOfColumn returns the causalityIndexName of a PK/UK of ti which contains the column at offset, or ""
+// if there is none.
+func uniqueIndexOfColumn(ti *model.TableInfo, offset int) string {
+	if offset < 0 || offset >= len(ti.Columns) {
+		return ""
+	}
+	if ti.PKIsHandle && mysql.HasPriKeyFlag(ti.Columns[offset].GetFlag()) {
+		return sqlmodel.PrimaryIndexName
+	}
+	for _, idx := range ti.Indices {
+		if !idx.Unique {
+			continue
+		}
+		for _, col := range idx.Columns {
+			if col.Offset == offset {
+				return causalityIndexName(idx)
+			}
+		}
+	}
+	return ""
+}
+
 // genSQLMultipleRows generates multiple rows SQL with different dmlOpType.
 func genSQLMultipleRows(op sqlmodel.DMLType, dmls []*sqlmodel.RowChange) (queries string, args []interface{}) {
 	if len(dmls) > 1 {
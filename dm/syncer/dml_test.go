@@ -27,6 +27,7 @@ import (
 	"github.com/pingcap/tidb/pkg/util/mock"
 	cdcmodel "github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/dm/pkg/binlog"
+	"github.com/pingcap/tiflow/dm/pkg/terror"
 	"github.com/pingcap/tiflow/pkg/sqlmodel"
 	"github.com/stretchr/testify/require"
 )
@@ -70,6 +71,83 @@ func TestCastUnsigned(t *testing.T) {
 	}
 }
 
+func TestLiveCausalityIndexes(t *testing.T) {
+	t.Parallel()
+
+	downstreamTI := mockTableInfo(t, "create table tb(a int primary key, b int, c int, unique key b(b), unique key c(c))")
+	whereHandle := sqlmodel.GetWhereHandle(downstreamTI, downstreamTI)
+	table := &cdcmodel.TableName{Schema: "test", Table: "tb"}
+	keys := func(sourceTI *model.TableInfo, indexes map[string]struct{}) []string {
+		change := sqlmodel.NewRowChange(table, nil, nil, []interface{}{1, 2, 3}, sourceTI, downstreamTI, nil)
+		change.SetWhereHandle(whereHandle)
+		change.SetCausalityIndexes(indexes)
+		return change.CausalityKeys()
+	}
+
+	// all indexes are live.
+	require.Nil(t, liveCausalityIndexes(nil, downstreamTI, whereHandle))
+	configured := map[string]struct{}{"b": {}}
+	require.Equal(t, configured, liveCausalityIndexes(configured, downstreamTI, whereHandle))
+
+	// index b is dropped upstream, but downstream hasn't dropped it yet.
+	droppedTI := mockTableInfo(t, "create table tb(a int primary key, b int, c int, unique key c(c))")
+	indexes := liveCausalityIndexes(nil, droppedTI, whereHandle)
+	require.Equal(t, map[string]struct{}{sqlmodel.PrimaryIndexName: {}, "c": {}}, indexes)
+	require.ElementsMatch(t, []string{"1.a.test.tb", "3.c.test.tb"}, keys(droppedTI, indexes))
+	indexes = liveCausalityIndexes(map[string]struct{}{sqlmodel.PrimaryIndexName: {}, "b": {}}, droppedTI, whereHandle)
+	require.Equal(t, map[string]struct{}{sqlmodel.PrimaryIndexName: {}}, indexes)
+	// the DMLs still have causality keys if all configured indexes are dropped.
+	require.Equal(t, configured, liveCausalityIndexes(configured, droppedTI, whereHandle))
+
+	// index b is being dropped upstream.
+	droppingTI := mockTableInfo(t, "create table tb(a int primary key, b int, c int, unique key b(b), unique key c(c))")
+	for _, idx := range droppingTI.Indices {
+		if idx.Name.L == "b" {
+			idx.State = model.StateWriteOnly
+		}
+	}
+	indexes = liveCausalityIndexes(nil, droppingTI, whereHandle)
+	require.Equal(t, map[string]struct{}{sqlmodel.PrimaryIndexName: {}, "c": {}}, indexes)
+	require.ElementsMatch(t, []string{"1.a.test.tb", "3.c.test.tb"}, keys(droppingTI, indexes))
+
+	// no index is live, all indexes are still used.
+	noIndexTI := mockTableInfo(t, "create table tb(a int, b int, c int)")
+	require.Nil(t, liveCausalityIndexes(nil, noIndexTI, whereHandle))
+	require.ElementsMatch(t, []string{"1.a.test.tb", "2.b.test.tb", "3.c.test.tb"}, keys(noIndexTI, nil))
+}
+
+func TestCheckLogColumns(t *testing.T) {
+	t.Parallel()
+
+	ti := mockTableInfo(t, "create table tb(a int primary key, b int, c int, d int, unique key b(b), unique key cd(c, d))")
+
+	// FULL row image.
+	require.NoError(t, checkLogColumns(nil, ti))
+	require.NoError(t, checkLogColumns([][]int{{}, {}}, ti))
+
+	// MINIMAL row image of `UPDATE tb SET d = 5 WHERE a = 1`, the before image only has the PK, and the after image
+	// only has the changed column. causality keys of UK b and cd can't be computed.
+	err := checkLogColumns([][]int{{1, 2, 3}, {0, 1, 2}}, ti)
+	require.True(t, terror.ErrBinlogNotLogColumn.Equal(err))
+	require.ErrorContains(t, err, "column `b` of unique key `b`")
+	err = checkLogColumns([][]int{{}, {2}}, ti)
+	require.True(t, terror.ErrBinlogNotLogColumn.Equal(err))
+	require.ErrorContains(t, err, "column `c` of unique key `cd`")
+
+	// the omitted column is not in any PK/UK.
+	noUKTI := mockTableInfo(t, "create table tb(a int primary key, b int, c int)")
+	err = checkLogColumns([][]int{{1, 2}, {0, 1}}, noUKTI)
+	require.True(t, terror.ErrBinlogNotLogColumn.Equal(err))
+	require.ErrorContains(t, err, "column `a` of unique key `primary`")
+	err = checkLogColumns([][]int{{1, 2}, {1}}, noUKTI)
+	require.True(t, terror.ErrBinlogNotLogColumn.Equal(err))
+	require.NotContains(t, err.Error(), "unique key")
+	// the table info is not known.
+	err = checkLogColumns([][]int{{1, 2}, {0, 1}}, nil)
+	require.True(t, terror.ErrBinlogNotLogColumn.Equal(err))
+	require.NotContains(t, err.Error(), "unique key")
+}
+
 func createTableInfo(p *parser.Parser, se sessionctx.Context, tableID int64, sql string) (*model.TableInfo, error) {
 	node, err := p.ParseOneStmt(sql, "utf8mb4", "utf8mb4_bin")
 	if err != nil {
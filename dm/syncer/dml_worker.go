@@ -26,9 +26,14 @@ import (
 	"github.com/pingcap/tiflow/dm/syncer/dbconn"
 	"github.com/pingcap/tiflow/dm/syncer/metrics"
 	"github.com/pingcap/tiflow/pkg/sqlmodel"
+	"go.uber.org/atomic"
 	"go.uber.org/zap"
 )
 
+// defaultConflictFlushTimeout is used when conflict-flush-timeout is not set, it's generous since a conflict job
+// waits for all executing DMLs and the downstream may be slow for a while.
+const defaultConflictFlushTimeout = 10 * time.Minute
+
 // DMLWorker is used to sync dml.
 type DMLWorker struct {
 	compact       bool
@@ -40,6 +45,13 @@ type DMLWorker struct {
 	syncCtx       *tcontext.Context
 	logger        log.Logger
 	metricProxies *metrics.Proxies
+	// verifier checks the order of executed DMLs, it's nil if causality-verify-sample-rate is disabled.
+	verifier *causalityVerifier
+
+	conflictFlushTimeout       time.Duration
+	failOnConflictFlushTimeout bool
+	// conflictPending[i] is true if the i-th DML worker hasn't executed the current conflict job.
+	conflictPending []atomic.Bool
 
 	// for MetricsProxies
 	task   string
@@ -58,18 +70,28 @@ type DMLWorker struct {
 	flushCh chan *job
 }
 
+// dmlQueueBucket returns the index of DML worker which the job with the queue key is dispatched to.
+func dmlQueueBucket(queueKey string, workerCount int) int {
+	return int(utils.GenHashKey(queueKey)) % workerCount
+}
+
 // dmlWorkerWrap creates and runs a dmlWorker instance and returns flush job channel.
 func dmlWorkerWrap(inCh chan *job, syncer *Syncer) chan *job {
 	chanSize := syncer.cfg.QueueSize / 2
 	if syncer.cfg.Compact {
 		chanSize /= 2
 	}
+	conflictFlushTimeout := time.Duration(syncer.cfg.ConflictFlushTimeout) * time.Second
+	if conflictFlushTimeout <= 0 {
+		conflictFlushTimeout = defaultConflictFlushTimeout
+	}
 	dmlWorker := &DMLWorker{
 		compact:              syncer.cfg.Compact,
 		batch:                syncer.cfg.Batch,
 		workerCount:          syncer.cfg.WorkerCount,
 		chanSize:             chanSize,
 		multipleRows:         syncer.cfg.MultipleRows,
+		conflictFlushTimeout: conflictFlushTimeout,
 		task:                 syncer.cfg.Name,
 		source:               syncer.cfg.SourceID,
 		worker:               syncer.cfg.WorkerName,
@@ -80,10 +102,12 @@ func dmlWorkerWrap(inCh chan *job, syncer *Syncer) chan *job {
 		updateJobMetricsFunc: syncer.updateJobMetrics,
 		syncCtx:              syncer.syncCtx, // this ctx can be used to cancel all the workers
 		metricProxies:        syncer.metricsProxies,
+		verifier:             syncer.causalityVerifier,
 		toDBConns:            syncer.toDBConns,
 		inCh:                 inCh,
 		flushCh:              make(chan *job),
 	}
+	dmlWorker.failOnConflictFlushTimeout = syncer.cfg.FailOnConflictFlushTimeout
 
 	go func() {
 		dmlWorker.run()
@@ -100,6 +124,7 @@ func (w *DMLWorker) close() {
 // run distribute jobs by queueBucket.
 func (w *DMLWorker) run() {
 	jobChs := make([]chan *job, w.workerCount)
+	w.conflictPending = make([]atomic.Bool, w.workerCount)
 
 	for i := 0; i < w.workerCount; i++ {
 		jobChs[i] = make(chan *job, w.chanSize)
@@ -117,6 +142,8 @@ func (w *DMLWorker) run() {
 		queueBucketMapping[i] = queueBucketName(i)
 	}
 	for j := range w.inCh {
+		// inCh of DML worker is the output of causality.
+		w.metricProxies.Metrics.CausalityOutputDequeueCounter.Inc()
 		w.metricProxies.QueueSizeGauge.WithLabelValues(w.task, "dml_worker_input", w.source).Set(float64(len(w.inCh)))
 		switch j.tp {
 		case flush:
@@ -131,20 +158,69 @@ func (w *DMLWorker) run() {
 			w.flushCh <- j
 		case conflict:
 			w.updateJobMetricsFunc(false, adminQueueName, j)
-			w.sendJobToAllDmlQueue(j, jobChs, queueBucketMapping)
-			j.flushWg.Wait()
+			if j.conflictWorkers != nil {
+				// a partial conflict job only waits for some DML workers, the others keep executing.
+				for _, i := range j.conflictWorkers {
+					w.conflictPending[i].Store(true)
+				}
+				w.sendJobToDmlQueues(j, j.conflictWorkers, jobChs, queueBucketMapping)
+			} else {
+				for i := range w.conflictPending {
+					w.conflictPending[i].Store(true)
+				}
+				w.sendJobToAllDmlQueue(j, jobChs, queueBucketMapping)
+			}
+			w.waitConflictFlush(j)
 			w.updateJobMetricsFunc(true, adminQueueName, j)
 		default:
-			queueBucket := int(utils.GenHashKey(j.dmlQueueKey)) % w.workerCount
+			queueBucket := dmlQueueBucket(j.dmlQueueKey, w.workerCount)
 			w.updateJobMetricsFunc(false, queueBucketMapping[queueBucket], j)
 			startTime := time.Now()
 			w.logger.Debug("queue for key", zap.Int("queue", queueBucket), zap.String("key", j.dmlQueueKey))
 			jobChs[queueBucket] <- j
+			w.metricProxies.DMLWorkerJobsTotal.WithLabelValues(w.task, queueBucketMapping[queueBucket], w.source).Inc()
 			w.metricProxies.AddJobDurationHistogram.WithLabelValues(j.tp.String(), w.task, queueBucketMapping[queueBucket], w.source).Observe(time.Since(startTime).Seconds())
 		}
 	}
 }
 
+// waitConflictFlush waits until all DML workers execute the conflict job. every conflictFlushTimeout it reports the
+// stuck DML workers, and fails the task on the first report if failOnConflictFlushTimeout is set. it keeps waiting
+// after reporting, because dispatching DMLs before the conflict job is done breaks causality.
+func (w *DMLWorker) waitConflictFlush(j *job) {
+	done := make(chan struct{})
+	go func() {
+		j.flushWg.Wait()
+		close(done)
+	}()
+	ticker := time.NewTicker(w.conflictFlushTimeout)
+	defer ticker.Stop()
+	startTime := time.Now()
+	failed := false
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+		}
+		stuck := make([]string, 0, len(w.conflictPending))
+		for i := range w.conflictPending {
+			if w.conflictPending[i].Load() {
+				stuck = append(stuck, queueBucketName(i))
+			}
+		}
+		waitTime := time.Since(startTime).Round(time.Millisecond)
+		err := terror.ErrSyncerConflictFlushTimeout.Generate(stuck, waitTime)
+		w.metricProxies.Metrics.ConflictFlushTimeoutCounter.Inc()
+		w.logger.Warn("DML workers are not drained by conflict job", zap.Strings("stuck workers", stuck),
+			zap.Duration("wait time", waitTime), log.ShortError(err))
+		if w.failOnConflictFlushTimeout && !failed {
+			failed = true
+			w.fatalFunc(j, err)
+		}
+	}
+}
+
 func (w *DMLWorker) sendJobToAllDmlQueue(j *job, jobChs []chan *job, queueBucketMapping []string) {
 	// flush for every DML queue
 	for i, jobCh := range jobChs {
@@ -154,6 +230,15 @@ func (w *DMLWorker) sendJobToAllDmlQueue(j *job, jobChs []chan *job, queueBucket
 	}
 }
 
+// sendJobToDmlQueues sends the job to the DML queues of workers.
+func (w *DMLWorker) sendJobToDmlQueues(j *job, workers []int, jobChs []chan *job, queueBucketMapping []string) {
+	for _, i := range workers {
+		startTime := time.Now()
+		jobChs[i] <- j
+		w.metricProxies.AddJobDurationHistogram.WithLabelValues(j.tp.String(), w.task, queueBucketMapping[i], w.source).Observe(time.Since(startTime).Seconds())
+	}
+}
+
 // executeJobs execute jobs in same queueBucket
 // All the jobs received should be executed consecutively.
 func (w *DMLWorker) executeJobs(queueID int, jobCh chan *job) {
@@ -181,6 +266,9 @@ func (w *DMLWorker) executeJobs(queueID int, jobCh chan *job) {
 		})
 
 		w.executeBatchJobs(queueID, jobs)
+		if j.tp == conflict {
+			w.conflictPending[queueID].Store(false)
+		}
 		if j.tp == conflict || j.tp == flush || j.tp == asyncFlush {
 			j.flushWg.Done()
 		}
@@ -209,6 +297,7 @@ func (w *DMLWorker) executeBatchJobs(queueID int, jobs []*job) {
 
 	defer func() {
 		if err == nil {
+			w.verifier.verify(queueID, jobs)
 			w.successFunc(queueID, len(dmls), jobs)
 		} else {
 			if len(queries) == len(jobs) {
@@ -15,6 +15,7 @@ package syncer
 
 import (
 	"testing"
+	"time"
 
 	tiddl "github.com/pingcap/tidb/pkg/ddl"
 	timodel "github.com/pingcap/tidb/pkg/meta/model"
@@ -22,11 +23,15 @@ import (
 	"github.com/pingcap/tidb/pkg/parser/ast"
 	timock "github.com/pingcap/tidb/pkg/util/mock"
 	cdcmodel "github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/dm/pkg/log"
+	"github.com/pingcap/tiflow/dm/pkg/terror"
+	"github.com/pingcap/tiflow/dm/syncer/metrics"
 	"github.com/pingcap/tiflow/pkg/sqlmodel"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
 )
 
-func mockTableInfo(t *testing.T, sql string) *timodel.TableInfo {
+func mockTableInfo(t testing.TB, sql string) *timodel.TableInfo {
 	t.Helper()
 
 	p := parser.New()
@@ -128,3 +133,67 @@ func TestJudgeKeyNotFound(t *testing.T) {
 	require.False(t, dmlWorker.judgeKeyNotFound(2, jobs))
 	require.False(t, dmlWorker.judgeKeyNotFound(4, jobs))
 }
+
+func TestWaitConflictFlush(t *testing.T) {
+	fatalCh := make(chan error, 10)
+	w := &DMLWorker{
+		workerCount:                2,
+		conflictFlushTimeout:       10 * time.Millisecond,
+		failOnConflictFlushTimeout: true,
+		conflictPending:            make([]atomic.Bool, 2),
+		logger:                     log.L(),
+		metricProxies:              metrics.DefaultMetricsProxies.CacheForOneTask("task", "worker", "source"),
+		fatalFunc: func(_ *job, err error) {
+			fatalCh <- err
+		},
+	}
+	j := newConflictJob(w.workerCount)
+	w.conflictPending[0].Store(true)
+	w.conflictPending[1].Store(true)
+	done := make(chan struct{})
+	go func() {
+		w.waitConflictFlush(j)
+		close(done)
+	}()
+
+	// the first worker is drained, the second one is stuck.
+	w.conflictPending[0].Store(false)
+	j.flushWg.Done()
+	err := <-fatalCh
+	require.True(t, terror.ErrSyncerConflictFlushTimeout.Equal(err))
+	require.ErrorContains(t, err, "[q_1]")
+
+	// the task only fails once, but the waiting continues until all workers are drained.
+	time.Sleep(50 * time.Millisecond)
+	require.Len(t, fatalCh, 0)
+	select {
+	case <-done:
+		require.FailNow(t, "conflict job is done before all workers are drained")
+	default:
+	}
+	w.conflictPending[1].Store(false)
+	j.flushWg.Done()
+	<-done
+}
+
+func TestSendPartialConflictJob(t *testing.T) {
+	t.Parallel()
+
+	w := &DMLWorker{
+		workerCount:   3,
+		task:          "task",
+		source:        "source",
+		metricProxies: metrics.DefaultMetricsProxies.CacheForOneTask("task", "worker", "source"),
+	}
+	jobChs := []chan *job{make(chan *job, 1), make(chan *job, 1), make(chan *job, 1)}
+	j := newPartialConflictJob([]int{0, 2})
+	w.sendJobToDmlQueues(j, j.conflictWorkers, jobChs, []string{queueBucketName(0), queueBucketName(1), queueBucketName(2)})
+	require.Len(t, jobChs[0], 1)
+	require.Len(t, jobChs[1], 0)
+	require.Len(t, jobChs[2], 1)
+
+	// the conflict job is done after the two workers execute it.
+	j.flushWg.Done()
+	j.flushWg.Done()
+	j.flushWg.Wait()
+}
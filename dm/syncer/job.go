@@ -90,9 +90,20 @@ type job struct {
 	eventHeader *replication.EventHeader
 	jobAddTime  time.Time       // job commit time
 	flushSeq    int64           // sequence number for sync and async flush job
+	inputSeq    int64           // number of jobs sent to the input channel of causality before the flush job
 	flushWg     *sync.WaitGroup // wait group for sync, async and conflict job
 	timestamp   uint32
 	timezone    string
+	// DML workers which a partial conflict job is sent to, nil means the conflict job is sent to all DML workers.
+	conflictWorkers []int
+	// causality keys computed by the router of sharded causality, nil means they are computed by causality.
+	causalityKeys []string
+	// the job is sent to all shards of sharded causality, and it's sent to DML workers once after all shards send it.
+	broadcast bool
+	// the order of the DML decided by causality and its sampled causality keys, they're only set when
+	// causality-verify-sample-rate is enabled and the DML has sampled keys, see causalityVerifier.
+	verifySeq  int64
+	verifyKeys []string
 }
 
 func (j *job) clone() *job {
@@ -237,6 +248,21 @@ func newConflictJob(workerCount int) *job {
 	}
 }
 
+// newPartialConflictJob creates a conflict job which only waits for the DML workers in workers to execute the DMLs
+// dispatched to them before.
+func newPartialConflictJob(workers []int) *job {
+	wg := &sync.WaitGroup{}
+	wg.Add(len(workers))
+
+	return &job{
+		tp:              conflict,
+		targetTable:     &filter.Table{},
+		jobAddTime:      time.Now(),
+		flushWg:         wg,
+		conflictWorkers: workers,
+	}
+}
+
 // newCompactJob is only used for MetricsProxies.
 func newCompactJob(targetTable *filter.Table) *job {
 	return &job{
@@ -31,25 +31,54 @@ const (
 
 // Metrics groups syncer's metric variables.
 type Metrics struct {
-	BinlogReadDurationHistogram      prometheus.Observer
-	BinlogEventSizeHistogram         prometheus.Observer
-	ConflictDetectDurationHistogram  prometheus.Observer
-	IdealQPS                         prometheus.Gauge
-	BinlogMasterPosGauge             prometheus.Gauge
-	BinlogSyncerPosGauge             prometheus.Gauge
-	BinlogMasterFileGauge            prometheus.Gauge
-	BinlogSyncerFileGauge            prometheus.Gauge
-	BinlogEventRowHistogram          prometheus.Observer
-	TxnHistogram                     prometheus.Observer
-	QueryHistogram                   prometheus.Observer
-	ExitWithResumableErrorCounter    prometheus.Counter
-	ExitWithNonResumableErrorCounter prometheus.Counter
-	ReplicationLagGauge              prometheus.Gauge
-	ReplicationLagHistogram          prometheus.Observer
-	RemainingTimeGauge               prometheus.Gauge
-	ShardLockResolving               prometheus.Gauge
-	FinishedTransactionTotal         prometheus.Counter
-	FlushCheckPointsTimeInterval     prometheus.Observer
+	BinlogReadDurationHistogram       prometheus.Observer
+	BinlogEventSizeHistogram          prometheus.Observer
+	ConflictDetectDurationHistogram   prometheus.Observer
+	FlushDetectDurationHistogram      prometheus.Observer
+	AsyncFlushDetectDurationHistogram prometheus.Observer
+	GCDetectDurationHistogram         prometheus.Observer
+	IdealQPS                          prometheus.Gauge
+	BinlogMasterPosGauge              prometheus.Gauge
+	BinlogSyncerPosGauge              prometheus.Gauge
+	BinlogMasterFileGauge             prometheus.Gauge
+	BinlogSyncerFileGauge             prometheus.Gauge
+	BinlogEventRowHistogram           prometheus.Observer
+	TxnHistogram                      prometheus.Observer
+	QueryHistogram                    prometheus.Observer
+	ExitWithResumableErrorCounter     prometheus.Counter
+	ExitWithNonResumableErrorCounter  prometheus.Counter
+	ReplicationLagGauge               prometheus.Gauge
+	ReplicationLagHistogram           prometheus.Observer
+	RemainingTimeGauge                prometheus.Gauge
+	ShardLockResolving                prometheus.Gauge
+	FinishedTransactionTotal          prometheus.Counter
+	FlushCheckPointsTimeInterval      prometheus.Observer
+	CausalityRelationSizeGauge        prometheus.Gauge
+	CausalityRelationGroupsGauge      prometheus.Gauge
+	CausalityRelationNewestKeysGauge  prometheus.Gauge
+	CausalityRelationOldestSeqGauge   prometheus.Gauge
+	CausalityRelationBytesGauge       prometheus.Gauge
+	CausalityGCReclaimedKeysCounter   prometheus.Counter
+	CausalityGCReclaimedGroupsGauge   prometheus.Gauge
+	CausalityForcedFlushCounter       prometheus.Counter
+	CausalityIdleClearCounter         prometheus.Counter
+	CausalityGroupMergeCounter        prometheus.Counter
+	ConflictFlushTimeoutCounter       prometheus.Counter
+	CausalityViolationCounter         prometheus.Counter
+	CausalitySkippedConflictCounter   prometheus.Counter
+	CausalitySavedConflictCounter     prometheus.Counter
+	CausalityPartialConflictCounter   prometheus.Counter
+	CausalityCrossShardFlushCounter   prometheus.Counter
+	CausalityGroupAgeFlushCounter     prometheus.Counter
+	CausalityTapDroppedCounter        prometheus.Counter
+	CausalityVerifyWarningCounter     prometheus.Counter
+	CausalitySuppressedFlushCounter   prometheus.Counter
+	CausalityOutBlockedHistogram      prometheus.Observer
+	CausalityInvalidJobCounter        prometheus.Counter
+	CausalityInputEnqueueCounter      prometheus.Counter
+	CausalityInputDequeueCounter      prometheus.Counter
+	CausalityOutputEnqueueCounter     prometheus.Counter
+	CausalityOutputDequeueCounter     prometheus.Counter
 }
 
 // Proxies provides the ability to clean Metrics values when syncer is closed.
@@ -84,6 +113,32 @@ type Proxies struct {
 	finishedTransactionTotal        *prometheus.CounterVec
 	ReplicationTransactionBatch     *prometheus.HistogramVec
 	flushCheckPointsTimeInterval    *prometheus.HistogramVec
+	causalityRelationSize           *prometheus.GaugeVec
+	causalityRelationGroups         *prometheus.GaugeVec
+	causalityRelationNewestKeys     *prometheus.GaugeVec
+	causalityRelationOldestSeq      *prometheus.GaugeVec
+	causalityRelationBytes          *prometheus.GaugeVec
+	causalityGCReclaimedKeysTotal   *prometheus.CounterVec
+	causalityGCReclaimedGroups      *prometheus.GaugeVec
+	causalityForcedFlushTotal       *prometheus.CounterVec
+	causalityIdleClearTotal         *prometheus.CounterVec
+	causalityGroupMergeTotal        *prometheus.CounterVec
+	conflictFlushTimeoutTotal       *prometheus.CounterVec
+	causalityViolationTotal         *prometheus.CounterVec
+	CausalityConflictTotal          *prometheus.CounterVec
+	causalitySkippedConflictTotal   *prometheus.CounterVec
+	causalitySavedConflictTotal     *prometheus.CounterVec
+	causalityPartialConflictTotal   *prometheus.CounterVec
+	causalityCrossShardFlushTotal   *prometheus.CounterVec
+	causalityGroupAgeFlushTotal     *prometheus.CounterVec
+	causalityTapDroppedTotal        *prometheus.CounterVec
+	causalityVerifyWarningTotal     *prometheus.CounterVec
+	causalitySuppressedFlushTotal   *prometheus.CounterVec
+	causalityOutBlockedDuration     *prometheus.HistogramVec
+	causalityInvalidJobTotal        *prometheus.CounterVec
+	causalityQueueJobsTotal         *prometheus.CounterVec
+	CausalityDryRunDMLTotal         *prometheus.CounterVec
+	DMLWorkerJobsTotal              *prometheus.CounterVec
 }
 
 var DefaultMetricsProxies *Proxies
@@ -124,9 +179,9 @@ func (m *Proxies) Init(f promutil.Factory) {
 			Namespace: "dm",
 			Subsystem: "syncer",
 			Name:      "conflict_detect_duration",
-			Help:      "bucketed histogram of conflict detect time (s) for single DML statement",
+			Help:      "bucketed histogram of conflict detect time (s) for single job of causality by job type",
 			Buckets:   prometheus.ExponentialBuckets(0.000005, 2, 25),
-		}, []string{"task", "source_id"})
+		}, []string{"type", "task", "source_id"})
 	m.AddJobDurationHistogram = f.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Namespace: "dm",
@@ -291,6 +346,189 @@ func (m *Proxies) Init(f promutil.Factory) {
 			Help:      "checkpoint flushed time interval in seconds",
 			Buckets:   prometheus.LinearBuckets(1, 50, 21), // linear from 1 to 1001, i think this is enough
 		}, []string{"worker", "task", "source_id"})
+	m.causalityRelationSize = f.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "dm",
+			Subsystem: "syncer",
+			Name:      "causality_relation_size",
+			Help:      "number of keys stored in the causality relation",
+		}, []string{"task", "source_id"})
+	m.causalityRelationGroups = f.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "dm",
+			Subsystem: "syncer",
+			Name:      "causality_relation_groups",
+			Help:      "number of groups in the causality relation which are waiting for gc",
+		}, []string{"task", "source_id"})
+	m.causalityRelationNewestKeys = f.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "dm",
+			Subsystem: "syncer",
+			Name:      "causality_relation_newest_group_keys",
+			Help:      "number of keys in the newest group of the causality relation",
+		}, []string{"task", "source_id"})
+	m.causalityRelationOldestSeq = f.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "dm",
+			Subsystem: "syncer",
+			Name:      "causality_relation_oldest_flush_seq",
+			Help:      "prevFlushJobSeq of the oldest group retained in the causality relation",
+		}, []string{"task", "source_id"})
+	m.causalityRelationBytes = f.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "dm",
+			Subsystem: "syncer",
+			Name:      "causality_relation_estimated_bytes",
+			Help:      "estimated memory in bytes used by the causality relation",
+		}, []string{"task", "source_id"})
+	m.causalityGCReclaimedKeysTotal = f.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "dm",
+			Subsystem: "syncer",
+			Name:      "causality_gc_reclaimed_keys_total",
+			Help:      "total number of keys reclaimed from the causality relation by gc and merging groups",
+		}, []string{"task", "source_id"})
+	m.causalityGCReclaimedGroups = f.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "dm",
+			Subsystem: "syncer",
+			Name:      "causality_gc_reclaimed_groups",
+			Help:      "number of groups reclaimed from the causality relation by the last gc",
+		}, []string{"task", "source_id"})
+	m.causalityForcedFlushTotal = f.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "dm",
+			Subsystem: "syncer",
+			Name:      "causality_forced_flush_total",
+			Help:      "total number of conflict jobs forced by the causality relation exceeding max-causality-keys",
+		}, []string{"task", "source_id"})
+	m.causalityIdleClearTotal = f.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "dm",
+			Subsystem: "syncer",
+			Name:      "causality_idle_clear_total",
+			Help:      "total number of times the causality relation is cleared because no job comes in causality-idle-interval",
+		}, []string{"task", "source_id"})
+	m.causalityGroupMergeTotal = f.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "dm",
+			Subsystem: "syncer",
+			Name:      "causality_group_merge_total",
+			Help:      "total number of merges of the oldest causality relation groups because of exceeding max-causality-groups",
+		}, []string{"task", "source_id"})
+	m.conflictFlushTimeoutTotal = f.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "dm",
+			Subsystem: "syncer",
+			Name:      "conflict_flush_timeout_total",
+			Help:      "total number of times DML workers are not drained by a conflict job in conflict-flush-timeout",
+		}, []string{"task", "source_id"})
+	m.causalityViolationTotal = f.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "dm",
+			Subsystem: "syncer",
+			Name:      "causality_self_check_violation_total",
+			Help:      "total number of violated invariants found by the self-check of causality relation",
+		}, []string{"task", "source_id"})
+	m.CausalityConflictTotal = f.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "dm",
+			Subsystem: "syncer",
+			Name:      "causality_conflict_total",
+			Help:      "total number of conflict jobs generated by causality for each source table",
+		}, []string{"task", "source_id", "source_schema", "source_table"})
+	m.causalitySkippedConflictTotal = f.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "dm",
+			Subsystem: "syncer",
+			Name:      "causality_skipped_conflict_total",
+			Help:      "total number of conflict jobs skipped because DML workers are already drained",
+		}, []string{"task", "source_id"})
+	m.causalitySavedConflictTotal = f.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "dm",
+			Subsystem: "syncer",
+			Name:      "causality_saved_conflict_total",
+			Help:      "total number of conflict jobs saved by holding conflicting jobs in the conflict window of causality",
+		}, []string{"task", "source_id"})
+	m.causalityPartialConflictTotal = f.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "dm",
+			Subsystem: "syncer",
+			Name:      "causality_partial_conflict_total",
+			Help:      "total number of conflicts resolved by only draining the DML workers of the conflicting relations",
+		}, []string{"task", "source_id"})
+	m.causalityCrossShardFlushTotal = f.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "dm",
+			Subsystem: "syncer",
+			Name:      "causality_cross_shard_flush_total",
+			Help:      "total number of flushes of all DML workers because the keys of a DML are owned by different causality shards",
+		}, []string{"task", "source_id"})
+	m.causalityGroupAgeFlushTotal = f.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "dm",
+			Subsystem: "syncer",
+			Name:      "causality_group_age_flush_total",
+			Help:      "total number of flushes of all DML workers because causality relation has groups older than max-causality-group-age",
+		}, []string{"task", "source_id"})
+	m.causalityTapDroppedTotal = f.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "dm",
+			Subsystem: "syncer",
+			Name:      "causality_decision_tap_dropped_total",
+			Help:      "total number of causality decisions dropped because the tap of external observers is full",
+		}, []string{"task", "source_id"})
+	m.causalityVerifyWarningTotal = f.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "dm",
+			Subsystem: "syncer",
+			Name:      "causality_consistency_warning_total",
+			Help:      "total number of sampled DMLs executed out of the order decided by causality, found by causality-verify-sample-rate",
+		}, []string{"task", "source_id"})
+	m.causalitySuppressedFlushTotal = f.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "dm",
+			Subsystem: "syncer",
+			Name:      "causality_suppressed_flush_total",
+			Help:      "total number of causality conflicts whose flush is suppressed by the conflict resolver",
+		}, []string{"task", "source_id"})
+	m.causalityOutBlockedDuration = f.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "dm",
+			Subsystem: "syncer",
+			Name:      "causality_output_blocked_duration",
+			Help:      "bucketed histogram of the time (s) causality is blocked on sending a job to DML workers, only blocked sends are observed",
+			Buckets:   prometheus.ExponentialBuckets(0.0001, 2, 22),
+		}, []string{"task", "source_id"})
+	m.causalityInvalidJobTotal = f.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "dm",
+			Subsystem: "syncer",
+			Name:      "causality_invalid_job_total",
+			Help:      "total number of invalid jobs dropped by causality, such as DML jobs without row change",
+		}, []string{"task", "source_id"})
+	m.causalityQueueJobsTotal = f.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "dm",
+			Subsystem: "syncer",
+			Name:      "causality_queue_jobs_total",
+			Help:      "total number of jobs enqueued to or dequeued from the input and output queues of causality",
+		}, []string{"task", "queue_id", "op", "source_id"})
+	m.CausalityDryRunDMLTotal = f.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "dm",
+			Subsystem: "syncer",
+			Name:      "causality_dry_run_dml_total",
+			Help:      "total number of DMLs checked by causality in dry-run mode for each source table",
+		}, []string{"task", "source_id", "source_schema", "source_table"})
+	m.DMLWorkerJobsTotal = f.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "dm",
+			Subsystem: "syncer",
+			Name:      "dml_worker_jobs_total",
+			Help:      "total number of DML jobs dispatched to each DML worker",
+		}, []string{"task", "queueNo", "source_id"})
 }
 
 // CacheForOneTask returns a new Proxies with m.Metrics filled. It is used
@@ -300,7 +538,10 @@ func (m *Proxies) CacheForOneTask(taskName, workerName, sourceID string) *Proxie
 	ret.Metrics = &Metrics{}
 	ret.Metrics.BinlogReadDurationHistogram = m.binlogReadDurationHistogram.WithLabelValues(taskName, sourceID)
 	ret.Metrics.BinlogEventSizeHistogram = m.binlogEventSizeHistogram.WithLabelValues(taskName, workerName, sourceID)
-	ret.Metrics.ConflictDetectDurationHistogram = m.conflictDetectDurationHistogram.WithLabelValues(taskName, sourceID)
+	ret.Metrics.ConflictDetectDurationHistogram = m.conflictDetectDurationHistogram.WithLabelValues("dml", taskName, sourceID)
+	ret.Metrics.FlushDetectDurationHistogram = m.conflictDetectDurationHistogram.WithLabelValues("flush", taskName, sourceID)
+	ret.Metrics.AsyncFlushDetectDurationHistogram = m.conflictDetectDurationHistogram.WithLabelValues("asyncFlush", taskName, sourceID)
+	ret.Metrics.GCDetectDurationHistogram = m.conflictDetectDurationHistogram.WithLabelValues("gc", taskName, sourceID)
 	ret.Metrics.IdealQPS = m.idealQPS.WithLabelValues(taskName, workerName, sourceID)
 	ret.Metrics.BinlogMasterPosGauge = m.binlogPosGauge.WithLabelValues("master", taskName, sourceID)
 	ret.Metrics.BinlogSyncerPosGauge = m.binlogPosGauge.WithLabelValues("syncer", taskName, sourceID)
@@ -317,6 +558,32 @@ func (m *Proxies) CacheForOneTask(taskName, workerName, sourceID string) *Proxie
 	ret.Metrics.ShardLockResolving = m.shardLockResolving.WithLabelValues(taskName, sourceID)
 	ret.Metrics.FinishedTransactionTotal = m.finishedTransactionTotal.WithLabelValues(taskName, workerName, sourceID)
 	ret.Metrics.FlushCheckPointsTimeInterval = m.flushCheckPointsTimeInterval.WithLabelValues(workerName, taskName, sourceID)
+	ret.Metrics.CausalityRelationSizeGauge = m.causalityRelationSize.WithLabelValues(taskName, sourceID)
+	ret.Metrics.CausalityRelationGroupsGauge = m.causalityRelationGroups.WithLabelValues(taskName, sourceID)
+	ret.Metrics.CausalityRelationNewestKeysGauge = m.causalityRelationNewestKeys.WithLabelValues(taskName, sourceID)
+	ret.Metrics.CausalityRelationOldestSeqGauge = m.causalityRelationOldestSeq.WithLabelValues(taskName, sourceID)
+	ret.Metrics.CausalityRelationBytesGauge = m.causalityRelationBytes.WithLabelValues(taskName, sourceID)
+	ret.Metrics.CausalityGCReclaimedKeysCounter = m.causalityGCReclaimedKeysTotal.WithLabelValues(taskName, sourceID)
+	ret.Metrics.CausalityGCReclaimedGroupsGauge = m.causalityGCReclaimedGroups.WithLabelValues(taskName, sourceID)
+	ret.Metrics.CausalityForcedFlushCounter = m.causalityForcedFlushTotal.WithLabelValues(taskName, sourceID)
+	ret.Metrics.CausalityIdleClearCounter = m.causalityIdleClearTotal.WithLabelValues(taskName, sourceID)
+	ret.Metrics.CausalityGroupMergeCounter = m.causalityGroupMergeTotal.WithLabelValues(taskName, sourceID)
+	ret.Metrics.ConflictFlushTimeoutCounter = m.conflictFlushTimeoutTotal.WithLabelValues(taskName, sourceID)
+	ret.Metrics.CausalityViolationCounter = m.causalityViolationTotal.WithLabelValues(taskName, sourceID)
+	ret.Metrics.CausalitySkippedConflictCounter = m.causalitySkippedConflictTotal.WithLabelValues(taskName, sourceID)
+	ret.Metrics.CausalitySavedConflictCounter = m.causalitySavedConflictTotal.WithLabelValues(taskName, sourceID)
+	ret.Metrics.CausalityPartialConflictCounter = m.causalityPartialConflictTotal.WithLabelValues(taskName, sourceID)
+	ret.Metrics.CausalityCrossShardFlushCounter = m.causalityCrossShardFlushTotal.WithLabelValues(taskName, sourceID)
+	ret.Metrics.CausalityGroupAgeFlushCounter = m.causalityGroupAgeFlushTotal.WithLabelValues(taskName, sourceID)
+	ret.Metrics.CausalityTapDroppedCounter = m.causalityTapDroppedTotal.WithLabelValues(taskName, sourceID)
+	ret.Metrics.CausalityVerifyWarningCounter = m.causalityVerifyWarningTotal.WithLabelValues(taskName, sourceID)
+	ret.Metrics.CausalitySuppressedFlushCounter = m.causalitySuppressedFlushTotal.WithLabelValues(taskName, sourceID)
+	ret.Metrics.CausalityOutBlockedHistogram = m.causalityOutBlockedDuration.WithLabelValues(taskName, sourceID)
+	ret.Metrics.CausalityInvalidJobCounter = m.causalityInvalidJobTotal.WithLabelValues(taskName, sourceID)
+	ret.Metrics.CausalityInputEnqueueCounter = m.causalityQueueJobsTotal.WithLabelValues(taskName, "causality_input", "enqueue", sourceID)
+	ret.Metrics.CausalityInputDequeueCounter = m.causalityQueueJobsTotal.WithLabelValues(taskName, "causality_input", "dequeue", sourceID)
+	ret.Metrics.CausalityOutputEnqueueCounter = m.causalityQueueJobsTotal.WithLabelValues(taskName, "causality_output", "enqueue", sourceID)
+	ret.Metrics.CausalityOutputDequeueCounter = m.causalityQueueJobsTotal.WithLabelValues(taskName, "causality_output", "dequeue", sourceID)
 	return &ret
 }
 
@@ -348,6 +615,32 @@ func (m *Proxies) RegisterMetrics(registry *prometheus.Registry) {
 	registry.MustRegister(m.finishedTransactionTotal)
 	registry.MustRegister(m.ReplicationTransactionBatch)
 	registry.MustRegister(m.flushCheckPointsTimeInterval)
+	registry.MustRegister(m.causalityRelationSize)
+	registry.MustRegister(m.causalityRelationGroups)
+	registry.MustRegister(m.causalityRelationNewestKeys)
+	registry.MustRegister(m.causalityRelationOldestSeq)
+	registry.MustRegister(m.causalityRelationBytes)
+	registry.MustRegister(m.causalityGCReclaimedKeysTotal)
+	registry.MustRegister(m.causalityGCReclaimedGroups)
+	registry.MustRegister(m.causalityForcedFlushTotal)
+	registry.MustRegister(m.causalityIdleClearTotal)
+	registry.MustRegister(m.causalityGroupMergeTotal)
+	registry.MustRegister(m.conflictFlushTimeoutTotal)
+	registry.MustRegister(m.causalityViolationTotal)
+	registry.MustRegister(m.CausalityConflictTotal)
+	registry.MustRegister(m.causalitySkippedConflictTotal)
+	registry.MustRegister(m.causalitySavedConflictTotal)
+	registry.MustRegister(m.causalityPartialConflictTotal)
+	registry.MustRegister(m.causalityCrossShardFlushTotal)
+	registry.MustRegister(m.causalityGroupAgeFlushTotal)
+	registry.MustRegister(m.causalityTapDroppedTotal)
+	registry.MustRegister(m.causalityVerifyWarningTotal)
+	registry.MustRegister(m.causalitySuppressedFlushTotal)
+	registry.MustRegister(m.causalityOutBlockedDuration)
+	registry.MustRegister(m.causalityInvalidJobTotal)
+	registry.MustRegister(m.causalityQueueJobsTotal)
+	registry.MustRegister(m.CausalityDryRunDMLTotal)
+	registry.MustRegister(m.DMLWorkerJobsTotal)
 }
 
 // RemoveLabelValuesWithTaskInMetrics cleans all Metrics related to the task.
@@ -378,4 +671,30 @@ func (m *Proxies) RemoveLabelValuesWithTaskInMetrics(task string) {
 	m.finishedTransactionTotal.DeletePartialMatch(prometheus.Labels{"task": task})
 	m.ReplicationTransactionBatch.DeletePartialMatch(prometheus.Labels{"task": task})
 	m.flushCheckPointsTimeInterval.DeletePartialMatch(prometheus.Labels{"task": task})
+	m.causalityRelationSize.DeletePartialMatch(prometheus.Labels{"task": task})
+	m.causalityRelationGroups.DeletePartialMatch(prometheus.Labels{"task": task})
+	m.causalityRelationNewestKeys.DeletePartialMatch(prometheus.Labels{"task": task})
+	m.causalityRelationOldestSeq.DeletePartialMatch(prometheus.Labels{"task": task})
+	m.causalityRelationBytes.DeletePartialMatch(prometheus.Labels{"task": task})
+	m.causalityGCReclaimedKeysTotal.DeletePartialMatch(prometheus.Labels{"task": task})
+	m.causalityGCReclaimedGroups.DeletePartialMatch(prometheus.Labels{"task": task})
+	m.causalityForcedFlushTotal.DeletePartialMatch(prometheus.Labels{"task": task})
+	m.causalityIdleClearTotal.DeletePartialMatch(prometheus.Labels{"task": task})
+	m.causalityGroupMergeTotal.DeletePartialMatch(prometheus.Labels{"task": task})
+	m.conflictFlushTimeoutTotal.DeletePartialMatch(prometheus.Labels{"task": task})
+	m.causalityViolationTotal.DeletePartialMatch(prometheus.Labels{"task": task})
+	m.CausalityConflictTotal.DeletePartialMatch(prometheus.Labels{"task": task})
+	m.causalitySkippedConflictTotal.DeletePartialMatch(prometheus.Labels{"task": task})
+	m.causalitySavedConflictTotal.DeletePartialMatch(prometheus.Labels{"task": task})
+	m.causalityPartialConflictTotal.DeletePartialMatch(prometheus.Labels{"task": task})
+	m.causalityCrossShardFlushTotal.DeletePartialMatch(prometheus.Labels{"task": task})
+	m.causalityGroupAgeFlushTotal.DeletePartialMatch(prometheus.Labels{"task": task})
+	m.causalityTapDroppedTotal.DeletePartialMatch(prometheus.Labels{"task": task})
+	m.causalityVerifyWarningTotal.DeletePartialMatch(prometheus.Labels{"task": task})
+	m.causalitySuppressedFlushTotal.DeletePartialMatch(prometheus.Labels{"task": task})
+	m.causalityOutBlockedDuration.DeletePartialMatch(prometheus.Labels{"task": task})
+	m.causalityInvalidJobTotal.DeletePartialMatch(prometheus.Labels{"task": task})
+	m.causalityQueueJobsTotal.DeletePartialMatch(prometheus.Labels{"task": task})
+	m.CausalityDryRunDMLTotal.DeletePartialMatch(prometheus.Labels{"task": task})
+	m.DMLWorkerJobsTotal.DeletePartialMatch(prometheus.Labels{"task": task})
 }
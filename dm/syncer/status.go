@@ -46,6 +46,15 @@ func (s *Syncer) Status(sourceStatus *binlog.SourceStatus) interface{} {
 		st.DumpIOTotalBytes = s.cfg.DumpIOTotalBytes.Load()
 	}
 
+	if stats := s.causalityStats.Load(); stats != nil {
+		st.Causality = &pb.CausalityStatus{
+			ConflictsPerSecond: stats.ConflictsPerSecond,
+			RelationSize:       stats.RelationSize,
+			WorkerSkew:         stats.WorkerSkew,
+			TotalConflicts:     stats.TotalConflicts,
+		}
+	}
+
 	if syncerLocation.GetGTID() != nil {
 		st.SyncerBinlogGtid = syncerLocation.GetGTID().String()
 	}
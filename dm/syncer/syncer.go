@@ -166,6 +166,8 @@ type Syncer struct {
 	baList          *filter.Filter
 	exprFilterGroup *ExprFilterGroup
 	sessCtx         sessionctx.Context
+	// target table ID -> lower-case names of indexes used to generate causality keys
+	causalityIndexes map[string]map[string]struct{}
 
 	running atomic.Bool
 	closed  atomic.Bool
@@ -257,6 +259,44 @@ type Syncer struct {
 	idAndCollationMap          map[int]string
 
 	ddlWorker *DDLWorker
+
+	// used to request a snapshot of causality relation for debugging.
+	causalityDumpCh chan chan []causalityRelationGroupDump
+	// used to request clearing causality relation by a conflict job.
+	causalityClearCh chan chan *sync.WaitGroup
+	// sends flush and asyncFlush jobs to causality ahead of the buffered jobs in dmlJobCh, it's nil if
+	// prioritize-causality-flush is disabled or compact is enabled.
+	causalityFlushCh chan *job
+	// closed when causality exits, it's nil if causalityFlushCh is nil.
+	causalityDoneCh chan struct{}
+	// the number of jobs sent to dmlJobCh when causalityFlushCh is used, it's recorded as inputSeq of flush jobs.
+	causalityInputSeq atomic.Int64
+	// gc jobs are sent by checkpointFlushWorker concurrently with the binlog loop, so sending a job to dmlJobCh and
+	// counting it in causalityInputSeq are done under it when causalityFlushCh is used, otherwise a job may be
+	// counted before a job which is ahead of it in dmlJobCh.
+	causalityInputLock sync.Mutex
+	// used to request a snapshot of the latest causality decisions for replay debugging.
+	causalityDecisionDumpCh chan chan []causalityDecision
+	// receives a copy of each causality decision for external observers if it's not nil, a decision is dropped
+	// when it's full, see causalityDecisionLog.tap. it must be set before causality runs, and it's ignored by sharded
+	// causality.
+	causalityDecisionTap chan<- causalityDecision
+	// decides whether a causality conflict flushes DML workers, nil means always. see CausalityConflictResolver.
+	causalityConflictResolver CausalityConflictResolver
+	// verifies the order of DMLs decided by causality against their execution, it's nil if
+	// causality-verify-sample-rate is disabled. it's created by causality for every run.
+	causalityVerifier *causalityVerifier
+	// the relation of the running causality, only its approxLen can be used by other goroutines.
+	causalityRelation atomic.Pointer[causalityRelation]
+	// the latest stats published by causality, it's nil before the first stats are published.
+	causalityStats atomic.Pointer[causalityStats]
+	// persists the causality relation, it's nil if persist-causality-relation is disabled or causality is sharded.
+	causalityRelationStore *causalityRelationStore
+	// receives the causality relation snapshots to be persisted by persistCausalityRelation, it's closed when
+	// causality exits. it's nil if causalityRelationStore is nil.
+	causalityRelationSnapshotCh chan *causalityRelationSnapshot
+	// the persisted causality relation loaded by loadCausalityRelation for the next run of causality.
+	causalityRestoredRelation map[string][]string
 }
 
 // NewSyncer creates a new Syncer.
@@ -288,6 +328,9 @@ func NewSyncer(cfg *config.SubTaskConfig, etcdClient *clientv3.Client, relay rel
 	syncer.count.Store(0)
 	syncer.handleJobFunc = syncer.handleJob
 	syncer.cli = etcdClient
+	syncer.causalityDumpCh = make(chan chan []causalityRelationGroupDump)
+	syncer.causalityClearCh = make(chan chan *sync.WaitGroup)
+	syncer.causalityDecisionDumpCh = make(chan chan []causalityDecision)
 
 	syncer.checkpoint = NewRemoteCheckPoint(syncer.tctx, cfg, syncer.metricsProxies, syncer.checkpointID())
 
@@ -330,6 +373,19 @@ func (s *Syncer) newJobChans() {
 	chanSize := calculateChanSize(s.cfg.QueueSize, s.cfg.WorkerCount, s.cfg.Compact)
 	s.dmlJobCh = make(chan *job, chanSize)
 	s.ddlJobCh = make(chan *job, s.cfg.QueueSize)
+	s.causalityFlushCh = nil
+	s.causalityDoneCh = nil
+	s.causalityInputSeq.Store(0)
+	// compactor must flush its buffer before the flush job, so the flush job goes through it. so does the router of
+	// sharded causality, which only receives jobs from dmlJobCh.
+	if s.cfg.PrioritizeCausalityFlush && !s.cfg.Compact && s.cfg.ExperimentalCausalityShards <= 1 {
+		s.causalityFlushCh = make(chan *job, causalityFlushChanSize)
+		s.causalityDoneCh = make(chan struct{})
+	}
+	s.causalityRelationSnapshotCh = nil
+	if s.causalityRelationStore != nil {
+		s.causalityRelationSnapshotCh = make(chan *causalityRelationSnapshot, 1)
+	}
 	s.jobsClosed.Store(false)
 }
 
@@ -480,6 +536,13 @@ func (s *Syncer) Init(ctx context.Context) (err error) {
 	if err != nil {
 		return err
 	}
+	// sharded causality has no single relation to persist.
+	if s.cfg.PersistCausalityRelation && s.cfg.ExperimentalCausalityShards <= 1 {
+		s.causalityRelationStore = newCausalityRelationStore(s.cfg, s.toDB)
+		if err = s.causalityRelationStore.init(tctx); err != nil {
+			return err
+		}
+	}
 	if s.SourceTableNamesFlavor == conn.LCTableNamesSensitive {
 		if err = s.checkpoint.CheckAndUpdate(ctx, schemaMap, tableMap); err != nil {
 			return err
@@ -1061,10 +1124,10 @@ func (s *Syncer) addJob(job *job) {
 	switch tp {
 	case flush:
 		s.jobWg.Add(1)
-		s.dmlJobCh <- job
+		s.sendDMLJob(job)
 	case asyncFlush:
 		s.jobWg.Add(1)
-		s.dmlJobCh <- job
+		s.sendDMLJob(job)
 	case ddl:
 		s.updateJobMetrics(false, adminQueueName, job)
 		s.jobWg.Add(1)
@@ -1080,19 +1143,67 @@ func (s *Syncer) addJob(job *job) {
 				failpoint.Goto("skip_dml")
 			}
 		})
-		s.dmlJobCh <- job
+		s.sendDMLJob(job)
 		failpoint.Label("skip_dml")
 		failpoint.Inject("checkCheckpointInMiddleOfTransaction", func() {
 			s.tctx.L().Info("receive dml job", zap.Any("dml job", job))
 			time.Sleep(500 * time.Millisecond)
 		})
 	case gc:
-		s.dmlJobCh <- job
+		s.sendDMLJob(job)
 	default:
 		s.tctx.L().DPanic("unhandled job type", zap.Stringer("job", job))
 	}
 }
 
+// causalityFlushChanSize is the buffer size of causalityFlushCh, the producer of jobs only waits when so many flush
+// jobs are not received by causality yet.
+const causalityFlushChanSize = 16
+
+// sendDMLJob sends a job to dmlJobCh, which is the input of causality when compactor is disabled.
+// flush and asyncFlush jobs are sent to causalityFlushCh if it's not nil, causality handles them after the
+// jobs sent to dmlJobCh before them. it's called by the binlog loop, and by checkpointFlushWorker for gc jobs.
+func (s *Syncer) sendDMLJob(j *job) {
+	if s.causalityFlushCh != nil && (j.tp == flush || j.tp == asyncFlush) {
+		j.inputSeq = s.causalityInputSeq.Load()
+		// causality keeps receiving flush jobs after the sync ctx is done until dmlJobCh is closed, so only its exit
+		// stops the sending. the exit is checked first, the buffer of causalityFlushCh may still have space.
+		select {
+		case <-s.causalityDoneCh:
+		default:
+			select {
+			case s.causalityFlushCh <- j:
+				return
+			case <-s.causalityDoneCh:
+			}
+		}
+		// all jobs sent before are handled when causality exits, release the flush job as DML workers do, so its
+		// waiters are not blocked forever.
+		s.tctx.L().Warn("causality has exited, skip the flush job", zap.Stringer("job", j))
+		if j.tp == asyncFlush {
+			for i := 0; i < s.cfg.WorkerCount; i++ {
+				j.flushWg.Done()
+			}
+		}
+		s.jobWg.Done()
+		return
+	}
+	if s.causalityFlushCh != nil {
+		s.causalityInputLock.Lock()
+		defer s.causalityInputLock.Unlock()
+	}
+	s.dmlJobCh <- j
+	failpoint.Inject("SlowDownCountingGCJob", func() {
+		if j.tp == gc {
+			time.Sleep(time.Millisecond)
+		}
+	})
+	if !s.cfg.Compact {
+		s.causalityInputSeq.Inc()
+		s.metricsProxies.Metrics.CausalityInputEnqueueCounter.Inc()
+	}
+}
+
 // flushIfOutdated checks whether syncer should flush now because last flushing is outdated.
 func (s *Syncer) flushIfOutdated() error {
 	if !s.checkpoint.LastFlushOutdated() {
@@ -1149,6 +1260,11 @@ func (s *Syncer) handleJob(job *job) (added2Queue bool, err error) {
 			panic("SkipSaveGlobalPoint")
 		})
 		s.waitXIDJob.CAS(int64(waiting), int64(waitComplete))
+		// sharded causality ignores atomic-txn-causality, like compactor which merges DMLs across transactions.
+		if s.cfg.AtomicTxnCausality && !s.cfg.Compact && s.cfg.ExperimentalCausalityShards <= 1 {
+			// tell causality the DMLs of the transaction are all sent.
+			s.sendDMLJob(job)
+		}
 		s.saveGlobalPoint(job.location)
 		s.isTransactionEnd = true
 		// nolint:nakedret
@@ -1365,13 +1481,17 @@ func (s *Syncer) createCheckpointSnapshot(isSyncFlush bool) (*SnapshotInfo, []*f
 
 func (s *Syncer) afterFlushCheckpoint(task *checkpointFlushTask) error {
 	// add a gc job to let causality module gc outdated kvs.
+	var gcJob *job
 	if task.asyncflushJob != nil {
 		s.tctx.L().Info("after async flushed checkpoint, gc stale causality keys", zap.Int64("flush job seq", task.asyncflushJob.flushSeq))
-		s.addJob(newGCJob(task.asyncflushJob.flushSeq))
+		gcJob = newGCJob(task.asyncflushJob.flushSeq)
 	} else {
 		s.tctx.L().Info("after sync flushed checkpoint, gc all causality keys")
-		s.addJob(newGCJob(math.MaxInt64))
+		gcJob = newGCJob(math.MaxInt64)
 	}
+	// the relation after the gc job is persisted at the flushed checkpoint, see sendRelationSnapshot.
+	gcJob.currentLocation = task.snapshotInfo.globalPos
+	s.addJob(gcJob)
 
 	// update current active relay log after checkpoint flushed
 	err := s.updateActiveRelayLog(task.snapshotInfo.globalPos.Position)
@@ -1621,7 +1741,7 @@ func (s *Syncer) syncDML() {
 	if s.cfg.Compact {
 		dmlJobCh = compactorWrap(dmlJobCh, s)
 	}
-	causalityCh := causalityWrap(dmlJobCh, s)
+	causalityCh := causalityWrap(s.syncCtx.Ctx, dmlJobCh, s)
 	flushCh := dmlWorkerWrap(causalityCh, s)
 
 	for range flushCh {
@@ -1809,8 +1929,14 @@ func (s *Syncer) Run(ctx context.Context) (err error) {
 		cleanDumpFile = false
 	}
 
+	// DMLs are replayed from the global checkpoint, which can't be changed after syncDML starts.
+	s.loadCausalityRelation(s.runCtx)
 	s.runWg.Add(1)
 	go s.syncDML()
+	if s.causalityRelationSnapshotCh != nil {
+		s.runWg.Add(1)
+		go s.persistCausalityRelation(s.causalityRelationSnapshotCh)
+	}
 	s.runWg.Add(1)
 	go func() {
 		defer s.runWg.Done()
@@ -1857,6 +1983,9 @@ func (s *Syncer) Run(ctx context.Context) (err error) {
 	if err != nil {
 		return terror.ErrSchemaTrackerInit.Delegate(err)
 	}
+	if err = s.initCausalityIndexes(s.tctx); err != nil {
+		return err
+	}
 
 	if freshAndAllMode {
 		err = s.loadTableStructureFromDump(ctx)
@@ -2636,8 +2765,8 @@ func (s *Syncer) handleRowsEvent(ev *replication.RowsEvent, ec eventContext) (*f
 		return nil, terror.WithScope(err, terror.ScopeDownstream)
 	}
 	originRows := ev.Rows
-	if err2 := checkLogColumns(ev.SkippedColumns); err2 != nil {
-		return nil, err2
+	if err2 := checkLogColumns(ev.SkippedColumns, tableInfo); err2 != nil {
+		return nil, terror.Annotate(err2, sourceTable.String())
 	}
 
 	extRows := generateExtendColumn(originRows, s.tableRouter, sourceTable, s.cfg.SourceID)
@@ -3262,6 +3391,7 @@ func (s *Syncer) Close() {
 	// when closing syncer by `stop-task`, remove active relay log from hub
 	s.removeActiveRelayLog()
 	s.metricsProxies.RemoveLabelValuesWithTaskInMetrics(s.cfg.Name)
+	causalityExpvar.Delete(s.causalityExpvarKey())
 
 	s.runWg.Wait()
 	s.closed.Store(true)
@@ -3620,6 +3750,7 @@ func (s *Syncer) delLoadTask() error {
 // DM originally cached s.cfg.QueueSize * s.cfg.WorkerCount dml jobs in memory in 2.0.X.
 // Now if compact: false, dmlJobCh and dmlWorker will both cached s.cfg.QueueSize * s.cfg.WorkerCount/2 jobs.
 // If compact: true, dmlJobCh, compactor buffer, compactor output channel and dmlWorker will all cached s.cfg.QueueSize * s.cfg.WorkerCount/4 jobs.
+// Besides, the output channel of causality caches s.cfg.CausalityQueueSize jobs, or s.cfg.QueueSize jobs if it's not set.
 func calculateChanSize(queueSize, workerCount int, compact bool) int {
 	chanSize := queueSize * workerCount / 2
 	if compact {
@@ -3706,6 +3837,48 @@ func (s *Syncer) getDownStreamTableInfo(tctx *tcontext.Context, tableID string,
 	return s.schemaTracker.GetDownStreamTableInfo(tctx, tableID, originTI)
 }
 
+// initCausalityIndexes checks the indexes in `causality-indexes` are unique indexes of downstream tables, and
+// initializes the indexes used to generate causality keys of these tables.
+func (s *Syncer) initCausalityIndexes(tctx *tcontext.Context) error {
+	if len(s.cfg.CausalityIndexes) == 0 {
+		return nil
+	}
+
+	causalityIndexes := make(map[string]map[string]struct{}, len(s.cfg.CausalityIndexes))
+	for _, rule := range s.cfg.CausalityIndexes {
+		tableID := utils.GenTableID(&filter.Table{Schema: rule.TargetSchema, Name: rule.TargetTable})
+		ti, err := s.schemaTracker.FetchDownStreamTableInfo(tctx, tableID)
+		if err != nil {
+			return err
+		}
+		uniqueIdxs := make(map[string]struct{}, len(ti.Indices)+1)
+		for _, idx := range sqlmodel.GetWhereHandle(ti, ti).UniqueIdxs {
+			if idx.Primary {
+				uniqueIdxs[sqlmodel.PrimaryIndexName] = struct{}{}
+				continue
+			}
+			uniqueIdxs[idx.Name.L] = struct{}{}
+		}
+
+		indexes, ok := causalityIndexes[tableID]
+		if !ok {
+			indexes = make(map[string]struct{}, len(rule.Indexes))
+			causalityIndexes[tableID] = indexes
+		}
+		for _, name := range rule.Indexes {
+			lowerName := strings.ToLower(name)
+			if _, ok := uniqueIdxs[lowerName]; !ok {
+				return terror.ErrSyncerCausalityIndexNotFound.Generate(name, tableID)
+			}
+			indexes[lowerName] = struct{}{}
+		}
+		tctx.L().Warn("causality keys are restricted to some indexes, omitting a mutable unique index may cause data inconsistency",
+			zap.String("table", tableID), zap.Strings("indexes", rule.Indexes))
+	}
+	s.causalityIndexes = causalityIndexes
+	return nil
+}
+
 func (s *Syncer) getTableInfoFromCheckpoint(table *filter.Table) *model.TableInfo {
 	return s.checkpoint.GetTableInfo(table.Schema, table.Name)
 }
@@ -15,6 +15,7 @@ package worker
 
 import (
 	"context"
+	"expvar"
 	"net"
 	"net/http"
 	"net/http/pprof"
@@ -25,6 +26,7 @@ import (
 	"github.com/pingcap/tiflow/dm/dumpling"
 	"github.com/pingcap/tiflow/dm/loader"
 	"github.com/pingcap/tiflow/dm/pkg/log"
+	"github.com/pingcap/tiflow/dm/pkg/terror"
 	"github.com/pingcap/tiflow/dm/relay"
 	"github.com/pingcap/tiflow/dm/syncer/metrics"
 	"github.com/pingcap/tiflow/engine/pkg/promutil"
@@ -32,9 +34,14 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/collectors"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.uber.org/zap"
 )
 
 const (
+	dumpCausalityTimeout = 10 * time.Second
+	// the conflict job waits for all dispatched DMLs, so it may take longer than dumping.
+	clearCausalityTimeout = time.Minute
+
 	opErrTypeBeforeOp    = "BeforeAnyOp"
 	opErrTypeSourceBound = "SourceBound"
 	opErrTypeRelaySource = "RelaySource"
@@ -79,6 +86,77 @@ func (h *statusHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	}
 }
 
+// causalityHandler dumps the causality relation of a subtask, the subtask name is given by the `task` query parameter.
+// the latest causality decisions are dumped instead if the `decisions` query parameter is true.
+type causalityHandler struct {
+	s *Server
+}
+
+func (h *causalityHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	st := findSubTaskForDebug(h.s, w, req)
+	if st == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(req.Context(), dumpCausalityTimeout)
+	defer cancel()
+	dump := st.DumpCausalityRelation
+	if req.URL.Query().Get("decisions") == "true" {
+		dump = st.DumpCausalityDecisions
+	}
+	data, err := dump(ctx)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, err = w.Write(data)
+	if err != nil && !common.IsErrNetClosing(err) {
+		log.L().Error("fail to write causality response", log.ShortError(err))
+	}
+}
+
+// causalityClearHandler forces the causality of a subtask to clear its relation by a conflict job, the subtask name
+// is given by the `task` query parameter. it responds after all DML workers execute the conflict job.
+type causalityClearHandler struct {
+	s *Server
+}
+
+func (h *causalityClearHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		http.Error(w, "only POST is allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	st := findSubTaskForDebug(h.s, w, req)
+	if st == nil {
+		return
+	}
+	log.L().Warn("receive request to clear causality relation", zap.String("task", st.cfg.Name), zap.String("remote addr", req.RemoteAddr))
+	ctx, cancel := context.WithTimeout(req.Context(), clearCausalityTimeout)
+	defer cancel()
+	if err := st.ClearCausalityRelation(ctx); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// findSubTaskForDebug finds the subtask given by the `task` query parameter, it responds with an error and returns
+// nil if the subtask is not found.
+func findSubTaskForDebug(s *Server, w http.ResponseWriter, req *http.Request) *SubTask {
+	taskName := req.URL.Query().Get("task")
+	sourceWorker := s.getSourceWorker(true)
+	if sourceWorker == nil {
+		http.Error(w, "no source is bound to this worker", http.StatusNotFound)
+		return nil
+	}
+	st := sourceWorker.subTaskHolder.findSubTask(taskName)
+	if st == nil {
+		http.Error(w, terror.ErrWorkerSubTaskNotFound.Generate(taskName).Error(), http.StatusNotFound)
+		return nil
+	}
+	return st
+}
+
 // Note: handle error inside the function with returning it.
 func (s *Server) collectMetrics() {
 	// CPU usage metric
@@ -122,10 +200,13 @@ func RegistryMetrics() {
 }
 
 // InitStatus initializes the HTTP status server.
-func InitStatus(lis net.Listener) {
+func InitStatus(lis net.Listener, s *Server) {
 	mux := http.NewServeMux()
 	mux.Handle("/status", &statusHandler{})
 	mux.Handle("/metrics", promhttp.Handler())
+	mux.Handle("/debug/causality", &causalityHandler{s: s})
+	mux.Handle("/debug/causality/clear", &causalityClearHandler{s: s})
+	mux.Handle("/debug/vars", expvar.Handler())
 
 	mux.HandleFunc("/debug/pprof/", pprof.Index)
 	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
@@ -248,7 +248,7 @@ func (s *Server) Start() error {
 		s.httpWg.Add(1)
 		go func() {
 			s.httpWg.Done()
-			InitStatus(httpL) // serve status
+			InitStatus(httpL, s) // serve status
 		}()
 
 		s.closed.Store(false) // the server started now.
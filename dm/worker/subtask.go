@@ -677,6 +677,45 @@ func (st *SubTask) OperateSchema(ctx context.Context, req *pb.OperateWorkerSchem
 	return syncUnit.OperateSchema(ctx, req)
 }
 
+// DumpCausalityRelation dumps the causality relation of the sync unit for debugging.
+func (st *SubTask) DumpCausalityRelation(ctx context.Context) ([]byte, error) {
+	cu := st.CurrUnit()
+	if cu == nil {
+		return nil, terror.ErrWorkerNoSyncerRunning.Generate()
+	}
+	syncUnit, ok := cu.(*syncer.Syncer)
+	if !ok {
+		return nil, terror.ErrWorkerOperSyncUnitOnly.Generate(cu.Type())
+	}
+	return syncUnit.DumpCausalityRelation(ctx)
+}
+
+// DumpCausalityDecisions dumps the latest causality decisions of the sync unit for replay debugging.
+func (st *SubTask) DumpCausalityDecisions(ctx context.Context) ([]byte, error) {
+	cu := st.CurrUnit()
+	if cu == nil {
+		return nil, terror.ErrWorkerNoSyncerRunning.Generate()
+	}
+	syncUnit, ok := cu.(*syncer.Syncer)
+	if !ok {
+		return nil, terror.ErrWorkerOperSyncUnitOnly.Generate(cu.Type())
+	}
+	return syncUnit.DumpCausalityDecisions(ctx)
+}
+
+// ClearCausalityRelation forces the causality of the sync unit to clear its relation by a conflict job.
+func (st *SubTask) ClearCausalityRelation(ctx context.Context) error {
+	cu := st.CurrUnit()
+	if cu == nil {
+		return terror.ErrWorkerNoSyncerRunning.Generate()
+	}
+	syncUnit, ok := cu.(*syncer.Syncer)
+	if !ok {
+		return terror.ErrWorkerOperSyncUnitOnly.Generate(cu.Type())
+	}
+	return syncUnit.ClearCausalityRelation(ctx)
+}
+
 // CheckUnit checks whether current unit is sync unit.
 func (st *SubTask) CheckUnit() bool {
 	st.RLock()
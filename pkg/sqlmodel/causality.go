@@ -15,44 +15,211 @@ package sqlmodel
 
 import (
 	"fmt"
+	"hash/fnv"
 	"strconv"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
+	"github.com/pingcap/tidb/pkg/expression"
 	timodel "github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser/charset"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/table"
 	"github.com/pingcap/tidb/pkg/tablecodec"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
 	"github.com/pingcap/tiflow/dm/pkg/log"
 	"github.com/pingcap/tiflow/dm/pkg/utils"
 	"go.uber.org/zap"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
 )
 
+// PrimaryIndexName is the name of primary key used by SetCausalityIndexes.
+const PrimaryIndexName = "primary"
+
 // CausalityKeys returns all string representation of causality keys. If two row
 // changes has the same causality keys, they must be replicated sequentially.
+// Every key ends with the target table, so row changes of different tables never
+// share a key even if they have the same values of PK/UK, while row changes of
+// different source tables merged into one target table, such as sharded tables,
+// share the keys of the same downstream row and are replicated sequentially.
+// The values of generated columns in PK/UK are computed from the other columns,
+// so they are correct even if the values of the row change are stale.
 func (r *RowChange) CausalityKeys() []string {
 	r.lazyInitWhereHandle()
 
 	ret := make([]string, 0, 1)
 	if r.preValues != nil {
-		ret = append(ret, r.getCausalityString(r.preValues)...)
+		ret = append(ret, r.getCausalityString(r.fillGeneratedColumnValues(r.preValues))...)
 	}
 	if r.postValues != nil {
-		ret = append(ret, r.getCausalityString(r.postValues)...)
+		ret = append(ret, r.getCausalityString(r.fillGeneratedColumnValues(r.postValues))...)
+	}
+	return ret
+}
+
+// generatedColumnExpr is the generation expression of a column of the source table.
+type generatedColumnExpr struct {
+	col  *timodel.ColumnInfo
+	expr expression.Expression
+}
+
+// getGeneratedColumnExprs returns the expressions of all generated columns of the
+// source table in the order of columns, or nil if no unique index has a generated
+// column. the expressions are cached in the whereHandle.
+func (r *RowChange) getGeneratedColumnExprs() []generatedColumnExpr {
+	h := r.whereHandle
+	h.genExprsOnce.Do(func() {
+		h.genExprs = buildGeneratedColumnExprs(r.tiSessionCtx, r.sourceTableInfo, h.UniqueIdxs)
+	})
+	return h.genExprs
+}
+
+func buildGeneratedColumnExprs(
+	ctx sessionctx.Context,
+	ti *timodel.TableInfo,
+	uniqueIdxs []*timodel.IndexInfo,
+) []generatedColumnExpr {
+	hasGenerated := false
+	for _, idx := range uniqueIdxs {
+		for _, idxCol := range idx.Columns {
+			if ti.Columns[idxCol.Offset].IsGenerated() {
+				hasGenerated = true
+			}
+		}
+	}
+	if !hasGenerated {
+		return nil
+	}
+
+	// a generated column may refer to the generated columns before it, so all of
+	// them are computed in the order of columns.
+	var ret []generatedColumnExpr
+	for _, col := range ti.Columns {
+		if !col.IsGenerated() {
+			continue
+		}
+		expr, err := expression.ParseSimpleExprWithTableInfo(ctx.GetExprCtx(), col.GeneratedExprString, ti)
+		if err != nil {
+			log.L().Warn("failed to parse generated column expression, use the value in row change instead",
+				zap.String("column", col.Name.O),
+				zap.String("expression", col.GeneratedExprString),
+				zap.Error(err))
+			continue
+		}
+		ret = append(ret, generatedColumnExpr{col: col, expr: expr})
 	}
 	return ret
 }
 
-func columnNeeds2LowerCase(col *timodel.ColumnInfo) bool {
+// fillGeneratedColumnValues returns the values whose generated columns are
+// computed from the other columns in the same way as TiDB. values is returned if
+// there is nothing to compute, and the value in values is kept if a column fails
+// to compute.
+func (r *RowChange) fillGeneratedColumnValues(values []interface{}) []interface{} {
+	exprs := r.getGeneratedColumnExprs()
+	if len(exprs) == 0 || len(values) != len(r.sourceTableInfo.Columns) {
+		return values
+	}
+	datums, err := utils.AdjustBinaryProtocolForDatum(r.tiSessionCtx, values, r.sourceTableInfo.Columns)
+	if err != nil {
+		log.L().Warn("adjust binary protocol for datum error", zap.Error(err))
+		return values
+	}
+
+	// the row is created from the column types, so a `null` value still has room for
+	// the computed datum of its column.
+	fieldTypes := make([]*types.FieldType, 0, len(r.sourceTableInfo.Columns))
+	for _, col := range r.sourceTableInfo.Columns {
+		fieldTypes = append(fieldTypes, &col.FieldType)
+	}
+	row := chunk.MutRowFromTypes(fieldTypes)
+	row.SetDatums(datums...)
+	evalCtx := r.tiSessionCtx.GetExprCtx().GetEvalCtx()
+	ret := make([]interface{}, len(values))
+	copy(ret, values)
+	for _, e := range exprs {
+		d, err := e.expr.Eval(evalCtx, row.ToRow())
+		if err == nil {
+			d, err = table.CastValue(r.tiSessionCtx, d, e.col, false, false)
+		}
+		if err != nil {
+			log.L().Warn("failed to compute generated column, use the value in row change instead",
+				zap.String("column", e.col.Name.O),
+				zap.String("table", r.sourceTable.String()),
+				zap.Error(err))
+			continue
+		}
+		row.SetDatum(e.col.Offset, d)
+		ret[e.col.Offset] = d.GetValue()
+	}
+	return ret
+}
+
+// columnCollation returns the collation of the string column, or "" if the column is not a string column. the
+// collation of the session is used if the column has no collation.
+func columnCollation(ctx sessionctx.Context, col *timodel.ColumnInfo) string {
 	switch col.GetType() {
 	case mysql.TypeVarchar, mysql.TypeString, mysql.TypeVarString, mysql.TypeTinyBlob,
 		mysql.TypeMediumBlob, mysql.TypeBlob, mysql.TypeLongBlob:
-		return collationNeeds2LowerCase(col.GetCollate())
+		if collation := col.GetCollate(); collation != "" || ctx == nil {
+			return collation
+		}
+		_, collation := ctx.GetSessionVars().GetCharsetInfo()
+		return collation
 	}
-	return false
+	return ""
 }
 
-func collationNeeds2LowerCase(collation string) bool {
-	return strings.HasSuffix(collation, "_ci")
+// collationKey returns the key of the string value in collation, values which are equal in the collation have the
+// same key, so rows violating the same unique key have the same causality key. it follows the padding, case and
+// accent sensitivity of the collation. different values may also get the same key, which only causes a false
+// conflict.
+// NOTE: characters which are equal to several characters in some collations, like `ß` and `ss` in
+// utf8mb4_unicode_ci, are not folded.
+func collationKey(collation, val string) string {
+	if collation == "" || collation == charset.CollationBin {
+		return val
+	}
+	// only the collations of UCA 9.0.0 are NO PAD, others ignore the trailing spaces.
+	if !strings.Contains(collation, "_0900_") {
+		val = strings.TrimRight(val, " ")
+	}
+	if !strings.HasSuffix(collation, "_ci") {
+		return val
+	}
+	// a case insensitive collation is also accent insensitive unless it's `_as_ci`.
+	return foldString(val, !strings.HasSuffix(collation, "_as_ci"))
+}
+
+// foldString folds the case, and also the accents if foldAccent, of val.
+func foldString(val string, foldAccent bool) string {
+	if isASCII(val) {
+		return strings.ToLower(val)
+	}
+	if foldAccent {
+		// the transformer is not safe for concurrent use, so it's created every time.
+		t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+		if folded, _, err := transform.String(t, val); err == nil {
+			val = folded
+		}
+	}
+	return strings.Map(func(r rune) rune {
+		return unicode.ToLower(unicode.ToUpper(r))
+	}, val)
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
 }
 
 func columnValue2String(value interface{}) string {
@@ -100,6 +267,7 @@ func columnValue2String(value interface{}) string {
 }
 
 func genKeyString(
+	ctx sessionctx.Context,
 	table string,
 	columns []*timodel.ColumnInfo,
 	values []interface{},
@@ -114,10 +282,7 @@ func genKeyString(
 		}
 		// one column key looks like:`column_val.column_name.`
 
-		val := columnValue2String(data)
-		if columnNeeds2LowerCase(columns[i]) {
-			val = strings.ToLower(val)
-		}
+		val := collationKey(columnCollation(ctx, columns[i]), columnValue2String(data))
 		buf.WriteString(val)
 		buf.WriteString(".")
 		buf.WriteString(columns[i].Name.L)
@@ -132,6 +297,43 @@ func genKeyString(
 	return buf.String()
 }
 
+// genRowKeyString generates the causality key from the full row content, it's used
+// when the row has no usable PK/UK. The key is a fixed-size hash of all column
+// values so it doesn't grow with wide rows. Identical rows always get the same key,
+// so the DELETE/UPDATE of duplicate rows, which will match any of them by
+// `WHERE ... LIMIT 1`, are still replicated in order. Distinct rows usually get
+// different keys and can be replicated in parallel, a hash collision only causes a
+// false conflict, which is safe.
+func genRowKeyString(
+	ctx sessionctx.Context,
+	table string,
+	columns []*timodel.ColumnInfo,
+	values []interface{},
+) string {
+	h := fnv.New64a()
+	for i, data := range values {
+		// one column looks like: `\x00` for null, or `\x01column_val\x00`.
+		if data == nil {
+			_, _ = h.Write([]byte{0})
+			continue
+		}
+		val := collationKey(columnCollation(ctx, columns[i]), columnValue2String(data))
+		_, _ = h.Write([]byte{1})
+		_, _ = h.Write([]byte(val))
+		_, _ = h.Write([]byte{0})
+	}
+	return fmt.Sprintf("%016x.%s", h.Sum64(), table)
+}
+
+func hasNullValue(values []interface{}) bool {
+	for _, v := range values {
+		if v == nil {
+			return true
+		}
+	}
+	return false
+}
+
 // truncateIndexValues truncate prefix index from data.
 func truncateIndexValues(
 	ctx sessionctx.Context,
@@ -153,11 +355,29 @@ func truncateIndexValues(
 	return values
 }
 
+// isCausalityIdx returns whether the unique index can be used to generate
+// causality keys, see SetCausalityIndexes.
+func (r *RowChange) isCausalityIdx(idx *timodel.IndexInfo) bool {
+	if r.causalityIdxNames == nil {
+		return true
+	}
+	name := idx.Name.L
+	if idx.Primary {
+		// the PK of PKIsHandle table has no name.
+		name = PrimaryIndexName
+	}
+	_, ok := r.causalityIdxNames[name]
+	return ok
+}
+
 func (r *RowChange) getCausalityString(values []interface{}) []string {
+	// the keys follow the target table since rows of different source tables may be
+	// merged into the same downstream row.
+	table := r.targetTable.String()
 	pkAndUks := r.whereHandle.UniqueIdxs
 	if len(pkAndUks) == 0 {
 		// the table has no PK/UK, all values of the row consists the causality key
-		return []string{genKeyString(r.sourceTable.String(), r.sourceTableInfo.Columns, values)}
+		return []string{genRowKeyString(r.tiSessionCtx, table, r.sourceTableInfo.Columns, values)}
 	}
 
 	ret := make([]string, 0, len(pkAndUks))
@@ -168,21 +388,28 @@ func (r *RowChange) getCausalityString(values []interface{}) []string {
 		if indexCols.MVIndex {
 			continue
 		}
+		if !r.isCausalityIdx(indexCols) {
+			continue
+		}
 		cols, vals := getColsAndValuesOfIdx(r.sourceTableInfo.Columns, indexCols, values)
+		// a unique index doesn't constrain rows having `null` in any of its columns, multiple
+		// such rows can coexist, so the index can't be used as causality key.
+		if hasNullValue(vals) {
+			log.L().Debug("ignore unique key with null value",
+				zap.String("index", indexCols.Name.O),
+				zap.String("table", r.sourceTable.String()))
+			continue
+		}
 		// handle prefix index
 		truncVals := truncateIndexValues(r.tiSessionCtx, r.sourceTableInfo, indexCols, cols, vals)
-		key := genKeyString(r.sourceTable.String(), cols, truncVals)
-		if len(key) > 0 { // ignore `null` value.
-			ret = append(ret, key)
-		} else {
-			log.L().Debug("ignore empty key", zap.String("table", r.sourceTable.String()))
-		}
+		key := genKeyString(r.tiSessionCtx, table, cols, truncVals)
+		ret = append(ret, key)
 	}
 
 	if len(ret) == 0 {
 		// the table has no PK/UK, or all UK are NULL. all values of the row
 		// consists the causality key
-		return []string{genKeyString(r.sourceTable.String(), r.sourceTableInfo.Columns, values)}
+		return []string{genRowKeyString(r.tiSessionCtx, table, r.sourceTableInfo.Columns, values)}
 	}
 
 	return ret
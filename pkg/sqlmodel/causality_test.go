@@ -17,6 +17,7 @@ import (
 	"sync"
 	"testing"
 
+	timodel "github.com/pingcap/tidb/pkg/meta/model"
 	cdcmodel "github.com/pingcap/tiflow/cdc/model"
 	"github.com/stretchr/testify/require"
 )
@@ -75,7 +76,7 @@ func TestCausalityKeys(t *testing.T) {
 			"CREATE TABLE tb1 (a INT, b TEXT, UNIQUE KEY c2(a, b(3)))",
 			[]interface{}{1, nil},
 			nil,
-			[]string{"1.a.db.tb1"},
+			[]string{"2b3c9576fe006b5f.db.tb1"},
 		},
 	}
 
@@ -86,6 +87,291 @@ func TestCausalityKeys(t *testing.T) {
 	}
 }
 
+func TestCausalityKeysNullUniqueKey(t *testing.T) {
+	t.Parallel()
+
+	source := &cdcmodel.TableName{Schema: "db", Table: "tb1"}
+
+	cases := []struct {
+		createSQL string
+		values1   []interface{}
+		values2   []interface{}
+	}{
+		// single column unique key
+		{
+			"CREATE TABLE tb1 (c INT PRIMARY KEY, c2 INT UNIQUE)",
+			[]interface{}{1, nil},
+			[]interface{}{2, nil},
+		},
+		// composite unique key
+		{
+			"CREATE TABLE tb1 (c INT PRIMARY KEY, c2 INT, c3 INT, UNIQUE KEY(c2, c3))",
+			[]interface{}{1, 10, nil},
+			[]interface{}{2, 10, nil},
+		},
+		// composite unique key and another unique key on its column
+		{
+			"CREATE TABLE tb1 (c INT PRIMARY KEY, c2 INT, c3 INT, UNIQUE KEY(c2, c3), UNIQUE KEY(c3))",
+			[]interface{}{1, 10, nil},
+			[]interface{}{2, 10, nil},
+		},
+	}
+
+	for _, ca := range cases {
+		ti := mockTableInfo(t, ca.createSQL)
+		keys1 := NewRowChange(source, nil, nil, ca.values1, ti, nil, nil).CausalityKeys()
+		keys2 := NewRowChange(source, nil, nil, ca.values2, ti, nil, nil).CausalityKeys()
+		for _, key := range keys1 {
+			require.NotContains(t, keys2, key)
+		}
+	}
+
+	// rows with the same not-null unique key still conflict
+	ti := mockTableInfo(t, "CREATE TABLE tb1 (c INT PRIMARY KEY, c2 INT, c3 INT, UNIQUE KEY(c2, c3))")
+	keys1 := NewRowChange(source, nil, nil, []interface{}{1, 10, 20}, ti, nil, nil).CausalityKeys()
+	keys2 := NewRowChange(source, nil, nil, []interface{}{2, 10, 20}, ti, nil, nil).CausalityKeys()
+	require.Contains(t, keys1, "10.c2.20.c3.db.tb1")
+	require.Contains(t, keys2, "10.c2.20.c3.db.tb1")
+}
+
+func TestCausalityKeysWithCausalityIndexes(t *testing.T) {
+	t.Parallel()
+
+	source := &cdcmodel.TableName{Schema: "db", Table: "tb1"}
+
+	cases := []struct {
+		createSQL string
+		indexes   map[string]struct{}
+		values    []interface{}
+
+		causalityKeys []string
+	}{
+		// only the named unique key
+		{
+			"CREATE TABLE tb1 (c INT PRIMARY KEY, c2 INT, c3 VARCHAR(10), UNIQUE KEY uk(c3))",
+			map[string]struct{}{"uk": {}},
+			[]interface{}{1, 2, "abc"},
+			[]string{"abc.c3.db.tb1"},
+		},
+		// PKIsHandle primary key
+		{
+			"CREATE TABLE tb1 (c INT PRIMARY KEY, c2 INT, c3 VARCHAR(10), UNIQUE KEY uk(c3))",
+			map[string]struct{}{PrimaryIndexName: {}},
+			[]interface{}{1, 2, "abc"},
+			[]string{"1.c.db.tb1"},
+		},
+		// clustered primary key
+		{
+			"CREATE TABLE tb1 (c VARCHAR(10) PRIMARY KEY, c2 INT UNIQUE)",
+			map[string]struct{}{PrimaryIndexName: {}},
+			[]interface{}{"abc", 2},
+			[]string{"abc.c.db.tb1"},
+		},
+		// the named unique key is NULL, use the hash of the whole row
+		{
+			"CREATE TABLE tb1 (c INT PRIMARY KEY, c2 INT, c3 VARCHAR(10), UNIQUE KEY uk(c3))",
+			map[string]struct{}{"uk": {}},
+			[]interface{}{1, 2, nil},
+			[]string{"ac295985d8a3508a.db.tb1"},
+		},
+	}
+
+	for _, ca := range cases {
+		ti := mockTableInfo(t, ca.createSQL)
+		change := NewRowChange(source, nil, nil, ca.values, ti, nil, nil)
+		change.SetCausalityIndexes(ca.indexes)
+		require.Equal(t, ca.causalityKeys, change.CausalityKeys())
+
+		// the restriction is kept after splitting an UPDATE
+		update := NewRowChange(source, nil, ca.values, ca.values, ti, nil, nil)
+		update.SetCausalityIndexes(ca.indexes)
+		pre, post := update.SplitUpdate()
+		require.Equal(t, ca.causalityKeys, pre.CausalityKeys())
+		require.Equal(t, ca.causalityKeys, post.CausalityKeys())
+	}
+}
+
+func TestCausalityKeysNoUniqueKey(t *testing.T) {
+	t.Parallel()
+
+	source := &cdcmodel.TableName{Schema: "db", Table: "tb1"}
+	ti := mockTableInfo(t, "CREATE TABLE tb1 (a INT, b VARCHAR(10)) DEFAULT CHARSET=utf8 COLLATE=utf8_unicode_ci")
+	keyOf := func(values ...interface{}) string {
+		keys := NewRowChange(source, nil, nil, values, ti, nil, nil).CausalityKeys()
+		require.Len(t, keys, 1)
+		return keys[0]
+	}
+
+	// the key is a fixed-size hash of the full row.
+	require.Equal(t, "6752f5f64b9c2c4c.db.tb1", keyOf(1, "abc"))
+	// rows matched by the same `WHERE` of case insensitive collation are identical.
+	require.Equal(t, keyOf(1, "abc"), keyOf(1, "ABC"))
+	// distinct rows, including `null` and empty string, have different keys.
+	require.NotEqual(t, keyOf(1, "abc"), keyOf(2, "abc"))
+	require.NotEqual(t, keyOf(1, nil), keyOf(1, ""))
+	require.NotEqual(t, keyOf(1, nil), keyOf(nil, nil))
+	require.NotEmpty(t, keyOf(nil, nil))
+
+	// DELETE and UPDATE of duplicate rows can affect any of them, so they conflict with
+	// the INSERT of all the duplicates.
+	insertKeys := NewRowChange(source, nil, nil, []interface{}{1, "abc"}, ti, nil, nil).CausalityKeys()
+	deleteKeys := NewRowChange(source, nil, []interface{}{1, "abc"}, nil, ti, nil, nil).CausalityKeys()
+	updateKeys := NewRowChange(source, nil, []interface{}{1, "abc"}, []interface{}{2, "abc"}, ti, nil, nil).CausalityKeys()
+	require.Equal(t, insertKeys, deleteKeys)
+	require.Equal(t, []string{keyOf(1, "abc"), keyOf(2, "abc")}, updateKeys)
+}
+
+func TestCausalityKeysCollation(t *testing.T) {
+	t.Parallel()
+
+	source := &cdcmodel.TableName{Schema: "db", Table: "tb1"}
+	ti := mockTableInfo(t, `CREATE TABLE tb1 (
+		a VARCHAR(16) COLLATE utf8mb4_general_ci PRIMARY KEY,
+		b VARCHAR(16) COLLATE utf8mb4_bin UNIQUE,
+		c VARBINARY(16) UNIQUE)`)
+	keysOf := func(values ...interface{}) []string {
+		return NewRowChange(source, nil, nil, values, ti, nil, nil).CausalityKeys()
+	}
+
+	// values of utf8mb4_general_ci are equal regardless of case, accents and trailing spaces.
+	require.ElementsMatch(t, []string{"abc.a.db.tb1", "x.b.db.tb1", "x.c.db.tb1"}, keysOf("abc", "x", "x"))
+	require.ElementsMatch(t, []string{"abc.a.db.tb1", "y.b.db.tb1", "y.c.db.tb1"}, keysOf("ABC", "y", "y"))
+	require.ElementsMatch(t, []string{"abc.a.db.tb1", "z.b.db.tb1", "z.c.db.tb1"}, keysOf("Ábc  ", "z", "z"))
+	// values of utf8mb4_bin are only equal regardless of trailing spaces, binary values are compared byte by byte.
+	require.ElementsMatch(t, []string{"abd.a.db.tb1", "X.b.db.tb1", "X.c.db.tb1"}, keysOf("abd", "X", "X"))
+	require.ElementsMatch(t, []string{"abe.a.db.tb1", "x.b.db.tb1", "x .c.db.tb1"}, keysOf("abe", "x ", "x "))
+}
+
+func TestCollationKey(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		collation string
+		val       string
+		key       string
+	}{
+		{"", "Abc ", "Abc "},
+		{"binary", "Abc ", "Abc "},
+		{"utf8mb4_bin", "Abc ", "Abc"},
+		{"utf8mb4_general_ci", "Abc ", "abc"},
+		{"utf8mb4_general_ci", "ÀbÇ", "abc"},
+		{"utf8mb4_unicode_ci", "Straße", "straße"},
+		{"utf8mb4_0900_ai_ci", "Àbc ", "abc "},
+		{"utf8mb4_0900_as_ci", "Àbc ", "àbc "},
+		{"utf8mb4_0900_as_cs", "Àbc ", "Àbc "},
+		{"utf8mb4_0900_bin", "Àbc ", "Àbc "},
+	}
+	for _, ca := range cases {
+		require.Equal(t, ca.key, collationKey(ca.collation, ca.val), "%s %q", ca.collation, ca.val)
+	}
+}
+
+func TestCausalityKeysOfDifferentTables(t *testing.T) {
+	t.Parallel()
+
+	// keys of PK/UK and keys of the full row.
+	withUK := mockTableInfo(t, "CREATE TABLE tb1 (a INT PRIMARY KEY, b INT UNIQUE)")
+	withoutUK := mockTableInfo(t, "CREATE TABLE tb1 (a INT, b INT)")
+	for _, ti := range []*timodel.TableInfo{withUK, withoutUK} {
+		keys1 := NewRowChange(&cdcmodel.TableName{Schema: "db", Table: "tb1"}, nil, nil, []interface{}{1, 1}, ti, nil, nil).CausalityKeys()
+		keys2 := NewRowChange(&cdcmodel.TableName{Schema: "db", Table: "tb2"}, nil, nil, []interface{}{1, 1}, ti, nil, nil).CausalityKeys()
+		keys3 := NewRowChange(&cdcmodel.TableName{Schema: "db2", Table: "tb1"}, nil, nil, []interface{}{1, 1}, ti, nil, nil).CausalityKeys()
+		for _, key := range keys1 {
+			require.NotContains(t, keys2, key)
+			require.NotContains(t, keys3, key)
+		}
+	}
+}
+
+func TestCausalityKeysOfMergedTables(t *testing.T) {
+	t.Parallel()
+
+	// the keys of sharded tables merged into one target table follow the target table.
+	withUK := mockTableInfo(t, "CREATE TABLE tb1 (a INT PRIMARY KEY, b INT UNIQUE)")
+	withoutUK := mockTableInfo(t, "CREATE TABLE tb1 (a INT, b INT)")
+	target := &cdcmodel.TableName{Schema: "db", Table: "tb"}
+	for _, ti := range []*timodel.TableInfo{withUK, withoutUK} {
+		keys1 := NewRowChange(&cdcmodel.TableName{Schema: "db1", Table: "tb1"}, target, nil, []interface{}{1, 1}, ti, nil, nil).CausalityKeys()
+		keys2 := NewRowChange(&cdcmodel.TableName{Schema: "db2", Table: "tb2"}, target, nil, []interface{}{1, 1}, ti, nil, nil).CausalityKeys()
+		require.Equal(t, keys1, keys2)
+		keys3 := NewRowChange(&cdcmodel.TableName{Schema: "db1", Table: "tb1"}, nil, nil, []interface{}{1, 1}, ti, nil, nil).CausalityKeys()
+		for _, key := range keys1 {
+			require.NotContains(t, keys3, key)
+		}
+	}
+}
+
+func TestCausalityKeysGeneratedColumn(t *testing.T) {
+	t.Parallel()
+
+	source := &cdcmodel.TableName{Schema: "db", Table: "tb1"}
+
+	cases := []struct {
+		createSQL string
+		preValue  []interface{}
+		postValue []interface{}
+
+		causalityKeys []string
+	}{
+		// the values of generated columns are stale, they are computed from base columns.
+		{
+			"CREATE TABLE tb1 (c INT PRIMARY KEY, c2 INT, c3 INT AS (c2 + 1) VIRTUAL UNIQUE)",
+			[]interface{}{1, 2, 100},
+			[]interface{}{1, 5, 3},
+			[]string{"3.c3.db.tb1", "1.c.db.tb1", "6.c3.db.tb1", "1.c.db.tb1"},
+		},
+		// a generated column refers to another generated column which is not in unique key.
+		{
+			"CREATE TABLE tb1 (c INT PRIMARY KEY, c2 INT, c3 INT AS (c2 * 2), c4 INT AS (c3 + 1) STORED UNIQUE)",
+			[]interface{}{1, 2, nil, nil},
+			[]interface{}{1, 3, 4, 5},
+			[]string{"5.c4.db.tb1", "1.c.db.tb1", "7.c4.db.tb1", "1.c.db.tb1"},
+		},
+		// composite unique key of a string generated column.
+		{
+			"CREATE TABLE tb1 (c INT PRIMARY KEY, c2 VARCHAR(10), c3 VARCHAR(20) AS (CONCAT(c2, '-x')), UNIQUE KEY(c, c3))",
+			[]interface{}{1, "a", "a-x"},
+			[]interface{}{1, "b", "a-x"},
+			[]string{"1.c.a-x.c3.db.tb1", "1.c.db.tb1", "1.c.b-x.c3.db.tb1", "1.c.db.tb1"},
+		},
+		// the generated column is null.
+		{
+			"CREATE TABLE tb1 (c INT PRIMARY KEY, c2 INT, c3 INT AS (c2 + 1) UNIQUE)",
+			[]interface{}{1, nil, 2},
+			nil,
+			[]string{"1.c.db.tb1"},
+		},
+	}
+
+	for _, ca := range cases {
+		ti := mockTableInfo(t, ca.createSQL)
+		change := NewRowChange(source, nil, ca.preValue, ca.postValue, ti, nil, nil)
+		require.Equal(t, ca.causalityKeys, change.CausalityKeys())
+		// the values of row change are not changed.
+		require.Equal(t, ca.preValue, change.preValues)
+		require.Equal(t, ca.postValue, change.postValues)
+	}
+
+	// the expressions are built once for rows sharing the where handle.
+	ti := mockTableInfo(t, "CREATE TABLE tb1 (c INT PRIMARY KEY, c2 INT, c3 INT AS (c2 + 1) UNIQUE)")
+	handle := GetWhereHandle(ti, ti)
+	change := NewRowChange(source, nil, nil, []interface{}{1, 2, nil}, ti, nil, nil)
+	change.SetWhereHandle(handle)
+	require.Equal(t, []string{"3.c3.db.tb1", "1.c.db.tb1"}, change.CausalityKeys())
+	require.Len(t, handle.genExprs, 1)
+	change = NewRowChange(source, nil, nil, []interface{}{2, 4, nil}, ti, nil, nil)
+	change.SetWhereHandle(handle)
+	require.Equal(t, []string{"5.c3.db.tb1", "2.c.db.tb1"}, change.CausalityKeys())
+
+	// no expression is built if no unique key has a generated column.
+	ti = mockTableInfo(t, "CREATE TABLE tb1 (c INT PRIMARY KEY, c2 INT, c3 INT AS (c2 + 1))")
+	handle = GetWhereHandle(ti, ti)
+	change = NewRowChange(source, nil, nil, []interface{}{1, 2, 100}, ti, nil, nil)
+	change.SetWhereHandle(handle)
+	require.Equal(t, []string{"1.c.db.tb1"}, change.CausalityKeys())
+	require.Nil(t, handle.genExprs)
+}
+
 func TestCausalityKeysNoRace(t *testing.T) {
 	t.Parallel()
 
@@ -117,7 +403,7 @@ func TestGetCausalityString(t *testing.T) {
 			// test no keys will use full row data instead of table name
 			schema: `create table t1(a int)`,
 			values: []interface{}{10},
-			keys:   []string{"10.a.db.tbl"},
+			keys:   []string{"2bdfb576fe8b030f.db.tbl"},
 		},
 		{
 			// one primary key
@@ -135,13 +421,13 @@ func TestGetCausalityString(t *testing.T) {
 			// one ordinary key
 			schema: `create table t4(a int, b double, key(b))`,
 			values: []interface{}{60, 70.5},
-			keys:   []string{"60.a.70.5.b.db.tbl"},
+			keys:   []string{"3bbc42cfc8321881.db.tbl"},
 		},
 		{
 			// multiple keys
 			schema: `create table t5(a int, b text, c int, key(a), key(b(3)))`,
 			values: []interface{}{13, "abcdef", 15},
-			keys:   []string{"13.a.abcdef.b.15.c.db.tbl"},
+			keys:   []string{"0c45b9311a50ddf3.db.tbl"},
 		},
 		{
 			// multiple keys with primary key
@@ -177,7 +463,7 @@ func TestGetCausalityString(t *testing.T) {
 			// ordinary key of multiple columns
 			schema: `create table t75(a int, b int, c int, key(a, b), key(c, b))`,
 			values: []interface{}{48, 58, 68},
-			keys:   []string{"48.a.58.b.68.c.db.tbl"},
+			keys:   []string{"4dd64b269c8ee1e5.db.tbl"},
 		},
 		{
 			// so many keys
@@ -205,6 +491,24 @@ func TestGetCausalityString(t *testing.T) {
 			values: []interface{}{17, nil},
 			keys:   []string{"17.a.db.tbl"},
 		},
+		{
+			// `null` for part of composite unique key
+			schema: `
+				create table t9(
+					a int, b int, c int,
+					primary key(a),
+					unique key(b, c)
+				)
+			`,
+			values: []interface{}{17, 27, nil},
+			keys:   []string{"17.a.db.tbl"},
+		},
+		{
+			// `null` for all unique keys, use full row data instead
+			schema: `create table t10(a int unique, b int, c int, unique key(b, c))`,
+			values: []interface{}{nil, 27, nil},
+			keys:   []string{"537a8d2f46a713e5.db.tbl"},
+		},
 	}
 
 	for _, ca := range testCases {
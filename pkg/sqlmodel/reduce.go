@@ -170,6 +170,8 @@ func (r *RowChange) SplitUpdate() (*RowChange, *RowChange) {
 		tiSessionCtx:    r.tiSessionCtx,
 		tp:              RowChangeDelete,
 		whereHandle:     r.whereHandle,
+
+		causalityIdxNames: r.causalityIdxNames,
 	}
 	post := &RowChange{
 		sourceTable:     r.sourceTable,
@@ -180,6 +182,8 @@ func (r *RowChange) SplitUpdate() (*RowChange, *RowChange) {
 		tiSessionCtx:    r.tiSessionCtx,
 		tp:              RowChangeInsert,
 		whereHandle:     r.whereHandle,
+
+		causalityIdxNames: r.causalityIdxNames,
 	}
 
 	return pre, post
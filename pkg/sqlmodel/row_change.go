@@ -69,6 +69,9 @@ type RowChange struct {
 
 	tp          RowChangeType
 	whereHandle *WhereHandle
+	// causalityIdxNames restricts the unique indexes used to generate
+	// causality keys, nil means all unique indexes are used.
+	causalityIdxNames map[string]struct{}
 
 	approximateDataSize int64
 }
@@ -206,6 +209,13 @@ func (r *RowChange) SetWhereHandle(whereHandle *WhereHandle) {
 	r.whereHandle = whereHandle
 }
 
+// SetCausalityIndexes restricts CausalityKeys to be generated only from the
+// unique indexes of target table whose lower-case names are in `names`, the
+// primary key is named "primary". nil means all unique indexes are used.
+func (r *RowChange) SetCausalityIndexes(names map[string]struct{}) {
+	r.causalityIdxNames = names
+}
+
 // GetApproximateDataSize returns internal approximateDataSize, it could be zero
 // if this value is not set.
 func (r *RowChange) GetApproximateDataSize() int64 {
@@ -14,6 +14,8 @@
 package sqlmodel
 
 import (
+	"sync"
+
 	"github.com/pingcap/log"
 	"github.com/pingcap/tidb/pkg/meta/model"
 	pmodel "github.com/pingcap/tidb/pkg/parser/model"
@@ -29,6 +31,11 @@ type WhereHandle struct {
 	// every index that is UNIQUE should be added to UniqueIdxs, even for
 	// PK and NOT NULL.
 	UniqueIdxs []*model.IndexInfo
+
+	// the expressions of generated columns of the source table, they are built
+	// at the first time causality keys are generated, see getGeneratedColumnExprs.
+	genExprsOnce sync.Once
+	genExprs     []generatedColumnExpr
 }
 
 // GetWhereHandle calculates a WhereHandle by source/target TableInfo's indices,